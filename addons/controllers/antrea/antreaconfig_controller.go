@@ -7,6 +7,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	yaml "gopkg.in/yaml.v3"
@@ -27,6 +28,7 @@ import (
 	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
 	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/metrics"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
 	"github.com/vmware-tanzu/tanzu-framework/addons/predicates"
 	cniv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cni/v1alpha1"
@@ -216,11 +218,13 @@ func (r *AntreaConfigReconciler) ReconcileAntreaConfigDataValue(
 		return nil
 	}
 
+	start := time.Now()
 	result, err := controllerutil.CreateOrPatch(ctx, r.Client, antreaDataValuesSecret, antreaDataValuesSecretMutateFn)
 	if err != nil {
 		log.Error(err, "Error creating or patching antrea data values secret")
 		return err
 	}
+	metrics.ObserveDataValuesSecretGeneration(constants.AntreaAddonName, start)
 
 	log.Info(fmt.Sprintf("Resource %s data values secret %s", constants.AntreaAddonName, result))
 
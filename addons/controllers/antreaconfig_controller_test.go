@@ -10,6 +10,8 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +20,7 @@ import (
 
 	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/metrics"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
 	"github.com/vmware-tanzu/tanzu-framework/addons/test/testutil"
 	cniv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cni/v1alpha1"
@@ -200,6 +203,8 @@ var _ = Describe("AntreaConfig Reconciler and Webhooks", func() {
 				return config.Status.SecretRef == util.GenerateDataValueSecretName(clusterName, constants.AntreaAddonName)
 			}, waitTimeout, pollingInterval).Should(BeTrue())
 
+			By("verifying the data values secret generation latency is recorded")
+			Expect(histogramSampleCount(metrics.DataValuesSecretGenerationDuration.WithLabelValues(constants.AntreaAddonName))).Should(BeNumerically(">", 0))
 		})
 
 	})
@@ -457,3 +462,10 @@ func generateDockerCluster(name, namespace, tkr, infraName string) *clusterapiv1
 	}
 	return cluster
 }
+
+// histogramSampleCount returns the number of observations of a histogram
+func histogramSampleCount(observer prometheus.Observer) uint64 {
+	metric := &dto.Metric{}
+	Expect(observer.(prometheus.Metric).Write(metric)).Should(Succeed())
+	return metric.GetHistogram().GetSampleCount()
+}
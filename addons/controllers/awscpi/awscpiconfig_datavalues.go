@@ -0,0 +1,21 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+// DataValues is the data values type generated by the AWSCPIConfig CR
+type DataValues struct {
+	AWSCPI *DataValuesAWSCPI `yaml:"awsCPI,omitempty"`
+}
+
+// DataValuesAWSCPI is the data values section of AWS CPI Secret
+type DataValuesAWSCPI struct {
+	ClusterName string   `yaml:"clusterName"`
+	Region      string   `yaml:"region"`
+	VPCID       string   `yaml:"vpcID"`
+	SubnetIDs   []string `yaml:"subnetIDs"`
+	RoleARN     string   `yaml:"roleARN"`
+	HTTPProxy   string   `yaml:"http_proxy"`
+	HTTPSProxy  string   `yaml:"https_proxy"`
+	NoProxy     string   `yaml:"no_proxy"`
+}
@@ -0,0 +1,136 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	capav1beta1 "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterapiutil "sigs.k8s.io/cluster-api/util"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	pkgtypes "github.com/vmware-tanzu/tanzu-framework/addons/pkg/types"
+	cpiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cpi/v1alpha1"
+)
+
+// ClusterToAWSCPIConfig returns a list of Requests with AWSCPIConfig ObjectKey based on Cluster events
+func (r *AWSCPIConfigReconciler) ClusterToAWSCPIConfig(o client.Object) []ctrl.Request {
+	cluster, ok := o.(*clusterapiv1beta1.Cluster)
+	if !ok {
+		r.Log.Error(errors.New("invalid type"),
+			"Expected to receive Cluster resource",
+			"actualType", fmt.Sprintf("%T", o))
+		return nil
+	}
+
+	r.Log.V(4).Info("Mapping Cluster to AWSCPIConfig")
+
+	cs := &cpiv1alpha1.AWSCPIConfigList{}
+	_ = r.List(context.Background(), cs, client.InNamespace(cluster.Namespace))
+
+	// corresponding AWSCPIConfig should have following ownerRef
+	ownerReference := metav1.OwnerReference{
+		APIVersion: clusterapiv1beta1.GroupVersion.String(),
+		Kind:       cluster.Kind,
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+	}
+
+	requests := []ctrl.Request{}
+	for i := 0; i < len(cs.Items); i++ {
+		config := &cs.Items[i]
+		// avoid enqueuing reconcile requests for template AWSCPIConfig CRs in event handler of Cluster CR
+		if _, ok := config.Annotations[constants.TKGAnnotationTemplateConfig]; ok && config.Namespace == r.Config.SystemNamespace {
+			continue
+		}
+		if clusterapiutil.HasOwnerRef(config.OwnerReferences, ownerReference) {
+			r.Log.V(4).Info("Adding AWSCPIConfig for reconciliation",
+				constants.NamespaceLogKey, config.Namespace, constants.NameLogKey, config.Name)
+
+			requests = append(requests, ctrl.Request{
+				NamespacedName: clusterapiutil.ObjectKey(config),
+			})
+		}
+	}
+
+	return requests
+}
+
+// mapAWSCPIConfigToDataValues maps AWSCPIConfig CR to data values. The values which are not set in the AWSCPIConfig
+// are derived from the AWSCluster of the cluster and the proxy annotations of the cluster.
+func (r *AWSCPIConfigReconciler) mapAWSCPIConfigToDataValues(ctx context.Context,
+	cpiConfig *cpiv1alpha1.AWSCPIConfig, cluster *clusterapiv1beta1.Cluster) (*DataValues, error) {
+
+	d := &DataValuesAWSCPI{ClusterName: cluster.Name}
+	c := cpiConfig.Spec.AWSCPI
+
+	// get the aws cluster object
+	awsCluster, err := cutil.AWSClusterForCluster(ctx, r.Client, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	// derive the region and network settings from the aws cluster object
+	d.Region = awsCluster.Spec.Region
+	d.VPCID = awsCluster.Spec.NetworkSpec.VPC.ID
+	d.SubnetIDs = awsCluster.Spec.NetworkSpec.Subnets.IDs()
+
+	// derive the IAM role from the identity of the aws cluster object, if it assumes a role
+	d.RoleARN, err = r.getRoleARN(ctx, awsCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	// derive proxy related settings from cluster annotations
+	if cluster.Annotations != nil {
+		d.HTTPProxy = cluster.Annotations[pkgtypes.HTTPProxyConfigAnnotation]
+		d.HTTPSProxy = cluster.Annotations[pkgtypes.HTTPSProxyConfigAnnotation]
+		d.NoProxy = cluster.Annotations[pkgtypes.NoProxyConfigAnnotation]
+	}
+
+	// allow API user to override the derived values if specified in the AWSCPIConfig
+	d.Region = tryParseString(d.Region, c.Region)
+	d.VPCID = tryParseString(d.VPCID, c.VPCID)
+	if len(c.SubnetIDs) > 0 {
+		d.SubnetIDs = c.SubnetIDs
+	}
+	d.RoleARN = tryParseString(d.RoleARN, c.RoleARN)
+	if c.Proxy != nil {
+		d.HTTPProxy = tryParseString(d.HTTPProxy, c.Proxy.HTTPProxy)
+		d.HTTPSProxy = tryParseString(d.HTTPSProxy, c.Proxy.HTTPSProxy)
+		d.NoProxy = tryParseString(d.NoProxy, c.Proxy.NoProxy)
+	}
+
+	return &DataValues{AWSCPI: d}, nil
+}
+
+// getRoleARN returns the ARN of the IAM role assumed by the AWSCluster, or an empty string if its identity is not
+// an AWSClusterRoleIdentity
+func (r *AWSCPIConfigReconciler) getRoleARN(ctx context.Context, awsCluster *capav1beta1.AWSCluster) (string, error) {
+	identityRef := awsCluster.Spec.IdentityRef
+	if identityRef == nil || identityRef.Kind != capav1beta1.ClusterRoleIdentityKind {
+		return "", nil
+	}
+	identity := &capav1beta1.AWSClusterRoleIdentity{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: identityRef.Name}, identity); err != nil {
+		return "", errors.Wrapf(err, "could not fetch AWSClusterRoleIdentity %s", identityRef.Name)
+	}
+	return identity.Spec.RoleArn, nil
+}
+
+// tryParseString tries to convert a string pointer and return its value, if not nil
+func tryParseString(src string, sub *string) string {
+	if sub != nil {
+		return *sub
+	}
+	return src
+}
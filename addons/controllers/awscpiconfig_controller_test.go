@@ -0,0 +1,119 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/test/testutil"
+	cpiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cpi/v1alpha1"
+)
+
+var _ = Describe("AWSCPIConfig Reconciler", func() {
+	const (
+		clusterNamespace = "default"
+	)
+
+	var (
+		key                     client.ObjectKey
+		clusterName             string
+		clusterResourceFilePath string
+	)
+
+	JustBeforeEach(func() {
+		By("Creating cluster and AWSCPIConfig resources")
+		key = client.ObjectKey{
+			Namespace: clusterNamespace,
+			Name:      clusterName,
+		}
+		f, err := os.Open(clusterResourceFilePath)
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+		err = testutil.CreateResources(f, cfg, dynamicClient)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		By("Deleting cluster and AWSCPIConfig resources")
+		for _, filePath := range []string{clusterResourceFilePath} {
+			f, err := os.Open(filePath)
+			Expect(err).ToNot(HaveOccurred())
+			err = testutil.DeleteResources(f, cfg, dynamicClient, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(f.Close()).ToNot(HaveOccurred())
+		}
+	})
+
+	Context("reconcile AWSCPIConfig for management cluster", func() {
+		const (
+			testClusterName = "test-cluster-aws-cpi"
+		)
+
+		BeforeEach(func() {
+			clusterName = testClusterName
+			clusterResourceFilePath = "testdata/test-aws-cpi-config.yaml"
+		})
+
+		It("Should reconcile AWSCPIConfig and create data values secret with values derived from the AWSCluster", func() {
+			cluster := &clusterapiv1beta1.Cluster{}
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, key, cluster); err != nil {
+					return fmt.Errorf("Failed to get Cluster '%v': '%v'", key, err)
+				}
+				return nil
+			}, waitTimeout, pollingInterval).Should(Succeed())
+
+			config := &cpiv1alpha1.AWSCPIConfig{}
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, key, config); err != nil {
+					return false
+				}
+
+				// check spec values
+				Expect(*config.Spec.AWSCPI.RoleARN).Should(Equal("arn:aws:iam::123456789012:role/test-role"))
+				// check owner reference
+				if len(config.OwnerReferences) == 0 {
+					return false
+				}
+				Expect(len(config.OwnerReferences)).Should(Equal(1))
+				Expect(config.OwnerReferences[0].Name).Should(Equal(testClusterName))
+				Expect(config.Status.SecretRef).Should(Equal(fmt.Sprintf("%s-%s-data-values", testClusterName, constants.AWSCPIAddonName)))
+				return true
+			}, waitTimeout, pollingInterval).Should(BeTrue())
+
+			Eventually(func() bool {
+				secretKey := client.ObjectKey{
+					Namespace: "default",
+					Name:      fmt.Sprintf("%s-%s-data-values", clusterName, constants.AWSCPIAddonName),
+				}
+				secret := &v1.Secret{}
+				if err := k8sClient.Get(ctx, secretKey, secret); err != nil {
+					return false
+				}
+				// check data values secret contents
+				Expect(secret.Type).Should(Equal(v1.SecretTypeOpaque))
+				secretData := string(secret.Data["values.yaml"])
+				Expect(strings.Contains(secretData, "clusterName: test-cluster-aws-cpi")).Should(BeTrue())
+				Expect(strings.Contains(secretData, "region: us-west-2")).Should(BeTrue())
+				Expect(strings.Contains(secretData, "vpcID: vpc-0123456789abcdef0")).Should(BeTrue())
+				Expect(strings.Contains(secretData, "- subnet-0123456789abcdef0")).Should(BeTrue())
+				Expect(strings.Contains(secretData, "- subnet-0123456789abcdef1")).Should(BeTrue())
+				Expect(strings.Contains(secretData, "roleARN: arn:aws:iam::123456789012:role/test-role")).Should(BeTrue())
+				Expect(strings.Contains(secretData, "http_proxy: foo.com")).Should(BeTrue())
+				Expect(strings.Contains(secretData, "https_proxy: bar.com")).Should(BeTrue())
+				Expect(strings.Contains(secretData, "no_proxy: foobar.com")).Should(BeTrue())
+				return true
+			}, waitTimeout, pollingInterval).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,180 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package controllers implements k8s controller functionality for azure-cloud-controller-manager.
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterapiutil "sigs.k8s.io/cluster-api/util"
+	clusterapipatchutil "sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
+	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/metrics"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
+	"github.com/vmware-tanzu/tanzu-framework/addons/predicates"
+	cpiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cpi/v1alpha1"
+)
+
+// AzureCPIConfigReconciler reconciles a AzureCPIConfig object
+type AzureCPIConfigReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	Config addonconfig.AzureCPIConfigControllerConfig
+}
+
+//+kubebuilder:rbac:groups=cpi.tanzu.vmware.com,resources=azurecpiconfigs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cpi.tanzu.vmware.com,resources=azurecpiconfigs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureclusters;azureclusteridentities,verbs=get;list;watch
+
+// Reconcile the AzureCPIConfig CRD
+func (r *AzureCPIConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("AzureCPIConfig", req.NamespacedName)
+
+	logger.Info("Start reconciliation for AzureCPIConfig")
+
+	// fetch AzureCPIConfig resource
+	cpiConfig := &cpiv1alpha1.AzureCPIConfig{}
+	if err := r.Client.Get(ctx, req.NamespacedName, cpiConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("AzureCPIConfig resource not found")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to fetch AzureCPIConfig resource")
+		return ctrl.Result{}, err
+	}
+
+	// deep copy AzureCPIConfig to avoid issues if in the future other controllers where interacting with the same copy
+	cpiConfig = cpiConfig.DeepCopy()
+
+	cluster, err := cutil.GetOwnerCluster(ctx, r.Client, cpiConfig, req.Namespace, constants.AzureCPIDefaultRefName)
+	if err != nil {
+		if apierrors.IsNotFound(err) && cluster != nil {
+			logger.Info(fmt.Sprintf("'%s/%s' is listed as owner reference but could not be found",
+				cluster.Namespace, cluster.Name))
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "could not determine owner cluster")
+		return ctrl.Result{}, err
+	}
+
+	if res, err := r.reconcileAzureCPIConfig(ctx, cpiConfig, cluster); err != nil {
+		logger.Error(err, "Failed to reconcile AzureCPIConfig")
+		return res, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileAzureCPIConfig reconciles AzureCPIConfig with its owner cluster
+func (r *AzureCPIConfigReconciler) reconcileAzureCPIConfig(ctx context.Context, cpiConfig *cpiv1alpha1.AzureCPIConfig, cluster *clusterapiv1beta1.Cluster) (_ ctrl.Result, retErr error) {
+	patchHelper, err := clusterapipatchutil.NewHelper(cpiConfig, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// patch AzureCPIConfig before returning the function
+	defer func() {
+		r.Log.Info("Patching AzureCPIConfig")
+		if err := patchHelper.Patch(ctx, cpiConfig); err != nil {
+			r.Log.Error(err, "Error patching AzureCPIConfig")
+			retErr = err
+		}
+		r.Log.Info("Successfully patched AzureCPIConfig")
+	}()
+
+	if !cpiConfig.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if err = r.reconcileAzureCPIConfigNormal(ctx, cpiConfig, cluster); err != nil {
+		r.Log.Error(err, "Error reconciling AzureCPIConfig to create/patch data values secret")
+		return ctrl.Result{}, err
+	}
+	r.Log.Info("Successfully reconciled AzureCPIConfig")
+	return ctrl.Result{}, nil
+}
+
+// reconcileAzureCPIConfigNormal triggers when an AzureCPIConfig is not being deleted
+// it ensures the owner reference of the AzureCPIConfig and generates the data values secret for CPI
+func (r *AzureCPIConfigReconciler) reconcileAzureCPIConfigNormal(ctx context.Context,
+	cpiConfig *cpiv1alpha1.AzureCPIConfig, cluster *clusterapiv1beta1.Cluster) (retErr error) {
+	// add owner reference to AzureCPIConfig if not already added by TanzuClusterBootstrap Controller
+	ownerReference := metav1.OwnerReference{
+		APIVersion: clusterapiv1beta1.GroupVersion.String(),
+		Kind:       cluster.Kind,
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+	}
+	r.Log.Info("Ensure AzureCPIConfig has the cluster as owner reference")
+	if !clusterapiutil.HasOwnerRef(cpiConfig.OwnerReferences, ownerReference) {
+		r.Log.Info("Adding owner reference to AzureCPIConfig")
+		cpiConfig.OwnerReferences = clusterapiutil.EnsureOwnerRef(cpiConfig.OwnerReferences, ownerReference)
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      util.GenerateDataValueSecretName(cluster.Name, constants.AzureCPIAddonName),
+			Namespace: cpiConfig.Namespace,
+		},
+		Type: v1.SecretTypeOpaque,
+	}
+	secret.SetOwnerReferences([]metav1.OwnerReference{ownerReference})
+
+	mutateFn := func() error {
+		secret.StringData = make(map[string]string)
+		dvs, err := r.mapAzureCPIConfigToDataValues(ctx, cpiConfig, cluster)
+		if err != nil {
+			r.Log.Error(err, "Error while mapping AzureCPIConfig to data values")
+			return err
+		}
+		yamlBytes, err := yaml.Marshal(dvs)
+		if err != nil {
+			r.Log.Error(err, "Error marshaling AzureCPIConfig to Yaml")
+			return err
+		}
+		secret.StringData[constants.TKGDataValueFileName] = string(yamlBytes)
+		r.Log.Info("Mutated AzureCPIConfig data values")
+		return nil
+	}
+	start := time.Now()
+	result, err := controllerutil.CreateOrPatch(ctx, r.Client, secret, mutateFn)
+	if err != nil {
+		r.Log.Error(err, "Error creating or patching AzureCPIConfig data values secret")
+		return err
+	}
+	metrics.ObserveDataValuesSecretGeneration(constants.AzureCPIAddonName, start)
+
+	r.Log.Info(fmt.Sprintf("Resource '%s' data values secret '%s'", constants.AzureCPIAddonName, result))
+	// update the secret reference in AzureCPIConfig status
+	cpiConfig.Status.SecretRef = secret.Name
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AzureCPIConfigReconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&cpiv1alpha1.AzureCPIConfig{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ConfigOfKindWithoutAnnotation(constants.TKGAnnotationTemplateConfig, constants.AzureCPIConfigKind, r.Config.SystemNamespace, r.Log)).
+		Watches(
+			&source.Kind{Type: &clusterapiv1beta1.Cluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.ClusterToAzureCPIConfig),
+		).
+		Complete(r)
+}
@@ -0,0 +1,31 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+// DataValues is the data values type generated by the AzureCPIConfig CR
+type DataValues struct {
+	AzureCPI *DataValuesAzureCPI `yaml:"azureCPI,omitempty"`
+}
+
+// DataValuesAzureCPI is the data values section of Azure CPI Secret
+type DataValuesAzureCPI struct {
+	ClusterName                 string `yaml:"clusterName"`
+	CloudName                   string `yaml:"cloudName"`
+	TenantID                    string `yaml:"tenantID"`
+	SubscriptionID              string `yaml:"subscriptionID"`
+	ClientID                    string `yaml:"clientID"`
+	ClientSecret                string `yaml:"clientSecret"`
+	UseManagedIdentityExtension bool   `yaml:"useManagedIdentityExtension"`
+	UserAssignedIdentityID      string `yaml:"userAssignedIdentityID"`
+	ResourceGroup               string `yaml:"resourceGroup"`
+	Location                    string `yaml:"location"`
+	VNetName                    string `yaml:"vnetName"`
+	VNetResourceGroup           string `yaml:"vnetResourceGroup"`
+	SubnetName                  string `yaml:"subnetName"`
+	SecurityGroupName           string `yaml:"securityGroupName"`
+	RouteTableName              string `yaml:"routeTableName"`
+	HTTPProxy                   string `yaml:"http_proxy"`
+	HTTPSProxy                  string `yaml:"https_proxy"`
+	NoProxy                     string `yaml:"no_proxy"`
+}
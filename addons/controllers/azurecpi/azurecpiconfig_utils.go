@@ -0,0 +1,161 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capzv1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterapiutil "sigs.k8s.io/cluster-api/util"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	pkgtypes "github.com/vmware-tanzu/tanzu-framework/addons/pkg/types"
+	cpiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cpi/v1alpha1"
+)
+
+// ClusterToAzureCPIConfig returns a list of Requests with AzureCPIConfig ObjectKey based on Cluster events
+func (r *AzureCPIConfigReconciler) ClusterToAzureCPIConfig(o client.Object) []ctrl.Request {
+	cluster, ok := o.(*clusterapiv1beta1.Cluster)
+	if !ok {
+		r.Log.Error(errors.New("invalid type"),
+			"Expected to receive Cluster resource",
+			"actualType", fmt.Sprintf("%T", o))
+		return nil
+	}
+
+	r.Log.V(4).Info("Mapping Cluster to AzureCPIConfig")
+
+	cs := &cpiv1alpha1.AzureCPIConfigList{}
+	_ = r.List(context.Background(), cs, client.InNamespace(cluster.Namespace))
+
+	// corresponding AzureCPIConfig should have following ownerRef
+	ownerReference := metav1.OwnerReference{
+		APIVersion: clusterapiv1beta1.GroupVersion.String(),
+		Kind:       cluster.Kind,
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+	}
+
+	requests := []ctrl.Request{}
+	for i := 0; i < len(cs.Items); i++ {
+		config := &cs.Items[i]
+		// avoid enqueuing reconcile requests for template AzureCPIConfig CRs in event handler of Cluster CR
+		if _, ok := config.Annotations[constants.TKGAnnotationTemplateConfig]; ok && config.Namespace == r.Config.SystemNamespace {
+			continue
+		}
+		if clusterapiutil.HasOwnerRef(config.OwnerReferences, ownerReference) {
+			r.Log.V(4).Info("Adding AzureCPIConfig for reconciliation",
+				constants.NamespaceLogKey, config.Namespace, constants.NameLogKey, config.Name)
+
+			requests = append(requests, ctrl.Request{
+				NamespacedName: clusterapiutil.ObjectKey(config),
+			})
+		}
+	}
+
+	return requests
+}
+
+// mapAzureCPIConfigToDataValues maps AzureCPIConfig CR to data values. The values which are not set in the
+// AzureCPIConfig are derived from the AzureCluster of the cluster, its AzureClusterIdentity and the proxy annotations
+// of the cluster.
+func (r *AzureCPIConfigReconciler) mapAzureCPIConfigToDataValues(ctx context.Context,
+	cpiConfig *cpiv1alpha1.AzureCPIConfig, cluster *clusterapiv1beta1.Cluster) (*DataValues, error) {
+
+	d := &DataValuesAzureCPI{ClusterName: cluster.Name}
+	c := cpiConfig.Spec.AzureCPI
+
+	// get the azure cluster object
+	azureCluster, err := cutil.AzureClusterForCluster(ctx, r.Client, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	// derive the cloud, subscription and location from the azure cluster object
+	d.CloudName = azureCluster.Spec.AzureEnvironment
+	if d.CloudName == "" {
+		d.CloudName = capzv1beta1.DefaultAzureCloud
+	}
+	d.SubscriptionID = azureCluster.Spec.SubscriptionID
+	d.ResourceGroup = azureCluster.Spec.ResourceGroup
+	d.Location = azureCluster.Spec.Location
+
+	// derive the network settings from the vnet and the node subnet of the azure cluster object
+	d.VNetName = azureCluster.Spec.NetworkSpec.Vnet.Name
+	d.VNetResourceGroup = azureCluster.Spec.NetworkSpec.Vnet.ResourceGroup
+	if d.VNetResourceGroup == "" {
+		d.VNetResourceGroup = d.ResourceGroup
+	}
+	if subnet := cutil.AzureNodeSubnet(azureCluster); subnet != nil {
+		d.SubnetName = subnet.Name
+		d.SecurityGroupName = subnet.SecurityGroup.Name
+		d.RouteTableName = subnet.RouteTable.Name
+	}
+
+	// derive the credentials from the identity of the azure cluster object
+	creds, err := cutil.GetAzureIdentityCredentials(ctx, r.Client, azureCluster)
+	if err != nil {
+		return nil, err
+	}
+	d.TenantID = creds.TenantID
+	d.ClientID = creds.ClientID
+	d.ClientSecret = creds.ClientSecret
+	d.UseManagedIdentityExtension = creds.UseManagedIdentityExtension
+	d.UserAssignedIdentityID = creds.UserAssignedIdentityID
+
+	// derive proxy related settings from cluster annotations
+	if cluster.Annotations != nil {
+		d.HTTPProxy = cluster.Annotations[pkgtypes.HTTPProxyConfigAnnotation]
+		d.HTTPSProxy = cluster.Annotations[pkgtypes.HTTPSProxyConfigAnnotation]
+		d.NoProxy = cluster.Annotations[pkgtypes.NoProxyConfigAnnotation]
+	}
+
+	// allow API user to override the derived values if specified in the AzureCPIConfig
+	d.CloudName = tryParseString(d.CloudName, c.CloudName)
+	d.TenantID = tryParseString(d.TenantID, c.TenantID)
+	d.SubscriptionID = tryParseString(d.SubscriptionID, c.SubscriptionID)
+	if c.CredentialLocalObjRef != nil {
+		credentialSecret, err := cutil.GetSecret(ctx, r.Client, cpiConfig.Namespace, c.CredentialLocalObjRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		d.ClientID, d.ClientSecret, err = cutil.GetAzureClientIDAndSecretFromSecret(credentialSecret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.UseManagedIdentityExtension != nil {
+		d.UseManagedIdentityExtension = *c.UseManagedIdentityExtension
+	}
+	d.UserAssignedIdentityID = tryParseString(d.UserAssignedIdentityID, c.UserAssignedIdentityID)
+	d.ResourceGroup = tryParseString(d.ResourceGroup, c.ResourceGroup)
+	d.Location = tryParseString(d.Location, c.Location)
+	d.VNetName = tryParseString(d.VNetName, c.VNetName)
+	d.VNetResourceGroup = tryParseString(d.VNetResourceGroup, c.VNetResourceGroup)
+	d.SubnetName = tryParseString(d.SubnetName, c.SubnetName)
+	d.SecurityGroupName = tryParseString(d.SecurityGroupName, c.SecurityGroupName)
+	d.RouteTableName = tryParseString(d.RouteTableName, c.RouteTableName)
+	if c.Proxy != nil {
+		d.HTTPProxy = tryParseString(d.HTTPProxy, c.Proxy.HTTPProxy)
+		d.HTTPSProxy = tryParseString(d.HTTPSProxy, c.Proxy.HTTPSProxy)
+		d.NoProxy = tryParseString(d.NoProxy, c.Proxy.NoProxy)
+	}
+
+	return &DataValues{AzureCPI: d}, nil
+}
+
+// tryParseString tries to convert a string pointer and return its value, if not nil
+func tryParseString(src string, sub *string) string {
+	if sub != nil {
+		return *sub
+	}
+	return src
+}
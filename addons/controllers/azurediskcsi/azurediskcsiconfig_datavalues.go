@@ -0,0 +1,27 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+// DataValues is the data values type generated by the AzureDiskCSIConfig CR
+type DataValues struct {
+	AzureDiskCSI *DataValuesAzureDiskCSI `yaml:"azureDiskCSIDriver,omitempty"`
+}
+
+// DataValuesAzureDiskCSI is the data values section of AzureDiskCSI Secret
+type DataValuesAzureDiskCSI struct {
+	Namespace                   string `yaml:"namespace"`
+	HTTPProxy                   string `yaml:"http_proxy"`
+	HTTPSProxy                  string `yaml:"https_proxy"`
+	NoProxy                     string `yaml:"no_proxy"`
+	DeploymentReplicas          int32  `yaml:"deployment_replicas"`
+	CloudName                   string `yaml:"cloudName"`
+	TenantID                    string `yaml:"tenantID"`
+	SubscriptionID              string `yaml:"subscriptionID"`
+	ClientID                    string `yaml:"clientID"`
+	ClientSecret                string `yaml:"clientSecret"`
+	UseManagedIdentityExtension bool   `yaml:"useManagedIdentityExtension"`
+	UserAssignedIdentityID      string `yaml:"userAssignedIdentityID"`
+	ResourceGroup               string `yaml:"resourceGroup"`
+	Location                    string `yaml:"location"`
+}
@@ -0,0 +1,196 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	capzv1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterapiutil "sigs.k8s.io/cluster-api/util"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	pkgtypes "github.com/vmware-tanzu/tanzu-framework/addons/pkg/types"
+	csiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/csi/v1alpha1"
+)
+
+const (
+	defaultDataValueNameSpace          = "kube-system"
+	defaultDataValueDeploymentReplicas = 3
+)
+
+// ClusterToAzureDiskCSIConfig returns a list of Requests with AzureDiskCSIConfig ObjectKey based on Cluster events
+func (r *AzureDiskCSIConfigReconciler) ClusterToAzureDiskCSIConfig(o client.Object) []ctrl.Request {
+	cluster, ok := o.(*clusterapiv1beta1.Cluster)
+	if !ok {
+		r.Log.Error(errors.New("invalid type"),
+			"Expected to receive Cluster resource",
+			"actualType", fmt.Sprintf("%T", o))
+		return nil
+	}
+
+	r.Log.V(4).Info("Mapping Cluster to AzureDiskCSIConfig")
+
+	cs := &csiv1alpha1.AzureDiskCSIConfigList{}
+	_ = r.List(context.Background(), cs, client.InNamespace(cluster.Namespace))
+
+	// corresponding AzureDiskCSIConfig should have following ownerRef
+	ownerReference := metav1.OwnerReference{
+		APIVersion: clusterapiv1beta1.GroupVersion.String(),
+		Kind:       cluster.Kind,
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+	}
+
+	requests := []ctrl.Request{}
+	for i := 0; i < len(cs.Items); i++ {
+		config := &cs.Items[i]
+		// avoid enqueuing reconcile requests for template AzureDiskCSIConfig CRs in event handler of Cluster CR
+		if _, ok := config.Annotations[constants.TKGAnnotationTemplateConfig]; ok && config.Namespace == r.Config.SystemNamespace {
+			continue
+		}
+		if clusterapiutil.HasOwnerRef(config.OwnerReferences, ownerReference) {
+			r.Log.V(4).Info("Adding AzureDiskCSIConfig for reconciliation",
+				constants.NamespaceLogKey, config.Namespace, constants.NameLogKey, config.Name)
+
+			requests = append(requests, ctrl.Request{
+				NamespacedName: clusterapiutil.ObjectKey(config),
+			})
+		}
+	}
+
+	return requests
+}
+
+// getOwnerCluster verifies that the AzureDiskCSIConfig has a cluster as its owner reference,
+// and returns the cluster. It tries to read the cluster name from the AzureDiskCSIConfig's owner reference objects.
+// If not there, we assume the owner cluster and AzureDiskCSIConfig always has the same name.
+func (r *AzureDiskCSIConfigReconciler) getOwnerCluster(ctx context.Context,
+	azurediskCSIConfig *csiv1alpha1.AzureDiskCSIConfig) (*clusterapiv1beta1.Cluster, error) {
+
+	logger := log.FromContext(ctx)
+	cluster := &clusterapiv1beta1.Cluster{}
+	clusterName := azurediskCSIConfig.Name // usually the corresponding 'cluster' shares the same name
+
+	// retrieve the owner cluster for the AzureDiskCSIConfig object
+	for _, ownerRef := range azurediskCSIConfig.GetOwnerReferences() {
+		if strings.EqualFold(ownerRef.Kind, constants.ClusterKind) {
+			clusterName = ownerRef.Name
+			break
+		}
+	}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: azurediskCSIConfig.Namespace, Name: clusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info(fmt.Sprintf("Cluster resource '%s/%s' not found", azurediskCSIConfig.Namespace, clusterName))
+			return nil, nil
+		}
+		logger.Error(err, fmt.Sprintf("Unable to fetch cluster '%s/%s'", azurediskCSIConfig.Namespace, clusterName))
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// mapAzureDiskCSIConfigToDataValues maps AzureDiskCSIConfig CR to data values. The Azure cloud settings which are not
+// set in the AzureDiskCSIConfig are derived from the AzureCluster of the cluster and its AzureClusterIdentity, and the
+// proxy settings from the proxy annotations of the cluster.
+func (r *AzureDiskCSIConfigReconciler) mapAzureDiskCSIConfigToDataValues(ctx context.Context,
+	azureDiskCSIConfig *csiv1alpha1.AzureDiskCSIConfig,
+	cluster *clusterapiv1beta1.Cluster) (*DataValues, error) {
+
+	d := &DataValuesAzureDiskCSI{
+		Namespace:          defaultDataValueNameSpace,
+		DeploymentReplicas: defaultDataValueDeploymentReplicas,
+	}
+	c := azureDiskCSIConfig.Spec.AzureDiskCSI
+
+	// get the azure cluster object
+	azureCluster, err := cutil.AzureClusterForCluster(ctx, r.Client, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	// derive the cloud, subscription and location from the azure cluster object
+	d.CloudName = azureCluster.Spec.AzureEnvironment
+	if d.CloudName == "" {
+		d.CloudName = capzv1beta1.DefaultAzureCloud
+	}
+	d.SubscriptionID = azureCluster.Spec.SubscriptionID
+	d.ResourceGroup = azureCluster.Spec.ResourceGroup
+	d.Location = azureCluster.Spec.Location
+
+	// derive the credentials from the identity of the azure cluster object
+	creds, err := cutil.GetAzureIdentityCredentials(ctx, r.Client, azureCluster)
+	if err != nil {
+		return nil, err
+	}
+	d.TenantID = creds.TenantID
+	d.ClientID = creds.ClientID
+	d.ClientSecret = creds.ClientSecret
+	d.UseManagedIdentityExtension = creds.UseManagedIdentityExtension
+	d.UserAssignedIdentityID = creds.UserAssignedIdentityID
+
+	// derive proxy related settings from cluster annotations
+	if cluster.Annotations != nil {
+		d.HTTPProxy = cluster.Annotations[pkgtypes.HTTPProxyConfigAnnotation]
+		d.HTTPSProxy = cluster.Annotations[pkgtypes.HTTPSProxyConfigAnnotation]
+		d.NoProxy = cluster.Annotations[pkgtypes.NoProxyConfigAnnotation]
+	}
+
+	// allow API user to override the derived values if specified in the AzureDiskCSIConfig
+	if c.Namespace != "" {
+		d.Namespace = c.Namespace
+	}
+	if c.HTTPProxy != "" {
+		d.HTTPProxy = c.HTTPProxy
+	}
+	if c.HTTPSProxy != "" {
+		d.HTTPSProxy = c.HTTPSProxy
+	}
+	if c.NoProxy != "" {
+		d.NoProxy = c.NoProxy
+	}
+	if c.DeploymentReplicas != nil {
+		d.DeploymentReplicas = *c.DeploymentReplicas
+	}
+	d.CloudName = tryParseString(d.CloudName, c.CloudName)
+	d.TenantID = tryParseString(d.TenantID, c.TenantID)
+	d.SubscriptionID = tryParseString(d.SubscriptionID, c.SubscriptionID)
+	if c.CredentialLocalObjRef != nil {
+		credentialSecret, err := cutil.GetSecret(ctx, r.Client, azureDiskCSIConfig.Namespace, c.CredentialLocalObjRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		d.ClientID, d.ClientSecret, err = cutil.GetAzureClientIDAndSecretFromSecret(credentialSecret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.UseManagedIdentityExtension != nil {
+		d.UseManagedIdentityExtension = *c.UseManagedIdentityExtension
+	}
+	d.UserAssignedIdentityID = tryParseString(d.UserAssignedIdentityID, c.UserAssignedIdentityID)
+	d.ResourceGroup = tryParseString(d.ResourceGroup, c.ResourceGroup)
+	d.Location = tryParseString(d.Location, c.Location)
+
+	return &DataValues{AzureDiskCSI: d}, nil
+}
+
+// tryParseString tries to convert a string pointer and return its value, if not nil
+func tryParseString(src string, sub *string) string {
+	if sub != nil {
+		return *sub
+	}
+	return src
+}
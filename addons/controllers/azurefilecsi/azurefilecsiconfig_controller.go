@@ -5,6 +5,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"gopkg.in/yaml.v2"
@@ -23,6 +24,7 @@ import (
 
 	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/metrics"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
 	"github.com/vmware-tanzu/tanzu-framework/addons/predicates"
 	csiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/csi/v1alpha1"
@@ -143,11 +145,13 @@ func (r *AzureFileCSIConfigReconciler) reconcileAzureFileCSIConfigNormal(ctx con
 
 	secret.SetOwnerReferences([]metav1.OwnerReference{ownerRef})
 
+	start := time.Now()
 	_, err := controllerutil.CreateOrPatch(ctx, r.Client, secret, mutateFn)
 	if err != nil {
 		logger.Error(err, "Error creating or patching AzureFileCSIConfig data values secret")
 		return ctrl.Result{}, err
 	}
+	metrics.ObserveDataValuesSecretGeneration(constants.AzureFileCSIAddonName, start)
 
 	csiCfg.Status.SecretRef = &secret.Name
 
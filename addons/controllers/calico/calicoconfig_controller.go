@@ -7,6 +7,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	yaml "gopkg.in/yaml.v3"
@@ -28,6 +29,7 @@ import (
 	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
 	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/metrics"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
 	"github.com/vmware-tanzu/tanzu-framework/addons/predicates"
 	cniv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cni/v1alpha1"
@@ -211,11 +213,13 @@ func (r *CalicoConfigReconciler) ReconcileCalicoDataValuesSecret(
 	}
 
 	// create/patch the data values secret for CalicoConfig
+	start := time.Now()
 	result, err := controllerutil.CreateOrPatch(r.Ctx, r.Client, calicoDataValuesSecret, calicoDataValuesSecretMutateFn)
 	if err != nil {
 		logger.Error(err, "Error creating or patching CalicoConfig data values secret")
 		return err
 	}
+	metrics.ObserveDataValuesSecretGeneration(constants.CalicoAddonName, start)
 	logger.Info(fmt.Sprintf("Resource '%s' data values secret '%s'", constants.CalicoAddonName, result))
 
 	return nil
@@ -11,6 +11,8 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -25,6 +27,7 @@ import (
 	"k8s.io/utils/pointer"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterapiutil "sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	clusterapipatchutil "sigs.k8s.io/cluster-api/util/patch"
 	clusterApiPredicates "sigs.k8s.io/cluster-api/util/predicates"
 	secretutil "sigs.k8s.io/cluster-api/util/secret"
@@ -227,12 +230,12 @@ func (r *ClusterBootstrapReconciler) reconcileNormal(cluster *clusterapiv1beta1.
 		return ctrl.Result{RequeueAfter: constants.RequeueAfterDuration}, err
 	}
 
-	_, err = r.createOrPatchResourcesForAdditionalPackages(cluster, clusterBootstrap, remoteClient, log)
+	result, err := r.createOrPatchResourcesForAdditionalPackages(cluster, clusterBootstrap, remoteClient, log)
 	if err != nil {
 		return ctrl.Result{RequeueAfter: constants.RequeueAfterDuration}, err
 	}
 
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 func (r *ClusterBootstrapReconciler) createOrPatchResourcesForCorePackages(cluster *clusterapiv1beta1.Cluster,
@@ -283,7 +286,18 @@ func (r *ClusterBootstrapReconciler) createOrPatchResourcesForAdditionalPackages
 	remoteClient client.Client,
 	log logr.Logger) (ctrl.Result, error) {
 
+	var result ctrl.Result
 	for _, additionalPkg := range clusterBootstrap.Spec.AdditionalPackages {
+		// The PackageInstall of a package is only created once its dependencies have reconciled successfully, the
+		// reconciliation is requeued until then
+		waiting, err := r.waitForPackageDependencies(cluster, clusterBootstrap, additionalPkg, remoteClient, log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if waiting {
+			result = ctrl.Result{RequeueAfter: constants.RequeueAfterDuration}
+			continue
+		}
 		if err := r.createOrPatchAddonResourcesOnRemote(cluster, additionalPkg, remoteClient); err != nil {
 			// Logging has been handled in createOrPatchAddonResourcesOnRemote()
 			return ctrl.Result{}, err
@@ -311,7 +325,100 @@ func (r *ClusterBootstrapReconciler) createOrPatchResourcesForAdditionalPackages
 		return ctrl.Result{Requeue: true}, err
 	}
 
-	return ctrl.Result{}, nil
+	return result, nil
+}
+
+// waitForPackageDependencies returns true if the PackageInstall of an additional package is not created yet and some of
+// the packages it depends on have not reconciled successfully. The WaitingForDependencies condition of the package is
+// set on the ClusterBootstrap with the packages it waits on, and removed once the package stops waiting.
+func (r *ClusterBootstrapReconciler) waitForPackageDependencies(cluster *clusterapiv1beta1.Cluster,
+	clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap,
+	cbPkg *runtanzuv1alpha3.ClusterBootstrapPackage,
+	remoteClient client.Client,
+	log logr.Logger) (bool, error) {
+
+	if len(cbPkg.DependsOn) == 0 {
+		return false, nil
+	}
+
+	var pendingDependencies []string
+	pkgi, err := r.getRemotePackageInstall(cluster, cbPkg.RefName, remoteClient)
+	if err != nil {
+		return false, err
+	}
+	// Dependencies are only honoured when creating the PackageInstall, an existing PackageInstall keeps being patched
+	if pkgi == nil {
+		for _, dependency := range cbPkg.DependsOn {
+			dependencyPkgi, err := r.getRemotePackageInstall(cluster, dependency, remoteClient)
+			if err != nil {
+				return false, err
+			}
+			if dependencyPkgi == nil || !isPackageInstallReconcileSucceeded(dependencyPkgi) {
+				pendingDependencies = append(pendingDependencies, dependency)
+			}
+		}
+	}
+
+	conditionType := clusterapiv1beta1.ConditionType(cases.Title(language.Und).String(strings.Split(cbPkg.RefName, ".")[0]) +
+		"-" + constants.WaitingForDependenciesConditionType)
+	existingCondition := conditions.Get(clusterBootstrap, conditionType)
+	if len(pendingDependencies) == 0 && existingCondition == nil {
+		return false, nil
+	}
+
+	patchHelper, err := clusterapipatchutil.NewHelper(clusterBootstrap, r.Client)
+	if err != nil {
+		return false, err
+	}
+	if len(pendingDependencies) == 0 {
+		conditions.Delete(clusterBootstrap, conditionType)
+	} else {
+		message := fmt.Sprintf("waiting for packages %s to reconcile successfully", strings.Join(pendingDependencies, ", "))
+		if existingCondition != nil && existingCondition.Message == message {
+			return true, nil
+		}
+		conditions.Set(clusterBootstrap, &clusterapiv1beta1.Condition{
+			Type:     conditionType,
+			Status:   corev1.ConditionTrue,
+			Severity: clusterapiv1beta1.ConditionSeverityInfo,
+			Reason:   constants.DependenciesNotReadyReason,
+			Message:  message,
+		})
+		log.Info(fmt.Sprintf("skip creating the PackageInstall for the package %s on cluster %s/%s, %s",
+			cbPkg.RefName, cluster.Namespace, cluster.Name, message))
+	}
+	if err := patchHelper.Patch(r.context, clusterBootstrap); err != nil {
+		log.Error(err, "failed to update clusterBootstrap status")
+		return false, err
+	}
+
+	return len(pendingDependencies) > 0, nil
+}
+
+// getRemotePackageInstall returns the PackageInstall of a package on the remote cluster, nil if it does not exist
+func (r *ClusterBootstrapReconciler) getRemotePackageInstall(cluster *clusterapiv1beta1.Cluster, pkgName string, remoteClient client.Client) (*kapppkgiv1alpha1.PackageInstall, error) {
+	packageRefName, _, err := util.GetPackageMetadata(r.context, r.aggregatedAPIResourcesClient, pkgName, cluster.Namespace)
+	if err != nil || packageRefName == "" {
+		errorMsg := fmt.Sprintf("unable to fetch Package.Spec.RefName from Package %s/%s", cluster.Namespace, pkgName)
+		r.Log.Error(err, errorMsg)
+		return nil, errors.Wrap(err, errorMsg)
+	}
+
+	pkgi := &kapppkgiv1alpha1.PackageInstall{}
+	key := client.ObjectKey{Namespace: r.Config.SystemNamespace, Name: util.GeneratePackageInstallName(cluster.Name, packageRefName)}
+	if err := remoteClient.Get(r.context, key, pkgi); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return pkgi, nil
+}
+
+// isPackageInstallReconcileSucceeded returns true if the summarized condition of a PackageInstall is ReconcileSucceeded
+func isPackageInstallReconcileSucceeded(pkgi *kapppkgiv1alpha1.PackageInstall) bool {
+	condition := util.SummarizeAppConditions(pkgi.Status.Conditions)
+	return condition != nil && condition.Type == kappctrlv1alpha1.ReconcileSucceeded
 }
 
 func (r *ClusterBootstrapReconciler) addFinalizersToClusterResources(cluster *clusterapiv1beta1.Cluster, log logr.Logger) error {
@@ -546,6 +653,8 @@ func (r *ClusterBootstrapReconciler) mergeClusterBootstrapPackagesWithTemplate(
 	// Add the additional package if it's only present in the new ClusterBootstrapTemplate
 	// Leave the package as it is if it's only present in ClusterBootstrap but not in the new Template
 	additionalPackageMap := map[string]*runtanzuv1alpha3.ClusterBootstrapPackage{}
+	// renamedPackages maps the old refNames of the updated additional packages to their new refNames
+	renamedPackages := map[string]string{}
 
 	for _, pkg := range updatedClusterBootstrap.Spec.AdditionalPackages {
 		packageRefName, _, err := util.GetPackageMetadata(r.context, r.aggregatedAPIResourcesClient, pkg.RefName, cluster.Namespace)
@@ -568,7 +677,11 @@ func (r *ClusterBootstrapReconciler) mergeClusterBootstrapPackagesWithTemplate(
 
 		// Find the one to one match for additional package in new ClusterBootstrapTemplate and old ClusterBootstrap and update
 		if pkg, ok := additionalPackageMap[packageRefName]; ok {
+			renamedPackages[pkg.RefName] = templatePkg.RefName
 			pkg.RefName = templatePkg.RefName
+			if templatePkg.DependsOn != nil {
+				pkg.DependsOn = append([]string{}, templatePkg.DependsOn...)
+			}
 		} else {
 			// If new additional package is added in ClusterBootstrapTemplate, just add it to updated ClusterBootstrap
 			newPkg := templatePkg.DeepCopy()
@@ -577,6 +690,15 @@ func (r *ClusterBootstrapReconciler) mergeClusterBootstrapPackagesWithTemplate(
 		}
 	}
 
+	// The dependencies of the additional packages kept from ClusterBootstrap follow the updated refNames
+	for _, pkg := range updatedClusterBootstrap.Spec.AdditionalPackages {
+		for i, dependency := range pkg.DependsOn {
+			if newRefName, ok := renamedPackages[dependency]; ok {
+				pkg.DependsOn[i] = newRefName
+			}
+		}
+	}
+
 	return packages, nil
 }
 
@@ -21,10 +21,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterapiutil "sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/secret"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	kapppkgiv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
 	kapppkgv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
 	antreaconfigv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cni/v1alpha1"
@@ -677,6 +679,36 @@ var _ = Describe("ClusterBootstrap Reconciler", func() {
 					err = k8sClient.Update(ctx, mutateClusterBootstrap)
 					Expect(strings.Contains(err.Error(), "package downgrade is not allowed")).To(BeTrue())
 
+					// Additional package can't depend on itself
+					mutateClusterBootstrap = clusterBootstrap.DeepCopy()
+					additionalPackages := mutateClusterBootstrap.Spec.AdditionalPackages
+					additionalPackages[0].DependsOn = []string{additionalPackages[0].RefName}
+					err = k8sClient.Update(ctx, mutateClusterBootstrap)
+					Expect(strings.Contains(err.Error(), "package can't depend on itself")).To(BeTrue())
+
+					// Additional package can only depend on other additional packages
+					mutateClusterBootstrap = clusterBootstrap.DeepCopy()
+					mutateClusterBootstrap.Spec.AdditionalPackages[0].DependsOn = []string{mutateClusterBootstrap.Spec.CNI.RefName}
+					err = k8sClient.Update(ctx, mutateClusterBootstrap)
+					Expect(strings.Contains(err.Error(), "dependency must be the refName of another additional package")).To(BeTrue())
+
+					// Dependencies of additional packages can't form a cycle
+					mutateClusterBootstrap = clusterBootstrap.DeepCopy()
+					additionalPackages = mutateClusterBootstrap.Spec.AdditionalPackages
+					additionalPackages[0].DependsOn = []string{additionalPackages[1].RefName}
+					additionalPackages[1].DependsOn = []string{additionalPackages[2].RefName}
+					additionalPackages[2].DependsOn = []string{additionalPackages[0].RefName}
+					err = k8sClient.Update(ctx, mutateClusterBootstrap)
+					Expect(strings.Contains(err.Error(), "dependencies of additional packages form a cycle")).To(BeTrue())
+					Expect(strings.Contains(err.Error(), fmt.Sprintf("%s -> %s -> %s -> %s", additionalPackages[0].RefName,
+						additionalPackages[1].RefName, additionalPackages[2].RefName, additionalPackages[0].RefName))).To(BeTrue())
+
+					// Core packages can't have dependencies
+					mutateClusterBootstrap = clusterBootstrap.DeepCopy()
+					mutateClusterBootstrap.Spec.CNI.DependsOn = []string{mutateClusterBootstrap.Spec.AdditionalPackages[0].RefName}
+					err = k8sClient.Update(ctx, mutateClusterBootstrap)
+					Expect(strings.Contains(err.Error(), "only additional packages can have dependencies")).To(BeTrue())
+
 					// Additional package can't be removed
 					mutateClusterBootstrap = clusterBootstrap.DeepCopy()
 					mutateClusterBootstrap.Spec.AdditionalPackages = mutateClusterBootstrap.Spec.AdditionalPackages[:len(mutateClusterBootstrap.Spec.AdditionalPackages)-1]
@@ -706,6 +738,17 @@ var _ = Describe("ClusterBootstrap Reconciler", func() {
 					Expect(strings.Contains(err.Error(), "calicoconfigs.cni.tanzu.vmware.com \"invalidName\" not found")).To(BeTrue())
 					Expect(strings.Contains(err.Error(), "unable to find server preferred resource run.tanzu.vmware.com/foo")).To(BeTrue())
 
+					// case3
+					in = builder.ClusterBootstrap(addonNamespace, "test-cb-1").
+						WithKappPackage(builder.ClusterBootstrapPackage("kapp-controller.tanzu.vmware.com.0.30.2").Build()).
+						WithCNIPackage(builder.ClusterBootstrapPackage("calico.tanzu.vmware.com.3.19.1--vmware.1-tkg.1").Build()).
+						WithAdditionalPackage(builder.ClusterBootstrapPackage("foobar.example.com.1.17.2").WithDependsOn("foobar1.example.com.1.17.2").Build()).
+						WithAdditionalPackage(builder.ClusterBootstrapPackage("foobar1.example.com.1.17.2").WithDependsOn("foobar.example.com.1.17.2", "foobar2.example.com.1.17.2").Build()).Build()
+					err = k8sClient.Create(ctx, in)
+					Expect(err).Should(HaveOccurred())
+					Expect(strings.Contains(err.Error(), "dependency must be the refName of another additional package")).To(BeTrue())
+					Expect(strings.Contains(err.Error(), "foobar.example.com.1.17.2 -> foobar1.example.com.1.17.2 -> foobar.example.com.1.17.2")).To(BeTrue())
+
 				})
 
 				By("Test ClusterBootstrapTemplate webhook validateUpdate", func() {
@@ -1161,6 +1204,63 @@ var _ = Describe("ClusterBootstrap Reconciler", func() {
 			})
 		})
 	})
+
+	When("cluster is created with an additional package depending on another one", func() {
+		BeforeEach(func() {
+			clusterName = "test-cluster-7"
+			clusterNamespace = "cluster-namespace-7"
+			clusterResourceFilePath = "testdata/test-cluster-bootstrap-7.yaml"
+		})
+		Context("from a ClusterBootstrapTemplate", func() {
+			It("should only create the PackageInstall of the dependent package once its dependency has reconciled successfully", func() {
+
+				By("setting cluster phase to provisioned")
+				cluster := &clusterapiv1beta1.Cluster{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: clusterNamespace, Name: clusterName}, cluster)).To(Succeed())
+				cluster.Status.Phase = string(clusterapiv1beta1.ClusterPhaseProvisioned)
+				Expect(k8sClient.Status().Update(ctx, cluster)).To(Succeed())
+
+				remoteClient, err := util.GetClusterClient(ctx, k8sClient, scheme, clusterapiutil.ObjectKey(cluster))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remoteClient).NotTo(BeNil())
+
+				foobarPkgiKey := client.ObjectKey{Namespace: constants.TKGSystemNS, Name: util.GeneratePackageInstallName(clusterName, foobarCarvelPackageRefName)}
+				foobar1PkgiKey := client.ObjectKey{Namespace: constants.TKGSystemNS, Name: util.GeneratePackageInstallName(clusterName, foobar1CarvelPackageRefName)}
+				waitingCondType := clusterapiv1beta1.ConditionType("Foobar1-" + constants.WaitingForDependenciesConditionType)
+
+				By("verifying that the PackageInstall of the dependency is created on the workload cluster")
+				assertEventuallyExistInNamespace(ctx, remoteClient, foobarPkgiKey.Namespace, foobarPkgiKey.Name, &kapppkgiv1alpha1.PackageInstall{})
+
+				By("verifying that the dependent package waits for its dependency")
+				clusterBootstrap := &runtanzuv1alpha3.ClusterBootstrap{}
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), clusterBootstrap)).To(Succeed())
+					condition := conditions.Get(clusterBootstrap, waitingCondType)
+					g.Expect(condition).NotTo(BeNil())
+					g.Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+					g.Expect(condition.Reason).To(Equal(constants.DependenciesNotReadyReason))
+					g.Expect(condition.Message).To(ContainSubstring(foobarCarvelPackageName))
+				}, waitTimeout, pollingInterval).Should(Succeed())
+
+				Consistently(func() bool {
+					err := remoteClient.Get(ctx, foobar1PkgiKey, &kapppkgiv1alpha1.PackageInstall{})
+					return apierrors.IsNotFound(err)
+				}, 3*pollingInterval, pollingInterval).Should(BeTrue())
+
+				By("marking the PackageInstall of the dependency as reconciled successfully")
+				updatePkgInstallStatus(foobarPkgiKey, kappctrlv1alpha1.ReconcileSucceeded)
+
+				By("verifying that the PackageInstall of the dependent package is created on the workload cluster")
+				assertEventuallyExistInNamespace(ctx, remoteClient, foobar1PkgiKey.Namespace, foobar1PkgiKey.Name, &kapppkgiv1alpha1.PackageInstall{})
+
+				By("verifying that the WaitingForDependencies condition is removed")
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), clusterBootstrap)).To(Succeed())
+					g.Expect(conditions.Get(clusterBootstrap, waitingCondType)).To(BeNil())
+				}, waitTimeout, pollingInterval).Should(Succeed())
+			})
+		})
+	})
 })
 
 func assertSecretContains(ctx context.Context, k8sClient client.Client, namespace, name string, secretContent map[string][]byte) {
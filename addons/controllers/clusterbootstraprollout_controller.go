@@ -0,0 +1,441 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	clusterapipatchutil "sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+)
+
+// ClusterBootstrapRolloutReconciler contains the reconciler information for ClusterBootstrapRollout controller
+type ClusterBootstrapRolloutReconciler struct {
+	Client client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	Config *addonconfig.ClusterBootstrapRolloutControllerConfig
+	ctx    context.Context
+
+	// aggregatedAPIResourcesClient is used when it is required to directly read from the server and not to use object caches
+	aggregatedAPIResourcesClient client.Client
+}
+
+// rolloutTarget is a cluster selected by a ClusterBootstrapRollout, along with the package of its ClusterBootstrap
+// which is rolled out
+type rolloutTarget struct {
+	clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap
+	cbPkg            *runtanzuv1alpha3.ClusterBootstrapPackage
+	status           *runtanzuv1alpha3.ClusterBootstrapRolloutClusterStatus
+}
+
+// NewClusterBootstrapRolloutReconciler returns a reconciler for ClusterBootstrapRollout
+func NewClusterBootstrapRolloutReconciler(c client.Client, log logr.Logger, scheme *runtime.Scheme, config *addonconfig.ClusterBootstrapRolloutControllerConfig) *ClusterBootstrapRolloutReconciler {
+	return &ClusterBootstrapRolloutReconciler{
+		Client: c,
+		Log:    log,
+		Scheme: scheme,
+		Config: config,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterBootstrapRolloutReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&runtanzuv1alpha3.ClusterBootstrapRollout{}).
+		Watches(
+			&source.Kind{Type: &runtanzuv1alpha3.ClusterBootstrap{}},
+			handler.EnqueueRequestsFromMapFunc(r.clusterBootstrapToClusterBootstrapRollouts),
+		).
+		WithOptions(options).
+		Complete(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	r.ctx = ctx
+	if r.aggregatedAPIResourcesClient, err = client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=run.tanzu.vmware.com,resources=clusterbootstraprollouts,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=run.tanzu.vmware.com,resources=clusterbootstraprollouts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=run.tanzu.vmware.com,resources=clusterBootstraps,verbs=get;list;watch;update;patch
+
+// Reconcile performs the reconciliation action for the controller; which is updating the package of the ClusterBootstrap
+// of the selected clusters in batches, and waiting for the PackageInstall of each batch to be reconciled successfully
+// before moving on to the next batch
+func (r *ClusterBootstrapRolloutReconciler) Reconcile(_ context.Context, req ctrl.Request) (_ ctrl.Result, retErr error) {
+	log := r.Log.WithValues(constants.NamespaceLogKey, req.Namespace, constants.NameLogKey, req.Name)
+
+	rollout := &runtanzuv1alpha3.ClusterBootstrapRollout{}
+	if err := r.Client.Get(r.ctx, req.NamespacedName, rollout); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("ClusterBootstrapRollout not found")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errors.Wrap(err, "unable to fetch ClusterBootstrapRollout")
+	}
+
+	if !rollout.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := clusterapipatchutil.NewHelper(rollout, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer func() {
+		rollout.Status.ObservedGeneration = rollout.Generation
+		if err := patchHelper.Patch(r.ctx, rollout); err != nil {
+			log.Error(err, "unable to patch ClusterBootstrapRollout")
+			retErr = kerrors.NewAggregate([]error{retErr, err})
+		}
+	}()
+
+	return r.reconcileNormal(rollout, log)
+}
+
+func (r *ClusterBootstrapRolloutReconciler) reconcileNormal(rollout *runtanzuv1alpha3.ClusterBootstrapRollout, log logr.Logger) (ctrl.Result, error) {
+	rolloutPkg := &rollout.Spec.Package
+	if rolloutPkg.ValuesFrom != nil && rolloutPkg.ValuesFrom.ProviderRef != nil {
+		conditions.MarkFalse(rollout, clusterapiv1beta1.ReadyCondition, constants.InvalidRolloutPackageReason,
+			clusterapiv1beta1.ConditionSeverityError, "providerRef is not supported in the valuesFrom of the package")
+		return ctrl.Result{}, nil
+	}
+
+	pkgName, pkgVersion, err := util.GetPackageMetadata(r.ctx, r.aggregatedAPIResourcesClient, rolloutPkg.RefName, r.Config.SystemNamespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkFalse(rollout, clusterapiv1beta1.ReadyCondition, constants.InvalidRolloutPackageReason,
+				clusterapiv1beta1.ConditionSeverityError, "package %s not found in namespace %s", rolloutPkg.RefName, r.Config.SystemNamespace)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errors.Wrapf(err, "unable to get the package %s", rolloutPkg.RefName)
+	}
+
+	targets, err := r.getRolloutTargets(rollout, pkgName, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, target := range targets {
+		setRolloutClusterState(target, rollout, pkgName, pkgVersion)
+	}
+
+	if !rollout.Spec.Paused && countRolloutClusters(targets, runtanzuv1alpha3.RolloutClusterStateFailed) == 0 &&
+		countRolloutClusters(targets, runtanzuv1alpha3.RolloutClusterStateUpdating) == 0 {
+		r.updateNextRolloutBatch(rollout, targets, log)
+	}
+
+	rollout.Status.Clusters = make([]runtanzuv1alpha3.ClusterBootstrapRolloutClusterStatus, 0, len(targets))
+	for _, target := range targets {
+		rollout.Status.Clusters = append(rollout.Status.Clusters, *target.status)
+	}
+
+	return summarizeRollout(rollout, targets, log), nil
+}
+
+// getRolloutTargets returns the clusters selected by the rollout whose ClusterBootstrap has a package with the same
+// Carvel package refName as the rolled out package, sorted by cluster name
+func (r *ClusterBootstrapRolloutReconciler) getRolloutTargets(rollout *runtanzuv1alpha3.ClusterBootstrapRollout, pkgName string, log logr.Logger) ([]*rolloutTarget, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&rollout.Spec.ClusterSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cluster selector")
+	}
+
+	clusterList := &clusterapiv1beta1.ClusterList{}
+	if err := r.Client.List(r.ctx, clusterList, client.InNamespace(rollout.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, errors.Wrap(err, "unable to list clusters")
+	}
+	sort.Slice(clusterList.Items, func(i, j int) bool {
+		return clusterList.Items[i].Name < clusterList.Items[j].Name
+	})
+
+	previousStatuses := map[string]runtanzuv1alpha3.ClusterBootstrapRolloutClusterStatus{}
+	for _, clusterStatus := range rollout.Status.Clusters {
+		previousStatuses[clusterStatus.Name] = clusterStatus
+	}
+
+	// package names are cached as ClusterBootstraps of different clusters usually share the same packages
+	pkgNames := map[string]string{}
+	var targets []*rolloutTarget
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if !cluster.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		clusterBootstrap := &runtanzuv1alpha3.ClusterBootstrap{}
+		if err := r.Client.Get(r.ctx, client.ObjectKeyFromObject(cluster), clusterBootstrap); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "unable to fetch ClusterBootstrap for cluster %s", cluster.Name)
+		}
+		if clusterBootstrap.Spec == nil {
+			continue
+		}
+
+		cbPkg := r.findClusterBootstrapPackage(clusterBootstrap, pkgName, pkgNames, log)
+		if cbPkg == nil {
+			log.V(4).Info("package is not installed on cluster, skipping", constants.ClusterNameLogKey, cluster.Name)
+			continue
+		}
+
+		clusterStatus := runtanzuv1alpha3.ClusterBootstrapRolloutClusterStatus{Name: cluster.Name, State: runtanzuv1alpha3.RolloutClusterStatePending}
+		if previousStatus, ok := previousStatuses[cluster.Name]; ok {
+			clusterStatus = previousStatus
+		}
+		targets = append(targets, &rolloutTarget{clusterBootstrap: clusterBootstrap, cbPkg: cbPkg, status: &clusterStatus})
+	}
+
+	return targets, nil
+}
+
+// findClusterBootstrapPackage returns the package of the ClusterBootstrap whose Carvel package refName is pkgName, or nil
+func (r *ClusterBootstrapRolloutReconciler) findClusterBootstrapPackage(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap,
+	pkgName string, pkgNames map[string]string, log logr.Logger) *runtanzuv1alpha3.ClusterBootstrapPackage {
+
+	packages := append([]*runtanzuv1alpha3.ClusterBootstrapPackage{
+		clusterBootstrap.Spec.CNI,
+		clusterBootstrap.Spec.CSI,
+		clusterBootstrap.Spec.CPI,
+		clusterBootstrap.Spec.Kapp,
+	}, clusterBootstrap.Spec.AdditionalPackages...)
+
+	for _, cbPkg := range packages {
+		if cbPkg == nil {
+			continue
+		}
+		name, ok := pkgNames[cbPkg.RefName]
+		if !ok {
+			var err error
+			name, _, err = util.GetPackageMetadata(r.ctx, r.aggregatedAPIResourcesClient, cbPkg.RefName, r.Config.SystemNamespace)
+			if err != nil {
+				log.Error(err, "unable to get the package", "package", cbPkg.RefName)
+				continue
+			}
+			pkgNames[cbPkg.RefName] = name
+		}
+		if name == pkgName {
+			return cbPkg
+		}
+	}
+
+	return nil
+}
+
+// setRolloutClusterState updates the rollout state of the cluster from its ClusterBootstrap package and status
+func setRolloutClusterState(target *rolloutTarget, rollout *runtanzuv1alpha3.ClusterBootstrapRollout, pkgName, pkgVersion string) {
+	clusterStatus := target.status
+
+	// the rollout is paused when a cluster fails, so a failed cluster of a running rollout means the rollout has been
+	// resumed and the cluster is retried
+	if clusterStatus.State == runtanzuv1alpha3.RolloutClusterStateFailed && !rollout.Spec.Paused {
+		clusterStatus.State = runtanzuv1alpha3.RolloutClusterStatePending
+		clusterStatus.LastUpdateTime = nil
+		clusterStatus.Message = ""
+	}
+
+	if !isRolloutPackageApplied(target.cbPkg, &rollout.Spec.Package) {
+		// keep the failure of a ClusterBootstrap the rollout could not update
+		if clusterStatus.State != runtanzuv1alpha3.RolloutClusterStateFailed {
+			clusterStatus.State = runtanzuv1alpha3.RolloutClusterStatePending
+			clusterStatus.LastUpdateTime = nil
+			clusterStatus.Message = ""
+			if target.clusterBootstrap.Spec.Paused {
+				clusterStatus.Message = "ClusterBootstrap is paused"
+			}
+		}
+		return
+	}
+
+	if clusterStatus.State == runtanzuv1alpha3.RolloutClusterStateUpdated {
+		return
+	}
+	if clusterStatus.LastUpdateTime == nil {
+		// the package has been updated outside of the rollout
+		now := metav1.Now()
+		clusterStatus.LastUpdateTime = &now
+	}
+
+	var pkgStatus *runtanzuv1alpha3.ClusterBootstrapPackageStatus
+	for i := range target.clusterBootstrap.Status.Packages {
+		if target.clusterBootstrap.Status.Packages[i].Name == pkgName {
+			pkgStatus = &target.clusterBootstrap.Status.Packages[i]
+			break
+		}
+	}
+
+	progressDeadline := rolloutProgressDeadline(rollout)
+
+	switch {
+	case pkgStatus != nil && pkgStatus.RefName == target.cbPkg.RefName && pkgStatus.Version == pkgVersion &&
+		pkgStatus.State == string(kappctrlv1alpha1.ReconcileSucceeded):
+		clusterStatus.State = runtanzuv1alpha3.RolloutClusterStateUpdated
+		clusterStatus.Message = ""
+	case pkgStatus != nil && pkgStatus.RefName == target.cbPkg.RefName &&
+		pkgStatus.State == string(kappctrlv1alpha1.ReconcileFailed) &&
+		!pkgStatus.LastTransitionTime.Before(clusterStatus.LastUpdateTime):
+		clusterStatus.State = runtanzuv1alpha3.RolloutClusterStateFailed
+		clusterStatus.Message = fmt.Sprintf("package failed to reconcile: %s", pkgStatus.LastError)
+	case time.Since(clusterStatus.LastUpdateTime.Time) > progressDeadline:
+		clusterStatus.State = runtanzuv1alpha3.RolloutClusterStateFailed
+		clusterStatus.Message = fmt.Sprintf("package did not reconcile successfully within %s", progressDeadline)
+	default:
+		clusterStatus.State = runtanzuv1alpha3.RolloutClusterStateUpdating
+		clusterStatus.Message = ""
+	}
+}
+
+// isRolloutPackageApplied returns true if the ClusterBootstrap package has the refName and values of the rolled out package
+func isRolloutPackageApplied(cbPkg *runtanzuv1alpha3.ClusterBootstrapPackage, rolloutPkg *runtanzuv1alpha3.ClusterBootstrapRolloutPackage) bool {
+	if cbPkg.RefName != rolloutPkg.RefName {
+		return false
+	}
+	return rolloutPkg.ValuesFrom == nil || apiequality.Semantic.DeepEqual(cbPkg.ValuesFrom, rolloutPkg.ValuesFrom)
+}
+
+// updateNextRolloutBatch updates the package of the ClusterBootstrap of the next batch of pending clusters. The first
+// batch is made of the canary clusters.
+func (r *ClusterBootstrapRolloutReconciler) updateNextRolloutBatch(rollout *runtanzuv1alpha3.ClusterBootstrapRollout, targets []*rolloutTarget, log logr.Logger) {
+	batchSize := rollout.Spec.Strategy.BatchSize
+	if countRolloutClusters(targets, runtanzuv1alpha3.RolloutClusterStateUpdated) == 0 {
+		batchSize = rollout.Spec.Strategy.CanaryClusters
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	var updated int32
+	for _, target := range targets {
+		if updated == batchSize {
+			return
+		}
+		if target.status.State != runtanzuv1alpha3.RolloutClusterStatePending || target.clusterBootstrap.Spec.Paused {
+			continue
+		}
+		updated++
+
+		log.Info("updating ClusterBootstrap package", constants.ClusterNameLogKey, target.clusterBootstrap.Name,
+			"from", target.cbPkg.RefName, "to", rollout.Spec.Package.RefName)
+		if err := r.updateClusterBootstrapPackage(target, &rollout.Spec.Package); err != nil {
+			log.Error(err, "unable to update ClusterBootstrap package", constants.ClusterNameLogKey, target.clusterBootstrap.Name)
+			target.status.State = runtanzuv1alpha3.RolloutClusterStateFailed
+			target.status.Message = fmt.Sprintf("unable to update ClusterBootstrap: %s", err.Error())
+			continue
+		}
+		now := metav1.Now()
+		target.status.State = runtanzuv1alpha3.RolloutClusterStateUpdating
+		target.status.LastUpdateTime = &now
+		target.status.Message = ""
+	}
+}
+
+// updateClusterBootstrapPackage patches the ClusterBootstrap package with the refName and values of the rolled out
+// package. The dependencies of the additional packages on the package follow its new refName in the same patch.
+func (r *ClusterBootstrapRolloutReconciler) updateClusterBootstrapPackage(target *rolloutTarget, rolloutPkg *runtanzuv1alpha3.ClusterBootstrapRolloutPackage) error {
+	patch := client.MergeFrom(target.clusterBootstrap.DeepCopy())
+	oldRefName := target.cbPkg.RefName
+	target.cbPkg.RefName = rolloutPkg.RefName
+	if rolloutPkg.ValuesFrom != nil {
+		target.cbPkg.ValuesFrom = rolloutPkg.ValuesFrom.DeepCopy()
+	}
+	for _, pkg := range target.clusterBootstrap.Spec.AdditionalPackages {
+		for i, dependency := range pkg.DependsOn {
+			if dependency == oldRefName {
+				pkg.DependsOn[i] = rolloutPkg.RefName
+			}
+		}
+	}
+	return r.Client.Patch(r.ctx, target.clusterBootstrap, patch)
+}
+
+// summarizeRollout sets the Ready condition of the rollout, pausing the rollout if the package failed to roll out on
+// some clusters, and returns when the rollout should be reconciled again
+func summarizeRollout(rollout *runtanzuv1alpha3.ClusterBootstrapRollout, targets []*rolloutTarget, log logr.Logger) ctrl.Result {
+	failed := countRolloutClusters(targets, runtanzuv1alpha3.RolloutClusterStateFailed)
+	updated := countRolloutClusters(targets, runtanzuv1alpha3.RolloutClusterStateUpdated)
+
+	switch {
+	case failed > 0:
+		if !rollout.Spec.Paused {
+			log.Info("pausing ClusterBootstrapRollout as the package failed to roll out on some clusters")
+			rollout.Spec.Paused = true
+		}
+		conditions.MarkFalse(rollout, clusterapiv1beta1.ReadyCondition, constants.RolloutFailedReason, clusterapiv1beta1.ConditionSeverityError,
+			"package failed to roll out on %d of %d clusters", failed, len(targets))
+	case updated == len(targets):
+		conditions.MarkTrue(rollout, clusterapiv1beta1.ReadyCondition)
+	case rollout.Spec.Paused:
+		conditions.MarkFalse(rollout, clusterapiv1beta1.ReadyCondition, constants.RolloutPausedReason, clusterapiv1beta1.ConditionSeverityInfo,
+			"rollout is paused, %d of %d clusters updated", updated, len(targets))
+	default:
+		conditions.MarkFalse(rollout, clusterapiv1beta1.ReadyCondition, constants.RolloutInProgressReason, clusterapiv1beta1.ConditionSeverityInfo,
+			"%d of %d clusters updated", updated, len(targets))
+	}
+
+	// requeue when the progress deadline of the earliest updated cluster expires, the PackageInstall status changes are
+	// received through the ClusterBootstrap watch
+	progressDeadline := rolloutProgressDeadline(rollout)
+	var requeueAfter time.Duration
+	for _, target := range targets {
+		if target.status.State != runtanzuv1alpha3.RolloutClusterStateUpdating {
+			continue
+		}
+		untilDeadline := time.Until(target.status.LastUpdateTime.Add(progressDeadline))
+		if untilDeadline < constants.RequeueAfterDuration {
+			untilDeadline = constants.RequeueAfterDuration
+		}
+		if requeueAfter == 0 || untilDeadline < requeueAfter {
+			requeueAfter = untilDeadline
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}
+}
+
+// rolloutProgressDeadline returns the progress deadline of the rollout, DefaultRolloutProgressDeadline if it is not set
+func rolloutProgressDeadline(rollout *runtanzuv1alpha3.ClusterBootstrapRollout) time.Duration {
+	if rollout.Spec.Strategy.ProgressDeadline != nil {
+		return rollout.Spec.Strategy.ProgressDeadline.Duration
+	}
+	return constants.DefaultRolloutProgressDeadline
+}
+
+// countRolloutClusters returns the number of clusters in the given rollout state
+func countRolloutClusters(targets []*rolloutTarget, state runtanzuv1alpha3.ClusterBootstrapRolloutClusterState) int {
+	count := 0
+	for _, target := range targets {
+		if target.status.State == state {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,251 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+)
+
+var _ = Describe("ClusterBootstrapRollout rollout state", func() {
+	const (
+		oldAntreaRefName = "antrea.tanzu.vmware.com.1.2.3--vmware.1-tkg.1"
+		newAntreaRefName = "antrea.tanzu.vmware.com.1.5.3--vmware.1-tkg.1"
+		antreaPkgName    = "antrea.tanzu.vmware.com"
+		newAntreaVersion = "1.5.3+vmware.1-tkg.1"
+	)
+
+	var (
+		rollout *runtanzuv1alpha3.ClusterBootstrapRollout
+		log     = ctrl.Log.WithName("ClusterBootstrapRolloutTest")
+	)
+
+	newTarget := func(clusterName, refName string) *rolloutTarget {
+		clusterBootstrap := &runtanzuv1alpha3.ClusterBootstrap{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: "default"},
+			Spec: &runtanzuv1alpha3.ClusterBootstrapTemplateSpec{
+				CNI: &runtanzuv1alpha3.ClusterBootstrapPackage{RefName: refName},
+			},
+		}
+		return &rolloutTarget{
+			clusterBootstrap: clusterBootstrap,
+			cbPkg:            clusterBootstrap.Spec.CNI,
+			status:           &runtanzuv1alpha3.ClusterBootstrapRolloutClusterStatus{Name: clusterName, State: runtanzuv1alpha3.RolloutClusterStatePending},
+		}
+	}
+
+	setPackageStatus := func(target *rolloutTarget, version string, state kappctrlv1alpha1.AppConditionType, lastTransitionTime time.Time) {
+		target.clusterBootstrap.Status.Packages = []runtanzuv1alpha3.ClusterBootstrapPackageStatus{{
+			Name:               antreaPkgName,
+			RefName:            target.cbPkg.RefName,
+			Version:            version,
+			State:              string(state),
+			LastError:          "image pull failed",
+			LastTransitionTime: metav1.NewTime(lastTransitionTime),
+		}}
+	}
+
+	markUpdating := func(target *rolloutTarget, lastUpdateTime time.Time) {
+		target.cbPkg.RefName = newAntreaRefName
+		updateTime := metav1.NewTime(lastUpdateTime)
+		target.status.State = runtanzuv1alpha3.RolloutClusterStateUpdating
+		target.status.LastUpdateTime = &updateTime
+	}
+
+	BeforeEach(func() {
+		rollout = &runtanzuv1alpha3.ClusterBootstrapRollout{
+			ObjectMeta: metav1.ObjectMeta{Name: "antrea-rollout", Namespace: "default"},
+			Spec: runtanzuv1alpha3.ClusterBootstrapRolloutSpec{
+				Package: runtanzuv1alpha3.ClusterBootstrapRolloutPackage{RefName: newAntreaRefName},
+				Strategy: runtanzuv1alpha3.ClusterBootstrapRolloutStrategy{
+					CanaryClusters:   1,
+					BatchSize:        2,
+					ProgressDeadline: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+		}
+	})
+
+	Context("setRolloutClusterState", func() {
+		It("should keep the cluster pending until its ClusterBootstrap is updated", func() {
+			target := newTarget("cluster-1", oldAntreaRefName)
+			target.clusterBootstrap.Spec.Paused = true
+			setRolloutClusterState(target, rollout, antreaPkgName, newAntreaVersion)
+			Expect(target.status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStatePending))
+			Expect(target.status.Message).To(Equal("ClusterBootstrap is paused"))
+		})
+
+		It("should mark the cluster updated once the PackageInstall reconciled the new version", func() {
+			target := newTarget("cluster-1", oldAntreaRefName)
+			markUpdating(target, time.Now())
+			setPackageStatus(target, "1.2.3+vmware.1-tkg.1", kappctrlv1alpha1.ReconcileSucceeded, time.Now())
+			setRolloutClusterState(target, rollout, antreaPkgName, newAntreaVersion)
+			Expect(target.status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateUpdating))
+
+			setPackageStatus(target, newAntreaVersion, kappctrlv1alpha1.ReconcileSucceeded, time.Now())
+			setRolloutClusterState(target, rollout, antreaPkgName, newAntreaVersion)
+			Expect(target.status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateUpdated))
+		})
+
+		It("should only mark the cluster failed for failures after its ClusterBootstrap was updated", func() {
+			target := newTarget("cluster-1", oldAntreaRefName)
+			markUpdating(target, time.Now())
+			setPackageStatus(target, newAntreaVersion, kappctrlv1alpha1.ReconcileFailed, time.Now().Add(-time.Hour))
+			setRolloutClusterState(target, rollout, antreaPkgName, newAntreaVersion)
+			Expect(target.status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateUpdating))
+
+			setPackageStatus(target, newAntreaVersion, kappctrlv1alpha1.ReconcileFailed, time.Now().Add(time.Minute))
+			setRolloutClusterState(target, rollout, antreaPkgName, newAntreaVersion)
+			Expect(target.status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateFailed))
+			Expect(target.status.Message).To(ContainSubstring("image pull failed"))
+		})
+
+		It("should mark the cluster failed when the progress deadline is exceeded", func() {
+			target := newTarget("cluster-1", oldAntreaRefName)
+			markUpdating(target, time.Now().Add(-time.Hour))
+			setRolloutClusterState(target, rollout, antreaPkgName, newAntreaVersion)
+			Expect(target.status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateFailed))
+			Expect(target.status.Message).To(ContainSubstring("within 10m0s"))
+		})
+
+		It("should retry the failed clusters once the rollout is resumed", func() {
+			target := newTarget("cluster-1", oldAntreaRefName)
+			markUpdating(target, time.Now().Add(-time.Hour))
+			target.status.State = runtanzuv1alpha3.RolloutClusterStateFailed
+
+			rollout.Spec.Paused = true
+			setRolloutClusterState(target, rollout, antreaPkgName, newAntreaVersion)
+			Expect(target.status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateFailed))
+
+			rollout.Spec.Paused = false
+			setRolloutClusterState(target, rollout, antreaPkgName, newAntreaVersion)
+			Expect(target.status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateUpdating))
+			Expect(target.status.LastUpdateTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("should wait for the values of the package to be updated", func() {
+			rollout.Spec.Package.ValuesFrom = &runtanzuv1alpha3.ValuesFrom{SecretRef: "antrea-values"}
+			target := newTarget("cluster-1", newAntreaRefName)
+			setRolloutClusterState(target, rollout, antreaPkgName, newAntreaVersion)
+			Expect(target.status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStatePending))
+
+			target.cbPkg.ValuesFrom = &runtanzuv1alpha3.ValuesFrom{SecretRef: "antrea-values"}
+			setRolloutClusterState(target, rollout, antreaPkgName, newAntreaVersion)
+			Expect(target.status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateUpdating))
+		})
+	})
+
+	Context("summarizeRollout", func() {
+		It("should pause the rollout when a cluster failed", func() {
+			targets := []*rolloutTarget{newTarget("cluster-1", oldAntreaRefName), newTarget("cluster-2", oldAntreaRefName)}
+			targets[0].status.State = runtanzuv1alpha3.RolloutClusterStateFailed
+			summarizeRollout(rollout, targets, log)
+			Expect(rollout.Spec.Paused).To(BeTrue())
+			Expect(conditions.GetReason(rollout, clusterapiv1beta1.ReadyCondition)).To(Equal(constants.RolloutFailedReason))
+		})
+
+		It("should requeue until the progress deadline of the updating clusters", func() {
+			targets := []*rolloutTarget{newTarget("cluster-1", oldAntreaRefName), newTarget("cluster-2", oldAntreaRefName)}
+			markUpdating(targets[0], time.Now().Add(-5*time.Minute))
+			result := summarizeRollout(rollout, targets, log)
+			Expect(rollout.Spec.Paused).To(BeFalse())
+			Expect(conditions.GetReason(rollout, clusterapiv1beta1.ReadyCondition)).To(Equal(constants.RolloutInProgressReason))
+			Expect(result.RequeueAfter).To(BeNumerically("~", 5*time.Minute, time.Minute))
+		})
+
+		It("should be ready once all the clusters are updated", func() {
+			targets := []*rolloutTarget{newTarget("cluster-1", newAntreaRefName)}
+			targets[0].status.State = runtanzuv1alpha3.RolloutClusterStateUpdated
+			result := summarizeRollout(rollout, targets, log)
+			Expect(conditions.IsTrue(rollout, clusterapiv1beta1.ReadyCondition)).To(BeTrue())
+			Expect(result.RequeueAfter).To(BeZero())
+		})
+	})
+
+	Context("updateNextRolloutBatch", func() {
+		It("should update the canary clusters first and then the clusters in batches", func() {
+			var targets []*rolloutTarget
+			var objs []client.Object
+			for _, clusterName := range []string{"cluster-1", "cluster-2", "cluster-3", "cluster-4"} {
+				target := newTarget(clusterName, oldAntreaRefName)
+				targets = append(targets, target)
+				objs = append(objs, target.clusterBootstrap)
+			}
+			targets[3].clusterBootstrap.Spec.Paused = true
+			r := &ClusterBootstrapRolloutReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+				Log:    log,
+				ctx:    ctx,
+			}
+
+			r.updateNextRolloutBatch(rollout, targets, log)
+			Expect(countRolloutClusters(targets, runtanzuv1alpha3.RolloutClusterStateUpdating)).To(Equal(1))
+			Expect(targets[0].status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateUpdating))
+			clusterBootstrap := &runtanzuv1alpha3.ClusterBootstrap{}
+			Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(targets[0].clusterBootstrap), clusterBootstrap)).To(Succeed())
+			Expect(clusterBootstrap.Spec.CNI.RefName).To(Equal(newAntreaRefName))
+
+			targets[0].status.State = runtanzuv1alpha3.RolloutClusterStateUpdated
+			r.updateNextRolloutBatch(rollout, targets, log)
+			Expect(targets[1].status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateUpdating))
+			Expect(targets[2].status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateUpdating))
+			// paused ClusterBootstraps are not updated
+			Expect(targets[3].status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStatePending))
+			Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(targets[3].clusterBootstrap), clusterBootstrap)).To(Succeed())
+			Expect(clusterBootstrap.Spec.CNI.RefName).To(Equal(oldAntreaRefName))
+		})
+
+		It("should update the dependencies of the additional packages on the rolled out package", func() {
+			const (
+				oldFoobarRefName = "foobar.example.com.1.17.2"
+				newFoobarRefName = "foobar.example.com.1.18.2"
+				foobar1RefName   = "foobar1.example.com.1.17.2"
+				foobar2RefName   = "foobar2.example.com.1.18.2"
+			)
+			rollout.Spec.Package.RefName = newFoobarRefName
+			clusterBootstrap := &runtanzuv1alpha3.ClusterBootstrap{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default"},
+				Spec: &runtanzuv1alpha3.ClusterBootstrapTemplateSpec{
+					CNI: &runtanzuv1alpha3.ClusterBootstrapPackage{RefName: oldAntreaRefName},
+					AdditionalPackages: []*runtanzuv1alpha3.ClusterBootstrapPackage{
+						{RefName: oldFoobarRefName},
+						{RefName: foobar1RefName, DependsOn: []string{oldFoobarRefName}},
+						{RefName: foobar2RefName, DependsOn: []string{foobar1RefName, oldFoobarRefName}},
+					},
+				},
+			}
+			targets := []*rolloutTarget{{
+				clusterBootstrap: clusterBootstrap,
+				cbPkg:            clusterBootstrap.Spec.AdditionalPackages[0],
+				status:           &runtanzuv1alpha3.ClusterBootstrapRolloutClusterStatus{Name: "cluster-1", State: runtanzuv1alpha3.RolloutClusterStatePending},
+			}}
+			r := &ClusterBootstrapRolloutReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clusterBootstrap.DeepCopy()).Build(),
+				Log:    log,
+				ctx:    ctx,
+			}
+
+			r.updateNextRolloutBatch(rollout, targets, log)
+			Expect(targets[0].status.State).To(Equal(runtanzuv1alpha3.RolloutClusterStateUpdating))
+			updatedClusterBootstrap := &runtanzuv1alpha3.ClusterBootstrap{}
+			Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(clusterBootstrap), updatedClusterBootstrap)).To(Succeed())
+			additionalPackages := updatedClusterBootstrap.Spec.AdditionalPackages
+			Expect(additionalPackages[0].RefName).To(Equal(newFoobarRefName))
+			Expect(additionalPackages[1].DependsOn).To(Equal([]string{newFoobarRefName}))
+			Expect(additionalPackages[2].DependsOn).To(Equal([]string{foobar1RefName, newFoobarRefName}))
+		})
+	})
+})
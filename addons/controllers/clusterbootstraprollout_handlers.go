@@ -0,0 +1,41 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+)
+
+// clusterBootstrapToClusterBootstrapRollouts returns a list of Requests with the ObjectKey of the ClusterBootstrapRollouts
+// in the namespace of the ClusterBootstrap
+func (r *ClusterBootstrapRolloutReconciler) clusterBootstrapToClusterBootstrapRollouts(o client.Object) []ctrl.Request {
+	clusterBootstrap, ok := o.(*runtanzuv1alpha3.ClusterBootstrap)
+	if !ok {
+		r.Log.Error(errors.New("invalid type"),
+			"Expected to receive ClusterBootstrap resource",
+			"actualType", fmt.Sprintf("%T", o))
+		return nil
+	}
+
+	log := r.Log.WithValues(constants.ClusterBootstrapNameLogKey, clusterBootstrap.Name, constants.NamespaceLogKey, clusterBootstrap.Namespace)
+
+	rolloutList := &runtanzuv1alpha3.ClusterBootstrapRolloutList{}
+	if err := r.Client.List(r.ctx, rolloutList, client.InNamespace(clusterBootstrap.Namespace)); err != nil {
+		log.Error(err, "Error listing ClusterBootstrapRollouts")
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for i := range rolloutList.Items {
+		requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&rolloutList.Items[i])})
+	}
+	return requests
+}
@@ -7,6 +7,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
@@ -28,6 +29,7 @@ import (
 	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
 	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/metrics"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
 	"github.com/vmware-tanzu/tanzu-framework/addons/predicates"
 	cpiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cpi/v1alpha1"
@@ -188,11 +190,13 @@ func (r *VSphereCPIConfigReconciler) reconcileVSphereCPIConfigNormal(ctx context
 		r.Log.Info("Mutated VSphereCPIConfig data values")
 		return nil
 	}
+	start := time.Now()
 	result, err := controllerutil.CreateOrPatch(ctx, r.Client, secret, mutateFn)
 	if err != nil {
 		r.Log.Error(err, "Error creating or patching VSphereCPIConfig data values secret")
 		return err
 	}
+	metrics.ObserveDataValuesSecretGeneration(constants.CPIAddonName, start)
 
 	// deploy the provider service account for paravirtual mode
 	if *cpiConfig.Spec.VSphereCPI.Mode == VSphereCPIParavirtualMode {
@@ -6,6 +6,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -31,6 +32,7 @@ import (
 	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
 	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/metrics"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
 	"github.com/vmware-tanzu/tanzu-framework/addons/predicates"
 	csiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/csi/v1alpha1"
@@ -266,12 +268,14 @@ func (r *VSphereCSIConfigReconciler) reconcileVSphereCSIConfigNormal(ctx context
 		return nil
 	}
 
+	start := time.Now()
 	_, err := controllerutil.CreateOrPatch(ctx, r.Client, secret, mutateFn)
 
 	if err != nil {
 		logger.Error(err, "Error creating or patching VSphereCSIConfig data values secret")
 		return ctrl.Result{}, err
 	}
+	metrics.ObserveDataValuesSecretGeneration(addonName, start)
 
 	// deploy the provider service account for paravirtual mode
 	if csiCfg.Spec.VSphereCSI.Mode == VSphereCSIParavirtualMode {
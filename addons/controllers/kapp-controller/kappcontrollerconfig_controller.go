@@ -7,6 +7,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"gopkg.in/yaml.v2"
@@ -27,6 +28,7 @@ import (
 	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
 	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/metrics"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
 	"github.com/vmware-tanzu/tanzu-framework/addons/predicates"
 	runv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
@@ -203,11 +205,13 @@ func (r *KappControllerConfigReconciler) ReconcileKappControllerConfigDataValue(
 		return nil
 	}
 
+	start := time.Now()
 	result, err := controllerutil.CreateOrPatch(ctx, r.Client, dataValuesSecret, dataValuesSecretMutateFn)
 	if err != nil {
 		log.Error(err, "Error creating or patching kappControllerConfig data values secret")
 		return err
 	}
+	metrics.ObserveDataValuesSecretGeneration(constants.KappControllerAddonName, start)
 
 	log.Info(fmt.Sprintf("Resource %s data values secret %s", constants.KappControllerAddonName, result))
 
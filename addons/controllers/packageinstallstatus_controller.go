@@ -13,6 +13,7 @@ import (
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -29,9 +30,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	kapppkgiv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
 	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/metrics"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/types"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
 	"github.com/vmware-tanzu/tanzu-framework/addons/predicates"
@@ -170,12 +173,14 @@ func (r *PackageInstallStatusReconciler) Reconcile(_ context.Context, req reconc
 		clusterRole = clusterRoleWorkload
 		remoteClient, err := r.tracker.GetClient(r.ctx, clusterapiutil.ObjectKey(cluster))
 		if err != nil {
+			metrics.RemoteClusterClientErrors.WithLabelValues(cluster.Namespace, cluster.Name).Inc()
 			return ctrl.Result{}, errors.Wrap(err, "error getting remote cluster's client")
 		}
 
 		log.Info("successfully got remoteClient")
 		// set watch if not already set. If the watch already exists, it doesn't get re-created
 		if err := watchPackageInstalls(r.ctx, r.controller, r.tracker, cluster, log); err != nil {
+			metrics.RemoteClusterClientErrors.WithLabelValues(cluster.Namespace, cluster.Name).Inc()
 			return ctrl.Result{}, errors.Wrap(err, "error watching PackageInstalls on target cluster")
 		}
 		log.Info("finished setting up remote watch for the cluster")
@@ -200,6 +205,7 @@ func (r *PackageInstallStatusReconciler) reconcile(clusterClient client.Client,
 	}
 
 	var errorList []error
+	corePackagesWereReady := corePackagesReady(clusterBootstrap, clusterRole)
 
 	patchHelper, err := clusterapipatchutil.NewHelper(clusterBootstrap, r.Client)
 	if err != nil {
@@ -231,6 +237,7 @@ func (r *PackageInstallStatusReconciler) reconcile(clusterClient client.Client,
 			// if a condition corresponding to the package is existing in the ClusterBootstrapStatus, we delete it as the corresponding pkgi or package resources do not exist for the package anymore
 			log.Error(err, fmt.Sprintf("failed to reconcile PackageInstallStatus for package '%s/%s'", r.Config.SystemNamespace, pkg.RefName))
 			r.removeConditionIfExistsForPkgName(clusterBootstrap, pkg.RefName)
+			removePackageStatusIfExists(clusterBootstrap, pkg.RefName)
 		}
 	}
 
@@ -243,12 +250,44 @@ func (r *PackageInstallStatusReconciler) reconcile(clusterClient client.Client,
 			// if a condition corresponding to the package is existing in the ClusterBootstrapStatus, we delete it as the corresponding pkgi or package resources do not exist for the package anymore
 			log.Error(err, fmt.Sprintf("failed to reconcile PackageInstallStatus for package '%s/%s'", cluster.Namespace, clusterBootstrap.Spec.Kapp.RefName))
 			r.removeConditionIfExistsForPkgName(clusterBootstrap, clusterBootstrap.Spec.Kapp.RefName)
+			removePackageStatusIfExists(clusterBootstrap, clusterBootstrap.Spec.Kapp.RefName)
 		}
 	}
 
+	// the status of the packages which are not part of the ClusterBootstrap anymore, e.g. after a TKR upgrade, is removed
+	removeStalePackageStatuses(clusterBootstrap, append(packages, clusterBootstrap.Spec.Kapp))
+
+	if !corePackagesWereReady && corePackagesReady(clusterBootstrap, clusterRole) {
+		metrics.ObserveClusterCorePackagesReady(cluster.UID, cluster.CreationTimestamp.Time)
+	}
+
 	return retErr
 }
 
+// corePackagesReady returns true if the PackageInstalls of all the core packages of the cluster reconciled successfully.
+// The kapp-controller package is only considered for workload clusters as its PackageInstall exists only for them.
+func corePackagesReady(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, clusterRole ClusterRole) bool {
+	corePackages := []*runtanzuv1alpha3.ClusterBootstrapPackage{
+		clusterBootstrap.Spec.CNI,
+		clusterBootstrap.Spec.CPI,
+		clusterBootstrap.Spec.CSI,
+	}
+	if clusterRole == clusterRoleWorkload {
+		corePackages = append(corePackages, clusterBootstrap.Spec.Kapp)
+	}
+
+	states := map[string]string{}
+	for _, packageStatus := range clusterBootstrap.Status.Packages {
+		states[packageStatus.RefName] = packageStatus.State
+	}
+	for _, pkg := range corePackages {
+		if pkg != nil && states[pkg.RefName] != string(kappctrlv1alpha1.ReconcileSucceeded) {
+			return false
+		}
+	}
+	return true
+}
+
 // reconcileClusterBootstrapStatus reconciles clusterBootstrapStatus by setting conditions corresponding to all core/additional packages.
 // The Status patch happens in the caller function
 func (r *PackageInstallStatusReconciler) reconcileClusterBootstrapStatus(
@@ -282,6 +321,7 @@ func (r *PackageInstallStatusReconciler) reconcileClusterBootstrapStatus(
 
 	// for each package, create a single summary condition from the condition slice
 	pkgiCondition := util.SummarizeAppConditions(pkgi.Status.Conditions)
+	setPackageStatus(clusterBootstrap, pkgRefName, pkgName, pkgi, pkgiCondition)
 
 	// in case of encountering an empty(nil) PackageInstall condition, just return err=nil and proceed with handling the next package
 	if pkgiCondition == nil {
@@ -321,16 +361,87 @@ func (r *PackageInstallStatusReconciler) reconcileClusterBootstrapStatus(
 	return nil
 }
 
-// removeConditionIfExistsForPkgName removes the corresponding condition for the provided pkgRefName from the clusterBootstrapStatus if existing
+// removeConditionIfExistsForPkgName removes the corresponding condition for the provided pkgRefName from the clusterBootstrapStatus if existing.
+// The WaitingForDependencies condition is kept as it is managed by the ClusterBootstrap controller while the PackageInstall does not exist.
 func (r *PackageInstallStatusReconciler) removeConditionIfExistsForPkgName(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, pkgRefName string) {
 	for i, existingCond := range clusterBootstrap.Status.Conditions {
 		pkgShortname := strings.Split(pkgRefName, ".")[0]
+		if strings.HasSuffix(string(existingCond.Type), "-"+constants.WaitingForDependenciesConditionType) {
+			continue
+		}
 		if strings.Contains(string(existingCond.Type), cases.Title(language.Und).String(pkgShortname)) {
 			clusterBootstrap.Status.Conditions = append(clusterBootstrap.Status.Conditions[:i], clusterBootstrap.Status.Conditions[i+1:]...)
 		}
 	}
 }
 
+// setPackageStatus adds or updates the status of a package in the clusterBootstrapStatus from its PackageInstall. The
+// last transition time is only updated when the state of the package changes.
+func setPackageStatus(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, name, refName string, pkgi *kapppkgiv1alpha1.PackageInstall, pkgiCondition *kappctrlv1alpha1.AppCondition) {
+	packageStatus := runtanzuv1alpha3.ClusterBootstrapPackageStatus{
+		Name:               name,
+		RefName:            refName,
+		Version:            pkgi.Status.Version,
+		LastTransitionTime: metav1.Now(),
+	}
+	if pkgiCondition != nil {
+		packageStatus.State = string(pkgiCondition.Type)
+		if pkgiCondition.Type == kappctrlv1alpha1.ReconcileFailed || pkgiCondition.Type == kappctrlv1alpha1.DeleteFailed {
+			packageStatus.LastError = util.GetKappUsefulErrorMessage(pkgi.Status.UsefulErrorMessage)
+		}
+	}
+
+	var existingStatus *runtanzuv1alpha3.ClusterBootstrapPackageStatus
+	for i := range clusterBootstrap.Status.Packages {
+		if clusterBootstrap.Status.Packages[i].Name == name {
+			existingStatus = &clusterBootstrap.Status.Packages[i]
+			break
+		}
+	}
+
+	// a reconcile failure is only counted when the package transitions to the failed state
+	if packageStatus.State == string(kappctrlv1alpha1.ReconcileFailed) && (existingStatus == nil || existingStatus.State != packageStatus.State) {
+		metrics.PackageInstallReconcileFailures.WithLabelValues(clusterBootstrap.Namespace, clusterBootstrap.Name, name).Inc()
+	}
+
+	if existingStatus == nil {
+		clusterBootstrap.Status.Packages = append(clusterBootstrap.Status.Packages, packageStatus)
+		return
+	}
+	if existingStatus.State == packageStatus.State {
+		packageStatus.LastTransitionTime = existingStatus.LastTransitionTime
+	}
+	*existingStatus = packageStatus
+}
+
+// removePackageStatusIfExists removes the status of the package with the provided refName from the clusterBootstrapStatus if existing
+func removePackageStatusIfExists(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, refName string) {
+	packageStatuses := clusterBootstrap.Status.Packages[:0]
+	for _, packageStatus := range clusterBootstrap.Status.Packages {
+		if packageStatus.RefName != refName {
+			packageStatuses = append(packageStatuses, packageStatus)
+		}
+	}
+	clusterBootstrap.Status.Packages = packageStatuses
+}
+
+// removeStalePackageStatuses removes the status of the packages which are not in the provided packages from the clusterBootstrapStatus
+func removeStalePackageStatuses(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, packages []*runtanzuv1alpha3.ClusterBootstrapPackage) {
+	refNames := map[string]bool{}
+	for _, pkg := range packages {
+		if pkg != nil {
+			refNames[pkg.RefName] = true
+		}
+	}
+	packageStatuses := clusterBootstrap.Status.Packages[:0]
+	for _, packageStatus := range clusterBootstrap.Status.Packages {
+		if refNames[packageStatus.RefName] {
+			packageStatuses = append(packageStatuses, packageStatus)
+		}
+	}
+	clusterBootstrap.Status.Packages = packageStatuses
+}
+
 // watchPackageInstalls sets a remote watch on the provided cluster on the Kind resource
 func watchPackageInstalls(ctx context.Context, watcher remote.Watcher, tracker *remote.ClusterCacheTracker, cluster *clusterapiv1beta1.Cluster, log logr.Logger) error {
 	// If there is no tracker, don't watch remote package installs
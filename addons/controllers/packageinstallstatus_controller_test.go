@@ -8,6 +8,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -20,6 +21,7 @@ import (
 	"github.com/vmware-tanzu/carvel-secretgen-controller/pkg/apis/secretgen/v1alpha1"
 	versions "github.com/vmware-tanzu/carvel-vendir/pkg/vendir/versions/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/metrics"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/types"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
 	"github.com/vmware-tanzu/tanzu-framework/addons/test/testutil"
@@ -134,6 +136,22 @@ var _ = Describe("PackageInstallStatus Reconciler", func() {
 			Expect(len(wlcClusterBootstrapStatus.Conditions)).Should(Equal(2))
 			Expect(wlcClusterBootstrapStatus.Conditions[0].Type).Should(Equal(antreaCondType))
 			Expect(wlcClusterBootstrapStatus.Conditions[1].Type).Should(Equal(kappCondType))
+
+			By("verifying ClusterBootstrap 'Status.Packages' gets updated for managed packages")
+			Expect(len(wlcClusterBootstrapStatus.Packages)).Should(Equal(2))
+			Expect(wlcClusterBootstrapStatus.Packages[0].Name).Should(Equal(antreaPkgRefName))
+			Expect(wlcClusterBootstrapStatus.Packages[0].RefName).Should(Equal(antreaPkg))
+			Expect(wlcClusterBootstrapStatus.Packages[0].State).Should(Equal(string(v1alpha1.ReconcileSucceeded)))
+			Expect(wlcClusterBootstrapStatus.Packages[0].LastTransitionTime.IsZero()).Should(BeFalse())
+			Expect(wlcClusterBootstrapStatus.Packages[1].Name).Should(Equal(kappPkgRefName))
+			Expect(wlcClusterBootstrapStatus.Packages[1].RefName).Should(Equal("kapp-controller.tanzu.vmware.com.0.30.1"))
+			Expect(wlcClusterBootstrapStatus.Packages[1].State).Should(Equal(string(v1alpha1.Reconciling)))
+
+			By("verifying PackageInstall reconcile failures are counted in the addons manager metrics")
+			updatePkgInstallStatus(wlcAntreaObjKey, kappctrlv1alpha1.ReconcileFailed)
+			Eventually(func() float64 {
+				return promtestutil.ToFloat64(metrics.PackageInstallReconcileFailures.WithLabelValues(clusterNamespaceWlc, clusterNameWlc, antreaPkgRefName))
+			}, waitTimeout, pollingInterval).Should(BeNumerically(">=", 1))
 		})
 	})
 })
@@ -25,6 +25,8 @@ import (
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	capav1beta1 "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	capzv1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	capvv1beta1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
 	capvvmwarev1beta1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/vmware/v1beta1"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -43,7 +45,10 @@ import (
 	pkgiv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
 	kapppkgv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
 	antrea "github.com/vmware-tanzu/tanzu-framework/addons/controllers/antrea"
+	awscpi "github.com/vmware-tanzu/tanzu-framework/addons/controllers/awscpi"
 	awsebscsi "github.com/vmware-tanzu/tanzu-framework/addons/controllers/awsebscsi"
+	azurecpi "github.com/vmware-tanzu/tanzu-framework/addons/controllers/azurecpi"
+	azurediskcsi "github.com/vmware-tanzu/tanzu-framework/addons/controllers/azurediskcsi"
 	azurefilecsi "github.com/vmware-tanzu/tanzu-framework/addons/controllers/azurefilecsi"
 	calico "github.com/vmware-tanzu/tanzu-framework/addons/controllers/calico"
 	cpi "github.com/vmware-tanzu/tanzu-framework/addons/controllers/cpi"
@@ -129,6 +134,8 @@ var _ = BeforeSuite(func(done Done) {
 			"controlplane/kubeadm/config/crd/bases"},
 		"github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1": {"config/crds.yml"},
 		"sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1":                      {"config/default/crd/bases", "config/supervisor/crd"},
+		"sigs.k8s.io/cluster-api-provider-aws/api/v1beta1":                           {"config/crd/bases"},
+		"sigs.k8s.io/cluster-api-provider-azure/api/v1beta1":                         {"config/crd/bases"},
 	}
 
 	externalCRDPaths, err := testutil.GetExternalCRDPaths(externalDeps)
@@ -216,6 +223,12 @@ var _ = BeforeSuite(func(done Done) {
 	err = capvvmwarev1beta1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = capav1beta1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = capzv1beta1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = vmoperatorv1alpha1.AddToScheme(scheme)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -301,6 +314,22 @@ var _ = BeforeSuite(func(done Done) {
 			ConfigControllerConfig: addonconfig.ConfigControllerConfig{SystemNamespace: constants.TKGSystemNS}},
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1})).To(Succeed())
 
+	Expect((&awscpi.AWSCPIConfigReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("AWSCPIConfig"),
+		Scheme: mgr.GetScheme(),
+		Config: addonconfig.AWSCPIConfigControllerConfig{
+			ConfigControllerConfig: addonconfig.ConfigControllerConfig{SystemNamespace: constants.TKGSystemNS}},
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1})).To(Succeed())
+
+	Expect((&azurecpi.AzureCPIConfigReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("AzureCPIConfig"),
+		Scheme: mgr.GetScheme(),
+		Config: addonconfig.AzureCPIConfigControllerConfig{
+			ConfigControllerConfig: addonconfig.ConfigControllerConfig{SystemNamespace: constants.TKGSystemNS}},
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1})).To(Succeed())
+
 	Expect((&csi.VSphereCSIConfigReconciler{
 		Client: mgr.GetClient(),
 		Log:    ctrl.Log.WithName("controllers").WithName("VSphereCSIConfig"),
@@ -315,6 +344,14 @@ var _ = BeforeSuite(func(done Done) {
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1})).To(Succeed())
 
+	Expect((&azurediskcsi.AzureDiskCSIConfigReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("AzureDiskCSIConfig"),
+		Scheme: mgr.GetScheme(),
+		Config: addonconfig.AzureDiskCSIConfigControllerConfig{
+			ConfigControllerConfig: addonconfig.ConfigControllerConfig{SystemNamespace: constants.TKGSystemNS}},
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1})).To(Succeed())
+
 	Expect((&antrea.AntreaConfigReconciler{
 		Client: mgr.GetClient(),
 		Log:    ctrl.Log.WithName("controllers").WithName("AntreaConfig"),
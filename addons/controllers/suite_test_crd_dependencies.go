@@ -9,6 +9,8 @@
 package controllers
 
 import (
+	_ "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	_ "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	_ "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
 	_ "sigs.k8s.io/cluster-api/api/v1beta1"
 
@@ -13,6 +13,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	capav1beta1 "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	capzv1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	capvv1beta1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
 	capvvmwarev1beta1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/vmware/v1beta1"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -77,6 +79,136 @@ func ControlPlaneVsphereMachineTemplateNonParavirtualForCluster(ctx context.Cont
 	return vSphereMachineTemplate, nil
 }
 
+// AWSClusterForCluster gets the AWSCluster CR for the cluster object
+func AWSClusterForCluster(ctx context.Context, clt client.Client, cluster *clusterapiv1beta1.Cluster) (*capav1beta1.AWSCluster, error) {
+	awsClusterRef := cluster.Spec.InfrastructureRef
+	if awsClusterRef == nil {
+		return nil, fmt.Errorf("cluster %s 's infrastructure reference is not set yet", cluster.Name)
+	}
+	if awsClusterRef.Kind != constants.InfrastructureRefAWS || awsClusterRef.APIVersion != capav1beta1.GroupVersion.String() {
+		return nil, fmt.Errorf("cluster %s 's infrastructure reference is not an AWSCluster: %v", cluster.Name, awsClusterRef)
+	}
+	awsCluster := &capav1beta1.AWSCluster{}
+	if err := clt.Get(ctx, types.NamespacedName{Namespace: awsClusterRef.Namespace, Name: awsClusterRef.Name}, awsCluster); err != nil {
+		return nil, err
+	}
+	return awsCluster, nil
+}
+
+// AzureClusterForCluster gets the AzureCluster CR for the cluster object
+func AzureClusterForCluster(ctx context.Context, clt client.Client, cluster *clusterapiv1beta1.Cluster) (*capzv1beta1.AzureCluster, error) {
+	azureClusterRef := cluster.Spec.InfrastructureRef
+	if azureClusterRef == nil {
+		return nil, fmt.Errorf("cluster %s 's infrastructure reference is not set yet", cluster.Name)
+	}
+	if azureClusterRef.Kind != constants.InfrastructureRefAzure || azureClusterRef.APIVersion != capzv1beta1.GroupVersion.String() {
+		return nil, fmt.Errorf("cluster %s 's infrastructure reference is not an AzureCluster: %v", cluster.Name, azureClusterRef)
+	}
+	azureCluster := &capzv1beta1.AzureCluster{}
+	if err := clt.Get(ctx, types.NamespacedName{Namespace: azureClusterRef.Namespace, Name: azureClusterRef.Name}, azureCluster); err != nil {
+		return nil, err
+	}
+	return azureCluster, nil
+}
+
+// AzureClusterIdentityForAzureCluster gets the AzureClusterIdentity CR referenced by the AzureCluster.
+// Returns nil if the AzureCluster doesn't reference an identity.
+func AzureClusterIdentityForAzureCluster(ctx context.Context, clt client.Client, azureCluster *capzv1beta1.AzureCluster) (*capzv1beta1.AzureClusterIdentity, error) {
+	identityRef := azureCluster.Spec.IdentityRef
+	if identityRef == nil {
+		return nil, nil
+	}
+	if identityRef.Kind != constants.AzureClusterIdentityKind {
+		return nil, fmt.Errorf("AzureCluster %s 's identity reference is not an AzureClusterIdentity: %v", azureCluster.Name, identityRef)
+	}
+	namespace := identityRef.Namespace
+	if namespace == "" {
+		namespace = azureCluster.Namespace
+	}
+	identity := &capzv1beta1.AzureClusterIdentity{}
+	if err := clt.Get(ctx, types.NamespacedName{Namespace: namespace, Name: identityRef.Name}, identity); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// AzureNodeSubnet returns the subnet of the worker nodes of the AzureCluster, or nil if there is none
+func AzureNodeSubnet(azureCluster *capzv1beta1.AzureCluster) *capzv1beta1.SubnetSpec {
+	for i := range azureCluster.Spec.NetworkSpec.Subnets {
+		if azureCluster.Spec.NetworkSpec.Subnets[i].Role == capzv1beta1.SubnetNode {
+			return &azureCluster.Spec.NetworkSpec.Subnets[i]
+		}
+	}
+	return nil
+}
+
+// GetAzureClientSecretForIdentity gets the service principal client secret of the AzureClusterIdentity
+func GetAzureClientSecretForIdentity(ctx context.Context, clt client.Client, identity *capzv1beta1.AzureClusterIdentity) (string, error) {
+	namespace := identity.Spec.ClientSecret.Namespace
+	if namespace == "" {
+		namespace = identity.Namespace
+	}
+	secret, err := GetSecret(ctx, clt, namespace, identity.Spec.ClientSecret.Name)
+	if err != nil {
+		return "", err
+	}
+	clientSecret, exists := secret.Data[constants.AzureClientSecretKey]
+	if !exists {
+		return "", errors.Errorf("Secret %s/%s doesn't have string data with %s", secret.Namespace, secret.Name, constants.AzureClientSecretKey)
+	}
+	return string(clientSecret), nil
+}
+
+// AzureIdentityCredentials holds the credentials used to access Azure on behalf of an AzureCluster
+type AzureIdentityCredentials struct {
+	TenantID                    string
+	ClientID                    string
+	ClientSecret                string
+	UseManagedIdentityExtension bool
+	UserAssignedIdentityID      string
+}
+
+// GetAzureIdentityCredentials derives the credentials used to access Azure from the AzureClusterIdentity of the
+// AzureCluster. Returns empty credentials if the AzureCluster doesn't reference an identity.
+func GetAzureIdentityCredentials(ctx context.Context, clt client.Client, azureCluster *capzv1beta1.AzureCluster) (*AzureIdentityCredentials, error) {
+	creds := &AzureIdentityCredentials{}
+	identity, err := AzureClusterIdentityForAzureCluster(ctx, clt, azureCluster)
+	if err != nil || identity == nil {
+		return creds, err
+	}
+
+	creds.TenantID = identity.Spec.TenantID
+	switch identity.Spec.Type {
+	case capzv1beta1.UserAssignedMSI:
+		creds.UseManagedIdentityExtension = true
+		creds.UserAssignedIdentityID = identity.Spec.ClientID
+	case capzv1beta1.ServicePrincipal, capzv1beta1.ManualServicePrincipal:
+		creds.ClientID = identity.Spec.ClientID
+		if identity.Spec.ClientSecret.Name != "" {
+			if creds.ClientSecret, err = GetAzureClientSecretForIdentity(ctx, clt, identity); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		// the certificate of a ServicePrincipalCertificate identity can't be passed on, only its client ID is used
+		creds.ClientID = identity.Spec.ClientID
+	}
+	return creds, nil
+}
+
+// GetAzureClientIDAndSecretFromSecret extracts the service principal client ID and secret from a secret
+func GetAzureClientIDAndSecretFromSecret(s *v1.Secret) (string, string, error) {
+	clientID, exists := s.Data[constants.AzureClientIDKey]
+	if !exists {
+		return "", "", errors.Errorf("Secret %s/%s doesn't have string data with %s", s.Namespace, s.Name, constants.AzureClientIDKey)
+	}
+	clientSecret, exists := s.Data[constants.AzureClientSecretKey]
+	if !exists {
+		return "", "", errors.Errorf("Secret %s/%s doesn't have string data with %s", s.Namespace, s.Name, constants.AzureClientSecretKey)
+	}
+	return string(clientID), string(clientSecret), nil
+}
+
 // ControlPlaneName returns the control plane name for a cluster name
 func ControlPlaneName(clusterName string) string {
 	return fmt.Sprintf("%s-control-plane", clusterName)
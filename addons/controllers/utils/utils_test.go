@@ -15,6 +15,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	capav1beta1 "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	capzv1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -154,3 +156,157 @@ var _ = Describe("AddonsControllerUtils", func() {
 		})
 	})
 })
+
+var _ = Describe("Infrastructure cluster utils", func() {
+
+	const (
+		CLUSTERNAME = "infra_cluster"
+		NAMESPACE   = "cluster_name_space"
+	)
+	var (
+		scheme     = runtime.NewScheme()
+		fakeClient client.Client
+		cluster    *clusterapiv1beta1.Cluster
+	)
+	BeforeEach(func() {
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(clusterapiv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(capav1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(capzv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		awsCluster := &capav1beta1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: CLUSTERNAME, Namespace: NAMESPACE},
+			Spec:       capav1beta1.AWSClusterSpec{Region: "us-west-2"},
+		}
+		azureCluster := &capzv1beta1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: CLUSTERNAME, Namespace: NAMESPACE},
+			Spec: capzv1beta1.AzureClusterSpec{
+				AzureClusterClassSpec: capzv1beta1.AzureClusterClassSpec{Location: "westus2"},
+			},
+		}
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster, azureCluster).Build()
+		cluster = &clusterapiv1beta1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: CLUSTERNAME, Namespace: NAMESPACE},
+		}
+	})
+
+	When("the cluster references an AWSCluster", func() {
+		BeforeEach(func() {
+			cluster.Spec.InfrastructureRef = &corev1.ObjectReference{
+				APIVersion: capav1beta1.GroupVersion.String(),
+				Kind:       constants.InfrastructureRefAWS,
+				Name:       CLUSTERNAME,
+				Namespace:  NAMESPACE,
+			}
+		})
+		It("AWSClusterForCluster should return the AWSCluster", func() {
+			awsCluster, err := AWSClusterForCluster(context.TODO(), fakeClient, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(awsCluster.Spec.Region).To(Equal("us-west-2"))
+		})
+		It("AzureClusterForCluster should return an error", func() {
+			_, err := AzureClusterForCluster(context.TODO(), fakeClient, cluster)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the cluster references an AzureCluster", func() {
+		BeforeEach(func() {
+			cluster.Spec.InfrastructureRef = &corev1.ObjectReference{
+				APIVersion: capzv1beta1.GroupVersion.String(),
+				Kind:       constants.InfrastructureRefAzure,
+				Name:       CLUSTERNAME,
+				Namespace:  NAMESPACE,
+			}
+		})
+		It("AzureClusterForCluster should return the AzureCluster", func() {
+			azureCluster, err := AzureClusterForCluster(context.TODO(), fakeClient, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(azureCluster.Spec.Location).To(Equal("westus2"))
+		})
+		It("AWSClusterForCluster should return an error", func() {
+			_, err := AWSClusterForCluster(context.TODO(), fakeClient, cluster)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the cluster has no infrastructure reference", func() {
+		It("AWSClusterForCluster and AzureClusterForCluster should return an error", func() {
+			_, err := AWSClusterForCluster(context.TODO(), fakeClient, cluster)
+			Expect(err).To(HaveOccurred())
+			_, err = AzureClusterForCluster(context.TODO(), fakeClient, cluster)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("AzureNodeSubnet", func() {
+		It("should return the subnet with the node role", func() {
+			azureCluster := &capzv1beta1.AzureCluster{}
+			Expect(AzureNodeSubnet(azureCluster)).To(BeNil())
+			azureCluster.Spec.NetworkSpec.Subnets = capzv1beta1.Subnets{
+				{SubnetClassSpec: capzv1beta1.SubnetClassSpec{Name: "cp-subnet", Role: capzv1beta1.SubnetControlPlane}},
+				{SubnetClassSpec: capzv1beta1.SubnetClassSpec{Name: "node-subnet", Role: capzv1beta1.SubnetNode}},
+			}
+			Expect(AzureNodeSubnet(azureCluster).Name).To(Equal("node-subnet"))
+		})
+	})
+
+	Context("GetAzureIdentityCredentials", func() {
+		const IDENTITYNAME = "identity"
+		var azureCluster *capzv1beta1.AzureCluster
+
+		BeforeEach(func() {
+			azureCluster = &capzv1beta1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: CLUSTERNAME, Namespace: NAMESPACE},
+			}
+		})
+		It("should return empty credentials if the AzureCluster has no identity", func() {
+			creds, err := GetAzureIdentityCredentials(context.TODO(), fakeClient, azureCluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*creds).To(Equal(AzureIdentityCredentials{}))
+		})
+		It("should return the client ID and secret of a service principal identity", func() {
+			identity := &capzv1beta1.AzureClusterIdentity{
+				ObjectMeta: metav1.ObjectMeta{Name: IDENTITYNAME, Namespace: NAMESPACE},
+				Spec: capzv1beta1.AzureClusterIdentitySpec{
+					Type:         capzv1beta1.ServicePrincipal,
+					TenantID:     "tenant",
+					ClientID:     "client",
+					ClientSecret: corev1.SecretReference{Name: "identity-secret"},
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "identity-secret", Namespace: NAMESPACE},
+				Data:       map[string][]byte{constants.AzureClientSecretKey: []byte("secret")},
+			}
+			Expect(fakeClient.Create(context.TODO(), identity)).To(Succeed())
+			Expect(fakeClient.Create(context.TODO(), secret)).To(Succeed())
+			azureCluster.Spec.IdentityRef = &corev1.ObjectReference{Kind: constants.AzureClusterIdentityKind, Name: IDENTITYNAME}
+
+			creds, err := GetAzureIdentityCredentials(context.TODO(), fakeClient, azureCluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*creds).To(Equal(AzureIdentityCredentials{TenantID: "tenant", ClientID: "client", ClientSecret: "secret"}))
+		})
+		It("should use the managed identity extension for a user assigned identity", func() {
+			identity := &capzv1beta1.AzureClusterIdentity{
+				ObjectMeta: metav1.ObjectMeta{Name: IDENTITYNAME, Namespace: NAMESPACE},
+				Spec: capzv1beta1.AzureClusterIdentitySpec{
+					Type:     capzv1beta1.UserAssignedMSI,
+					TenantID: "tenant",
+					ClientID: "client",
+				},
+			}
+			Expect(fakeClient.Create(context.TODO(), identity)).To(Succeed())
+			azureCluster.Spec.IdentityRef = &corev1.ObjectReference{Kind: constants.AzureClusterIdentityKind, Name: IDENTITYNAME}
+
+			creds, err := GetAzureIdentityCredentials(context.TODO(), fakeClient, azureCluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*creds).To(Equal(AzureIdentityCredentials{TenantID: "tenant", UseManagedIdentityExtension: true, UserAssignedIdentityID: "client"}))
+		})
+		It("should return an error if the identity reference is not an AzureClusterIdentity", func() {
+			azureCluster.Spec.IdentityRef = &corev1.ObjectReference{Kind: "Foo", Name: IDENTITYNAME}
+			_, err := GetAzureIdentityCredentials(context.TODO(), fakeClient, azureCluster)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
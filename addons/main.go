@@ -20,6 +20,8 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/klogr"
+	capav1beta1 "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	capzv1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	capvv1beta1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
 	capvvmwarev1beta1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/vmware/v1beta1"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -34,7 +36,10 @@ import (
 	kappdatapkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/addons/controllers"
 	antreacontroller "github.com/vmware-tanzu/tanzu-framework/addons/controllers/antrea"
+	awscpicontroller "github.com/vmware-tanzu/tanzu-framework/addons/controllers/awscpi"
 	awsebscsicontroller "github.com/vmware-tanzu/tanzu-framework/addons/controllers/awsebscsi"
+	azurecpicontroller "github.com/vmware-tanzu/tanzu-framework/addons/controllers/azurecpi"
+	azurediskcsicontroller "github.com/vmware-tanzu/tanzu-framework/addons/controllers/azurediskcsi"
 	azurefilecsicontroller "github.com/vmware-tanzu/tanzu-framework/addons/controllers/azurefilecsi"
 	calicocontroller "github.com/vmware-tanzu/tanzu-framework/addons/controllers/calico"
 	cpicontroller "github.com/vmware-tanzu/tanzu-framework/addons/controllers/cpi"
@@ -44,6 +49,7 @@ import (
 	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/crdwait"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/metrics"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/webhooks"
 	addonwebhooks "github.com/vmware-tanzu/tanzu-framework/addons/webhooks"
 	cniv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cni/v1alpha1"
@@ -75,6 +81,8 @@ func init() {
 	_ = cpiv1alpha1.AddToScheme(scheme)
 	_ = csiv1alpha1.AddToScheme(scheme)
 	_ = capvv1beta1.AddToScheme(scheme)
+	_ = capav1beta1.AddToScheme(scheme)
+	_ = capzv1beta1.AddToScheme(scheme)
 	_ = capvvmwarev1beta1.AddToScheme(scheme)
 	_ = vmoperatorv1alpha1.AddToScheme(scheme)
 	_ = topologyv1alpha1.AddToScheme(scheme)
@@ -244,6 +252,11 @@ func main() {
 		enablePackageInstallStatusController(ctx, mgr, flags)
 	}
 
+	// ClusterBootstrapRollout relies on the package statuses reported on ClusterBootstrap by the PackageInstallStatus controller
+	if flags.featureGateClusterBootstrap && flags.featureGatePackageInstallStatus {
+		enableClusterBootstrapRolloutController(ctx, mgr, flags)
+	}
+
 	setupChecks(mgr)
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
@@ -307,6 +320,28 @@ func enableClusterBootstrapAndConfigControllers(ctx context.Context, mgr ctrl.Ma
 		os.Exit(1)
 	}
 
+	if err := (&awscpicontroller.AWSCPIConfigReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("AWSCPIConfig"),
+		Scheme: mgr.GetScheme(),
+		Config: addonconfig.AWSCPIConfigControllerConfig{
+			ConfigControllerConfig: addonconfig.ConfigControllerConfig{SystemNamespace: flags.addonNamespace}},
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1}); err != nil {
+		setupLog.Error(err, "unable to create CPIConfigController", "controller", "awscpi")
+		os.Exit(1)
+	}
+
+	if err := (&azurecpicontroller.AzureCPIConfigReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("AzureCPIConfig"),
+		Scheme: mgr.GetScheme(),
+		Config: addonconfig.AzureCPIConfigControllerConfig{
+			ConfigControllerConfig: addonconfig.ConfigControllerConfig{SystemNamespace: flags.addonNamespace}},
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1}); err != nil {
+		setupLog.Error(err, "unable to create CPIConfigController", "controller", "azurecpi")
+		os.Exit(1)
+	}
+
 	if err := (&csicontroller.VSphereCSIConfigReconciler{
 		Client: mgr.GetClient(),
 		Log:    ctrl.Log.WithName("VSphereCSIConfig"),
@@ -340,6 +375,17 @@ func enableClusterBootstrapAndConfigControllers(ctx context.Context, mgr ctrl.Ma
 		os.Exit(1)
 	}
 
+	if err := (&azurediskcsicontroller.AzureDiskCSIConfigReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("AzureDiskCSIConfig"),
+		Scheme: mgr.GetScheme(),
+		Config: addonconfig.AzureDiskCSIConfigControllerConfig{
+			ConfigControllerConfig: addonconfig.ConfigControllerConfig{SystemNamespace: flags.addonNamespace}},
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1}); err != nil {
+		setupLog.Error(err, "unable to create CSIConfigController", "controller", "azurediskcsi")
+		os.Exit(1)
+	}
+
 	if err := (&controllers.MachineReconciler{
 		Client: mgr.GetClient(),
 		Log:    ctrl.Log.WithName("MachineController"),
@@ -367,6 +413,11 @@ func enableClusterBootstrapAndConfigControllers(ctx context.Context, mgr ctrl.Ma
 		setupLog.Error(err, "unable to create controller", "controller", "clusterbootstrap")
 		os.Exit(1)
 	}
+
+	if err := metrics.RegisterClusterBootstrapCollector(mgr.GetClient(), ctrl.Log.WithName("ClusterBootstrapMetrics")); err != nil {
+		setupLog.Error(err, "unable to register metrics collector", "collector", "clusterbootstrap")
+		os.Exit(1)
+	}
 }
 
 func enableWebhooks(ctx context.Context, mgr ctrl.Manager, flags *addonFlags) {
@@ -466,3 +517,18 @@ func enablePackageInstallStatusController(ctx context.Context, mgr ctrl.Manager,
 		os.Exit(1)
 	}
 }
+
+func enableClusterBootstrapRolloutController(ctx context.Context, mgr ctrl.Manager, flags *addonFlags) {
+	rolloutReconciler := controllers.NewClusterBootstrapRolloutReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("ClusterBootstrapRolloutController"),
+		mgr.GetScheme(),
+		&addonconfig.ClusterBootstrapRolloutControllerConfig{
+			SystemNamespace: flags.addonNamespace,
+		},
+	)
+	if err := rolloutReconciler.SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "clusterbootstraprollout")
+		os.Exit(1)
+	}
+}
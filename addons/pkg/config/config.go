@@ -43,6 +43,12 @@ type PackageInstallStatusControllerConfig struct {
 	SystemNamespace string
 }
 
+// ClusterBootstrapRolloutControllerConfig contains configuration information related to ClusterBootstrapRollout
+type ClusterBootstrapRolloutControllerConfig struct {
+	// The namespace where the Carvel packages are available, i.e., tkg-system
+	SystemNamespace string
+}
+
 // ConfigControllerConfig contains common configuration information of config controller
 type ConfigControllerConfig struct {
 	// The namespace where the template config objects will be created, i.e., tkg-system
@@ -83,3 +89,18 @@ type AwsEbsCSIConfigControllerConfig struct {
 type AzureFileCSIConfigControllerConfig struct {
 	ConfigControllerConfig
 }
+
+// AzureDiskCSIConfigControllerConfig contains configuration information of AzureDiskCSIConfig controller
+type AzureDiskCSIConfigControllerConfig struct {
+	ConfigControllerConfig
+}
+
+// AWSCPIConfigControllerConfig contains configuration information of AWSCPIConfig controller
+type AWSCPIConfigControllerConfig struct {
+	ConfigControllerConfig
+}
+
+// AzureCPIConfigControllerConfig contains configuration information of AzureCPIConfig controller
+type AzureCPIConfigControllerConfig struct {
+	ConfigControllerConfig
+}
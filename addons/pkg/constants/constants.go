@@ -48,6 +48,23 @@ const (
 	AwsEbsCSIAddonName = "aws-ebs-csi"
 	// AzureFileCSIAddonName is name of the azurefile-csi addon
 	AzureFileCSIAddonName = "azurefile-csi"
+	// AzureDiskCSIAddonName is name of the azuredisk-csi addon
+	AzureDiskCSIAddonName = "azuredisk-csi"
+
+	// AzureDiskCSIDefaultRefName is default refname for azuredisk-csi addon
+	AzureDiskCSIDefaultRefName = AzureDiskCSIAddonName + ".tanzu.vmware.com"
+
+	// AWSCPIAddonName is name of the aws-cloud-controller-manager addon
+	AWSCPIAddonName = "aws-cloud-controller-manager"
+
+	// AWSCPIDefaultRefName is default refname for aws-cloud-controller-manager addon
+	AWSCPIDefaultRefName = AWSCPIAddonName + ".tanzu.vmware.com"
+
+	// AzureCPIAddonName is name of the azure-cloud-controller-manager addon
+	AzureCPIAddonName = "azure-cloud-controller-manager"
+
+	// AzureCPIDefaultRefName is default refname for azure-cloud-controller-manager addon
+	AzureCPIDefaultRefName = AzureCPIAddonName + ".tanzu.vmware.com"
 
 	// TKGBomNamespace is the TKG add on BOM namespace.
 	TKGBomNamespace = "tkr-system"
@@ -210,6 +227,32 @@ const (
 	// RequeueAfterDuration determines the duration after which the Controller should requeue the reconcile key
 	RequeueAfterDuration = time.Second * 10
 
+	// WaitingForDependenciesConditionType is the suffix of the type of the ClusterBootstrap condition set while the
+	// PackageInstall of an additional package waits for its dependencies to reconcile successfully
+	WaitingForDependenciesConditionType = "WaitingForDependencies"
+
+	// DependenciesNotReadyReason is the reason of the ClusterBootstrap condition set while the PackageInstall of an
+	// additional package waits for its dependencies to reconcile successfully
+	DependenciesNotReadyReason = "DependenciesNotReady"
+
+	// RolloutInProgressReason is the reason of the ClusterBootstrapRollout Ready condition while clusters are being updated
+	RolloutInProgressReason = "RolloutInProgress"
+
+	// RolloutPausedReason is the reason of the ClusterBootstrapRollout Ready condition while the rollout is paused
+	RolloutPausedReason = "RolloutPaused"
+
+	// RolloutFailedReason is the reason of the ClusterBootstrapRollout Ready condition when the package failed to roll
+	// out on some clusters
+	RolloutFailedReason = "RolloutFailed"
+
+	// InvalidRolloutPackageReason is the reason of the ClusterBootstrapRollout Ready condition when the package of the
+	// rollout can't be rolled out
+	InvalidRolloutPackageReason = "InvalidRolloutPackage"
+
+	// DefaultRolloutProgressDeadline is the default time to wait for the PackageInstall of a cluster updated by a
+	// ClusterBootstrapRollout to be reconciled successfully
+	DefaultRolloutProgressDeadline = time.Minute * 30
+
 	// WebhookCertDir is the directory where the certificate and key are stored for webhook server TLS handshake
 	WebhookCertDir = "/tmp/k8s-webhook-server/serving-certs"
 
@@ -294,6 +337,15 @@ const (
 	// InfrastructureRefAzure is the Azure infrastructure
 	InfrastructureRefAzure = "AzureCluster"
 
+	// AzureClusterIdentityKind is the kind of the identity referenced by an AzureCluster
+	AzureClusterIdentityKind = "AzureClusterIdentity"
+
+	// AzureClientSecretKey is the key of the service principal client secret in the secret of an AzureClusterIdentity
+	AzureClientSecretKey = "clientSecret"
+
+	// AzureClientIDKey is the key of the service principal client ID in the credential secret of an Azure addon config
+	AzureClientIDKey = "clientID"
+
 	// InfrastructureRefDocker is the docker infrastructure
 	InfrastructureRefDocker = "DockerCluster"
 
@@ -325,4 +377,13 @@ var (
 
 	// AwsEbsCSIConfigKind is the Kind for csi AwsEbsCSIConfig object
 	AwsEbsCSIConfigKind = reflect.TypeOf(csiv1alpha1.AwsEbsCSIConfig{}).Name()
+
+	// AzureDiskCSIConfigKind is the Kind for csi AzureDiskCSIConfig object
+	AzureDiskCSIConfigKind = reflect.TypeOf(csiv1alpha1.AzureDiskCSIConfig{}).Name()
+
+	// AWSCPIConfigKind is the Kind for cpi AWSCPIConfig object
+	AWSCPIConfigKind = reflect.TypeOf(cpiv1alpha1.AWSCPIConfig{}).Name()
+
+	// AzureCPIConfigKind is the Kind for cpi AzureCPIConfig object
+	AzureCPIConfigKind = reflect.TypeOf(cpiv1alpha1.AzureCPIConfig{}).Name()
 )
@@ -0,0 +1,130 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+)
+
+const (
+	// ClusterBootstrapPhasePaused is the phase of a paused ClusterBootstrap
+	ClusterBootstrapPhasePaused = "Paused"
+	// ClusterBootstrapPhasePending is the phase of a ClusterBootstrap which has not been resolved to a TKR yet
+	ClusterBootstrapPhasePending = "Pending"
+	// ClusterBootstrapPhaseProvisioning is the phase of a ClusterBootstrap whose packages are not all reconciled yet
+	ClusterBootstrapPhaseProvisioning = "Provisioning"
+	// ClusterBootstrapPhaseReady is the phase of a ClusterBootstrap whose packages are all reconciled successfully
+	ClusterBootstrapPhaseReady = "Ready"
+	// ClusterBootstrapPhaseFailed is the phase of a ClusterBootstrap with packages which failed to reconcile
+	ClusterBootstrapPhaseFailed = "Failed"
+
+	// collectTimeout is the maximum time spent listing the ClusterBootstraps on a scrape
+	collectTimeout = 10 * time.Second
+)
+
+var clusterBootstrapPhases = []string{
+	ClusterBootstrapPhasePaused,
+	ClusterBootstrapPhasePending,
+	ClusterBootstrapPhaseProvisioning,
+	ClusterBootstrapPhaseReady,
+	ClusterBootstrapPhaseFailed,
+}
+
+// clusterBootstrapCollector collects the number of clusters per ClusterBootstrap phase when the metrics are scraped
+type clusterBootstrapCollector struct {
+	client       client.Client
+	log          logr.Logger
+	clustersDesc *prometheus.Desc
+}
+
+// NewClusterBootstrapCollector returns a collector of the number of clusters per ClusterBootstrap phase
+func NewClusterBootstrapCollector(c client.Client, log logr.Logger) prometheus.Collector {
+	return &clusterBootstrapCollector{
+		client: c,
+		log:    log,
+		clustersDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "clusterbootstrap_clusters"),
+			"Number of clusters per ClusterBootstrap phase.",
+			[]string{PhaseLabel}, nil,
+		),
+	}
+}
+
+// RegisterClusterBootstrapCollector registers the collector of the number of clusters per ClusterBootstrap phase in the
+// controller-runtime metrics registry
+func RegisterClusterBootstrapCollector(c client.Client, log logr.Logger) error {
+	return metrics.Registry.Register(NewClusterBootstrapCollector(c, log))
+}
+
+// Describe implements prometheus.Collector
+func (c *clusterBootstrapCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.clustersDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *clusterBootstrapCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	clusterBootstrapList := &runtanzuv1alpha3.ClusterBootstrapList{}
+	if err := c.client.List(ctx, clusterBootstrapList); err != nil {
+		c.log.Error(err, "unable to list ClusterBootstraps for metrics")
+		return
+	}
+
+	clusters := map[string]int{}
+	for i := range clusterBootstrapList.Items {
+		clusters[ClusterBootstrapPhase(&clusterBootstrapList.Items[i])]++
+	}
+	for _, phase := range clusterBootstrapPhases {
+		ch <- prometheus.MustNewConstMetric(c.clustersDesc, prometheus.GaugeValue, float64(clusters[phase]), phase)
+	}
+}
+
+// ClusterBootstrapPhase returns the phase of the ClusterBootstrap from the package statuses reported by the
+// PackageInstallStatus controller. The kapp-controller package is not considered as its status is only reported for
+// workload clusters, and the other packages can't reconcile on a workload cluster without it.
+func ClusterBootstrapPhase(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap) string {
+	if clusterBootstrap.Spec != nil && clusterBootstrap.Spec.Paused {
+		return ClusterBootstrapPhasePaused
+	}
+	if clusterBootstrap.Spec == nil || clusterBootstrap.Status.ResolvedTKR == "" {
+		return ClusterBootstrapPhasePending
+	}
+
+	states := map[string]string{}
+	for _, packageStatus := range clusterBootstrap.Status.Packages {
+		states[packageStatus.RefName] = packageStatus.State
+	}
+
+	packages := append([]*runtanzuv1alpha3.ClusterBootstrapPackage{
+		clusterBootstrap.Spec.CNI,
+		clusterBootstrap.Spec.CPI,
+		clusterBootstrap.Spec.CSI,
+	}, clusterBootstrap.Spec.AdditionalPackages...)
+
+	phase := ClusterBootstrapPhaseReady
+	for _, pkg := range packages {
+		if pkg == nil {
+			continue
+		}
+		switch states[pkg.RefName] {
+		case string(kappctrlv1alpha1.ReconcileFailed), string(kappctrlv1alpha1.DeleteFailed):
+			return ClusterBootstrapPhaseFailed
+		case string(kappctrlv1alpha1.ReconcileSucceeded):
+		default:
+			phase = ClusterBootstrapPhaseProvisioning
+		}
+	}
+	return phase
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics defines the Prometheus metrics of the addons manager. The metrics are registered in the
+// controller-runtime metrics registry and served on the metrics endpoint of the manager.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// metricsNamespace is the prefix of the addons manager metrics
+	metricsNamespace = "tanzu_addons"
+
+	// ClusterNamespaceLabel is the label of the namespace of the cluster
+	ClusterNamespaceLabel = "cluster_namespace"
+	// ClusterNameLabel is the label of the name of the cluster
+	ClusterNameLabel = "cluster_name"
+	// PackageLabel is the label of the Carvel package refName, e.g. antrea.tanzu.vmware.com
+	PackageLabel = "package"
+	// AddonLabel is the label of the addon name of a config controller, e.g. antrea
+	AddonLabel = "addon"
+	// PhaseLabel is the label of the ClusterBootstrap phase
+	PhaseLabel = "phase"
+)
+
+var (
+	// PackageInstallReconcileFailures counts the PackageInstalls that failed to reconcile, per package and cluster
+	PackageInstallReconcileFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "packageinstall_reconcile_failures_total",
+			Help:      "Number of times the PackageInstall of a package of a cluster failed to reconcile.",
+		},
+		[]string{ClusterNamespaceLabel, ClusterNameLabel, PackageLabel},
+	)
+
+	// ClusterCorePackagesReadyDuration observes the time from the creation of a cluster to all its core packages being
+	// reconciled successfully
+	ClusterCorePackagesReadyDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "cluster_core_packages_ready_duration_seconds",
+			Help:      "Time from the creation of a cluster to all its core packages being reconciled successfully.",
+			Buckets:   []float64{60, 120, 180, 300, 450, 600, 900, 1200, 1800, 2700, 3600},
+		},
+	)
+
+	// RemoteClusterClientErrors counts the errors getting a client or a watch for a remote cluster from the ClusterCacheTracker
+	RemoteClusterClientErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "remote_cluster_client_errors_total",
+			Help:      "Number of errors getting a client or a watch for a remote cluster from the cluster cache tracker.",
+		},
+		[]string{ClusterNamespaceLabel, ClusterNameLabel},
+	)
+
+	// DataValuesSecretGenerationDuration observes the time taken by the config controllers to generate the data values
+	// secret of an addon
+	DataValuesSecretGenerationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "data_values_secret_generation_duration_seconds",
+			Help:      "Time taken by a config controller to generate the data values secret of an addon.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{AddonLabel},
+	)
+
+	// observedClusters holds the UIDs of the clusters whose core packages ready duration has been observed
+	observedClusters sync.Map
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		PackageInstallReconcileFailures,
+		ClusterCorePackagesReadyDuration,
+		RemoteClusterClientErrors,
+		DataValuesSecretGenerationDuration,
+	)
+}
+
+// ObserveDataValuesSecretGeneration records the time taken to generate the data values secret of the addon since start
+func ObserveDataValuesSecretGeneration(addonName string, start time.Time) {
+	DataValuesSecretGenerationDuration.WithLabelValues(addonName).Observe(time.Since(start).Seconds())
+}
+
+// ObserveClusterCorePackagesReady records the time from the creation of the cluster to all its core packages being
+// reconciled successfully. It is only recorded the first time the core packages of the cluster become ready.
+func ObserveClusterCorePackagesReady(clusterUID types.UID, creationTime time.Time) {
+	if _, observed := observedClusters.LoadOrStore(clusterUID, true); observed {
+		return
+	}
+	ClusterCorePackagesReadyDuration.Observe(time.Since(creationTime).Seconds())
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+)
+
+const (
+	antreaRefName = "antrea.tanzu.vmware.com.1.2.3--vmware.1-tkg.1"
+	csiRefName    = "vsphere-csi.tanzu.vmware.com.2.4.1--vmware.1-tkg.1"
+	kappRefName   = "kapp-controller.tanzu.vmware.com.0.38.4--vmware.1-tkg.1"
+)
+
+func newClusterBootstrap(name string, states ...kappctrlv1alpha1.AppConditionType) *runtanzuv1alpha3.ClusterBootstrap {
+	clusterBootstrap := &runtanzuv1alpha3.ClusterBootstrap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: &runtanzuv1alpha3.ClusterBootstrapTemplateSpec{
+			CNI:  &runtanzuv1alpha3.ClusterBootstrapPackage{RefName: antreaRefName},
+			CSI:  &runtanzuv1alpha3.ClusterBootstrapPackage{RefName: csiRefName},
+			Kapp: &runtanzuv1alpha3.ClusterBootstrapPackage{RefName: kappRefName},
+		},
+		Status: runtanzuv1alpha3.ClusterBootstrapStatus{ResolvedTKR: "v1.23.8---vmware.2-tkg.2-zshippable"},
+	}
+	for i, refName := range []string{antreaRefName, csiRefName} {
+		if i < len(states) {
+			clusterBootstrap.Status.Packages = append(clusterBootstrap.Status.Packages,
+				runtanzuv1alpha3.ClusterBootstrapPackageStatus{RefName: refName, State: string(states[i])})
+		}
+	}
+	return clusterBootstrap
+}
+
+func histogramSampleCount(histogram prometheus.Histogram) uint64 {
+	metric := &dto.Metric{}
+	Expect(histogram.Write(metric)).To(Succeed())
+	return metric.GetHistogram().GetSampleCount()
+}
+
+var _ = Describe("Addons manager metrics", func() {
+	Context("ClusterBootstrapPhase", func() {
+		It("should be pending until the TKR is resolved", func() {
+			clusterBootstrap := newClusterBootstrap("cluster-1")
+			clusterBootstrap.Status.ResolvedTKR = ""
+			Expect(ClusterBootstrapPhase(clusterBootstrap)).To(Equal(ClusterBootstrapPhasePending))
+		})
+
+		It("should be paused when the ClusterBootstrap is paused", func() {
+			clusterBootstrap := newClusterBootstrap("cluster-1", kappctrlv1alpha1.ReconcileFailed)
+			clusterBootstrap.Spec.Paused = true
+			Expect(ClusterBootstrapPhase(clusterBootstrap)).To(Equal(ClusterBootstrapPhasePaused))
+		})
+
+		It("should be provisioning until all the packages reconciled", func() {
+			Expect(ClusterBootstrapPhase(newClusterBootstrap("cluster-1"))).To(Equal(ClusterBootstrapPhaseProvisioning))
+			Expect(ClusterBootstrapPhase(newClusterBootstrap("cluster-1",
+				kappctrlv1alpha1.ReconcileSucceeded, kappctrlv1alpha1.Reconciling))).To(Equal(ClusterBootstrapPhaseProvisioning))
+		})
+
+		It("should be failed when a package failed to reconcile", func() {
+			Expect(ClusterBootstrapPhase(newClusterBootstrap("cluster-1",
+				kappctrlv1alpha1.Reconciling, kappctrlv1alpha1.ReconcileFailed))).To(Equal(ClusterBootstrapPhaseFailed))
+		})
+
+		It("should be ready when all the packages but kapp-controller reconciled successfully", func() {
+			Expect(ClusterBootstrapPhase(newClusterBootstrap("cluster-1",
+				kappctrlv1alpha1.ReconcileSucceeded, kappctrlv1alpha1.ReconcileSucceeded))).To(Equal(ClusterBootstrapPhaseReady))
+		})
+	})
+
+	Context("ClusterBootstrap collector", func() {
+		It("should report the number of clusters per phase", func() {
+			scheme := runtime.NewScheme()
+			Expect(runtanzuv1alpha3.AddToScheme(scheme)).To(Succeed())
+			objs := []client.Object{
+				newClusterBootstrap("cluster-1", kappctrlv1alpha1.ReconcileSucceeded, kappctrlv1alpha1.ReconcileSucceeded),
+				newClusterBootstrap("cluster-2", kappctrlv1alpha1.ReconcileSucceeded, kappctrlv1alpha1.ReconcileSucceeded),
+				newClusterBootstrap("cluster-3", kappctrlv1alpha1.ReconcileFailed),
+				newClusterBootstrap("cluster-4"),
+			}
+			collector := NewClusterBootstrapCollector(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+				ctrl.Log.WithName("ClusterBootstrapMetricsTest"))
+
+			expected := `
+# HELP tanzu_addons_clusterbootstrap_clusters Number of clusters per ClusterBootstrap phase.
+# TYPE tanzu_addons_clusterbootstrap_clusters gauge
+tanzu_addons_clusterbootstrap_clusters{phase="Failed"} 1
+tanzu_addons_clusterbootstrap_clusters{phase="Paused"} 0
+tanzu_addons_clusterbootstrap_clusters{phase="Pending"} 0
+tanzu_addons_clusterbootstrap_clusters{phase="Provisioning"} 1
+tanzu_addons_clusterbootstrap_clusters{phase="Ready"} 2
+`
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(expected))).To(Succeed())
+		})
+	})
+
+	Context("ObserveClusterCorePackagesReady", func() {
+		It("should only observe the first time the core packages of a cluster become ready", func() {
+			sampleCount := histogramSampleCount(ClusterCorePackagesReadyDuration)
+			ObserveClusterCorePackagesReady("cluster-uid-1", time.Now().Add(-5*time.Minute))
+			ObserveClusterCorePackagesReady("cluster-uid-1", time.Now().Add(-5*time.Minute))
+			Expect(histogramSampleCount(ClusterCorePackagesReadyDuration)).To(Equal(sampleCount + 1))
+
+			ObserveClusterCorePackagesReady("cluster-uid-2", time.Now().Add(-10*time.Minute))
+			Expect(histogramSampleCount(ClusterCorePackagesReadyDuration)).To(Equal(sampleCount + 2))
+		})
+	})
+
+	Context("controller-runtime metrics registry", func() {
+		It("should serve the addons manager metrics", func() {
+			PackageInstallReconcileFailures.WithLabelValues("default", "cluster-1", "antrea.tanzu.vmware.com").Inc()
+			RemoteClusterClientErrors.WithLabelValues("default", "cluster-1").Inc()
+			ObserveDataValuesSecretGeneration("antrea", time.Now())
+
+			metricFamilies, err := metrics.Registry.Gather()
+			Expect(err).NotTo(HaveOccurred())
+			var names []string
+			for _, metricFamily := range metricFamilies {
+				names = append(names, metricFamily.GetName())
+			}
+			Expect(names).To(ContainElements(
+				"tanzu_addons_packageinstall_reconcile_failures_total",
+				"tanzu_addons_cluster_core_packages_ready_duration_seconds",
+				"tanzu_addons_remote_cluster_client_errors_total",
+				"tanzu_addons_data_values_secret_generation_duration_seconds",
+			))
+			Expect(testutil.ToFloat64(RemoteClusterClientErrors.WithLabelValues("default", "cluster-1"))).To(BeNumerically(">=", 1))
+		})
+	})
+})
@@ -83,6 +83,7 @@ type ClusterBootstrapPackageBuilder struct {
 	inline      map[string]interface{}
 	secretRef   string
 	providerRef *corev1.TypedLocalObjectReference
+	dependsOn   []string
 }
 
 // ClusterBootstrap returns a ClusterBootstrapBuilder with the given name and namespace.
@@ -111,10 +112,16 @@ func (c *ClusterBootstrapPackageBuilder) WithProviderRef(APIGroup, kind, name st
 	return c
 }
 
+func (c *ClusterBootstrapPackageBuilder) WithDependsOn(refNames ...string) *ClusterBootstrapPackageBuilder {
+	c.dependsOn = refNames
+	return c
+}
+
 // Build takes the objects and variables in the ClusterClass builder and uses them to create a ClusterClass object.
 func (c *ClusterBootstrapPackageBuilder) Build() *runv1alpha3.ClusterBootstrapPackage {
 	obj := &runv1alpha3.ClusterBootstrapPackage{
-		RefName: c.refName,
+		RefName:   c.refName,
+		DependsOn: c.dependsOn,
 		ValuesFrom: &runv1alpha3.ValuesFrom{
 			Inline:      c.inline,
 			SecretRef:   c.secretRef,
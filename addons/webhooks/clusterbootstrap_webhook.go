@@ -6,6 +6,7 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -196,6 +197,8 @@ func (wh *ClusterBootstrap) ValidateCreate(ctx context.Context, obj runtime.Obje
 		}
 	}
 
+	allErrs = append(allErrs, validatePackageDependencies(clusterBootstrap)...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -224,6 +227,99 @@ func (wh *ClusterBootstrap) validateClusterBootstrapPackage(ctx context.Context,
 	return nil
 }
 
+// validatePackageDependencies validates the dependencies of the packages. Only additional packages can have dependencies,
+// which must be other additional packages and must not form a cycle
+func validatePackageDependencies(clusterBootstrap *runv1alpha3.ClusterBootstrap) field.ErrorList {
+	var allErrs field.ErrorList
+	corePackages := []struct {
+		fieldName string
+		pkg       *runv1alpha3.ClusterBootstrapPackage
+	}{
+		{"cni", clusterBootstrap.Spec.CNI},
+		{"cpi", clusterBootstrap.Spec.CPI},
+		{"csi", clusterBootstrap.Spec.CSI},
+		{"kapp", clusterBootstrap.Spec.Kapp},
+	}
+	for _, corePackage := range corePackages {
+		if corePackage.pkg != nil && len(corePackage.pkg.DependsOn) > 0 {
+			allErrs = append(allErrs, field.Forbidden(getFieldPath(corePackage.fieldName).Child("dependsOn"), "only additional packages can have dependencies"))
+		}
+	}
+
+	additionalPkgFldPath := getFieldPath("additionalPackages")
+	dependencies := map[string][]string{}
+	var refNames []string
+	for _, pkg := range clusterBootstrap.Spec.AdditionalPackages {
+		if pkg != nil {
+			dependencies[pkg.RefName] = pkg.DependsOn
+			refNames = append(refNames, pkg.RefName)
+		}
+	}
+	for idx, pkg := range clusterBootstrap.Spec.AdditionalPackages {
+		if pkg == nil {
+			continue
+		}
+		for dependencyIdx, dependency := range pkg.DependsOn {
+			fldPath := additionalPkgFldPath.Index(idx).Child("dependsOn").Index(dependencyIdx)
+			if dependency == pkg.RefName {
+				allErrs = append(allErrs, field.Invalid(fldPath, dependency, "package can't depend on itself"))
+			} else if _, ok := dependencies[dependency]; !ok {
+				allErrs = append(allErrs, field.Invalid(fldPath, dependency, "dependency must be the refName of another additional package"))
+			}
+		}
+	}
+
+	if cycle := findDependencyCycle(refNames, dependencies); cycle != nil {
+		allErrs = append(allErrs, field.Invalid(additionalPkgFldPath, strings.Join(cycle, " -> "), "dependencies of additional packages form a cycle"))
+	}
+	return allErrs
+}
+
+// findDependencyCycle returns the refNames of the packages forming a dependency cycle, starting and ending with the same
+// refName, or nil if there is no cycle. Dependencies on the package itself or on unknown packages are ignored.
+func findDependencyCycle(refNames []string, dependencies map[string][]string) []string {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := map[string]int{}
+	var path []string
+
+	var visit func(refName string) []string
+	visit = func(refName string) []string {
+		switch state[refName] {
+		case visited:
+			return nil
+		case visiting:
+			for i := range path {
+				if path[i] == refName {
+					return append(append([]string{}, path[i:]...), refName)
+				}
+			}
+		}
+		state[refName] = visiting
+		path = append(path, refName)
+		for _, dependency := range dependencies[refName] {
+			if _, ok := dependencies[dependency]; !ok || dependency == refName {
+				continue
+			}
+			if cycle := visit(dependency); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[refName] = visited
+		return nil
+	}
+
+	for _, refName := range refNames {
+		if cycle := visit(refName); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
 // validateValuesFrom validates content of valuesFrom
 func (wh *ClusterBootstrap) validateValuesFrom(ctx context.Context, valuesFrom *runv1alpha3.ValuesFrom, clusterBootstrapNamespace string, fldPath *field.Path) *field.Error {
 	// valuesFrom can be nil
@@ -381,6 +477,8 @@ func (wh *ClusterBootstrap) ValidateUpdate(ctx context.Context, oldObj, newObj r
 		allErrs = append(allErrs, err...)
 	}
 
+	allErrs = append(allErrs, validatePackageDependencies(newClusterBootstrap)...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
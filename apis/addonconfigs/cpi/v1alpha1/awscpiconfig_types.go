@@ -0,0 +1,71 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AWSCPIConfigSpec defines the desired state of AWSCPIConfig
+type AWSCPIConfigSpec struct {
+	AWSCPI AWSCPI `json:"awsCPI"`
+}
+
+// AWSCPI defines the settings of the AWS cloud provider. Settings which are not provided are derived from the
+// AWSCluster of the owner cluster.
+type AWSCPI struct {
+	// The AWS region the cluster is deployed in
+	// +kubebuilder:validation:Optional
+	Region *string `json:"region,omitempty"`
+
+	// The ID of the VPC the cluster is deployed in
+	// +kubebuilder:validation:Optional
+	VPCID *string `json:"vpcID,omitempty"`
+
+	// The IDs of the subnets the cluster is deployed in
+	// +kubebuilder:validation:Optional
+	SubnetIDs []string `json:"subnetIDs,omitempty"`
+
+	// The ARN of the IAM role assumed by the cloud provider
+	// +kubebuilder:validation:Optional
+	RoleARN *string `json:"roleARN,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+}
+
+// AWSCPIConfigStatus defines the observed state of AWSCPIConfig
+type AWSCPIConfigStatus struct {
+	// Name of the data value secret created by AWS CPI controller
+	//+ kubebuilder:validation:Optional
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=awscpiconfigs,shortName=awscpic,scope=Namespaced
+//+kubebuilder:printcolumn:name="Region",type="string",JSONPath=".spec.awsCPI.region",description="The AWS region of the cluster"
+//+kubebuilder:printcolumn:name="SecretRef",type="string",JSONPath=".status.secretRef",description="Name of the kapp-controller data values secret"
+
+// AWSCPIConfig is the Schema for the AWSCPIConfig API
+type AWSCPIConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AWSCPIConfigSpec   `json:"spec,omitempty"`
+	Status AWSCPIConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AWSCPIConfigList contains a list of AWSCPIConfig
+type AWSCPIConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AWSCPIConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AWSCPIConfig{}, &AWSCPIConfigList{})
+}
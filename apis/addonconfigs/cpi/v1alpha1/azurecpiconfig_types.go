@@ -0,0 +1,109 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AzureCPIConfigSpec defines the desired state of AzureCPIConfig
+type AzureCPIConfigSpec struct {
+	AzureCPI AzureCPI `json:"azureCPI"`
+}
+
+// AzureCPI defines the settings of the Azure cloud provider. Settings which are not provided are derived from the
+// AzureCluster of the owner cluster and its AzureClusterIdentity.
+type AzureCPI struct {
+	// The name of the Azure cloud, e.g. AzurePublicCloud
+	// +kubebuilder:validation:Optional
+	CloudName *string `json:"cloudName,omitempty"`
+
+	// The ID of the Azure tenant
+	// +kubebuilder:validation:Optional
+	TenantID *string `json:"tenantID,omitempty"`
+
+	// The ID of the Azure subscription
+	// +kubebuilder:validation:Optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// A secret reference that contains the service principal credentials used by the cloud provider
+	// consists of the fields clientID and clientSecret
+	// +kubebuilder:validation:Optional
+	CredentialLocalObjRef *v1.TypedLocalObjectReference `json:"credentialLocalObjRef,omitempty"`
+
+	// The flag that makes the cloud provider authenticate with a managed identity instead of a service principal
+	// +kubebuilder:validation:Optional
+	UseManagedIdentityExtension *bool `json:"useManagedIdentityExtension,omitempty"`
+
+	// The client ID of the user assigned managed identity
+	// +kubebuilder:validation:Optional
+	UserAssignedIdentityID *string `json:"userAssignedIdentityID,omitempty"`
+
+	// The resource group the cluster is deployed in
+	// +kubebuilder:validation:Optional
+	ResourceGroup *string `json:"resourceGroup,omitempty"`
+
+	// The Azure location the cluster is deployed in
+	// +kubebuilder:validation:Optional
+	Location *string `json:"location,omitempty"`
+
+	// The name of the virtual network the cluster is deployed in
+	// +kubebuilder:validation:Optional
+	VNetName *string `json:"vnetName,omitempty"`
+
+	// The resource group of the virtual network
+	// +kubebuilder:validation:Optional
+	VNetResourceGroup *string `json:"vnetResourceGroup,omitempty"`
+
+	// The name of the subnet of the worker nodes
+	// +kubebuilder:validation:Optional
+	SubnetName *string `json:"subnetName,omitempty"`
+
+	// The name of the security group attached to the subnet of the worker nodes
+	// +kubebuilder:validation:Optional
+	SecurityGroupName *string `json:"securityGroupName,omitempty"`
+
+	// The name of the route table attached to the subnet of the worker nodes
+	// +kubebuilder:validation:Optional
+	RouteTableName *string `json:"routeTableName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+}
+
+// AzureCPIConfigStatus defines the observed state of AzureCPIConfig
+type AzureCPIConfigStatus struct {
+	// Name of the data value secret created by Azure CPI controller
+	//+ kubebuilder:validation:Optional
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=azurecpiconfigs,shortName=azcpic,scope=Namespaced
+//+kubebuilder:printcolumn:name="Location",type="string",JSONPath=".spec.azureCPI.location",description="The Azure location of the cluster"
+//+kubebuilder:printcolumn:name="SecretRef",type="string",JSONPath=".status.secretRef",description="Name of the kapp-controller data values secret"
+
+// AzureCPIConfig is the Schema for the AzureCPIConfig API
+type AzureCPIConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AzureCPIConfigSpec   `json:"spec,omitempty"`
+	Status AzureCPIConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AzureCPIConfigList contains a list of AzureCPIConfig
+type AzureCPIConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureCPIConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AzureCPIConfig{}, &AzureCPIConfigList{})
+}
@@ -13,6 +13,311 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSCPI) DeepCopyInto(out *AWSCPI) {
+	*out = *in
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.VPCID != nil {
+		in, out := &in.VPCID, &out.VPCID
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RoleARN != nil {
+		in, out := &in.RoleARN, &out.RoleARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(Proxy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSCPI.
+func (in *AWSCPI) DeepCopy() *AWSCPI {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSCPI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSCPIConfig) DeepCopyInto(out *AWSCPIConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSCPIConfig.
+func (in *AWSCPIConfig) DeepCopy() *AWSCPIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSCPIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AWSCPIConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSCPIConfigList) DeepCopyInto(out *AWSCPIConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AWSCPIConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSCPIConfigList.
+func (in *AWSCPIConfigList) DeepCopy() *AWSCPIConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSCPIConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AWSCPIConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSCPIConfigSpec) DeepCopyInto(out *AWSCPIConfigSpec) {
+	*out = *in
+	in.AWSCPI.DeepCopyInto(&out.AWSCPI)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSCPIConfigSpec.
+func (in *AWSCPIConfigSpec) DeepCopy() *AWSCPIConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSCPIConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSCPIConfigStatus) DeepCopyInto(out *AWSCPIConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSCPIConfigStatus.
+func (in *AWSCPIConfigStatus) DeepCopy() *AWSCPIConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSCPIConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureCPI) DeepCopyInto(out *AzureCPI) {
+	*out = *in
+	if in.CloudName != nil {
+		in, out := &in.CloudName, &out.CloudName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TenantID != nil {
+		in, out := &in.TenantID, &out.TenantID
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubscriptionID != nil {
+		in, out := &in.SubscriptionID, &out.SubscriptionID
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialLocalObjRef != nil {
+		in, out := &in.CredentialLocalObjRef, &out.CredentialLocalObjRef
+		*out = new(v1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UseManagedIdentityExtension != nil {
+		in, out := &in.UseManagedIdentityExtension, &out.UseManagedIdentityExtension
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UserAssignedIdentityID != nil {
+		in, out := &in.UserAssignedIdentityID, &out.UserAssignedIdentityID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourceGroup != nil {
+		in, out := &in.ResourceGroup, &out.ResourceGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(string)
+		**out = **in
+	}
+	if in.VNetName != nil {
+		in, out := &in.VNetName, &out.VNetName
+		*out = new(string)
+		**out = **in
+	}
+	if in.VNetResourceGroup != nil {
+		in, out := &in.VNetResourceGroup, &out.VNetResourceGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubnetName != nil {
+		in, out := &in.SubnetName, &out.SubnetName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecurityGroupName != nil {
+		in, out := &in.SecurityGroupName, &out.SecurityGroupName
+		*out = new(string)
+		**out = **in
+	}
+	if in.RouteTableName != nil {
+		in, out := &in.RouteTableName, &out.RouteTableName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(Proxy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureCPI.
+func (in *AzureCPI) DeepCopy() *AzureCPI {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureCPI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureCPIConfig) DeepCopyInto(out *AzureCPIConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureCPIConfig.
+func (in *AzureCPIConfig) DeepCopy() *AzureCPIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureCPIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureCPIConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureCPIConfigList) DeepCopyInto(out *AzureCPIConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AzureCPIConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureCPIConfigList.
+func (in *AzureCPIConfigList) DeepCopy() *AzureCPIConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureCPIConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureCPIConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureCPIConfigSpec) DeepCopyInto(out *AzureCPIConfigSpec) {
+	*out = *in
+	in.AzureCPI.DeepCopyInto(&out.AzureCPI)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureCPIConfigSpec.
+func (in *AzureCPIConfigSpec) DeepCopy() *AzureCPIConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureCPIConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureCPIConfigStatus) DeepCopyInto(out *AzureCPIConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureCPIConfigStatus.
+func (in *AzureCPIConfigStatus) DeepCopy() *AzureCPIConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureCPIConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NSXT) DeepCopyInto(out *NSXT) {
 	*out = *in
@@ -0,0 +1,99 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AzureDiskCSIConfigSpec defines the desired state of AzureDiskCSIConfig
+type AzureDiskCSIConfigSpec struct {
+	AzureDiskCSI AzureDiskCSI `json:"azureDiskCSIDriver"`
+}
+
+// AzureDiskCSIConfigStatus defines the observed state of AzureDiskCSIConfig
+type AzureDiskCSIConfigStatus struct {
+	// Name of the secret created by csi controller
+	//+ kubebuilder:validation:Optional
+	SecretRef *string `json:"secretRef,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// AzureDiskCSIConfig is the Schema for the azurediskcsiconfigs API
+type AzureDiskCSIConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AzureDiskCSIConfigSpec   `json:"spec,omitempty"`
+	Status AzureDiskCSIConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AzureDiskCSIConfigList contains a list of AzureDiskCSIConfig
+type AzureDiskCSIConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureDiskCSIConfig `json:"items"`
+}
+
+// AzureDiskCSI is the Schema for the azurediskcsiconfigs API. The Azure cloud settings which are not provided are
+// derived from the AzureCluster of the owner cluster and its AzureClusterIdentity.
+type AzureDiskCSI struct {
+	// The namespace csi components are to be deployed in
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace"`
+
+	// +kubebuilder:validation:Optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	DeploymentReplicas *int32 `json:"deploymentReplicas,omitempty"`
+
+	// The name of the Azure cloud, e.g. AzurePublicCloud
+	// +kubebuilder:validation:Optional
+	CloudName *string `json:"cloudName,omitempty"`
+
+	// The ID of the Azure tenant
+	// +kubebuilder:validation:Optional
+	TenantID *string `json:"tenantID,omitempty"`
+
+	// The ID of the Azure subscription
+	// +kubebuilder:validation:Optional
+	SubscriptionID *string `json:"subscriptionID,omitempty"`
+
+	// A secret reference that contains the service principal credentials used by the csi driver
+	// consists of the fields clientID and clientSecret
+	// +kubebuilder:validation:Optional
+	CredentialLocalObjRef *v1.TypedLocalObjectReference `json:"credentialLocalObjRef,omitempty"`
+
+	// The flag that makes the csi driver authenticate with a managed identity instead of a service principal
+	// +kubebuilder:validation:Optional
+	UseManagedIdentityExtension *bool `json:"useManagedIdentityExtension,omitempty"`
+
+	// The client ID of the user assigned managed identity
+	// +kubebuilder:validation:Optional
+	UserAssignedIdentityID *string `json:"userAssignedIdentityID,omitempty"`
+
+	// The resource group the cluster is deployed in
+	// +kubebuilder:validation:Optional
+	ResourceGroup *string `json:"resourceGroup,omitempty"`
+
+	// The Azure location the cluster is deployed in
+	// +kubebuilder:validation:Optional
+	Location *string `json:"location,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AzureDiskCSIConfig{}, &AzureDiskCSIConfigList{})
+}
@@ -128,6 +128,161 @@ func (in *AwsEbsCSIConfigStatus) DeepCopy() *AwsEbsCSIConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureDiskCSI) DeepCopyInto(out *AzureDiskCSI) {
+	*out = *in
+	if in.DeploymentReplicas != nil {
+		in, out := &in.DeploymentReplicas, &out.DeploymentReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CloudName != nil {
+		in, out := &in.CloudName, &out.CloudName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TenantID != nil {
+		in, out := &in.TenantID, &out.TenantID
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubscriptionID != nil {
+		in, out := &in.SubscriptionID, &out.SubscriptionID
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialLocalObjRef != nil {
+		in, out := &in.CredentialLocalObjRef, &out.CredentialLocalObjRef
+		*out = new(v1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UseManagedIdentityExtension != nil {
+		in, out := &in.UseManagedIdentityExtension, &out.UseManagedIdentityExtension
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UserAssignedIdentityID != nil {
+		in, out := &in.UserAssignedIdentityID, &out.UserAssignedIdentityID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourceGroup != nil {
+		in, out := &in.ResourceGroup, &out.ResourceGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDiskCSI.
+func (in *AzureDiskCSI) DeepCopy() *AzureDiskCSI {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureDiskCSI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureDiskCSIConfig) DeepCopyInto(out *AzureDiskCSIConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDiskCSIConfig.
+func (in *AzureDiskCSIConfig) DeepCopy() *AzureDiskCSIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureDiskCSIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureDiskCSIConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureDiskCSIConfigList) DeepCopyInto(out *AzureDiskCSIConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AzureDiskCSIConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDiskCSIConfigList.
+func (in *AzureDiskCSIConfigList) DeepCopy() *AzureDiskCSIConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureDiskCSIConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureDiskCSIConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureDiskCSIConfigSpec) DeepCopyInto(out *AzureDiskCSIConfigSpec) {
+	*out = *in
+	in.AzureDiskCSI.DeepCopyInto(&out.AzureDiskCSI)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDiskCSIConfigSpec.
+func (in *AzureDiskCSIConfigSpec) DeepCopy() *AzureDiskCSIConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureDiskCSIConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureDiskCSIConfigStatus) DeepCopyInto(out *AzureDiskCSIConfigStatus) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDiskCSIConfigStatus.
+func (in *AzureDiskCSIConfigStatus) DeepCopy() *AzureDiskCSIConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureDiskCSIConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureFileCSI) DeepCopyInto(out *AzureFileCSI) {
 	*out = *in
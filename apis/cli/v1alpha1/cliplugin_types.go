@@ -18,6 +18,10 @@ type Artifact struct {
 	URI string `json:"uri,omitempty"`
 	// SHA256 hash of the plugin binary.
 	Digest string `json:"digest,omitempty"`
+	// Signature is the location of the detached signature of the plugin binary.
+	// This can be a fully qualified HTTP path, a local path or an OCI image.
+	// If not specified, the signature is looked up next to the plugin binary.
+	Signature string `json:"signature,omitempty"`
 	// Type of the binary artifact. Valid values are S3, GCP, OCIImage.
 	Type string `json:"type"`
 	// OS of the plugin binary in `GOOS` format.
@@ -33,6 +33,36 @@ type ClusterBootstrapStatus struct {
 	ResolvedTKR string `json:"resolvedTKR,omitempty"`
 
 	Conditions clusterapiv1beta1.Conditions `json:"conditions,omitempty"`
+
+	// Packages is the status of the PackageInstalls of the core and additional packages of the cluster
+	// +optional
+	Packages []ClusterBootstrapPackageStatus `json:"packages,omitempty"`
+}
+
+// ClusterBootstrapPackageStatus defines the observed state of the PackageInstall of a package of the cluster
+type ClusterBootstrapPackageStatus struct {
+	// Name is the name of the Carvel package, i.e. the spec.refName of its Package, e.g. antrea.tanzu.vmware.com
+	Name string `json:"name"`
+
+	// RefName is the refName of the package in ClusterBootstrap, i.e. the name of its Package,
+	// e.g. antrea.tanzu.vmware.com.1.5.3--vmware.1-tkg.1
+	RefName string `json:"refName"`
+
+	// Version is the version of the package installed by the PackageInstall
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// State is the summarized condition type of the PackageInstall, e.g. Reconciling, ReconcileSucceeded or ReconcileFailed
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// LastError is the error message of the PackageInstall when it failed to reconcile
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// LastTransitionTime is the last time the state of the package changed
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 // GetConditions returns the set of conditions for this object. implements Setter interface
@@ -0,0 +1,148 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// ClusterBootstrapRolloutClusterState is the state of a cluster in a ClusterBootstrapRollout
+type ClusterBootstrapRolloutClusterState string
+
+const (
+	// RolloutClusterStatePending means the ClusterBootstrap of the cluster has not been updated yet
+	RolloutClusterStatePending ClusterBootstrapRolloutClusterState = "Pending"
+	// RolloutClusterStateUpdating means the ClusterBootstrap of the cluster has been updated and the rollout is waiting
+	// for the PackageInstall to be reconciled successfully
+	RolloutClusterStateUpdating ClusterBootstrapRolloutClusterState = "Updating"
+	// RolloutClusterStateUpdated means the PackageInstall of the cluster has been reconciled successfully with the
+	// updated package
+	RolloutClusterStateUpdated ClusterBootstrapRolloutClusterState = "Updated"
+	// RolloutClusterStateFailed means the ClusterBootstrap of the cluster could not be updated, or the PackageInstall
+	// failed to reconcile or did not become healthy within the progress deadline
+	RolloutClusterStateFailed ClusterBootstrapRolloutClusterState = "Failed"
+)
+
+// ClusterBootstrapRolloutSpec defines the desired state of ClusterBootstrapRollout
+type ClusterBootstrapRolloutSpec struct {
+	// Paused can be used to stop the rollout from updating further clusters. The controller pauses the rollout when
+	// the package fails to roll out on a cluster.
+	// +optional
+	// +kubebuilder:default:=false
+	Paused bool `json:"paused,omitempty"`
+
+	// ClusterSelector selects the clusters in the namespace of the rollout whose ClusterBootstrap is updated
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+
+	// Package is the package to roll out. The package of the ClusterBootstrap with the same Carvel package refName is
+	// updated.
+	Package ClusterBootstrapRolloutPackage `json:"package"`
+
+	// +optional
+	Strategy ClusterBootstrapRolloutStrategy `json:"strategy,omitempty"`
+}
+
+// ClusterBootstrapRolloutPackage defines the package rolled out by a ClusterBootstrapRollout
+type ClusterBootstrapRolloutPackage struct {
+	// RefName is the name of the package to roll out, e.g. antrea.tanzu.vmware.com.1.7.2+vmware.1-tkg.1
+	RefName string `json:"refName"`
+
+	// ValuesFrom replaces the values of the package when set. Only inline values and secretRef are supported.
+	// +optional
+	ValuesFrom *ValuesFrom `json:"valuesFrom,omitempty"`
+}
+
+// ClusterBootstrapRolloutStrategy defines how the clusters are updated by a ClusterBootstrapRollout
+type ClusterBootstrapRolloutStrategy struct {
+	// CanaryClusters is the number of clusters updated in the first batch
+	// +optional
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	CanaryClusters int32 `json:"canaryClusters,omitempty"`
+
+	// BatchSize is the number of clusters updated in each batch after the canary clusters have been updated
+	// +optional
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	BatchSize int32 `json:"batchSize,omitempty"`
+
+	// ProgressDeadline is the maximum amount of time to wait for the PackageInstall of an updated cluster to be
+	// reconciled successfully before the cluster is considered failed
+	// +optional
+	// +kubebuilder:default:="30m"
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+}
+
+// ClusterBootstrapRolloutStatus defines the observed state of ClusterBootstrapRollout
+type ClusterBootstrapRolloutStatus struct {
+	// ObservedGeneration is the latest generation observed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Clusters is the rollout state of the selected clusters
+	// +optional
+	Clusters []ClusterBootstrapRolloutClusterStatus `json:"clusters,omitempty"`
+
+	// +optional
+	Conditions clusterapiv1beta1.Conditions `json:"conditions,omitempty"`
+}
+
+// ClusterBootstrapRolloutClusterStatus defines the rollout state of a cluster
+type ClusterBootstrapRolloutClusterStatus struct {
+	// Name is the name of the cluster
+	Name string `json:"name"`
+
+	// State is the rollout state of the cluster
+	State ClusterBootstrapRolloutClusterState `json:"state"`
+
+	// LastUpdateTime is the time the ClusterBootstrap of the cluster was updated by the rollout
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// Message is a human readable message indicating why the cluster failed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=clusterbootstraprollouts,shortName=cbr,scope=Namespaced
+// +kubebuilder:printcolumn:name="Package",type="string",JSONPath=".spec.package.refName",description="Package name and version"
+// +kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".spec.paused",description="Whether the rollout is paused"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Whether the rollout is completed"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterBootstrapRollout is the Schema for the ClusterBootstrapRollouts API
+type ClusterBootstrapRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterBootstrapRolloutSpec   `json:"spec"`
+	Status ClusterBootstrapRolloutStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the set of conditions for this object. implements Setter interface
+func (c *ClusterBootstrapRollout) GetConditions() clusterapiv1beta1.Conditions {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object. implements Setter interface
+func (c *ClusterBootstrapRollout) SetConditions(conditions clusterapiv1beta1.Conditions) {
+	c.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterBootstrapRolloutList contains a list of ClusterBootstrapRollout
+type ClusterBootstrapRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterBootstrapRollout `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterBootstrapRollout{}, &ClusterBootstrapRolloutList{})
+}
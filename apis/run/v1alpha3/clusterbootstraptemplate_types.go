@@ -47,6 +47,10 @@ type ClusterBootstrapTemplate struct {
 
 type ClusterBootstrapPackage struct {
 	RefName string `json:"refName"`
+	// DependsOn is the list of refNames of the additional packages which must be reconciled successfully before this
+	// package is installed. Only supported for additional packages.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
 	// +optional
 	ValuesFrom *ValuesFrom `json:"valuesFrom,omitempty"`
 }
@@ -10,6 +10,7 @@ package v1alpha3
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -80,6 +81,11 @@ func (in *ClusterBootstrapList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterBootstrapPackage) DeepCopyInto(out *ClusterBootstrapPackage) {
 	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ValuesFrom != nil {
 		in, out := &in.ValuesFrom, &out.ValuesFrom
 		*out = (*in).DeepCopy()
@@ -96,6 +102,187 @@ func (in *ClusterBootstrapPackage) DeepCopy() *ClusterBootstrapPackage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapPackageStatus) DeepCopyInto(out *ClusterBootstrapPackageStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapPackageStatus.
+func (in *ClusterBootstrapPackageStatus) DeepCopy() *ClusterBootstrapPackageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapPackageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapRollout) DeepCopyInto(out *ClusterBootstrapRollout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapRollout.
+func (in *ClusterBootstrapRollout) DeepCopy() *ClusterBootstrapRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBootstrapRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapRolloutClusterStatus) DeepCopyInto(out *ClusterBootstrapRolloutClusterStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapRolloutClusterStatus.
+func (in *ClusterBootstrapRolloutClusterStatus) DeepCopy() *ClusterBootstrapRolloutClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapRolloutClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapRolloutList) DeepCopyInto(out *ClusterBootstrapRolloutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterBootstrapRollout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapRolloutList.
+func (in *ClusterBootstrapRolloutList) DeepCopy() *ClusterBootstrapRolloutList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapRolloutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBootstrapRolloutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapRolloutPackage) DeepCopyInto(out *ClusterBootstrapRolloutPackage) {
+	*out = *in
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = new(ValuesFrom)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapRolloutPackage.
+func (in *ClusterBootstrapRolloutPackage) DeepCopy() *ClusterBootstrapRolloutPackage {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapRolloutPackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapRolloutSpec) DeepCopyInto(out *ClusterBootstrapRolloutSpec) {
+	*out = *in
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	in.Package.DeepCopyInto(&out.Package)
+	in.Strategy.DeepCopyInto(&out.Strategy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapRolloutSpec.
+func (in *ClusterBootstrapRolloutSpec) DeepCopy() *ClusterBootstrapRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapRolloutStatus) DeepCopyInto(out *ClusterBootstrapRolloutStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterBootstrapRolloutClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(v1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapRolloutStatus.
+func (in *ClusterBootstrapRolloutStatus) DeepCopy() *ClusterBootstrapRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapRolloutStrategy) DeepCopyInto(out *ClusterBootstrapRolloutStrategy) {
+	*out = *in
+	if in.ProgressDeadline != nil {
+		in, out := &in.ProgressDeadline, &out.ProgressDeadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapRolloutStrategy.
+func (in *ClusterBootstrapRolloutStrategy) DeepCopy() *ClusterBootstrapRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterBootstrapStatus) DeepCopyInto(out *ClusterBootstrapStatus) {
 	*out = *in
@@ -106,6 +293,13 @@ func (in *ClusterBootstrapStatus) DeepCopyInto(out *ClusterBootstrapStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = make([]ClusterBootstrapPackageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapStatus.
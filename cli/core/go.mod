@@ -48,7 +48,7 @@ require (
 	k8s.io/apimachinery v0.24.2
 	k8s.io/client-go v0.24.2
 	sigs.k8s.io/controller-runtime v0.12.3
-
+	sigs.k8s.io/yaml v1.3.0
 )
 
 require (
@@ -149,5 +149,4 @@ require (
 	sigs.k8s.io/cluster-api v1.2.4 // indirect
 	sigs.k8s.io/json v0.0.0-20211208200746-9f7c6b3444d2 // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.2.1 // indirect
-	sigs.k8s.io/yaml v1.3.0 // indirect
 )
@@ -0,0 +1,189 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package artifact
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/constants"
+	configlib "github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+)
+
+const (
+	// cacheDirName is the name of the artifact cache directory under the tanzu local directory
+	cacheDirName = "plugin-cache"
+	// blobsDirName is the directory containing the cached artifacts named by their SHA256 digest
+	blobsDirName = "blobs"
+	// refsDirName is the directory containing the digests of the artifacts cached by reference
+	refsDirName = "refs"
+	// defaultCacheMaxSizeMB is the default maximum size of the artifact cache in megabytes
+	defaultCacheMaxSizeMB = 1024
+	// tmpFilePrefix is the prefix of the files being written to the cache
+	tmpFilePrefix = ".tmp-"
+)
+
+// Cache is a content-addressed cache of plugin artifacts shared across all servers
+// and contexts. Artifacts are stored by their SHA256 digest and the least recently
+// used artifacts are evicted once the cache grows beyond its maximum size.
+type Cache struct {
+	// Dir is the root directory of the cache
+	Dir string
+	// MaxSize is the maximum size of the cached artifacts in bytes
+	MaxSize int64
+}
+
+// NewCache returns the artifact cache located under the tanzu local directory.
+// The maximum size of the cache can be configured in megabytes with
+// TANZU_CLI_PLUGIN_CACHE_MAX_SIZE_MB
+func NewCache() (*Cache, error) {
+	localDir, err := configlib.LocalDir()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find local tanzu directory")
+	}
+
+	maxSizeMB := int64(defaultCacheMaxSizeMB)
+	if s, err := strconv.ParseInt(os.Getenv(constants.PluginCacheMaxSizeMB), 10, 64); err == nil && s >= 0 {
+		maxSizeMB = s
+	}
+	return &Cache{
+		Dir:     filepath.Join(localDir, cacheDirName),
+		MaxSize: maxSizeMB * 1024 * 1024,
+	}, nil
+}
+
+// Get returns the cached artifact with the given SHA256 digest
+func (c *Cache) Get(digest string) ([]byte, bool) {
+	path := c.blobPath(digest)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	// Discard corrupted artifacts
+	if fmt.Sprintf("%x", sha256.Sum256(b)) != digest {
+		_ = os.Remove(path)
+		return nil, false
+	}
+
+	// Record the access time used for the LRU eviction
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return b, true
+}
+
+// Put stores the artifact in the cache if it matches the given SHA256 digest
+// and evicts the least recently used artifacts if the cache exceeds its maximum size
+func (c *Cache) Put(digest string, b []byte) error {
+	if actual := fmt.Sprintf("%x", sha256.Sum256(b)); actual != digest {
+		return errors.Errorf("artifact digest %s does not match the expected digest %s", actual, digest)
+	}
+	if err := writeFileAtomically(c.blobPath(digest), b); err != nil {
+		return errors.Wrap(err, "could not write artifact to the cache")
+	}
+	return c.evict()
+}
+
+// GetByReference returns the artifact last cached for the given reference,
+// e.g. an OCI image, regardless of its digest
+func (c *Cache) GetByReference(ref string) ([]byte, bool) {
+	digest, err := os.ReadFile(c.refPath(ref))
+	if err != nil {
+		return nil, false
+	}
+	return c.Get(strings.TrimSpace(string(digest)))
+}
+
+// PutByReference stores the artifact in the cache and records it as the
+// latest artifact for the given reference
+func (c *Cache) PutByReference(ref string, b []byte) error {
+	digest := fmt.Sprintf("%x", sha256.Sum256(b))
+	if err := c.Put(digest, b); err != nil {
+		return err
+	}
+	if err := writeFileAtomically(c.refPath(ref), []byte(digest)); err != nil {
+		return errors.Wrap(err, "could not write artifact reference to the cache")
+	}
+	return nil
+}
+
+// Clean deletes all the cached artifacts
+func (c *Cache) Clean() error {
+	return os.RemoveAll(c.Dir)
+}
+
+func (c *Cache) blobPath(digest string) string {
+	return filepath.Join(c.Dir, blobsDirName, digest)
+}
+
+func (c *Cache) refPath(ref string) string {
+	return filepath.Join(c.Dir, refsDirName, fmt.Sprintf("%x", sha256.Sum256([]byte(ref))))
+}
+
+// evict deletes the least recently used artifacts until the size of the
+// cached artifacts is within the maximum size of the cache
+func (c *Cache) evict() error {
+	entries, err := os.ReadDir(filepath.Join(c.Dir, blobsDirName))
+	if err != nil {
+		return err
+	}
+
+	blobs := make([]os.FileInfo, 0, len(entries))
+	var size int64
+	for _, entry := range entries {
+		// Skip the artifacts being written to the cache
+		if strings.HasPrefix(entry.Name(), tmpFilePrefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.IsDir() {
+			continue
+		}
+		blobs = append(blobs, info)
+		size += info.Size()
+	}
+
+	sort.Slice(blobs, func(i, j int) bool {
+		return blobs[i].ModTime().Before(blobs[j].ModTime())
+	})
+	for _, blob := range blobs {
+		if size <= c.MaxSize {
+			break
+		}
+		if err := os.Remove(c.blobPath(blob.Name())); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "could not evict artifact from the cache")
+		}
+		size -= blob.Size()
+	}
+	return nil
+}
+
+// writeFileAtomically writes the file through a temporary file so that
+// concurrent readers never observe a partially written file
+func writeFileAtomically(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), tmpFilePrefix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package artifact
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func digestOf(b []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
+
+var _ = Describe("Unit tests for the plugin artifact cache", func() {
+	var (
+		cache *Cache
+		dir   string
+		err   error
+	)
+
+	BeforeEach(func() {
+		dir, err = os.MkdirTemp("", "plugin-cache")
+		Expect(err).ToNot(HaveOccurred())
+		cache = &Cache{Dir: dir, MaxSize: 1024}
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Context("when caching by digest", func() {
+		It("should return the cached artifact", func() {
+			b := []byte("plugin binary")
+			Expect(cache.Put(digestOf(b), b)).To(Succeed())
+
+			cached, ok := cache.Get(digestOf(b))
+			Expect(ok).To(BeTrue())
+			Expect(cached).To(Equal(b))
+		})
+		It("should not cache an artifact with a mismatched digest", func() {
+			b := []byte("plugin binary")
+			err = cache.Put(digestOf([]byte("other binary")), b)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not match the expected digest"))
+
+			_, ok := cache.Get(digestOf([]byte("other binary")))
+			Expect(ok).To(BeFalse())
+		})
+		It("should discard a corrupted artifact", func() {
+			b := []byte("plugin binary")
+			Expect(cache.Put(digestOf(b), b)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, blobsDirName, digestOf(b)), []byte("corrupted"), 0644)).To(Succeed())
+
+			_, ok := cache.Get(digestOf(b))
+			Expect(ok).To(BeFalse())
+			Expect(filepath.Join(dir, blobsDirName, digestOf(b))).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Context("when the cache exceeds its maximum size", func() {
+		It("should evict the least recently used artifacts", func() {
+			first := make([]byte, 400)
+			second := make([]byte, 400)
+			second[0] = 1
+			third := make([]byte, 400)
+			third[0] = 2
+
+			Expect(cache.Put(digestOf(first), first)).To(Succeed())
+			Expect(cache.Put(digestOf(second), second)).To(Succeed())
+			// Access the first artifact so that the second one is the least recently used
+			past := time.Now().Add(-time.Hour)
+			Expect(os.Chtimes(filepath.Join(dir, blobsDirName, digestOf(second)), past, past)).To(Succeed())
+			_, ok := cache.Get(digestOf(first))
+			Expect(ok).To(BeTrue())
+
+			Expect(cache.Put(digestOf(third), third)).To(Succeed())
+
+			_, ok = cache.Get(digestOf(first))
+			Expect(ok).To(BeTrue())
+			_, ok = cache.Get(digestOf(second))
+			Expect(ok).To(BeFalse())
+			_, ok = cache.Get(digestOf(third))
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Context("when caching by reference", func() {
+		It("should return the latest artifact for the reference", func() {
+			ref := "fake.repo.com/plugins/discovery:latest"
+			_, ok := cache.GetByReference(ref)
+			Expect(ok).To(BeFalse())
+
+			Expect(cache.PutByReference(ref, []byte("v1"))).To(Succeed())
+			Expect(cache.PutByReference(ref, []byte("v2"))).To(Succeed())
+
+			cached, ok := cache.GetByReference(ref)
+			Expect(ok).To(BeTrue())
+			Expect(cached).To(Equal([]byte("v2")))
+		})
+	})
+
+	Context("when cleaning the cache", func() {
+		It("should delete all the cached artifacts", func() {
+			b := []byte("plugin binary")
+			Expect(cache.Put(digestOf(b), b)).To(Succeed())
+			Expect(cache.Clean()).To(Succeed())
+
+			_, ok := cache.Get(digestOf(b))
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,42 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package artifact
+
+import (
+	"strings"
+)
+
+const (
+	// signatureSuffix is the suffix used to locate the detached signature
+	// of a plugin binary next to the binary itself
+	signatureSuffix = ".sig"
+	// defaultImageTag is the tag assumed for images without an explicit tag
+	defaultImageTag = "latest"
+)
+
+// SignatureURI returns the location of the detached signature for the plugin
+// binary available at the given URI.
+// E.g., https://storage.googleapis.com/bucket/tanzu-foo-linux_amd64.sig
+func SignatureURI(uri string) string {
+	return uri + signatureSuffix
+}
+
+// SignatureImage returns the OCI image containing the detached signature for the
+// plugin binary image. The signature image is published in the same repository as
+// the plugin image, with the plugin image tag suffixed with `.sig`.
+// E.g., harbor.my-domain.local/tanzu-cli/plugins/foo:v1.0.0.sig
+func SignatureImage(image string) string {
+	// Images referenced by digest are signed by digest in a cosign
+	// compatible format, i.e. `repo:sha256-<digest>.sig`
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[:i] + ":" + strings.Replace(image[i+1:], ":", "-", 1) + signatureSuffix
+	}
+
+	// A colon after the last slash denotes a tag, otherwise it is part of the
+	// registry host:port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image + signatureSuffix
+	}
+	return image + ":" + defaultImageTag + signatureSuffix
+}
@@ -0,0 +1,27 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package artifact
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Unit tests for signature locations", func() {
+	It("should suffix the URI of the binary", func() {
+		Expect(SignatureURI("https://storage.googleapis.com/bucket/tanzu-foo")).To(Equal("https://storage.googleapis.com/bucket/tanzu-foo.sig"))
+		Expect(SignatureURI("foo/v1.0.0/tanzu-foo")).To(Equal("foo/v1.0.0/tanzu-foo.sig"))
+	})
+	It("should suffix the tag of the image", func() {
+		Expect(SignatureImage("fake.repo.com/plugins/foo:v1.0.0")).To(Equal("fake.repo.com/plugins/foo:v1.0.0.sig"))
+		Expect(SignatureImage("localhost:5000/plugins/foo:v1.0.0")).To(Equal("localhost:5000/plugins/foo:v1.0.0.sig"))
+	})
+	It("should default the tag of the image", func() {
+		Expect(SignatureImage("fake.repo.com/plugins/foo")).To(Equal("fake.repo.com/plugins/foo:latest.sig"))
+		Expect(SignatureImage("localhost:5000/plugins/foo")).To(Equal("localhost:5000/plugins/foo:latest.sig"))
+	})
+	It("should use the digest of the image", func() {
+		Expect(SignatureImage("fake.repo.com/plugins/foo@sha256:abcd")).To(Equal("fake.repo.com/plugins/foo:sha256-abcd.sig"))
+	})
+})
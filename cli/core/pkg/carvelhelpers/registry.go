@@ -47,10 +47,42 @@ func DownloadImageBundleAndSaveFilesToTempDir(imageWithTag string) (string, erro
 	return tmpDir, nil
 }
 
-// newRegistry returns a new registry object by also
+// PushImage pushes the file as a plain OCI image and returns the digest
+// reference of the pushed image. The registry credentials are read from
+// the docker configuration
+func PushImage(imageWithTag, filePath string) (string, error) {
+	reg, err := newRegistryWithAuth()
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to initialize registry")
+	}
+	return reg.PushImage(imageWithTag, filePath)
+}
+
+// PushImageBundle pushes the directory as an imgpkg bundle and returns the
+// digest reference of the pushed bundle. The registry credentials are read
+// from the docker configuration
+func PushImageBundle(imageWithTag, inputDir string) (string, error) {
+	reg, err := newRegistryWithAuth()
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to initialize registry")
+	}
+	return reg.PushBundle(imageWithTag, inputDir)
+}
+
+// newRegistry returns a new anonymous registry object by also
 // taking into account for any custom registry or proxy
 // environment variable provided by the user
 func newRegistry() (registry.Registry, error) {
+	return newRegistryClient(true)
+}
+
+// newRegistryWithAuth returns a new registry object authenticating with
+// the credentials of the docker configuration
+func newRegistryWithAuth() (registry.Registry, error) {
+	return newRegistryClient(false)
+}
+
+func newRegistryClient(anon bool) (registry.Registry, error) {
 	verifyCerts := true
 	skipVerifyCerts := os.Getenv(constants.ConfigVariableCustomImageRepositorySkipTLSVerify)
 	if strings.EqualFold(skipVerifyCerts, "true") {
@@ -59,7 +91,7 @@ func newRegistry() (registry.Registry, error) {
 
 	registryOpts := &ctlimg.Opts{
 		VerifyCerts: verifyCerts,
-		Anon:        true,
+		Anon:        anon,
 	}
 
 	if runtime.GOOS == "windows" {
@@ -12,6 +12,7 @@ import (
 	"github.com/aunum/log"
 	"github.com/spf13/cobra"
 
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/config"
 	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
 )
 
@@ -21,6 +22,9 @@ func GetCmd(p *cliapi.PluginDescriptor) *cobra.Command {
 		Use:   p.Name,
 		Short: p.Description,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.CheckPluginDescriptorPolicy(p); err != nil {
+				return err
+			}
 			runner := NewRunner(p.Name, p.InstallationPath, args)
 			ctx := context.Background()
 			return runner.Run(ctx)
@@ -6,6 +6,7 @@ package cluster
 
 import (
 	"context"
+	"sort"
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
@@ -25,6 +26,7 @@ import (
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/cli/v1alpha1"
 	capdiscovery "github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/constants"
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
 )
 
 var (
@@ -47,6 +49,8 @@ type Client interface {
 	VerifyCLIPluginCRD() (bool, error)
 	// GetCLIPluginImageRepositoryOverride returns map of image repository override
 	GetCLIPluginImageRepositoryOverride() (map[string]string, error)
+	// GetCLIPluginPolicy returns the CLIPlugin policy served by the cluster
+	GetCLIPluginPolicy() (*configapi.PluginPolicy, error)
 
 	// BuildClusterQuery builds ClusterQuery with Dynamic client and Discovery client
 	BuildClusterQuery() (*capdiscovery.ClusterQuery, error)
@@ -177,6 +181,62 @@ func (c *client) GetCLIPluginImageRepositoryOverride() (map[string]string, error
 	return ConsolidateImageRepoMaps(cmList)
 }
 
+// GetCLIPluginPolicy returns the CLIPlugin policy served by the cluster.
+// It returns nil if the cluster does not serve any policy
+func (c *client) GetCLIPluginPolicy() (*configapi.PluginPolicy, error) {
+	cmList := &corev1.ConfigMapList{}
+
+	labelMatch, _ := labels.NewRequirement(constants.CLIPluginPolicyLabel, selection.Exists, []string{})
+	labelSelector := labels.NewSelector()
+	labelSelector = labelSelector.Add(*labelMatch)
+
+	err := c.CrtClient.ListObjects(context.TODO(), cmList, &crtclient.ListOptions{Namespace: constants.TanzuCLISystemNamespace, LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	return ConsolidatePluginPolicies(cmList)
+}
+
+// ConsolidatePluginPolicies merges the policies of the configmaps in the order of
+// their names. The plugins not matching any rule are denied if any of the policies
+// denies them by default
+func ConsolidatePluginPolicies(cmList *corev1.ConfigMapList) (*configapi.PluginPolicy, error) {
+	items := cmList.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+
+	var policy *configapi.PluginPolicy
+	for i := range items {
+		policyString, ok := items[i].Data["policy"]
+		if !ok {
+			continue
+		}
+		p := configapi.PluginPolicy{}
+		if err := yaml.Unmarshal([]byte(policyString), &p); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal the CLIPlugin policy of configmap %q", items[i].Name)
+		}
+		policy = MergePluginPolicies(policy, &p)
+	}
+	return policy, nil
+}
+
+// MergePluginPolicies appends the rules of the policy to the merged policy.
+// The plugins not matching any rule are denied if either policy denies them by default
+func MergePluginPolicies(merged, policy *configapi.PluginPolicy) *configapi.PluginPolicy {
+	if policy == nil {
+		return merged
+	}
+	if merged == nil {
+		return policy.DeepCopy()
+	}
+	if policy.DefaultAction == configapi.PluginPolicyActionDeny {
+		merged.DefaultAction = configapi.PluginPolicyActionDeny
+	}
+	merged.Rules = append(merged.Rules, policy.Rules...)
+	return merged
+}
+
 func ConsolidateImageRepoMaps(cmList *corev1.ConfigMapList) (map[string]string, error) {
 	imageRepoMap := make(map[string]string)
 
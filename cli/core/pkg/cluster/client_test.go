@@ -125,6 +125,30 @@ var _ = Describe("New Cluster Client Tests", func() {
 		})
 	})
 
+	Context("test ConsolidatePluginPolicies() helper", func() {
+		It("should merge the policies in the order of the configmap names", func() {
+			cmList := &corev1.ConfigMapList{}
+			cmList.Items = append(cmList.Items,
+				PolicyConfigMapObject("policy-b", "defaultAction: deny\nrules:\n- name: allow-login\n  action: allow\n  plugin: login\n"),
+				PolicyConfigMapObject("policy-a", "rules:\n- name: deny-old-cluster\n  action: deny\n  plugin: cluster\n  versions: \"< 0.26.0\"\n"),
+			)
+
+			policy, err := cluster.ConsolidatePluginPolicies(cmList)
+			Expect(err).To(BeNil())
+			Expect(policy).NotTo(BeNil())
+			Expect(string(policy.DefaultAction)).To(Equal("deny"))
+			Expect(len(policy.Rules)).To(Equal(2))
+			Expect(policy.Rules[0].Name).To(Equal("deny-old-cluster"))
+			Expect(policy.Rules[0].Versions).To(Equal("< 0.26.0"))
+			Expect(policy.Rules[1].Name).To(Equal("allow-login"))
+		})
+		It("should return nil if no policy is served", func() {
+			policy, err := cluster.ConsolidatePluginPolicies(&corev1.ConfigMapList{})
+			Expect(err).To(BeNil())
+			Expect(policy).To(BeNil())
+		})
+	})
+
 	Context("test ConsolidateImageRepoMaps() helper", func() {
 		It("should consolidate imageRepoMaps", func() {
 			cmList := &corev1.ConfigMapList{}
@@ -156,6 +180,21 @@ prod.repo.com: prod.custom.repo.com`
 	return configMap
 }
 
+func PolicyConfigMapObject(name, policy string) corev1.ConfigMap {
+	return corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "tanzu-cli-system",
+			Labels: map[string]string{
+				"cli.tanzu.vmware.com/cliplugin-policy": "",
+			},
+		},
+		Data: map[string]string{
+			"policy": policy,
+		},
+	}
+}
+
 /*
 var _ = Context("New Cluster Client Tests", func() {
 		BeforeEach(func() {
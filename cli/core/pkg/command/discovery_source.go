@@ -96,14 +96,17 @@ func outputFromDiscoverySources(discoverySources []configapi.PluginDiscovery, sc
 var addDiscoverySourceCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add a discovery source",
-	Long:  "Add a discovery source. Supported discovery types are: oci, local",
+	Long:  "Add a discovery source. Supported discovery types are: oci, local, http",
 	Example: `
     # Add a local discovery source. If URI is relative path,
     # $HOME/.config/tanzu-plugins will be considered based path
     tanzu plugin source add --name standalone-local --type local --uri path/to/local/discovery
 
     # Add an OCI discovery source. URI should be an OCI image.
-    tanzu plugin source add --name standalone-oci --type oci --uri projects.registry.vmware.com/tkg/tanzu-plugins/standalone:latest`,
+    tanzu plugin source add --name standalone-oci --type oci --uri projects.registry.vmware.com/tkg/tanzu-plugins/standalone:latest
+
+    # Add an HTTP discovery source. URI should be the URL of a plugin inventory index.
+    tanzu plugin source add --name standalone-http --type http --uri https://artifactory.my-domain.local/tanzu-cli/plugins/index.yaml`,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Acquire tanzu config lock
@@ -236,6 +239,8 @@ func createDiscoverySource(dsType, dsName, uri string) (configapi.PluginDiscover
 		pluginDiscoverySource.OCI = createOCIDiscoverySource(dsName, uri)
 	case common.DiscoveryTypeREST:
 		pluginDiscoverySource.REST = createRESTDiscoverySource(dsName, uri)
+	case common.DiscoveryTypeHTTP:
+		pluginDiscoverySource.HTTP = createHTTPDiscoverySource(dsName, uri)
 	case common.DiscoveryTypeGCP, common.DiscoveryTypeKubernetes:
 		return pluginDiscoverySource, errors.Errorf("discovery source type '%s' is not yet supported", dsType)
 	default:
@@ -265,6 +270,13 @@ func createRESTDiscoverySource(discoveryName, uri string) *configapi.GenericREST
 	}
 }
 
+func createHTTPDiscoverySource(discoveryName, uri string) *configapi.HTTPDiscovery {
+	return &configapi.HTTPDiscovery{
+		Name: discoveryName,
+		URL:  uri,
+	}
+}
+
 func discoverySourceNameAndType(ds configapi.PluginDiscovery) (string, string) {
 	switch {
 	case ds.GCP != nil:
@@ -277,6 +289,8 @@ func discoverySourceNameAndType(ds configapi.PluginDiscovery) (string, string) {
 		return ds.OCI.Name, common.DiscoveryTypeOCI
 	case ds.REST != nil:
 		return ds.REST.Name, common.DiscoveryTypeREST
+	case ds.HTTP != nil:
+		return ds.HTTP.Name, common.DiscoveryTypeHTTP
 	default:
 		return "-", "Unknown" // Unknown discovery source found
 	}
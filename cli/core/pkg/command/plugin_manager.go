@@ -18,6 +18,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/cli"
 	cliconfig "github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/config"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/plugin"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/pluginmanager"
 	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
@@ -30,6 +31,18 @@ var (
 	local       string
 	version     string
 	forceDelete bool
+	lockFile    string
+	locked      bool
+	cleanCache  bool
+	offline     bool
+
+	bundleOutput     string
+	bundlePlugins    []string
+	bundleVersions   []string
+	bundlePlatforms  []string
+	bundleTarball    string
+	bundleRepository string
+	bundleDiscovery  string
 )
 
 func init() {
@@ -44,12 +57,28 @@ func init() {
 		cleanPluginCmd,
 		syncPluginCmd,
 		discoverySourceCmd,
+		bundlePluginCmd,
+		uploadBundlePluginCmd,
 	)
 	listPluginCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
 	listPluginCmd.Flags().StringVarP(&local, "local", "l", "", "path to local discovery/distribution source")
 	installPluginCmd.Flags().StringVarP(&local, "local", "l", "", "path to local discovery/distribution source")
 	installPluginCmd.Flags().StringVarP(&version, "version", "v", cli.VersionLatest, "version of the plugin")
 	deletePluginCmd.Flags().BoolVarP(&forceDelete, "yes", "y", false, "delete the plugin without asking for confirmation")
+	syncPluginCmd.Flags().StringVar(&lockFile, "lockfile", "", "path to the plugin lockfile to write after syncing, or to install from with --locked")
+	syncPluginCmd.Flags().BoolVar(&locked, "locked", false, "install exactly the plugin versions and digests recorded in the lockfile")
+	syncPluginCmd.Flags().BoolVar(&offline, "offline", false, "install the plugins from the plugin artifact cache without accessing the network")
+	bundlePluginCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "path of the plugin bundle tarball to write")
+	bundlePluginCmd.Flags().StringSliceVar(&bundlePlugins, "plugin", nil, "names of the plugins to bundle. All plugins are bundled if not specified")
+	bundlePluginCmd.Flags().StringSliceVar(&bundleVersions, "version", nil, "versions of the plugins to bundle. Only the recommended versions are bundled if not specified")
+	bundlePluginCmd.Flags().StringSliceVar(&bundlePlatforms, "os-arch", nil, "platforms to bundle in os/arch format, e.g. linux/amd64. All platforms are bundled if not specified")
+	_ = bundlePluginCmd.MarkFlagRequired("output")
+	uploadBundlePluginCmd.Flags().StringVar(&bundleTarball, "tar", "", "path of the plugin bundle tarball")
+	uploadBundlePluginCmd.Flags().StringVar(&bundleRepository, "to-repo", "", "repository of the private registry to push the plugins to")
+	uploadBundlePluginCmd.Flags().StringVar(&bundleDiscovery, "discovery-name", "airgapped", "name of the discovery source to point to the pushed plugins")
+	_ = uploadBundlePluginCmd.MarkFlagRequired("tar")
+	_ = uploadBundlePluginCmd.MarkFlagRequired("to-repo")
+	cleanPluginCmd.Flags().BoolVar(&cleanCache, "cache", false, "clean the plugin artifact cache instead of the installed plugins")
 
 	command.DeprecateCommand(repoCmd, "")
 }
@@ -419,6 +448,15 @@ var cleanPluginCmd = &cobra.Command{
 	Use:   "clean",
 	Short: "Clean the plugins",
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if cleanCache {
+			err = pluginmanager.CleanCache()
+			if err != nil {
+				return err
+			}
+			log.Success("successfully cleaned up the plugin artifact cache")
+			return nil
+		}
+
 		if config.IsFeatureActivated(cliconfig.FeatureContextAwareCLIForPlugins) {
 			err = pluginmanager.Clean()
 			if err != nil {
@@ -447,10 +485,28 @@ var syncPluginCmd = &cobra.Command{
 			if err == nil && server != nil {
 				serverName = server.Name
 			}
-			err = pluginmanager.SyncPlugins(serverName)
+			if locked {
+				if lockFile == "" {
+					return errors.New("the --lockfile flag is required with --locked")
+				}
+				err = pluginmanager.SyncPluginsLocked(serverName, lockFile, discovery.WithOfflineMode(offline))
+				if err != nil {
+					return err
+				}
+				log.Success("Done")
+				return nil
+			}
+
+			err = pluginmanager.SyncPlugins(serverName, discovery.WithOfflineMode(offline))
 			if err != nil {
 				return err
 			}
+			if lockFile != "" {
+				if err = pluginmanager.WriteLockFile(serverName, lockFile, discovery.WithOfflineMode(offline)); err != nil {
+					return err
+				}
+				log.Infof("Plugin lockfile written to %q", lockFile)
+			}
 			log.Success("Done")
 			return nil
 		}
@@ -458,6 +514,47 @@ var syncPluginCmd = &cobra.Command{
 	},
 }
 
+var bundlePluginCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Bundle the plugins for air-gapped installation",
+	Long:  "Bundle the plugins of the configured standalone discovery sources into a tarball containing the plugin binaries, their signatures and a discovery index",
+	Example: `
+    # Bundle the recommended version of all plugins for all platforms
+    tanzu plugin bundle --output plugins.tar.gz
+
+    # Bundle specific plugins and versions for linux/amd64
+    tanzu plugin bundle --output plugins.tar.gz --plugin cluster,package --version v0.28.0 --os-arch linux/amd64`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := &pluginmanager.BundleOptions{
+			Plugins:   bundlePlugins,
+			Versions:  bundleVersions,
+			Platforms: bundlePlatforms,
+		}
+		if err := pluginmanager.CreatePluginBundle(opts, bundleOutput); err != nil {
+			return err
+		}
+		log.Successf("successfully created plugin bundle %s", bundleOutput)
+		return nil
+	},
+}
+
+var uploadBundlePluginCmd = &cobra.Command{
+	Use:   "upload-bundle",
+	Short: "Upload a plugin bundle to a private registry",
+	Long:  "Push the plugins of a bundle created with `tanzu plugin bundle` to a private registry and point a discovery source to them",
+	Example: `
+    # Push the plugins to a private registry and discover them with the 'airgapped' discovery source
+    tanzu plugin upload-bundle --tar plugins.tar.gz --to-repo harbor.my-domain.local/tanzu-cli/plugins`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		image, err := pluginmanager.UploadPluginBundle(bundleTarball, bundleRepository, bundleDiscovery)
+		if err != nil {
+			return err
+		}
+		log.Successf("successfully uploaded plugin bundle. Discovery source %q now points to %s", bundleDiscovery, image)
+		return nil
+	},
+}
+
 func getRepositories() *cli.MultiRepo {
 	cfg, err := config.GetClientConfig()
 	if err != nil {
@@ -20,6 +20,7 @@ const (
 	DiscoveryTypeGCP        = "gcp"
 	DiscoveryTypeKubernetes = "kubernetes"
 	DiscoveryTypeREST       = "rest"
+	DiscoveryTypeHTTP       = "http"
 )
 
 // DistributionType constants
@@ -0,0 +1,229 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/common"
+	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	configlib "github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+)
+
+// pluginPoliciesDirName is the directory under the tanzu local directory
+// where the plugin policies served by the servers are cached
+const pluginPoliciesDirName = "plugin-policies"
+
+// PolicyPlugin is a plugin version evaluated against the plugin policies
+type PolicyPlugin struct {
+	// Name of the plugin
+	Name string
+	// Version of the plugin
+	Version string
+	// Target is the type of the context the plugin is used with.
+	// It is empty for standalone plugins
+	Target configapi.ContextType
+	// DiscoverySource is the name of the discovery source of the plugin
+	DiscoverySource string
+}
+
+// namedPluginPolicy is a plugin policy along with the description of where it is defined
+type namedPluginPolicy struct {
+	origin string
+	policy *configapi.PluginPolicy
+}
+
+// CheckPluginPolicy returns an error citing the matching rule if the plugin is
+// denied by the policy of the client configuration or by the policy served by
+// the current server
+func CheckPluginPolicy(p *PolicyPlugin) error {
+	for _, np := range getPluginPolicies() {
+		if err := checkPluginPolicy(np, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckPluginDescriptorPolicy checks the policies for the installed plugin
+func CheckPluginDescriptorPolicy(pd *cliapi.PluginDescriptor) error {
+	p := &PolicyPlugin{
+		Name:            pd.Name,
+		Version:         pd.Version,
+		DiscoverySource: pd.Discovery,
+	}
+	if pd.Scope == common.PluginScopeContext {
+		if server, err := configlib.GetCurrentServer(); err == nil {
+			p.Target = GetServerTarget(server.Name)
+		}
+	}
+	return CheckPluginPolicy(p)
+}
+
+// GetServerTarget returns the type of the context of the server.
+// It returns empty("") if the server is unknown
+func GetServerTarget(serverName string) configapi.ContextType {
+	if serverName == "" {
+		return ""
+	}
+	server, err := configlib.GetServer(serverName)
+	if err != nil {
+		return ""
+	}
+	switch server.Type {
+	case configapi.ManagementClusterServerType:
+		return configapi.CtxTypeK8s
+	case configapi.GlobalServerType:
+		return configapi.CtxTypeTMC
+	}
+	return ""
+}
+
+func getPluginPolicies() []namedPluginPolicy {
+	var policies []namedPluginPolicy
+	cfg, err := configlib.GetClientConfig()
+	if err == nil && cfg != nil && cfg.ClientOptions != nil && cfg.ClientOptions.CLI != nil && cfg.ClientOptions.CLI.PluginPolicy != nil {
+		policies = append(policies, namedPluginPolicy{origin: "the client configuration", policy: cfg.ClientOptions.CLI.PluginPolicy})
+	}
+
+	server, err := configlib.GetCurrentServer()
+	if err == nil && server != nil {
+		if policy, err := GetServerPluginPolicy(server.Name); err == nil && policy != nil {
+			policies = append(policies, namedPluginPolicy{origin: fmt.Sprintf("server %q", server.Name), policy: policy})
+		}
+	}
+	return policies
+}
+
+func checkPluginPolicy(np namedPluginPolicy, p *PolicyPlugin) error {
+	for i := range np.policy.Rules {
+		rule := &np.policy.Rules[i]
+		matched, err := pluginPolicyRuleMatches(rule, p)
+		if err != nil {
+			return errors.Wrapf(err, "invalid rule %q of the plugin policy of %s", rule.Name, np.origin)
+		}
+		if !matched {
+			continue
+		}
+		switch rule.Action {
+		case configapi.PluginPolicyActionAllow:
+			return nil
+		case configapi.PluginPolicyActionDeny:
+			return errors.Errorf("plugin '%v:%v' is denied by rule %q of the plugin policy of %s", p.Name, p.Version, rule.Name, np.origin)
+		default:
+			return errors.Errorf("invalid action %q of rule %q of the plugin policy of %s", rule.Action, rule.Name, np.origin)
+		}
+	}
+
+	if np.policy.DefaultAction == configapi.PluginPolicyActionDeny {
+		return errors.Errorf("plugin '%v:%v' does not match any rule and is denied by default by the plugin policy of %s", p.Name, p.Version, np.origin)
+	}
+	return nil
+}
+
+// pluginPolicyRuleMatches returns true if the plugin matches all the criteria of the rule.
+// A plugin version which cannot be parsed matches the versions of the deny rules
+// so that the policy cannot be bypassed with an invalid version
+func pluginPolicyRuleMatches(rule *configapi.PluginPolicyRule, p *PolicyPlugin) (bool, error) {
+	if rule.Plugin != "" {
+		matched, err := path.Match(rule.Plugin, p.Name)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid plugin pattern %q", rule.Plugin)
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	if rule.DiscoverySource != "" {
+		matched, err := path.Match(rule.DiscoverySource, p.DiscoverySource)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid discovery source pattern %q", rule.DiscoverySource)
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	if rule.Target != "" && rule.Target != p.Target {
+		return false, nil
+	}
+	if rule.Versions != "" {
+		constraint, err := semver.NewConstraint(rule.Versions)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid versions %q", rule.Versions)
+		}
+		v, err := semver.NewVersion(p.Version)
+		if err != nil {
+			return rule.Action == configapi.PluginPolicyActionDeny, nil
+		}
+		if !constraint.Check(v) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// GetServerPluginPolicy returns the cached plugin policy served by the server.
+// It returns nil if the server does not serve any policy
+func GetServerPluginPolicy(serverName string) (*configapi.PluginPolicy, error) {
+	policyPath, err := serverPluginPolicyPath(serverName)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(policyPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read the plugin policy of server %q", serverName)
+	}
+
+	policy := &configapi.PluginPolicy{}
+	if err := yaml.Unmarshal(b, policy); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal the plugin policy of server %q", serverName)
+	}
+	return policy, nil
+}
+
+// StoreServerPluginPolicy caches the plugin policy served by the server so that
+// it is enforced without contacting the server. A nil policy removes the cached policy
+func StoreServerPluginPolicy(serverName string, policy *configapi.PluginPolicy) error {
+	policyPath, err := serverPluginPolicyPath(serverName)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		if err := os.Remove(policyPath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "could not remove the plugin policy of server %q", serverName)
+		}
+		return nil
+	}
+
+	b, err := yaml.Marshal(policy)
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal the plugin policy of server %q", serverName)
+	}
+	if err := os.MkdirAll(filepath.Dir(policyPath), 0755); err != nil {
+		return errors.Wrap(err, "could not make the plugin policies directory")
+	}
+	if err := os.WriteFile(policyPath, b, 0644); err != nil {
+		return errors.Wrapf(err, "could not write the plugin policy of server %q", serverName)
+	}
+	return nil
+}
+
+func serverPluginPolicyPath(serverName string) (string, error) {
+	localDir, err := configlib.LocalDir()
+	if err != nil {
+		return "", errors.Wrap(err, "could not find local tanzu directory")
+	}
+	return filepath.Join(localDir, pluginPoliciesDirName, filepath.Base(serverName)+".yaml"), nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+)
+
+var _ = Describe("plugin policy test cases", func() {
+	var policy namedPluginPolicy
+	cluster := &PolicyPlugin{Name: "cluster", Version: "v0.25.0", Target: configapi.CtxTypeK8s, DiscoverySource: "default-mgmt"}
+
+	BeforeEach(func() {
+		policy = namedPluginPolicy{
+			origin: "the client configuration",
+			policy: &configapi.PluginPolicy{
+				Rules: []configapi.PluginPolicyRule{
+					{Name: "allow-tmc", Action: configapi.PluginPolicyActionAllow, Target: configapi.CtxTypeTMC},
+					{Name: "deny-old-cluster", Action: configapi.PluginPolicyActionDeny, Plugin: "clus*", Versions: "< 0.26.0"},
+					{Name: "deny-untrusted-source", Action: configapi.PluginPolicyActionDeny, DiscoverySource: "untrusted-*"},
+				},
+			},
+		}
+	})
+
+	Context("when evaluating the rules", func() {
+		It("should deny the plugin with the first matching rule", func() {
+			err := checkPluginPolicy(policy, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("plugin 'cluster:v0.25.0' is denied by rule \"deny-old-cluster\" of the plugin policy of the client configuration"))
+		})
+		It("should allow the plugin with an earlier allow rule", func() {
+			p := *cluster
+			p.Target = configapi.CtxTypeTMC
+			Expect(checkPluginPolicy(policy, &p)).To(Succeed())
+		})
+		It("should match the versions range", func() {
+			p := *cluster
+			p.Version = "v0.26.1"
+			Expect(checkPluginPolicy(policy, &p)).To(Succeed())
+		})
+		It("should deny the plugin with an invalid version matching a deny rule with versions", func() {
+			p := *cluster
+			p.Version = "latest"
+			err := checkPluginPolicy(policy, &p)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("rule \"deny-old-cluster\""))
+		})
+		It("should not allow the plugin with an invalid version with an allow rule with versions", func() {
+			policy.policy.Rules[0] = configapi.PluginPolicyRule{Name: "allow-new-login", Action: configapi.PluginPolicyActionAllow, Plugin: "login", Versions: ">= 0.26.0"}
+			policy.policy.DefaultAction = configapi.PluginPolicyActionDeny
+			p := PolicyPlugin{Name: "login", Version: "latest"}
+			err := checkPluginPolicy(policy, &p)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not match any rule"))
+		})
+		It("should match the discovery source", func() {
+			p := PolicyPlugin{Name: "login", Version: "v0.25.0", DiscoverySource: "untrusted-oci"}
+			err := checkPluginPolicy(policy, &p)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("rule \"deny-untrusted-source\""))
+		})
+	})
+
+	Context("when no rule matches", func() {
+		It("should allow the plugin by default", func() {
+			p := PolicyPlugin{Name: "login", Version: "v0.25.0"}
+			Expect(checkPluginPolicy(policy, &p)).To(Succeed())
+		})
+		It("should deny the plugin if the default action is deny", func() {
+			policy.policy.DefaultAction = configapi.PluginPolicyActionDeny
+			p := PolicyPlugin{Name: "login", Version: "v0.25.0"}
+			err := checkPluginPolicy(policy, &p)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("plugin 'login:v0.25.0' does not match any rule and is denied by default by the plugin policy of the client configuration"))
+		})
+	})
+
+	Context("when the policy is invalid", func() {
+		It("should report the invalid versions range", func() {
+			policy.policy.Rules[1].Versions = "not a range"
+			err := checkPluginPolicy(policy, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid rule \"deny-old-cluster\" of the plugin policy of the client configuration: invalid versions \"not a range\""))
+		})
+		It("should report the invalid action", func() {
+			policy.policy.Rules[1].Action = "block"
+			err := checkPluginPolicy(policy, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("invalid action \"block\" of rule \"deny-old-cluster\" of the plugin policy of the client configuration"))
+		})
+	})
+})
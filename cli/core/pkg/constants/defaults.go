@@ -10,6 +10,9 @@ const (
 	// CLIPluginImageRepositoryOverrideLabel is the label on the configmap which specifies CLIPlugin image repository override
 	CLIPluginImageRepositoryOverrideLabel = "cli.tanzu.vmware.com/cliplugin-image-repository-override"
 
+	// CLIPluginPolicyLabel is the label on the configmap which specifies the CLIPlugin policy
+	CLIPluginPolicyLabel = "cli.tanzu.vmware.com/cliplugin-policy"
+
 	// DefaultQPS is the default maximum query per second for the rest config
 	DefaultQPS = 200
 
@@ -13,4 +13,11 @@ const (
 
 const (
 	AllowedRegistries = "ALLOWED_REGISTRY"
+	// AllowUnsignedPlugins allows the installation of plugins whose signature
+	// is missing or could not be verified
+	AllowUnsignedPlugins = "TANZU_CLI_ALLOW_UNSIGNED_PLUGINS"
+	// PluginInstallConcurrency is the maximum number of plugins downloaded in parallel
+	PluginInstallConcurrency = "TANZU_CLI_PLUGIN_INSTALL_CONCURRENCY"
+	// PluginCacheMaxSizeMB is the maximum size of the plugin artifact cache in megabytes
+	PluginCacheMaxSizeMB = "TANZU_CLI_PLUGIN_CACHE_MAX_SIZE_MB"
 )
@@ -0,0 +1,385 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aunum/log"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/artifact"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/common"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/plugin"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/signature"
+	configlib "github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+)
+
+const (
+	// httpDiscoveryCacheDirName is the directory under the tanzu local directory
+	// where the inventory indexes of the HTTP discoveries are cached
+	httpDiscoveryCacheDirName = "discovery-cache"
+	// indexFileName is the name of the cached inventory index
+	indexFileName = "index"
+	// indexMetadataFileName is the name of the metadata of the cached inventory index
+	indexMetadataFileName = "metadata.json"
+	// indexSignatureSuffix is appended to the URL of the inventory index to
+	// get the URL of its detached signature
+	indexSignatureSuffix = ".sig"
+	// DefaultHTTPDiscoveryRefreshInterval is the duration for which a cached
+	// inventory index is used without checking the server for updates
+	DefaultHTTPDiscoveryRefreshInterval = time.Hour
+)
+
+// InventoryIndex is the index of the plugins served by an HTTP discovery.
+type InventoryIndex struct {
+	// Plugins lists the plugins along with the artifacts of every supported version.
+	Plugins []Plugin `json:"plugins"`
+}
+
+// indexMetadata records the validators of the cached inventory index used
+// for the conditional requests, and whether its signature was verified.
+type indexMetadata struct {
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+	Verified     bool      `json:"verified,omitempty"`
+}
+
+// HTTPDiscovery is an artifact discovery reading a static inventory index
+// hosted on any HTTP server. The index is cached on disk and revalidated with
+// conditional requests once the refresh interval has elapsed.
+type HTTPDiscovery struct {
+	// name of the discovery.
+	name string
+	// indexURL is the URL of the inventory index.
+	indexURL string
+	// refreshInterval is the duration for which the cached index is used
+	// without checking the server for updates.
+	refreshInterval time.Duration
+	// cacheDir is the directory where the index is cached. The index is not
+	// cached if empty.
+	cacheDir string
+	// client is the HTTP client used to fetch the index.
+	client *http.Client
+	// offline discovers the plugins from the cached index
+	offline bool
+}
+
+// NewHTTPDiscovery returns a new HTTP inventory index discovery. A zero refresh
+// interval defaults to DefaultHTTPDiscoveryRefreshInterval.
+func NewHTTPDiscovery(name, indexURL string, refreshInterval time.Duration, opts ...Option) Discovery {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultHTTPDiscoveryRefreshInterval
+	}
+	d := &HTTPDiscovery{
+		name:            name,
+		indexURL:        indexURL,
+		refreshInterval: refreshInterval,
+		client:          http.DefaultClient,
+		offline:         newOptions(opts).Offline,
+	}
+	if localDir, err := configlib.LocalDir(); err == nil {
+		d.cacheDir = filepath.Join(localDir, httpDiscoveryCacheDirName, fmt.Sprintf("%x", sha256.Sum256([]byte(indexURL))))
+	}
+	return d
+}
+
+// List available plugins.
+func (d *HTTPDiscovery) List() ([]plugin.Discovered, error) {
+	return d.Manifest()
+}
+
+// Describe a plugin.
+func (d *HTTPDiscovery) Describe(name string) (p plugin.Discovered, err error) {
+	plugins, err := d.Manifest()
+	if err != nil {
+		return
+	}
+
+	for i := range plugins {
+		if plugins[i].Name == name {
+			p = plugins[i]
+			return
+		}
+	}
+	err = errors.Errorf("cannot find plugin with name '%v'", name)
+	return
+}
+
+// Name of the repository.
+func (d *HTTPDiscovery) Name() string {
+	return d.name
+}
+
+// Type of the discovery.
+func (d *HTTPDiscovery) Type() string {
+	return common.DiscoveryTypeHTTP
+}
+
+// Manifest returns the plugins listed in the inventory index.
+func (d *HTTPDiscovery) Manifest() ([]plugin.Discovered, error) {
+	data, err := d.fetchIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var index InventoryIndex
+	if err := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), len(data)).Decode(&index); err != nil {
+		return nil, errors.Wrapf(err, "could not decode the inventory index of discovery %q", d.name)
+	}
+
+	plugins := make([]plugin.Discovered, 0, len(index.Plugins))
+	for i := range index.Plugins {
+		if err := d.resolveArtifactURIs(&index.Plugins[i]); err != nil {
+			return nil, err
+		}
+		dp, err := DiscoveredFromREST(&index.Plugins[i])
+		if err != nil {
+			return nil, err
+		}
+		dp.Source = d.name
+		dp.DiscoveryType = d.Type()
+		plugins = append(plugins, dp)
+	}
+	if d.offline {
+		useOfflineDistributions(plugins)
+	}
+	return plugins, nil
+}
+
+// resolveArtifactURIs resolves the relative artifact and signature URIs
+// against the URL of the inventory index.
+func (d *HTTPDiscovery) resolveArtifactURIs(p *Plugin) error {
+	base, err := url.Parse(d.indexURL)
+	if err != nil {
+		return errors.Wrapf(err, "invalid inventory index URL %q", d.indexURL)
+	}
+	resolve := func(uri string) (string, error) {
+		if uri == "" {
+			return uri, nil
+		}
+		u, err := url.Parse(uri)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid artifact URI %q of plugin %q", uri, p.Name)
+		}
+		if u.IsAbs() {
+			return uri, nil
+		}
+		return base.ResolveReference(u).String(), nil
+	}
+
+	for _, artifacts := range p.Artifacts {
+		for i := range artifacts {
+			if artifacts[i].URI, err = resolve(artifacts[i].URI); err != nil {
+				return err
+			}
+			if artifacts[i].Signature, err = resolve(artifacts[i].Signature); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fetchIndex returns the inventory index. The cached index is used as long as
+// the refresh interval has not elapsed, in offline mode and when the server
+// cannot be reached. Otherwise it is revalidated with a conditional request.
+// A cached index whose signature was not verified is verified again once the
+// signature options require it, and refetched if it cannot be verified.
+func (d *HTTPDiscovery) fetchIndex() ([]byte, error) {
+	cached, metadata, cacheErr := d.readCachedIndex()
+	if cacheErr == nil && !metadata.Verified {
+		required, err := isIndexSignatureRequired()
+		if err != nil {
+			return nil, err
+		}
+		if required {
+			if metadata.Verified, err = d.verifyIndexSignature(cached); err != nil {
+				if d.offline {
+					return nil, err
+				}
+				log.Debugf("refetching the unverified cached inventory index of discovery %q: %v", d.name, err)
+				cached, metadata, cacheErr = nil, nil, err
+			} else if err := d.writeCachedIndex(cached, metadata); err != nil {
+				log.Debugf("unable to cache the inventory index of discovery %q: %v", d.name, err)
+			}
+		}
+	}
+
+	if d.offline {
+		if cacheErr != nil {
+			return nil, errors.Errorf("inventory index %q is not available in the discovery cache", d.indexURL)
+		}
+		return cached, nil
+	}
+	if cacheErr == nil && time.Since(metadata.FetchedAt) < d.refreshInterval {
+		return cached, nil
+	}
+
+	data, newMetadata, err := d.doRequest(metadata)
+	if err != nil {
+		if cacheErr != nil {
+			return nil, err
+		}
+		log.Warningf("Warning: using the cached inventory index of discovery %q: %v", d.name, err.Error())
+		return cached, nil
+	}
+	if data == nil {
+		// The cached index has not been modified
+		data = cached
+		newMetadata.Verified = metadata.Verified
+	} else if newMetadata.Verified, err = d.verifyIndexSignature(data); err != nil {
+		return nil, err
+	}
+
+	if err := d.writeCachedIndex(data, newMetadata); err != nil {
+		log.Debugf("unable to cache the inventory index of discovery %q: %v", d.name, err)
+	}
+	return data, nil
+}
+
+// doRequest fetches the inventory index. The request is conditional if the
+// metadata of a cached index is given, in which case a nil index is returned
+// if the index has not been modified.
+func (d *HTTPDiscovery) doRequest(metadata *indexMetadata) ([]byte, *indexMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.indexURL, http.NoBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	if metadata != nil {
+		if metadata.ETag != "" {
+			req.Header.Set("If-None-Match", metadata.ETag)
+		}
+		if metadata.LastModified != "" {
+			req.Header.Set("If-Modified-Since", metadata.LastModified)
+		}
+	}
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not fetch the inventory index %q", d.indexURL)
+	}
+	defer res.Body.Close()
+
+	newMetadata := &indexMetadata{
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+		FetchedAt:    time.Now(),
+	}
+	if res.StatusCode == http.StatusNotModified && metadata != nil {
+		if newMetadata.ETag == "" {
+			newMetadata.ETag = metadata.ETag
+		}
+		if newMetadata.LastModified == "" {
+			newMetadata.LastModified = metadata.LastModified
+		}
+		return nil, newMetadata, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, errors.Errorf("could not fetch the inventory index %q, status code: %d", d.indexURL, res.StatusCode)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not read the inventory index %q", d.indexURL)
+	}
+	return data, newMetadata, nil
+}
+
+// isIndexSignatureRequired returns true if public keys are configured and
+// unsigned inventory indexes are not allowed.
+func isIndexSignatureRequired() (bool, error) {
+	opts, err := signature.GetOptions()
+	if err != nil {
+		return false, err
+	}
+	return opts != nil && len(opts.PublicKeys) != 0 && !signature.IsUnsignedAllowed(opts), nil
+}
+
+// verifyIndexSignature verifies the detached signature of the inventory index
+// against the configured plugin signing keys and returns whether it was
+// verified. Verification is skipped if no public keys are configured.
+func (d *HTTPDiscovery) verifyIndexSignature(data []byte) (bool, error) {
+	opts, err := signature.GetOptions()
+	if err != nil {
+		return false, err
+	}
+	if opts == nil || len(opts.PublicKeys) == 0 {
+		return false, nil
+	}
+	allowUnsigned := signature.IsUnsignedAllowed(opts)
+
+	verifier, err := signature.NewVerifier(opts.PublicKeys)
+	if err != nil {
+		return false, err
+	}
+
+	sig, err := artifact.NewHTTPArtifact(d.indexURL + indexSignatureSuffix).Fetch()
+	if err != nil {
+		if allowUnsigned {
+			log.Warningf("Warning: using unsigned inventory index of discovery %q: %v", d.name, err.Error())
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "unable to fetch the signature of the inventory index of discovery %q", d.name)
+	}
+	if _, err := verifier.Verify(data, sig); err != nil {
+		if allowUnsigned {
+			log.Warningf("Warning: using inventory index of discovery %q with unverified signature: %v", d.name, err.Error())
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "invalid signature of the inventory index of discovery %q", d.name)
+	}
+	return true, nil
+}
+
+func (d *HTTPDiscovery) readCachedIndex() ([]byte, *indexMetadata, error) {
+	if d.cacheDir == "" {
+		return nil, nil, errors.New("the discovery cache is not available")
+	}
+	b, err := os.ReadFile(filepath.Join(d.cacheDir, indexMetadataFileName))
+	if err != nil {
+		return nil, nil, err
+	}
+	metadata := &indexMetadata{}
+	if err := json.Unmarshal(b, metadata); err != nil {
+		return nil, nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(d.cacheDir, indexFileName))
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, metadata, nil
+}
+
+func (d *HTTPDiscovery) writeCachedIndex(data []byte, metadata *indexMetadata) error {
+	if d.cacheDir == "" {
+		return nil
+	}
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(d.cacheDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(d.cacheDir, indexFileName), data, 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(d.cacheDir, indexMetadataFileName), b, 0644)
+}
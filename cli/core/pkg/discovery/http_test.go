@@ -0,0 +1,248 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/common"
+)
+
+const inventoryIndex = `plugins:
+- name: foo
+  description: A plugin for Foo
+  recommendedVersion: 1.0.0
+  artifacts:
+    1.0.0:
+    - uri: foo/1.0.0/tanzu-foo-linux_amd64
+      signature: foo/1.0.0/tanzu-foo-linux_amd64.sig
+      digest: test digest
+      os: linux
+      arch: amd64
+    - uri: https://storage.googleapis.com/tanzu-plugins/foo-1.0.0-darwin-amd64
+      digest: test digest
+      os: darwin
+      arch: amd64
+- name: bar
+  description: A plugin for Bar
+  recommendedVersion: 0.0.1
+  optional: true
+  artifacts:
+    0.0.1:
+    - uri: bar/0.0.1/tanzu-bar-linux_amd64
+      digest: test digest
+      os: linux
+      arch: amd64
+`
+
+// indexServer serves the inventory index with an ETag and counts the
+// requests and the full responses. The signature of the index is served if set.
+type indexServer struct {
+	*httptest.Server
+	requests  int
+	responses int
+	down      bool
+	signature []byte
+}
+
+func newIndexServer() *indexServer {
+	s := &indexServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, indexSignatureSuffix) {
+			if s.signature == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(s.signature)
+			return
+		}
+		s.requests++
+		if s.down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		s.responses++
+		_, _ = w.Write([]byte(inventoryIndex))
+	}))
+	return s
+}
+
+func newTestHTTPDiscovery(t *testing.T, indexURL string, refreshInterval time.Duration) *HTTPDiscovery {
+	return &HTTPDiscovery{
+		name:            "test",
+		indexURL:        indexURL,
+		refreshInterval: refreshInterval,
+		cacheDir:        t.TempDir(),
+		client:          http.DefaultClient,
+	}
+}
+
+func TestHTTPDiscovery(t *testing.T) {
+	s := newIndexServer()
+	defer s.Close()
+
+	d := newTestHTTPDiscovery(t, s.URL+"/tanzu-cli/index.yaml", time.Hour)
+	assert.Equal(t, common.DiscoveryTypeHTTP, d.Type())
+
+	plugins, err := d.List()
+	assert.NoError(t, err)
+	assert.Len(t, plugins, 2)
+	assert.Equal(t, "foo", plugins[0].Name)
+	assert.Equal(t, "test", plugins[0].Source)
+	assert.Equal(t, common.DiscoveryTypeHTTP, plugins[0].DiscoveryType)
+	assert.Equal(t, []string{"1.0.0"}, plugins[0].SupportedVersions)
+
+	// Relative URIs are resolved against the URL of the index
+	a, err := plugins[0].Distribution.DescribeArtifact("1.0.0", "linux", "amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, s.URL+"/tanzu-cli/foo/1.0.0/tanzu-foo-linux_amd64", a.URI)
+	assert.Equal(t, s.URL+"/tanzu-cli/foo/1.0.0/tanzu-foo-linux_amd64.sig", a.Signature)
+	a, err = plugins[0].Distribution.DescribeArtifact("1.0.0", "darwin", "amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://storage.googleapis.com/tanzu-plugins/foo-1.0.0-darwin-amd64", a.URI)
+
+	p, err := d.Describe("bar")
+	assert.NoError(t, err)
+	assert.True(t, p.Optional)
+	_, err = d.Describe("baz")
+	assert.Error(t, err)
+
+	// The cached index is used within the refresh interval
+	assert.Equal(t, 1, s.requests)
+}
+
+func TestHTTPDiscoveryConditionalRequest(t *testing.T) {
+	s := newIndexServer()
+	defer s.Close()
+
+	d := newTestHTTPDiscovery(t, s.URL+"/index.yaml", time.Nanosecond)
+	_, err := d.List()
+	assert.NoError(t, err)
+
+	// The cached index is revalidated once the refresh interval has elapsed
+	plugins, err := d.List()
+	assert.NoError(t, err)
+	assert.Len(t, plugins, 2)
+	assert.Equal(t, 2, s.requests)
+	assert.Equal(t, 1, s.responses)
+
+	// The cached index is used when the server is unavailable
+	s.down = true
+	plugins, err = d.List()
+	assert.NoError(t, err)
+	assert.Len(t, plugins, 2)
+	assert.Equal(t, 3, s.requests)
+
+	// No index is available without a cache
+	d = newTestHTTPDiscovery(t, s.URL+"/index.yaml", time.Nanosecond)
+	_, err = d.List()
+	assert.ErrorContains(t, err, "status code: 503")
+}
+
+func TestHTTPDiscoveryOfflineMode(t *testing.T) {
+	s := newIndexServer()
+	defer s.Close()
+
+	d := newTestHTTPDiscovery(t, s.URL+"/index.yaml", time.Nanosecond)
+	d.offline = true
+
+	_, err := d.List()
+	assert.ErrorContains(t, err, "is not available in the discovery cache")
+
+	d.offline = false
+	_, err = d.List()
+	assert.NoError(t, err)
+
+	d.offline = true
+	plugins, err := d.List()
+	assert.NoError(t, err)
+	assert.Len(t, plugins, 2)
+	assert.Equal(t, 1, s.requests)
+	for i := range plugins {
+		_, err = plugins[i].Distribution.FetchTest(plugins[i].RecommendedVersion, "linux", "amd64")
+		assert.ErrorContains(t, err, "cannot be installed in offline mode")
+	}
+}
+
+func TestHTTPDiscoveryVerifiesUnverifiedCachedIndex(t *testing.T) {
+	s := newIndexServer()
+	defer s.Close()
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("TANZU_CONFIG", configFile)
+	require.NoError(t, os.WriteFile(configFile, []byte("clientOptions:\n  cli: {}\n"), 0600))
+
+	// The index is cached unverified while no public keys are configured
+	d := newTestHTTPDiscovery(t, s.URL+"/index.yaml", time.Hour)
+	_, err := d.List()
+	require.NoError(t, err)
+	_, metadata, err := d.readCachedIndex()
+	require.NoError(t, err)
+	assert.False(t, metadata.Verified)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKey, err := json.Marshal(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf(`clientOptions:
+  cli:
+    pluginSignature:
+      publicKeys:
+      - name: default
+        data: %s
+`, publicKey)), 0600))
+
+	// The unverified cached index is not used offline once the signature is required
+	d.offline = true
+	_, err = d.List()
+	assert.ErrorContains(t, err, "unable to fetch the signature of the inventory index")
+
+	// The unsigned index is refetched within the refresh interval and rejected
+	d.offline = false
+	_, err = d.List()
+	assert.ErrorContains(t, err, "unable to fetch the signature of the inventory index")
+	assert.Equal(t, 2, s.responses)
+
+	digest := sha256.Sum256([]byte(inventoryIndex))
+	s.signature, err = ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	// The cached index is verified without being refetched
+	plugins, err := d.List()
+	assert.NoError(t, err)
+	assert.Len(t, plugins, 2)
+	assert.Equal(t, 2, s.responses)
+	_, metadata, err = d.readCachedIndex()
+	require.NoError(t, err)
+	assert.True(t, metadata.Verified)
+
+	// The verified cached index is used offline
+	d.offline = true
+	_, err = d.List()
+	assert.NoError(t, err)
+}
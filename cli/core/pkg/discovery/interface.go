@@ -9,8 +9,12 @@
 package discovery
 
 import (
-	"errors"
+	"time"
 
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/common"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/distribution"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/plugin"
 	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
 )
@@ -30,19 +34,86 @@ type Discovery interface {
 	Type() string
 }
 
-// CreateDiscoveryFromV1alpha1 creates discovery interface from v1alpha1 API
-func CreateDiscoveryFromV1alpha1(pd configapi.PluginDiscovery) (Discovery, error) {
+// Options are the options used to create a discovery.
+type Options struct {
+	// Offline discovers the plugins from the cached discovery data and serves
+	// their artifacts purely from the plugin artifact cache without accessing
+	// the network.
+	Offline bool
+}
+
+// Option customizes the options used to create a discovery.
+type Option func(*Options)
+
+// WithOfflineMode sets whether the plugins are discovered and installed purely
+// from the cache without accessing the network.
+func WithOfflineMode(offline bool) Option {
+	return func(o *Options) {
+		o.Offline = offline
+	}
+}
+
+func newOptions(opts []Option) *Options {
+	o := &Options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// CreateDiscoveryFromV1alpha1 creates discovery interface from v1alpha1 API.
+// In offline mode, only the OCI, HTTP and local discovery sources can be used
+// as the other discovery types are not cached.
+func CreateDiscoveryFromV1alpha1(pd configapi.PluginDiscovery, opts ...Option) (Discovery, error) {
+	if newOptions(opts).Offline && pd.OCI == nil && pd.HTTP == nil && pd.Local == nil {
+		return nil, errors.Errorf("discovery source %q cannot be used in offline mode, only the %s, %s and %s discovery sources are cached",
+			discoverySourceName(pd), common.DiscoveryTypeOCI, common.DiscoveryTypeHTTP, common.DiscoveryTypeLocal)
+	}
+
 	switch {
 	case pd.GCP != nil:
 		return NewGCPDiscovery(pd.GCP.Bucket, pd.GCP.ManifestPath, pd.GCP.Name), nil
 	case pd.OCI != nil:
-		return NewOCIDiscovery(pd.OCI.Name, pd.OCI.Image), nil
+		return NewOCIDiscovery(pd.OCI.Name, pd.OCI.Image, opts...), nil
 	case pd.Local != nil:
 		return NewLocalDiscovery(pd.Local.Name, pd.Local.Path), nil
 	case pd.Kubernetes != nil:
 		return NewKubernetesDiscovery(pd.Kubernetes.Name, pd.Kubernetes.Path, pd.Kubernetes.Context), nil
 	case pd.REST != nil:
 		return NewRESTDiscovery(pd.REST.Name, pd.REST.Endpoint, pd.REST.BasePath), nil
+	case pd.HTTP != nil:
+		var refreshInterval time.Duration
+		if pd.HTTP.RefreshInterval != nil {
+			refreshInterval = pd.HTTP.RefreshInterval.Duration
+		}
+		return NewHTTPDiscovery(pd.HTTP.Name, pd.HTTP.URL, refreshInterval, opts...), nil
 	}
 	return nil, errors.New("unknown plugin discovery source")
 }
+
+// discoverySourceName returns the name of the discovery source
+func discoverySourceName(pd configapi.PluginDiscovery) string {
+	switch {
+	case pd.GCP != nil:
+		return pd.GCP.Name
+	case pd.OCI != nil:
+		return pd.OCI.Name
+	case pd.Local != nil:
+		return pd.Local.Name
+	case pd.Kubernetes != nil:
+		return pd.Kubernetes.Name
+	case pd.REST != nil:
+		return pd.REST.Name
+	case pd.HTTP != nil:
+		return pd.HTTP.Name
+	}
+	return ""
+}
+
+// useOfflineDistributions serves the artifacts of the discovered plugins purely
+// from the plugin artifact cache
+func useOfflineDistributions(plugins []plugin.Discovered) {
+	for i := range plugins {
+		plugins[i].Distribution = distribution.Offline(plugins[i].Distribution)
+	}
+}
@@ -66,3 +66,28 @@ func Test_CreateDiscoveryFromV1alpha1(t *testing.T) {
 	assert.Equal(common.DiscoveryTypeREST, discovery.Type())
 	assert.Equal("fake-rest", discovery.Name())
 }
+
+func Test_CreateDiscoveryFromV1alpha1OfflineMode(t *testing.T) {
+	assert := assert.New(t)
+
+	// The cached discovery types can be used in offline mode
+	for _, pd := range []configapi.PluginDiscovery{
+		{OCI: &configapi.OCIDiscovery{Name: "fake-oci", Image: "fake.repo.com/test:v1.0.0"}},
+		{HTTP: &configapi.HTTPDiscovery{Name: "fake-http", URL: "https://fake.repo.com/index.yaml"}},
+		{Local: &configapi.LocalDiscovery{Name: "fake-local", Path: "test/path"}},
+	} {
+		_, err := CreateDiscoveryFromV1alpha1(pd, WithOfflineMode(true))
+		assert.Nil(err)
+	}
+
+	// The other discovery types are rejected in offline mode
+	for _, pd := range []configapi.PluginDiscovery{
+		{GCP: &configapi.GCPDiscovery{Name: "fake-gcp"}},
+		{Kubernetes: &configapi.KubernetesDiscovery{Name: "fake-k8s"}},
+		{REST: &configapi.GenericRESTDiscovery{Name: "fake-rest"}},
+	} {
+		_, err := CreateDiscoveryFromV1alpha1(pd, WithOfflineMode(true))
+		assert.NotNil(err)
+		assert.Contains(err.Error(), "cannot be used in offline mode")
+	}
+}
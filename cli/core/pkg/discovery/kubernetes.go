@@ -14,6 +14,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/common"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/distribution"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/plugin"
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
 )
 
 // KubernetesDiscovery is an artifact discovery utilizing CLIPlugin API in kubernetes cluster
@@ -109,6 +110,16 @@ func (k *KubernetesDiscovery) GetDiscoveredPlugins(clusterClient cluster.Client)
 	return plugins, nil
 }
 
+// Policy returns the CLIPlugin policy served by the kubernetes cluster.
+// It returns nil if the cluster does not serve any policy
+func (k *KubernetesDiscovery) Policy() (*configapi.PluginPolicy, error) {
+	clusterClient, err := cluster.NewClient(k.kubeconfigPath, k.kubecontext, cluster.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return clusterClient.GetCLIPluginPolicy()
+}
+
 // Type of the repository.
 func (k *KubernetesDiscovery) Type() string {
 	return common.DiscoveryTypeKubernetes
@@ -6,10 +6,12 @@ package discovery
 import (
 	"strings"
 
+	"github.com/aunum/log"
 	"github.com/pkg/errors"
 	apimachineryjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
 
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/cli/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/artifact"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/carvelhelpers"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/common"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/plugin"
@@ -25,13 +27,16 @@ type OCIDiscovery struct {
 	// Contains a directory containing YAML files, each of which contains single
 	// CLIPlugin API resource.
 	image string
+	// offline discovers the plugins from the cached discovery image
+	offline bool
 }
 
 // NewOCIDiscovery returns a new local repository.
-func NewOCIDiscovery(name, image string) Discovery {
+func NewOCIDiscovery(name, image string, opts ...Option) Discovery {
 	return &OCIDiscovery{
-		name:  name,
-		image: image,
+		name:    name,
+		image:   image,
+		offline: newOptions(opts).Offline,
 	}
 }
 
@@ -69,12 +74,45 @@ func (od *OCIDiscovery) Type() string {
 
 // Manifest returns the manifest for a local repository.
 func (od *OCIDiscovery) Manifest() ([]plugin.Discovered, error) {
+	outputData, err := od.fetchManifestData()
+	if err != nil {
+		return nil, err
+	}
+
+	plugins, err := processDiscoveryManifestData(outputData, od.name)
+	if err != nil {
+		return nil, err
+	}
+	if od.offline {
+		useOfflineDistributions(plugins)
+	}
+	return plugins, nil
+}
+
+// fetchManifestData returns the processed discovery image. The last processed
+// discovery image is kept in the plugin artifact cache so that plugins can be
+// discovered in offline mode.
+func (od *OCIDiscovery) fetchManifestData() ([]byte, error) {
+	cache, cacheErr := artifact.NewCache()
+	if od.offline {
+		if cacheErr == nil {
+			if b, ok := cache.GetByReference(od.image); ok {
+				return b, nil
+			}
+		}
+		return nil, errors.Errorf("discovery image %q is not available in the plugin artifact cache", od.image)
+	}
+
 	outputData, err := carvelhelpers.ProcessCarvelPackage(od.image)
 	if err != nil {
 		return nil, errors.Wrap(err, "error while processing package")
 	}
-
-	return processDiscoveryManifestData(outputData, od.name)
+	if cacheErr == nil {
+		if err := cache.PutByReference(od.image, outputData); err != nil {
+			log.Debugf("unable to cache discovery image %q: %v", od.image, err)
+		}
+	}
+	return outputData, nil
 }
 
 func processDiscoveryManifestData(data []byte, discoveryName string) ([]plugin.Discovered, error) {
@@ -5,6 +5,7 @@ package discovery
 
 import (
 	"sort"
+	"time"
 
 	"github.com/Masterminds/semver"
 
@@ -18,6 +19,7 @@ func CheckDiscoveryName(ds configapi.PluginDiscovery, dn string) bool {
 		(ds.Kubernetes != nil && ds.Kubernetes.Name == dn) ||
 		(ds.Local != nil && ds.Local.Name == dn) ||
 		(ds.REST != nil && ds.REST.Name == dn) ||
+		(ds.HTTP != nil && ds.HTTP.Name == dn) ||
 		(ds.OCI != nil && ds.OCI.Name == dn)
 }
 
@@ -38,6 +40,9 @@ func CompareDiscoverySource(ds1, ds2 configapi.PluginDiscovery, dsType string) b
 
 	case common.DiscoveryTypeREST:
 		return compareRESTDiscoverySources(ds1, ds2)
+
+	case common.DiscoveryTypeHTTP:
+		return compareHTTPDiscoverySources(ds1, ds2)
 	}
 	return false
 }
@@ -75,6 +80,20 @@ func compareRESTDiscoverySources(ds1, ds2 configapi.PluginDiscovery) bool {
 		ds1.REST.Endpoint == ds2.REST.Endpoint
 }
 
+func compareHTTPDiscoverySources(ds1, ds2 configapi.PluginDiscovery) bool {
+	return ds1.HTTP != nil && ds2.HTTP != nil &&
+		ds1.HTTP.Name == ds2.HTTP.Name &&
+		ds1.HTTP.URL == ds2.HTTP.URL &&
+		getRefreshInterval(ds1.HTTP) == getRefreshInterval(ds2.HTTP)
+}
+
+func getRefreshInterval(ds *configapi.HTTPDiscovery) time.Duration {
+	if ds.RefreshInterval == nil {
+		return 0
+	}
+	return ds.RefreshInterval.Duration
+}
+
 // SortVersions sorts the supported version strings in semver 2.0 order.
 func SortVersions(vStrArr []string) error {
 	vArr := make([]*semver.Version, len(vStrArr))
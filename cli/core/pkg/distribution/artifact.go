@@ -4,6 +4,7 @@
 package distribution
 
 import (
+	"github.com/aunum/log"
 	"github.com/pkg/errors"
 
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/cli/v1alpha1"
@@ -22,6 +23,9 @@ type Artifact struct {
 	// SHA256 hash of the plugin binary.
 	Digest string
 
+	// Signature is the location of the detached signature of the plugin binary.
+	Signature string
+
 	// OS of the plugin binary in `GOOS` format.
 	OS string
 
@@ -59,25 +63,66 @@ func (aMap Artifacts) GetArtifact(version, os, arch string) (Artifact, error) {
 }
 
 // Fetch the binary for a plugin version.
+// Remote artifacts with a known digest are served from the plugin artifact
+// cache when available and are added to the cache once downloaded.
 func (aMap Artifacts) Fetch(version, os, arch string) ([]byte, error) {
+	return aMap.fetch(version, os, arch, false)
+}
+
+func (aMap Artifacts) fetch(version, os, arch string, offline bool) ([]byte, error) {
 	a, err := aMap.GetArtifact(version, os, arch)
 	if err != nil {
 		return nil, err
 	}
 
-	if a.Image != "" {
-		return artifact.NewOCIArtifact(a.Image).Fetch()
-	}
-	if a.URI != "" {
-		u, err := artifact.NewURIArtifact(a.URI)
+	var art artifact.Artifact
+	switch {
+	case a.Image != "":
+		art = artifact.NewOCIArtifact(a.Image)
+	case a.URI != "":
+		art, err = artifact.NewURIArtifact(a.URI)
 		if err != nil {
 			return nil, err
 		}
-		return u.Fetch()
+	default:
+		return nil, errors.Errorf("invalid artifact for version:%s, os:%s, "+
+			"arch:%s", version, os, arch)
+	}
+
+	// Local artifacts are not cached
+	if _, ok := art.(*artifact.LocalArtifact); ok {
+		return art.Fetch()
+	}
+	return fetchWithCache(art, a.Digest, version, os, arch, offline)
+}
+
+func fetchWithCache(art artifact.Artifact, digest, version, os, arch string, offline bool) ([]byte, error) {
+	cache, err := artifact.NewCache()
+	if err != nil {
+		log.Warningf("unable to use the plugin artifact cache: %v", err)
+		return art.Fetch()
+	}
+
+	if digest != "" {
+		if b, ok := cache.Get(digest); ok {
+			return b, nil
+		}
+	}
+	if offline {
+		return nil, errors.Errorf("artifact for version:%s, os:%s, arch:%s is not available in the plugin artifact cache", version, os, arch)
 	}
 
-	return nil, errors.Errorf("invalid artifact for version:%s, os:%s, "+
-		"arch:%s", version, os, arch)
+	b, err := art.Fetch()
+	if err != nil {
+		return nil, err
+	}
+	if digest != "" {
+		// Caching is best effort; the digest mismatch is reported by the plugin verification
+		if err := cache.Put(digest, b); err != nil {
+			log.Debugf("unable to cache plugin artifact: %v", err)
+		}
+	}
+	return b, nil
 }
 
 // FetchTest the test binary for a plugin version.
@@ -116,14 +161,97 @@ func (aMap Artifacts) DescribeArtifact(version, os, arch string) (Artifact, erro
 	return aMap.GetArtifact(version, os, arch)
 }
 
+// ListArtifacts returns the artifacts of every supported platform of a plugin version.
+func (aMap Artifacts) ListArtifacts(version string) (ArtifactList, error) {
+	aList, ok := aMap[version]
+	if !ok {
+		return nil, errors.Errorf("could not find the artifacts for version:%s", version)
+	}
+
+	artifacts := make(ArtifactList, 0, len(aList))
+	for _, a := range aList {
+		if a.OS != "" && a.Arch != "" {
+			artifacts = append(artifacts, a)
+		}
+	}
+	return artifacts, nil
+}
+
+// FetchSignature the detached signature of the binary for a plugin version.
+// If the artifact does not specify the signature location, the signature is
+// looked up next to the plugin binary.
+func (aMap Artifacts) FetchSignature(version, os, arch string) ([]byte, error) {
+	return aMap.fetchSignature(version, os, arch, false)
+}
+
+func (aMap Artifacts) fetchSignature(version, os, arch string, offline bool) ([]byte, error) {
+	a, err := aMap.GetArtifact(version, os, arch)
+	if err != nil {
+		return nil, err
+	}
+
+	var sigRef string
+	var sig artifact.Artifact
+	switch {
+	case a.Image != "":
+		sigRef = a.Signature
+		if sigRef == "" {
+			sigRef = artifact.SignatureImage(a.Image)
+		}
+		sig = artifact.NewOCIArtifact(sigRef)
+	case a.URI != "":
+		sigRef = a.Signature
+		if sigRef == "" {
+			sigRef = artifact.SignatureURI(a.URI)
+		}
+		sig, err = artifact.NewURIArtifact(sigRef)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("invalid artifact for version:%s, os:%s, arch:%s", version, os, arch)
+	}
+
+	// Local signatures are not cached
+	if _, ok := sig.(*artifact.LocalArtifact); ok {
+		return sig.Fetch()
+	}
+	return fetchSignatureWithCache(sig, sigRef, offline)
+}
+
+func fetchSignatureWithCache(sig artifact.Artifact, sigRef string, offline bool) ([]byte, error) {
+	cache, err := artifact.NewCache()
+	if err != nil {
+		log.Warningf("unable to use the plugin artifact cache: %v", err)
+		return sig.Fetch()
+	}
+
+	if offline {
+		if b, ok := cache.GetByReference(sigRef); ok {
+			return b, nil
+		}
+		return nil, errors.Errorf("signature %q is not available in the plugin artifact cache", sigRef)
+	}
+
+	b, err := sig.Fetch()
+	if err != nil {
+		return nil, err
+	}
+	if err := cache.PutByReference(sigRef, b); err != nil {
+		log.Debugf("unable to cache plugin signature: %v", err)
+	}
+	return b, nil
+}
+
 // ArtifactFromK8sV1alpha1 returns Artifact from k8sV1alpha1
 func ArtifactFromK8sV1alpha1(a cliv1alpha1.Artifact) Artifact { //nolint:gocritic
 	return Artifact{
-		Image:  a.Image,
-		URI:    a.URI,
-		Digest: a.Digest,
-		OS:     a.OS,
-		Arch:   a.Arch,
+		Image:     a.Image,
+		URI:       a.URI,
+		Digest:    a.Digest,
+		Signature: a.Signature,
+		OS:        a.OS,
+		Arch:      a.Arch,
 	}
 }
 
@@ -20,4 +20,10 @@ type Distribution interface {
 
 	// DescribeArtifact returns the artifact resource based plugin metadata
 	DescribeArtifact(version, os, arch string) (Artifact, error)
+
+	// FetchSignature the detached signature of the binary for a plugin version.
+	FetchSignature(version, os, arch string) ([]byte, error)
+
+	// ListArtifacts returns the artifacts of every supported platform of a plugin version.
+	ListArtifacts(version string) (ArtifactList, error)
 }
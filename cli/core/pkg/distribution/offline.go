@@ -0,0 +1,38 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package distribution
+
+import (
+	"github.com/pkg/errors"
+)
+
+// offlineArtifacts serves the plugin binaries and signatures purely from the
+// plugin artifact cache without accessing the artifact repositories.
+type offlineArtifacts struct {
+	Artifacts
+}
+
+// Offline returns a distribution which serves the artifacts of the given
+// distribution purely from the plugin artifact cache.
+func Offline(d Distribution) Distribution {
+	if a, ok := d.(Artifacts); ok {
+		return offlineArtifacts{Artifacts: a}
+	}
+	return d
+}
+
+// Fetch the cached binary for a plugin version.
+func (o offlineArtifacts) Fetch(version, os, arch string) ([]byte, error) {
+	return o.fetch(version, os, arch, true)
+}
+
+// FetchTest returns an error as the test binaries are not cached.
+func (o offlineArtifacts) FetchTest(version, os, arch string) ([]byte, error) {
+	return nil, errors.Errorf("test plugin for version:%s, os:%s, arch:%s cannot be installed in offline mode", version, os, arch)
+}
+
+// FetchSignature the cached detached signature of the binary for a plugin version.
+func (o offlineArtifacts) FetchSignature(version, os, arch string) ([]byte, error) {
+	return o.fetchSignature(version, os, arch, true)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/apis/cli/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/cluster"
+	v1alpha1a "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
 )
 
 type ClusterClient struct {
@@ -34,6 +35,18 @@ type ClusterClient struct {
 		result1 map[string]string
 		result2 error
 	}
+	GetCLIPluginPolicyStub        func() (*v1alpha1a.PluginPolicy, error)
+	getCLIPluginPolicyMutex       sync.RWMutex
+	getCLIPluginPolicyArgsForCall []struct {
+	}
+	getCLIPluginPolicyReturns struct {
+		result1 *v1alpha1a.PluginPolicy
+		result2 error
+	}
+	getCLIPluginPolicyReturnsOnCall map[int]struct {
+		result1 *v1alpha1a.PluginPolicy
+		result2 error
+	}
 	ListCLIPluginResourcesStub        func() ([]v1alpha1.CLIPlugin, error)
 	listCLIPluginResourcesMutex       sync.RWMutex
 	listCLIPluginResourcesArgsForCall []struct {
@@ -174,6 +187,62 @@ func (fake *ClusterClient) GetCLIPluginImageRepositoryOverrideReturnsOnCall(i in
 	}{result1, result2}
 }
 
+func (fake *ClusterClient) GetCLIPluginPolicy() (*v1alpha1a.PluginPolicy, error) {
+	fake.getCLIPluginPolicyMutex.Lock()
+	ret, specificReturn := fake.getCLIPluginPolicyReturnsOnCall[len(fake.getCLIPluginPolicyArgsForCall)]
+	fake.getCLIPluginPolicyArgsForCall = append(fake.getCLIPluginPolicyArgsForCall, struct {
+	}{})
+	stub := fake.GetCLIPluginPolicyStub
+	fakeReturns := fake.getCLIPluginPolicyReturns
+	fake.recordInvocation("GetCLIPluginPolicy", []interface{}{})
+	fake.getCLIPluginPolicyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ClusterClient) GetCLIPluginPolicyCallCount() int {
+	fake.getCLIPluginPolicyMutex.RLock()
+	defer fake.getCLIPluginPolicyMutex.RUnlock()
+	return len(fake.getCLIPluginPolicyArgsForCall)
+}
+
+func (fake *ClusterClient) GetCLIPluginPolicyCalls(stub func() (*v1alpha1a.PluginPolicy, error)) {
+	fake.getCLIPluginPolicyMutex.Lock()
+	defer fake.getCLIPluginPolicyMutex.Unlock()
+	fake.GetCLIPluginPolicyStub = stub
+}
+
+func (fake *ClusterClient) GetCLIPluginPolicyReturns(result1 *v1alpha1a.PluginPolicy, result2 error) {
+	fake.getCLIPluginPolicyMutex.Lock()
+	defer fake.getCLIPluginPolicyMutex.Unlock()
+	fake.GetCLIPluginPolicyStub = nil
+	fake.getCLIPluginPolicyReturns = struct {
+		result1 *v1alpha1a.PluginPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ClusterClient) GetCLIPluginPolicyReturnsOnCall(i int, result1 *v1alpha1a.PluginPolicy, result2 error) {
+	fake.getCLIPluginPolicyMutex.Lock()
+	defer fake.getCLIPluginPolicyMutex.Unlock()
+	fake.GetCLIPluginPolicyStub = nil
+	if fake.getCLIPluginPolicyReturnsOnCall == nil {
+		fake.getCLIPluginPolicyReturnsOnCall = make(map[int]struct {
+			result1 *v1alpha1a.PluginPolicy
+			result2 error
+		})
+	}
+	fake.getCLIPluginPolicyReturnsOnCall[i] = struct {
+		result1 *v1alpha1a.PluginPolicy
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ClusterClient) ListCLIPluginResources() ([]v1alpha1.CLIPlugin, error) {
 	fake.listCLIPluginResourcesMutex.Lock()
 	ret, specificReturn := fake.listCLIPluginResourcesReturnsOnCall[len(fake.listCLIPluginResourcesArgsForCall)]
@@ -293,6 +362,8 @@ func (fake *ClusterClient) Invocations() map[string][][]interface{} {
 	defer fake.buildClusterQueryMutex.RUnlock()
 	fake.getCLIPluginImageRepositoryOverrideMutex.RLock()
 	defer fake.getCLIPluginImageRepositoryOverrideMutex.RUnlock()
+	fake.getCLIPluginPolicyMutex.RLock()
+	defer fake.getCLIPluginPolicyMutex.RUnlock()
 	fake.listCLIPluginResourcesMutex.RLock()
 	defer fake.listCLIPluginResourcesMutex.RUnlock()
 	fake.verifyCLIPluginCRDMutex.RLock()
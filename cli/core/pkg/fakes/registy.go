@@ -60,6 +60,34 @@ type Registry struct {
 		result1 []string
 		result2 error
 	}
+	PushBundleStub        func(string, string) (string, error)
+	pushBundleMutex       sync.RWMutex
+	pushBundleArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	pushBundleReturns struct {
+		result1 string
+		result2 error
+	}
+	pushBundleReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	PushImageStub        func(string, string) (string, error)
+	pushImageMutex       sync.RWMutex
+	pushImageArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	pushImageReturns struct {
+		result1 string
+		result2 error
+	}
+	pushImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -277,6 +305,10 @@ func (fake *Registry) ListImageTags(arg1 string) ([]string, error) {
 func (fake *Registry) ListImageTagsCallCount() int {
 	fake.listImageTagsMutex.RLock()
 	defer fake.listImageTagsMutex.RUnlock()
+	fake.pushBundleMutex.RLock()
+	defer fake.pushBundleMutex.RUnlock()
+	fake.pushImageMutex.RLock()
+	defer fake.pushImageMutex.RUnlock()
 	return len(fake.listImageTagsArgsForCall)
 }
 
@@ -289,6 +321,10 @@ func (fake *Registry) ListImageTagsCalls(stub func(string) ([]string, error)) {
 func (fake *Registry) ListImageTagsArgsForCall(i int) string {
 	fake.listImageTagsMutex.RLock()
 	defer fake.listImageTagsMutex.RUnlock()
+	fake.pushBundleMutex.RLock()
+	defer fake.pushBundleMutex.RUnlock()
+	fake.pushImageMutex.RLock()
+	defer fake.pushImageMutex.RUnlock()
 	argsForCall := fake.listImageTagsArgsForCall[i]
 	return argsForCall.arg1
 }
@@ -319,6 +355,136 @@ func (fake *Registry) ListImageTagsReturnsOnCall(i int, result1 []string, result
 	}{result1, result2}
 }
 
+func (fake *Registry) PushBundle(arg1 string, arg2 string) (string, error) {
+	fake.pushBundleMutex.Lock()
+	ret, specificReturn := fake.pushBundleReturnsOnCall[len(fake.pushBundleArgsForCall)]
+	fake.pushBundleArgsForCall = append(fake.pushBundleArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.PushBundleStub
+	fakeReturns := fake.pushBundleReturns
+	fake.recordInvocation("PushBundle", []interface{}{arg1, arg2})
+	fake.pushBundleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Registry) PushBundleCallCount() int {
+	fake.pushBundleMutex.RLock()
+	defer fake.pushBundleMutex.RUnlock()
+	return len(fake.pushBundleArgsForCall)
+}
+
+func (fake *Registry) PushBundleCalls(stub func(string, string) (string, error)) {
+	fake.pushBundleMutex.Lock()
+	defer fake.pushBundleMutex.Unlock()
+	fake.PushBundleStub = stub
+}
+
+func (fake *Registry) PushBundleArgsForCall(i int) (string, string) {
+	fake.pushBundleMutex.RLock()
+	defer fake.pushBundleMutex.RUnlock()
+	argsForCall := fake.pushBundleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Registry) PushBundleReturns(result1 string, result2 error) {
+	fake.pushBundleMutex.Lock()
+	defer fake.pushBundleMutex.Unlock()
+	fake.PushBundleStub = nil
+	fake.pushBundleReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Registry) PushBundleReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pushBundleMutex.Lock()
+	defer fake.pushBundleMutex.Unlock()
+	fake.PushBundleStub = nil
+	if fake.pushBundleReturnsOnCall == nil {
+		fake.pushBundleReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pushBundleReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Registry) PushImage(arg1 string, arg2 string) (string, error) {
+	fake.pushImageMutex.Lock()
+	ret, specificReturn := fake.pushImageReturnsOnCall[len(fake.pushImageArgsForCall)]
+	fake.pushImageArgsForCall = append(fake.pushImageArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.PushImageStub
+	fakeReturns := fake.pushImageReturns
+	fake.recordInvocation("PushImage", []interface{}{arg1, arg2})
+	fake.pushImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Registry) PushImageCallCount() int {
+	fake.pushImageMutex.RLock()
+	defer fake.pushImageMutex.RUnlock()
+	return len(fake.pushImageArgsForCall)
+}
+
+func (fake *Registry) PushImageCalls(stub func(string, string) (string, error)) {
+	fake.pushImageMutex.Lock()
+	defer fake.pushImageMutex.Unlock()
+	fake.PushImageStub = stub
+}
+
+func (fake *Registry) PushImageArgsForCall(i int) (string, string) {
+	fake.pushImageMutex.RLock()
+	defer fake.pushImageMutex.RUnlock()
+	argsForCall := fake.pushImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Registry) PushImageReturns(result1 string, result2 error) {
+	fake.pushImageMutex.Lock()
+	defer fake.pushImageMutex.Unlock()
+	fake.PushImageStub = nil
+	fake.pushImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Registry) PushImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pushImageMutex.Lock()
+	defer fake.pushImageMutex.Unlock()
+	fake.PushImageStub = nil
+	if fake.pushImageReturnsOnCall == nil {
+		fake.pushImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pushImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Registry) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -330,6 +496,10 @@ func (fake *Registry) Invocations() map[string][][]interface{} {
 	defer fake.getFilesMutex.RUnlock()
 	fake.listImageTagsMutex.RLock()
 	defer fake.listImageTagsMutex.RUnlock()
+	fake.pushBundleMutex.RLock()
+	defer fake.pushBundleMutex.RUnlock()
+	fake.pushImageMutex.RLock()
+	defer fake.pushImageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
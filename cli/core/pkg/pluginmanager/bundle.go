@@ -0,0 +1,516 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aunum/log"
+	"github.com/k14s/imgpkg/pkg/imgpkg/lockconfig"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	cliv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/cli/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/artifact"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/carvelhelpers"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/common"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/plugin"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/utils"
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	configlib "github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+)
+
+const (
+	// bundleIndexFileName is the inventory index of the plugins of a bundle
+	bundleIndexFileName = "index.yaml"
+	// bundlePluginsDirName is the directory of the plugin binaries and signatures of a bundle
+	bundlePluginsDirName = "plugins"
+	// bundleDiscoveryImageName is the name of the discovery image pushed to the repository
+	bundleDiscoveryImageName = "plugins-manifest:latest"
+	// signatureFileSuffix is the suffix of the detached signatures of the plugin binaries
+	signatureFileSuffix = ".sig"
+	// kbldIDAnnotation is the ImagesLock annotation identifying the image references resolved by kbld
+	kbldIDAnnotation = "kbld.carvel.dev/id"
+	// bundleBinaryFileMode is the permissions of the plugin binaries of a bundle
+	bundleBinaryFileMode os.FileMode = 0755
+	// bundleDataFileMode is the permissions of the index, signatures and other data files of a bundle
+	bundleDataFileMode os.FileMode = 0644
+)
+
+// BundleOptions selects the plugins included in a plugin bundle
+type BundleOptions struct {
+	// Plugins are the names of the plugins to include. All plugins are included if empty
+	Plugins []string
+	// Versions are the plugin versions to include. Only the recommended version of
+	// each plugin is included if empty
+	Versions []string
+	// Platforms are the platforms to include in `os/arch` format, e.g. linux/amd64.
+	// All platforms are included if empty
+	Platforms []string
+}
+
+// CreatePluginBundle resolves the plugins of the configured standalone discovery
+// sources and writes a gzipped tarball containing their binaries, signatures and
+// an inventory index listing them. The bundle can be served as is by an HTTP
+// discovery source or pushed to a private registry with UploadPluginBundle.
+// The plugins of the context-scoped discovery sources cannot be bundled, as the
+// discovery sources serving a bundle are standalone discovery sources.
+func CreatePluginBundle(opts *BundleOptions, bundlePath string) error {
+	plugins, err := DiscoverStandalonePlugins()
+	if err != nil {
+		return err
+	}
+	if err := checkContextScopedPlugins(opts, plugins); err != nil {
+		return err
+	}
+
+	bundleDir, err := os.MkdirTemp("", "plugin-bundle")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(bundleDir)
+
+	index := discovery.InventoryIndex{}
+	bundled := map[string]bool{}
+	for i := range plugins {
+		p := &plugins[i]
+		if len(opts.Plugins) != 0 && !utils.ContainsString(opts.Plugins, p.Name) {
+			continue
+		}
+		if bundled[p.Name] {
+			log.Warningf("Warning: plugin %q of discovery source %q is skipped as it has already been bundled from another discovery source", p.Name, p.Source)
+			continue
+		}
+
+		bp, err := bundlePlugin(p, opts, bundleDir)
+		if err != nil {
+			return err
+		}
+		if len(bp.Artifacts) == 0 {
+			continue
+		}
+		bundled[p.Name] = true
+		index.Plugins = append(index.Plugins, *bp)
+	}
+
+	for _, name := range opts.Plugins {
+		if !bundled[name] {
+			return errors.Errorf("unable to find plugin %q matching the bundle filters", name)
+		}
+	}
+	if len(index.Plugins) == 0 {
+		return errors.New("no plugins match the bundle filters")
+	}
+
+	b, err := yaml.Marshal(index)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal the plugin bundle index")
+	}
+	if err := os.WriteFile(filepath.Join(bundleDir, bundleIndexFileName), b, bundleDataFileMode); err != nil {
+		return errors.Wrap(err, "could not write the plugin bundle index")
+	}
+	return writeTarball(bundleDir, bundlePath)
+}
+
+// checkContextScopedPlugins rejects the selected plugins which are only offered by the
+// context-scoped discovery sources of the current server. If no plugin is selected, the
+// context-scoped plugins which are not bundled are reported.
+func checkContextScopedPlugins(opts *BundleOptions, standalonePlugins []plugin.Discovered) error {
+	server, err := configlib.GetCurrentServer()
+	if err != nil || server == nil {
+		return nil
+	}
+	serverPlugins, err := DiscoverServerPlugins(server.Name)
+	if err != nil {
+		log.Warningf("unable to discover the context-scoped plugins of server %q: %v", server.Name, err.Error())
+		return nil
+	}
+
+	standalone := map[string]bool{}
+	for i := range standalonePlugins {
+		standalone[standalonePlugins[i].Name] = true
+	}
+	var skipped []string
+	for i := range serverPlugins {
+		name := serverPlugins[i].Name
+		if standalone[name] || (len(opts.Plugins) != 0 && !utils.ContainsString(opts.Plugins, name)) {
+			continue
+		}
+		if len(opts.Plugins) != 0 {
+			return errors.Errorf("plugin %q is only offered by the context-scoped discovery sources of server %q, which cannot be bundled", name, server.Name)
+		}
+		skipped = append(skipped, name)
+	}
+	if len(skipped) != 0 {
+		log.Warningf("Warning: the context-scoped plugins %s of server %q are not bundled, only the plugins of the standalone discovery sources can be bundled", strings.Join(skipped, ", "), server.Name)
+	}
+	return nil
+}
+
+// bundlePlugin downloads the binaries and signatures of the selected versions
+// and platforms of the plugin into the bundle directory and returns the plugin
+// inventory entry with artifact URIs relative to the bundle directory
+func bundlePlugin(p *plugin.Discovered, opts *BundleOptions, bundleDir string) (*discovery.Plugin, error) {
+	bp := &discovery.Plugin{
+		Name:               p.Name,
+		Description:        p.Description,
+		RecommendedVersion: p.RecommendedVersion,
+		Optional:           p.Optional,
+		Artifacts:          map[string]cliv1alpha1.ArtifactList{},
+	}
+
+	for _, version := range bundleVersions(p, opts.Versions) {
+		artifacts, err := p.Distribution.ListArtifacts(version)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range artifacts {
+			if len(opts.Platforms) != 0 && !utils.ContainsString(opts.Platforms, a.OS+"/"+a.Arch) {
+				continue
+			}
+
+			log.Infof("Bundling plugin '%v:%v' for %v/%v", p.Name, version, a.OS, a.Arch)
+			b, err := p.Distribution.Fetch(version, a.OS, a.Arch)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to fetch plugin '%v:%v' for %v/%v", p.Name, version, a.OS, a.Arch)
+			}
+			digest := fmt.Sprintf("%x", sha256.Sum256(b))
+			if a.Digest != "" && a.Digest != digest {
+				return nil, errors.Errorf("plugin '%v:%v' for %v/%v has been corrupted during download. source digest: %s, actual digest: %s", p.Name, version, a.OS, a.Arch, a.Digest, digest)
+			}
+
+			binaryName := "tanzu-" + p.Name
+			if a.OS == "windows" {
+				binaryName += ".exe"
+			}
+			uri := path.Join(bundlePluginsDirName, p.Name, version, a.OS+"_"+a.Arch, binaryName)
+			if err := writeBundleFile(bundleDir, uri, b, bundleBinaryFileMode); err != nil {
+				return nil, err
+			}
+
+			ba := cliv1alpha1.Artifact{URI: uri, Digest: digest, OS: a.OS, Arch: a.Arch}
+			if sig, err := p.Distribution.FetchSignature(version, a.OS, a.Arch); err == nil {
+				ba.Signature = uri + signatureFileSuffix
+				if err := writeBundleFile(bundleDir, ba.Signature, sig, bundleDataFileMode); err != nil {
+					return nil, err
+				}
+			} else {
+				log.Debugf("plugin '%v:%v' for %v/%v is bundled without signature: %v", p.Name, version, a.OS, a.Arch, err)
+			}
+			bp.Artifacts[version] = append(bp.Artifacts[version], ba)
+		}
+	}
+
+	if _, ok := bp.Artifacts[bp.RecommendedVersion]; !ok {
+		bp.RecommendedVersion = latestBundledVersion(bp)
+	}
+	return bp, nil
+}
+
+// bundleVersions returns the supported versions of the plugin matching the
+// requested versions. `latest` denotes the recommended version
+func bundleVersions(p *plugin.Discovered, versions []string) []string {
+	if len(versions) == 0 {
+		versions = []string{cli.VersionLatest}
+	}
+	var selected []string
+	for _, v := range p.SupportedVersions {
+		if utils.ContainsString(versions, v) || (v == p.RecommendedVersion && utils.ContainsString(versions, cli.VersionLatest)) {
+			selected = append(selected, v)
+		}
+	}
+	return selected
+}
+
+func latestBundledVersion(bp *discovery.Plugin) string {
+	versions := make([]string, 0, len(bp.Artifacts))
+	for v := range bp.Artifacts {
+		versions = append(versions, v)
+	}
+	if len(versions) == 0 || discovery.SortVersions(versions) != nil {
+		return ""
+	}
+	return versions[len(versions)-1]
+}
+
+// UploadPluginBundle pushes the plugins of the bundle to the given repository
+// of a private registry, along with a discovery image listing them, and points
+// the standalone discovery source with the given name to the discovery image.
+// It returns the reference of the discovery image.
+func UploadPluginBundle(bundlePath, repository, discoveryName string) (string, error) {
+	bundleDir, err := os.MkdirTemp("", "plugin-bundle")
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(bundleDir)
+
+	if err := extractTarball(bundlePath, bundleDir); err != nil {
+		return "", err
+	}
+	b, err := os.ReadFile(filepath.Join(bundleDir, bundleIndexFileName))
+	if err != nil {
+		return "", errors.Wrap(err, "could not read the plugin bundle index")
+	}
+	var index discovery.InventoryIndex
+	if err := yaml.Unmarshal(b, &index); err != nil {
+		return "", errors.Wrap(err, "could not unmarshal the plugin bundle index")
+	}
+
+	discoveryDir, err := os.MkdirTemp("", "plugin-discovery")
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(discoveryDir)
+
+	repository = strings.TrimSuffix(repository, "/")
+	imagesLock := lockconfig.NewEmptyImagesLock()
+	for i := range index.Plugins {
+		cliPlugin, err := uploadBundledPlugin(&index.Plugins[i], bundleDir, repository, &imagesLock)
+		if err != nil {
+			return "", err
+		}
+		b, err := yaml.Marshal(cliPlugin)
+		if err != nil {
+			return "", errors.Wrapf(err, "could not marshal the CLIPlugin resource of plugin %q", cliPlugin.Name)
+		}
+		if err := writeBundleFile(discoveryDir, path.Join("config", cliPlugin.Name+".yaml"), b, bundleDataFileMode); err != nil {
+			return "", err
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(discoveryDir, ".imgpkg"), 0755); err != nil {
+		return "", errors.Wrap(err, "could not create the ImagesLock directory")
+	}
+	if err := imagesLock.WriteToPath(filepath.Join(discoveryDir, ".imgpkg", "images.yml")); err != nil {
+		return "", errors.Wrap(err, "could not write the ImagesLock of the discovery image")
+	}
+
+	discoveryImage := repository + "/" + bundleDiscoveryImageName
+	log.Infof("Pushing discovery image %q", discoveryImage)
+	if _, err := carvelhelpers.PushImageBundle(discoveryImage, discoveryDir); err != nil {
+		return "", errors.Wrapf(err, "unable to push discovery image %q", discoveryImage)
+	}
+
+	if err := setStandaloneOCIDiscoverySource(discoveryName, discoveryImage); err != nil {
+		return "", err
+	}
+	return discoveryImage, nil
+}
+
+// uploadBundledPlugin pushes the binaries and signatures of the bundled plugin
+// as OCI images and returns the CLIPlugin resource referencing them
+func uploadBundledPlugin(bp *discovery.Plugin, bundleDir, repository string, imagesLock *lockconfig.ImagesLock) (*cliv1alpha1.CLIPlugin, error) {
+	cliPlugin := &cliv1alpha1.CLIPlugin{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: cliv1alpha1.GroupVersion.String(),
+			Kind:       cliv1alpha1.GroupVersionKindCLIPlugin.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: bp.Name},
+		Spec: cliv1alpha1.CLIPluginSpec{
+			Description:        bp.Description,
+			RecommendedVersion: bp.RecommendedVersion,
+			Optional:           bp.Optional,
+			Artifacts:          map[string]cliv1alpha1.ArtifactList{},
+		},
+	}
+
+	for version, artifacts := range bp.Artifacts {
+		for _, a := range artifacts {
+			binaryPath, err := bundleFilePath(bundleDir, a.URI)
+			if err != nil {
+				return nil, err
+			}
+			b, err := os.ReadFile(binaryPath)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not read the binary of plugin '%v:%v' for %v/%v", bp.Name, version, a.OS, a.Arch)
+			}
+			if digest := fmt.Sprintf("%x", sha256.Sum256(b)); a.Digest != "" && a.Digest != digest {
+				return nil, errors.Errorf("plugin '%v:%v' for %v/%v has been corrupted in the bundle. source digest: %s, actual digest: %s", bp.Name, version, a.OS, a.Arch, a.Digest, digest)
+			}
+
+			image := fmt.Sprintf("%s/%s/%s-%s:%s", repository, bp.Name, a.OS, a.Arch, strings.ReplaceAll(version, "+", "_"))
+			log.Infof("Pushing plugin '%v:%v' for %v/%v to %q", bp.Name, version, a.OS, a.Arch, image)
+			digestRef, err := carvelhelpers.PushImage(image, binaryPath)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to push plugin image %q", image)
+			}
+			if a.Signature != "" {
+				sigPath, err := bundleFilePath(bundleDir, a.Signature)
+				if err != nil {
+					return nil, err
+				}
+				if _, err := carvelhelpers.PushImage(artifact.SignatureImage(digestRef), sigPath); err != nil {
+					return nil, errors.Wrapf(err, "unable to push the signature of plugin image %q", image)
+				}
+			}
+
+			imagesLock.AddImageRef(lockconfig.ImageRef{
+				Image:       digestRef,
+				Annotations: map[string]string{kbldIDAnnotation: digestRef},
+			})
+			cliPlugin.Spec.Artifacts[version] = append(cliPlugin.Spec.Artifacts[version], cliv1alpha1.Artifact{
+				Image:  digestRef,
+				Digest: a.Digest,
+				Type:   common.DistributionTypeOCI,
+				OS:     a.OS,
+				Arch:   a.Arch,
+			})
+		}
+	}
+	return cliPlugin, nil
+}
+
+// setStandaloneOCIDiscoverySource adds or updates the standalone OCI discovery
+// source with the given name
+func setStandaloneOCIDiscoverySource(name, image string) error {
+	configlib.AcquireTanzuConfigLock()
+	defer configlib.ReleaseTanzuConfigLock()
+
+	cfg, err := configlib.GetClientConfigNoLock()
+	if err != nil {
+		return err
+	}
+	if cfg.ClientOptions == nil {
+		cfg.ClientOptions = &configapi.ClientOptions{}
+	}
+	if cfg.ClientOptions.CLI == nil {
+		cfg.ClientOptions.CLI = &configapi.CLIOptions{}
+	}
+
+	source := configapi.PluginDiscovery{OCI: &configapi.OCIDiscovery{Name: name, Image: image}}
+	updated := false
+	for i := range cfg.ClientOptions.CLI.DiscoverySources {
+		if discovery.CheckDiscoveryName(cfg.ClientOptions.CLI.DiscoverySources[i], name) {
+			source.ContextType = cfg.ClientOptions.CLI.DiscoverySources[i].ContextType
+			cfg.ClientOptions.CLI.DiscoverySources[i] = source
+			updated = true
+		}
+	}
+	if !updated {
+		cfg.ClientOptions.CLI.DiscoverySources = append(cfg.ClientOptions.CLI.DiscoverySources, source)
+	}
+	return configlib.StoreClientConfig(cfg)
+}
+
+// bundleFilePath returns the path of a file of the bundle, making sure that
+// it does not escape the bundle directory
+func bundleFilePath(bundleDir, name string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("invalid path %q in the plugin bundle", name)
+	}
+	return filepath.Join(bundleDir, cleaned), nil
+}
+
+// writeBundleFile writes a file of the bundle with the given permissions, which are
+// bundleBinaryFileMode for the plugin binaries and bundleDataFileMode for the other files
+func writeBundleFile(bundleDir, name string, b []byte, mode os.FileMode) error {
+	p, err := bundleFilePath(bundleDir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return errors.Wrapf(err, "could not create the directory of %q", name)
+	}
+	if err := os.WriteFile(p, b, mode); err != nil {
+		return errors.Wrapf(err, "could not write %q", name)
+	}
+	return nil
+}
+
+// writeTarball writes the content of the directory as a gzipped tarball
+func writeTarball(dir, tarballPath string) error {
+	f, err := os.Create(tarballPath)
+	if err != nil {
+		return errors.Wrapf(err, "could not create the plugin bundle %q", tarballPath)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not write the plugin bundle %q", tarballPath)
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Wrapf(err, "could not write the plugin bundle %q", tarballPath)
+	}
+	if err := gw.Close(); err != nil {
+		return errors.Wrapf(err, "could not write the plugin bundle %q", tarballPath)
+	}
+	return nil
+}
+
+// extractTarball extracts the regular files of the gzipped tarball into the directory
+func extractTarball(tarballPath, dir string) error {
+	f, err := os.Open(tarballPath)
+	if err != nil {
+		return errors.Wrapf(err, "could not open the plugin bundle %q", tarballPath)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return errors.Wrapf(err, "could not read the plugin bundle %q", tarballPath)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not read the plugin bundle %q", tarballPath)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			return errors.Wrapf(err, "could not read %q from the plugin bundle", hdr.Name)
+		}
+		mode := bundleDataFileMode
+		if hdr.FileInfo().Mode()&0111 != 0 {
+			mode = bundleBinaryFileMode
+		}
+		if err := writeBundleFile(dir, hdr.Name, b, mode); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"io"
+	stdlog "log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/discovery"
+	configlib "github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+)
+
+func Test_CreatePluginBundle_UploadPluginBundle(t *testing.T) {
+	assert := assert.New(t)
+
+	defer setupLocalDistoForTesting()()
+
+	bundlePath := filepath.Join(t.TempDir(), "plugins.tar.gz")
+	opts := &BundleOptions{
+		Plugins:   []string{"login"},
+		Platforms: []string{"linux/amd64", "darwin/arm64"},
+	}
+	err := CreatePluginBundle(opts, bundlePath)
+	assert.Nil(err)
+
+	// The bundle contains the selected binaries and an inventory index listing them
+	bundleDir := t.TempDir()
+	err = extractTarball(bundlePath, bundleDir)
+	assert.Nil(err)
+	b, err := os.ReadFile(filepath.Join(bundleDir, bundleIndexFileName))
+	assert.Nil(err)
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(filepath.Join(bundleDir, bundleIndexFileName))
+		assert.Nil(err)
+		assert.Equal(bundleDataFileMode, fi.Mode().Perm())
+	}
+	var index discovery.InventoryIndex
+	assert.Nil(yaml.Unmarshal(b, &index))
+	assert.Equal(1, len(index.Plugins))
+	assert.Equal("login", index.Plugins[0].Name)
+	assert.Equal("v0.2.0", index.Plugins[0].RecommendedVersion)
+	assert.Equal(2, len(index.Plugins[0].Artifacts["v0.2.0"]))
+	for _, a := range index.Plugins[0].Artifacts["v0.2.0"] {
+		assert.FileExists(filepath.Join(bundleDir, a.URI))
+		if runtime.GOOS != "windows" {
+			fi, err := os.Stat(filepath.Join(bundleDir, a.URI))
+			assert.Nil(err)
+			assert.Equal(bundleBinaryFileMode, fi.Mode().Perm())
+		}
+		assert.Equal("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", a.Digest)
+	}
+
+	// Plugins that cannot be found are reported
+	err = CreatePluginBundle(&BundleOptions{Plugins: []string{"notexists"}}, bundlePath+".notexists")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), `unable to find plugin "notexists"`)
+
+	// Plugins of the context-scoped discovery sources are rejected
+	err = CreatePluginBundle(&BundleOptions{Plugins: []string{"cluster"}}, bundlePath+".cluster")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), `plugin "cluster" is only offered by the context-scoped discovery sources of server "mgmt"`)
+
+	// Upload the bundle to a local registry
+	s := httptest.NewServer(registry.New(registry.Logger(stdlog.New(io.Discard, "", 0))))
+	defer s.Close()
+	repository := strings.TrimPrefix(s.URL, "http://") + "/tanzu-cli/plugins"
+
+	image, err := UploadPluginBundle(bundlePath, repository, "airgapped")
+	assert.Nil(err)
+	assert.Equal(repository+"/"+bundleDiscoveryImageName, image)
+
+	// The discovery source points to the pushed discovery image
+	cfg, err := configlib.GetClientConfig()
+	assert.Nil(err)
+	var found bool
+	for _, ds := range cfg.ClientOptions.CLI.DiscoverySources {
+		if ds.OCI != nil && ds.OCI.Name == "airgapped" {
+			found = true
+			assert.Equal(image, ds.OCI.Image)
+		}
+	}
+	assert.True(found)
+
+	// The plugins are discovered and downloaded from the local registry
+	plugins, err := discovery.NewOCIDiscovery("airgapped", image).List()
+	assert.Nil(err)
+	assert.Equal(1, len(plugins))
+	assert.Equal("login", plugins[0].Name)
+	a, err := plugins[0].Distribution.DescribeArtifact("v0.2.0", "linux", "amd64")
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(a.Image, repository+"/login/linux-amd64@sha256:"))
+	binary, err := plugins[0].Distribution.Fetch("v0.2.0", "linux", "amd64")
+	assert.Nil(err)
+	assert.Equal(0, len(binary))
+
+	// Bundles with paths escaping the bundle directory are refused
+	_, err = bundleFilePath(bundleDir, "../evil")
+	assert.NotNil(err)
+}
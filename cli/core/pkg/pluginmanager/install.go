@@ -0,0 +1,143 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/aunum/log"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/common"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/config"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/plugin"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+)
+
+// defaultPluginInstallConcurrency is the default number of plugins downloaded in parallel
+const defaultPluginInstallConcurrency = 4
+
+// pluginInstallRequest is a plugin version to be installed for a server
+type pluginInstallRequest struct {
+	serverName        string
+	plugin            *plugin.Discovered
+	version           string
+	installTestPlugin bool
+	// printf reports the installation progress. The progress is logged if not set.
+	printf func(format string, a ...interface{})
+}
+
+// newPluginInstallRequest returns the request to install the plugin version.
+// Standalone plugins are always installed without server association.
+func newPluginInstallRequest(serverName string, p *plugin.Discovered, version string, installTestPlugin bool) pluginInstallRequest {
+	if p.Scope == common.PluginScopeStandalone {
+		serverName = ""
+	}
+	return pluginInstallRequest{
+		serverName:        serverName,
+		plugin:            p,
+		version:           version,
+		installTestPlugin: installTestPlugin,
+	}
+}
+
+// getPluginInstallConcurrency returns the maximum number of plugins to download
+// in parallel, which can be configured with TANZU_CLI_PLUGIN_INSTALL_CONCURRENCY
+func getPluginInstallConcurrency() int {
+	if c, err := strconv.Atoi(os.Getenv(constants.PluginInstallConcurrency)); err == nil && c > 0 {
+		return c
+	}
+	return defaultPluginInstallConcurrency
+}
+
+// installPlugins downloads, verifies and installs the requested plugins using a
+// bounded pool of workers. Only the catalog and configuration updates are
+// serialized. The progress is printed through the spinner so that it doesn't
+// interleave with the spinner output. Failures are reported per plugin and
+// aggregated instead of aborting the remaining installations.
+func installPlugins(requests []pluginInstallRequest) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	ows, err := component.NewOutputWriterWithSpinner(os.Stdout, "", installProgressText(0, len(requests)), true)
+	if err != nil {
+		return err
+	}
+	defer ows.StopSpinner()
+	spinner, _ := ows.(component.OutputWriterSpinnerWithText)
+
+	var (
+		wg        sync.WaitGroup
+		mutex     sync.Mutex
+		completed int
+	)
+	errs := make([]error, len(requests))
+	workers := make(chan struct{}, getPluginInstallConcurrency())
+
+	for i := range requests {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			if spinner != nil {
+				requests[i].printf = spinner.Printf
+			}
+			errs[i] = errors.Wrapf(installPlugin(&requests[i]), "unable to install plugin '%v:%v'", requests[i].plugin.Name, requests[i].version)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			completed++
+			if spinner != nil {
+				spinner.SetSpinnerText(installProgressText(completed, len(requests)))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return kerrors.NewAggregate(errs)
+}
+
+// installPlugin installs the requested plugin version. It can be invoked concurrently.
+func installPlugin(r *pluginInstallRequest) error {
+	r.infof("Installing plugin '%v:%v'", r.plugin.Name, r.version)
+
+	err := config.CheckPluginPolicy(&config.PolicyPlugin{
+		Name:            r.plugin.Name,
+		Version:         r.version,
+		Target:          config.GetServerTarget(r.serverName),
+		DiscoverySource: r.plugin.Source,
+	})
+	if err != nil {
+		return err
+	}
+
+	descriptor, err := fetchAndInstallPlugin(r)
+	if err != nil {
+		return err
+	}
+	return updateDescriptorAndInitializePlugin(r.serverName, r.plugin, descriptor)
+}
+
+// infof reports the installation progress of the request
+func (r *pluginInstallRequest) infof(format string, a ...interface{}) {
+	if r.printf == nil {
+		log.Infof(format, a...)
+		return
+	}
+	r.printf(format, a...)
+}
+
+func installProgressText(completed, total int) string {
+	return fmt.Sprintf("Installing plugins (%d/%d)...", completed, total)
+}
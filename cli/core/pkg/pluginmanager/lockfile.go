@@ -0,0 +1,221 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/aunum/log"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/common"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/plugin"
+	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
+)
+
+// LockFile records the exact set of plugins installed by `tanzu plugin sync`
+// so that the same plugins can be installed again with `tanzu plugin sync --locked`
+type LockFile struct {
+	// Plugins are the locked plugins
+	Plugins []LockedPlugin `yaml:"plugins"`
+}
+
+// LockedPlugin is a plugin pinned to a specific version and binary digests
+type LockedPlugin struct {
+	// Name of the plugin
+	Name string `yaml:"name"`
+	// Scope of the plugin. Standalone or Context
+	Scope string `yaml:"scope"`
+	// Version of the plugin
+	Version string `yaml:"version"`
+	// Digests are the SHA256 hashes of the plugin binaries keyed by platform in `GOOS/GOARCH` format
+	Digests map[string]string `yaml:"digests"`
+	// DiscoverySource is the name of the discovery source the plugin was installed from
+	DiscoverySource string `yaml:"discoverySource"`
+}
+
+// platform returns the lockfile key of the digest of a plugin binary
+func platform(os, arch string) string {
+	return os + "/" + arch
+}
+
+// ReadLockFile reads the plugin lockfile from the given path
+func ReadLockFile(path string) (*LockFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read plugin lockfile %q", path)
+	}
+
+	var lf LockFile
+	if err := yaml.Unmarshal(b, &lf); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal plugin lockfile %q", path)
+	}
+	return &lf, nil
+}
+
+// WriteLockFile records the plugins currently installed for the given server,
+// as well as the standalone plugins, in the lockfile at the given path. The digests
+// of the binaries of every platform offered by the discovery sources are recorded
+// so that the lockfile can be used on other platforms
+// If serverName is empty(""), only standalone plugins are recorded
+func WriteLockFile(serverName, path string, opts ...discovery.Option) error {
+	serverPlugins, standalonePlugins, err := InstalledPlugins(serverName)
+	if err != nil {
+		return err
+	}
+	discoveredServerPlugins, discoveredStandalonePlugins := DiscoverPlugins(serverName, opts...)
+
+	lf := LockFile{}
+	for i := range serverPlugins {
+		lp, err := lockedPluginFromDescriptor(&serverPlugins[i], common.PluginScopeContext, discoveredServerPlugins)
+		if err != nil {
+			return err
+		}
+		lf.Plugins = append(lf.Plugins, lp)
+	}
+	for i := range standalonePlugins {
+		lp, err := lockedPluginFromDescriptor(&standalonePlugins[i], common.PluginScopeStandalone, discoveredStandalonePlugins)
+		if err != nil {
+			return err
+		}
+		lf.Plugins = append(lf.Plugins, lp)
+	}
+
+	b, err := yaml.Marshal(lf)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal plugin lockfile")
+	}
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return errors.Wrapf(err, "could not write plugin lockfile %q", path)
+	}
+	return nil
+}
+
+// lockedPluginFromDescriptor locks an installed plugin to the digests of the binaries its discovery
+// source offers for each platform. The digest of the current platform is the one of the installed binary
+func lockedPluginFromDescriptor(pd *cliapi.PluginDescriptor, scope string, discovered []plugin.Discovered) (LockedPlugin, error) {
+	b, err := os.ReadFile(pd.InstallationPath)
+	if err != nil {
+		return LockedPlugin{}, errors.Wrapf(err, "could not read the binary of plugin %q", pd.Name)
+	}
+
+	digests := make(map[string]string)
+	for i := range discovered {
+		if discovered[i].Name != pd.Name || discovered[i].Source != pd.Discovery {
+			continue
+		}
+		d := discovered[i].Distribution
+		artifacts, err := d.ListArtifacts(pd.Version)
+		if err != nil {
+			log.Warningf("unable to lock plugin '%v:%v' for other platforms: %v", pd.Name, pd.Version, err.Error())
+			break
+		}
+		for j := range artifacts {
+			a, err := d.DescribeArtifact(pd.Version, artifacts[j].OS, artifacts[j].Arch)
+			if err != nil {
+				return LockedPlugin{}, errors.Wrapf(err, "could not describe plugin '%v:%v'", pd.Name, pd.Version)
+			}
+			if a.Digest != "" {
+				digests[platform(a.OS, a.Arch)] = a.Digest
+			}
+		}
+		break
+	}
+	digests[platform(runtime.GOOS, runtime.GOARCH)] = fmt.Sprintf("%x", sha256.Sum256(b))
+
+	return LockedPlugin{
+		Name:            pd.Name,
+		Scope:           scope,
+		Version:         pd.Version,
+		Digests:         digests,
+		DiscoverySource: pd.Discovery,
+	}, nil
+}
+
+// lockedDistribution pins the digest of the plugin binary to the one recorded
+// in the lockfile, so that the downloaded binary is verified against it
+type lockedDistribution struct {
+	distribution.Distribution
+	digest string
+}
+
+// GetDigest returns the digest recorded in the lockfile
+func (d *lockedDistribution) GetDigest(version, os, arch string) (string, error) {
+	return d.digest, nil
+}
+
+// SyncPluginsLocked installs exactly the plugin versions and binaries recorded
+// in the lockfile at the given path. It fails if a discovery source no longer
+// offers a locked plugin version.
+// If serverName is empty(""), context scoped plugins cannot be installed
+func SyncPluginsLocked(serverName, lockFilePath string, opts ...discovery.Option) error {
+	log.Infof("Installing plugins from lockfile %q...", lockFilePath)
+	lf, err := ReadLockFile(lockFilePath)
+	if err != nil {
+		return err
+	}
+
+	if err := validateDiscoverySources(serverName, opts...); err != nil {
+		return err
+	}
+	serverPlugins, standalonePlugins := DiscoverPlugins(serverName, opts...)
+
+	errList := make([]error, 0)
+	var requests []pluginInstallRequest
+	for i := range lf.Plugins {
+		lp := &lf.Plugins[i]
+		discovered := serverPlugins
+		if lp.Scope == common.PluginScopeStandalone {
+			discovered = standalonePlugins
+		}
+
+		p, err := lockedPluginFromDiscovered(lp, discovered)
+		if err != nil {
+			errList = append(errList, err)
+			continue
+		}
+		requests = append(requests, newPluginInstallRequest(serverName, p, lp.Version, false))
+	}
+	if err := installPlugins(requests); err != nil {
+		errList = append(errList, err)
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+// lockedPluginFromDiscovered returns the discovered plugin matching the locked
+// plugin with its distribution pinned to the digest locked for the current platform
+func lockedPluginFromDiscovered(lp *LockedPlugin, discovered []plugin.Discovered) (*plugin.Discovered, error) {
+	digest, ok := lp.Digests[platform(runtime.GOOS, runtime.GOARCH)]
+	if !ok {
+		return nil, errors.Errorf("plugin %q is not locked for the current platform %s", lp.Name, platform(runtime.GOOS, runtime.GOARCH))
+	}
+
+	for i := range discovered {
+		if discovered[i].Name != lp.Name || discovered[i].Source != lp.DiscoverySource {
+			continue
+		}
+		p := discovered[i]
+
+		a, err := p.Distribution.DescribeArtifact(lp.Version, runtime.GOOS, runtime.GOARCH)
+		if err != nil {
+			return nil, errors.Wrapf(err, "discovery source %q no longer offers plugin '%v:%v'", lp.DiscoverySource, lp.Name, lp.Version)
+		}
+		if a.Digest != "" && a.Digest != digest {
+			return nil, errors.Errorf("discovery source %q offers plugin '%v:%v' with digest %s instead of the locked digest %s", lp.DiscoverySource, lp.Name, lp.Version, a.Digest, digest)
+		}
+
+		// The recommended version is used to verify the trust of the artifact location
+		p.RecommendedVersion = lp.Version
+		p.Distribution = &lockedDistribution{Distribution: p.Distribution, digest: digest}
+		return &p, nil
+	}
+	return nil, errors.Errorf("discovery source %q no longer offers plugin %q", lp.DiscoverySource, lp.Name)
+}
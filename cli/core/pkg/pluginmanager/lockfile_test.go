@@ -0,0 +1,130 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/common"
+)
+
+const emptyBinaryDigest = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func Test_WriteLockFile_SyncPluginsLocked(t *testing.T) {
+	assert := assert.New(t)
+
+	defer setupLocalDistoForTesting()()
+	execCommand = fakeExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	lockFilePath := filepath.Join(t.TempDir(), "plugins.lock")
+
+	err := SyncPlugins("mgmt")
+	assert.Nil(err)
+	err = WriteLockFile("mgmt", lockFilePath)
+	assert.Nil(err)
+
+	lf, err := ReadLockFile(lockFilePath)
+	assert.Nil(err)
+	assert.Equal(3, len(lf.Plugins))
+	// The digests of all the platforms offered by the discovery source are locked
+	digests := map[string]string{
+		"darwin/amd64":  emptyBinaryDigest,
+		"darwin/arm64":  emptyBinaryDigest,
+		"linux/amd64":   emptyBinaryDigest,
+		"windows/amd64": emptyBinaryDigest,
+	}
+	digests[runtime.GOOS+"/"+runtime.GOARCH] = emptyBinaryDigest
+	assert.Equal(LockedPlugin{
+		Name:            "cluster",
+		Scope:           common.PluginScopeContext,
+		Version:         "v0.2.0",
+		Digests:         digests,
+		DiscoverySource: "fake-mgmt",
+	}, lf.Plugins[0])
+	for _, lp := range lf.Plugins[1:] {
+		assert.Equal(common.PluginScopeStandalone, lp.Scope)
+		assert.Contains([]string{"login", "management-cluster"}, lp.Name)
+	}
+
+	// Installing from the lockfile after cleaning up reinstalls the locked plugins
+	err = Clean()
+	assert.Nil(err)
+	err = SyncPluginsLocked("mgmt", lockFilePath)
+	assert.Nil(err)
+	installedServerPlugins, installedStandalonePlugins, err := InstalledPlugins("mgmt")
+	assert.Nil(err)
+	assert.Equal(1, len(installedServerPlugins))
+	assert.Equal(2, len(installedStandalonePlugins))
+}
+
+func Test_SyncPluginsLocked_Failures(t *testing.T) {
+	defer setupLocalDistoForTesting()()
+	execCommand = fakeExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	lockedLogin := LockedPlugin{
+		Name:            "login",
+		Scope:           common.PluginScopeStandalone,
+		Version:         "v0.2.0",
+		DiscoverySource: "fake",
+	}
+	currentPlatform := runtime.GOOS + "/" + runtime.GOARCH
+
+	tcs := []struct {
+		name   string
+		update func(lp *LockedPlugin)
+		errStr string
+	}{
+		{
+			name:   "version no longer offered",
+			update: func(lp *LockedPlugin) { lp.Version = "v0.1.0" },
+			errStr: "discovery source \"fake\" no longer offers plugin 'login:v0.1.0': could not find the artifact for version:v0.1.0, os:" + runtime.GOOS + ", arch:" + runtime.GOARCH,
+		},
+		{
+			name:   "discovery source no longer offers the plugin",
+			update: func(lp *LockedPlugin) { lp.DiscoverySource = "removed" },
+			errStr: "discovery source \"removed\" no longer offers plugin \"login\"",
+		},
+		{
+			name: "digest mismatch",
+			update: func(lp *LockedPlugin) {
+				lp.Digests[currentPlatform] = "f3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+			},
+			errStr: "discovery source \"fake\" offers plugin 'login:v0.2.0' with digest " + emptyBinaryDigest + " instead of the locked digest f3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		{
+			name: "different platform",
+			update: func(lp *LockedPlugin) {
+				lp.Digests = map[string]string{"plan9/" + runtime.GOARCH: emptyBinaryDigest}
+			},
+			errStr: "plugin \"login\" is not locked for the current platform " + currentPlatform,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			lp := lockedLogin
+			lp.Digests = map[string]string{
+				"plan9/" + runtime.GOARCH: "f3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+				currentPlatform:           emptyBinaryDigest,
+			}
+			tc.update(&lp)
+			b, err := yaml.Marshal(LockFile{Plugins: []LockedPlugin{lp}})
+			assert.NoError(t, err)
+			lockFilePath := filepath.Join(t.TempDir(), "plugins.lock")
+			assert.NoError(t, os.WriteFile(lockFilePath, b, 0644))
+
+			err = SyncPluginsLocked("", lockFilePath)
+			assert.EqualError(t, err, tc.errStr)
+		})
+	}
+}
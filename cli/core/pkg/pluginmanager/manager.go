@@ -20,7 +20,6 @@ import (
 	"golang.org/x/mod/semver"
 	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/cli/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/artifact"
@@ -30,6 +29,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/config"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/plugin"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/signature"
 	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
 	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
@@ -77,10 +77,10 @@ func ValidatePlugin(p *cliapi.PluginDescriptor) (err error) {
 	return
 }
 
-func discoverPlugins(pd []configapi.PluginDiscovery) ([]plugin.Discovered, error) {
+func discoverPlugins(pd []configapi.PluginDiscovery, opts ...discovery.Option) ([]plugin.Discovered, error) {
 	allPlugins := make([]plugin.Discovered, 0)
 	for _, d := range pd {
-		discObject, err := discovery.CreateDiscoveryFromV1alpha1(d)
+		discObject, err := discovery.CreateDiscoveryFromV1alpha1(d, opts...)
 		if err != nil {
 			return nil, errors.Wrapf(err, "unable to create discovery")
 		}
@@ -96,7 +96,7 @@ func discoverPlugins(pd []configapi.PluginDiscovery) ([]plugin.Discovered, error
 }
 
 // DiscoverStandalonePlugins returns the available standalone plugins
-func DiscoverStandalonePlugins() (plugins []plugin.Discovered, err error) {
+func DiscoverStandalonePlugins(opts ...discovery.Option) (plugins []plugin.Discovered, err error) {
 	cfg, e := configlib.GetClientConfig()
 	if e != nil {
 		err = errors.Wrapf(e, "unable to get client configuration")
@@ -108,7 +108,7 @@ func DiscoverStandalonePlugins() (plugins []plugin.Discovered, err error) {
 		return
 	}
 
-	plugins, err = discoverPlugins(cfg.ClientOptions.CLI.DiscoverySources)
+	plugins, err = discoverPlugins(cfg.ClientOptions.CLI.DiscoverySources, opts...)
 	if err != nil {
 		return
 	}
@@ -121,7 +121,7 @@ func DiscoverStandalonePlugins() (plugins []plugin.Discovered, err error) {
 }
 
 // DiscoverServerPlugins returns the available plugins associated with the given server
-func DiscoverServerPlugins(serverName string) ([]plugin.Discovered, error) {
+func DiscoverServerPlugins(serverName string, opts ...discovery.Option) ([]plugin.Discovered, error) {
 	plugins := []plugin.Discovered{}
 	if serverName == "" {
 		// If servername is not specified than returning empty list
@@ -130,10 +130,11 @@ func DiscoverServerPlugins(serverName string) ([]plugin.Discovered, error) {
 	}
 
 	discoverySources := configlib.GetDiscoverySources(serverName)
-	plugins, err := discoverPlugins(discoverySources)
+	plugins, err := discoverPlugins(discoverySources, opts...)
 	if err != nil {
 		return plugins, err
 	}
+	refreshServerPluginPolicy(serverName, discoverySources)
 	for i := range plugins {
 		plugins[i].Scope = common.PluginScopeContext
 		plugins[i].Status = common.PluginStatusNotInstalled
@@ -143,13 +144,13 @@ func DiscoverServerPlugins(serverName string) ([]plugin.Discovered, error) {
 
 // DiscoverPlugins returns the available plugins that can be used with the given server
 // If serverName is empty(""), return only standalone plugins
-func DiscoverPlugins(serverName string) ([]plugin.Discovered, []plugin.Discovered) {
-	serverPlugins, err := DiscoverServerPlugins(serverName)
+func DiscoverPlugins(serverName string, opts ...discovery.Option) ([]plugin.Discovered, []plugin.Discovered) {
+	serverPlugins, err := DiscoverServerPlugins(serverName, opts...)
 	if err != nil {
 		log.Warningf("unable to discover server plugins, %v", err.Error())
 	}
 
-	standalonePlugins, err := DiscoverStandalonePlugins()
+	standalonePlugins, err := DiscoverStandalonePlugins(opts...)
 	if err != nil {
 		log.Warningf("unable to discover standalone plugins, %v", err.Error())
 	}
@@ -160,8 +161,8 @@ func DiscoverPlugins(serverName string) ([]plugin.Discovered, []plugin.Discovere
 
 // AvailablePlugins returns the list of available plugins including discovered and installed plugins
 // If serverName is empty(""), return only available standalone plugins
-func AvailablePlugins(serverName string) ([]plugin.Discovered, error) {
-	discoveredServerPlugins, discoveredStandalonePlugins := DiscoverPlugins(serverName)
+func AvailablePlugins(serverName string, opts ...discovery.Option) ([]plugin.Discovered, error) {
+	discoveredServerPlugins, discoveredStandalonePlugins := DiscoverPlugins(serverName, opts...)
 	return availablePlugins(serverName, discoveredServerPlugins, discoveredStandalonePlugins)
 }
 
@@ -437,49 +438,68 @@ func GetRecommendedVersionOfPlugin(serverName, pluginName string) (string, error
 }
 
 func installOrUpgradePlugin(serverName string, p *plugin.Discovered, version string, installTestPlugin bool) error {
-	log.Infof("Installing plugin '%v:%v'", p.Name, version)
+	return installPlugin(&pluginInstallRequest{
+		serverName:        serverName,
+		plugin:            p,
+		version:           version,
+		installTestPlugin: installTestPlugin,
+	})
+}
 
-	binary, err := fetchAndVerifyPlugin(p, version)
+// fetchAndInstallPlugin downloads, verifies and writes the plugin binary to
+// the plugin root directory and returns the plugin descriptor. It does not
+// update the catalog, hence it can be invoked concurrently for different plugins.
+func fetchAndInstallPlugin(r *pluginInstallRequest) (*cliapi.PluginDescriptor, error) {
+	binary, signer, err := fetchAndVerifyPlugin(r)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	descriptor, err := installAndDescribePlugin(p, version, binary)
+	descriptor, err := installAndDescribePlugin(r.plugin, r.version, binary)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	descriptor.Signer = signer
 
-	if installTestPlugin {
-		if err := doInstallTestPlugin(p, version); err != nil {
-			return err
+	if r.installTestPlugin {
+		if err := doInstallTestPlugin(r); err != nil {
+			return nil, err
 		}
 	}
-
-	return updateDescriptorAndInitializePlugin(serverName, p, descriptor)
+	return descriptor, nil
 }
 
-func fetchAndVerifyPlugin(p *plugin.Discovered, version string) ([]byte, error) {
+// fetchAndVerifyPlugin downloads and verifies the plugin binary and returns the
+// binary along with the name of the key that verified its signature, if any.
+func fetchAndVerifyPlugin(r *pluginInstallRequest) ([]byte, string, error) {
+	p, version := r.plugin, r.version
+
 	// verify plugin before download
 	err := verifyPluginPreDownload(p)
 	if err != nil {
-		return nil, errors.Wrapf(err, "%q plugin pre-download verification failed", p.Name)
+		return nil, "", errors.Wrapf(err, "%q plugin pre-download verification failed", p.Name)
 	}
 
 	b, err := p.Distribution.Fetch(version, runtime.GOOS, runtime.GOARCH)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// verify plugin after download but before installation
 	d, err := p.Distribution.GetDigest(version, runtime.GOOS, runtime.GOARCH)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	err = verifyPluginPostDownload(p, d, b)
 	if err != nil {
-		return nil, errors.Wrapf(err, "%q plugin post-download verification failed", p.Name)
+		return nil, "", errors.Wrapf(err, "%q plugin post-download verification failed", p.Name)
+	}
+
+	signer, err := verifyPluginSignature(r, b)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "%q plugin signature verification failed", p.Name)
 	}
-	return b, nil
+	return b, signer, nil
 }
 
 func installAndDescribePlugin(p *plugin.Discovered, version string, binary []byte) (*cliapi.PluginDescriptor, error) {
@@ -512,8 +532,9 @@ func installAndDescribePlugin(p *plugin.Discovered, version string, binary []byt
 	return &descriptor, nil
 }
 
-func doInstallTestPlugin(p *plugin.Discovered, version string) error {
-	log.Infof("Installing test plugin for '%v:%v'", p.Name, version)
+func doInstallTestPlugin(r *pluginInstallRequest) error {
+	p, version := r.plugin, r.version
+	r.infof("Installing test plugin for '%v:%v'", p.Name, version)
 	binary, err := p.Distribution.FetchTest(version, runtime.GOOS, runtime.GOARCH)
 	if err != nil {
 		return errors.Wrapf(err, "unable to install test plugin for '%v:%v'", p.Name, version)
@@ -527,13 +548,9 @@ func doInstallTestPlugin(p *plugin.Discovered, version string) error {
 }
 
 func updateDescriptorAndInitializePlugin(serverName string, p *plugin.Discovered, descriptor *cliapi.PluginDescriptor) error {
-	c, err := catalog.NewContextCatalog(serverName)
-	if err != nil {
+	if err := upsertCatalogDescriptor(serverName, descriptor); err != nil {
 		return err
 	}
-	if err := c.Upsert(descriptor); err != nil {
-		log.Info("Plugin descriptor could not be updated in cache")
-	}
 	if err := InitializePlugin(serverName, p.Name); err != nil {
 		log.Infof("could not initialize plugin after installing: %v", err.Error())
 	}
@@ -543,6 +560,23 @@ func updateDescriptorAndInitializePlugin(serverName string, p *plugin.Discovered
 	return nil
 }
 
+// upsertCatalogDescriptor updates the plugin descriptor in the catalog of the server.
+// The catalog is read and rewritten while holding the tanzu config lock so that
+// concurrent installations, in this or another process, don't overwrite each other's updates.
+func upsertCatalogDescriptor(serverName string, descriptor *cliapi.PluginDescriptor) error {
+	configlib.AcquireTanzuConfigLock()
+	defer configlib.ReleaseTanzuConfigLock()
+
+	c, err := catalog.NewContextCatalog(serverName)
+	if err != nil {
+		return err
+	}
+	if err := c.Upsert(descriptor); err != nil {
+		log.Info("Plugin descriptor could not be updated in cache")
+	}
+	return nil
+}
+
 // DeletePlugin deletes a plugin.
 // If serverName is empty(""), only consider standalone plugins
 func DeletePlugin(options DeletePluginOptions) error {
@@ -572,31 +606,28 @@ func DeletePlugin(options DeletePluginOptions) error {
 
 // SyncPlugins automatically downloads all available plugins to users machine
 // If serverName is empty(""), only sync standalone plugins
-func SyncPlugins(serverName string) error {
+func SyncPlugins(serverName string, opts ...discovery.Option) error {
 	log.Info("Checking for required plugins...")
-	plugins, err := AvailablePlugins(serverName)
+	if err := validateDiscoverySources(serverName, opts...); err != nil {
+		return err
+	}
+	plugins, err := AvailablePlugins(serverName, opts...)
 	if err != nil {
 		return err
 	}
 
-	installed := false
-
-	errList := make([]error, 0)
+	var requests []pluginInstallRequest
 	for idx := range plugins {
 		if plugins[idx].Status != common.PluginStatusInstalled {
-			installed = true
-			err = InstallPlugin(serverName, plugins[idx].Name, plugins[idx].RecommendedVersion)
-			if err != nil {
-				errList = append(errList, err)
-			}
+			requests = append(requests, newPluginInstallRequest(serverName, &plugins[idx], plugins[idx].RecommendedVersion, false))
 		}
 	}
-	err = kerrors.NewAggregate(errList)
+	err = installPlugins(requests)
 	if err != nil {
 		return err
 	}
 
-	if !installed {
+	if len(requests) == 0 {
 		log.Info("All required plugins are already installed and up-to-date")
 	} else {
 		log.Info("Successfully installed all required plugins")
@@ -604,6 +635,31 @@ func SyncPlugins(serverName string) error {
 	return nil
 }
 
+// validateDiscoverySources verifies that the standalone discovery sources and the
+// discovery sources of the server can be used with the given discovery options.
+// The plugin discovery only warns about the discovery sources that cannot be used,
+// which would silently skip them, e.g. in offline mode.
+func validateDiscoverySources(serverName string, opts ...discovery.Option) error {
+	var discoverySources []configapi.PluginDiscovery
+	if serverName != "" {
+		discoverySources = configlib.GetDiscoverySources(serverName)
+	}
+	cfg, err := configlib.GetClientConfig()
+	if err != nil {
+		return errors.Wrapf(err, "unable to get client configuration")
+	}
+	if cfg != nil && cfg.ClientOptions != nil && cfg.ClientOptions.CLI != nil {
+		discoverySources = append(discoverySources, cfg.ClientOptions.CLI.DiscoverySources...)
+	}
+
+	for _, ds := range discoverySources {
+		if _, err := discovery.CreateDiscoveryFromV1alpha1(ds, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // InstallPluginsFromLocalSource installs plugin from local source directory
 func InstallPluginsFromLocalSource(pluginName, version, localPath string, installTestPlugin bool) error {
 	// Set default local plugin distro to localpath as while installing the plugin
@@ -615,23 +671,17 @@ func InstallPluginsFromLocalSource(pluginName, version, localPath string, instal
 		return errors.Wrap(err, "unable to discover plugins")
 	}
 
-	found := false
-
-	errList := make([]error, 0)
+	var requests []pluginInstallRequest
 	for idx := range plugins {
 		if pluginName == cli.AllPlugins || pluginName == plugins[idx].Name {
-			found = true
-			err := installOrUpgradePlugin("", &plugins[idx], plugins[idx].RecommendedVersion, installTestPlugin)
-			if err != nil {
-				errList = append(errList, err)
-			}
+			requests = append(requests, newPluginInstallRequest("", &plugins[idx], plugins[idx].RecommendedVersion, installTestPlugin))
 		}
 	}
-	err = kerrors.NewAggregate(errList)
+	err = installPlugins(requests)
 	if err != nil {
 		return err
 	}
-	if !found {
+	if len(requests) == 0 {
 		return errors.Errorf("unable to find plugin '%v'", pluginName)
 	}
 	return nil
@@ -704,6 +754,15 @@ func Clean() error {
 	return os.RemoveAll(common.DefaultPluginRoot)
 }
 
+// CleanCache deletes all the artifacts from the plugin artifact cache
+func CleanCache() error {
+	cache, err := artifact.NewCache()
+	if err != nil {
+		return err
+	}
+	return cache.Clean()
+}
+
 // getCLIPluginResourceWithLocalDistroFromPluginDescriptor return cliv1alpha1.CLIPlugin resource from the plugin descriptor
 // Note: This function generates cliv1alpha1.CLIPlugin which contains only single local distribution type artifact for
 // OS-ARCH where user is running the cli
@@ -892,3 +951,44 @@ func verifyPluginPostDownload(p *plugin.Discovered, srcDigest string, b []byte)
 
 	return nil
 }
+
+// verifyPluginSignature verifies the detached signature of the downloaded plugin
+// binary against the configured public keys and returns the name of the key that
+// verified the signature. Verification is skipped if no public keys are configured.
+// Plugins which are unsigned or whose signature is invalid are refused unless
+// unsigned plugins are explicitly allowed.
+func verifyPluginSignature(r *pluginInstallRequest, b []byte) (string, error) {
+	p, version := r.plugin, r.version
+	opts, err := signature.GetOptions()
+	if err != nil {
+		return "", err
+	}
+	if opts == nil || len(opts.PublicKeys) == 0 {
+		return "", nil
+	}
+	allowUnsigned := signature.IsUnsignedAllowed(opts)
+
+	verifier, err := signature.NewVerifier(opts.PublicKeys)
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := p.Distribution.FetchSignature(version, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		if allowUnsigned {
+			log.Warningf("installing unsigned plugin '%v:%v': %v", p.Name, version, err.Error())
+			return "", nil
+		}
+		return "", errors.Wrap(err, "unable to fetch plugin signature")
+	}
+
+	signer, err := verifier.Verify(b, sig)
+	if err != nil {
+		if allowUnsigned {
+			log.Warningf("installing plugin '%v:%v' with unverified signature: %v", p.Name, version, err.Error())
+			return "", nil
+		}
+		return "", err
+	}
+	return signer, nil
+}
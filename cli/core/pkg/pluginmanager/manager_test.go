@@ -4,10 +4,18 @@
 package pluginmanager
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/aunum/log"
@@ -19,6 +27,8 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/plugin"
 	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	configlib "github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
 )
 
 const (
@@ -189,6 +199,8 @@ func Test_AvailablePlugins_From_LocalSource(t *testing.T) {
 func Test_InstallPlugin_InstalledPlugins_From_LocalSource(t *testing.T) {
 	assert := assert.New(t)
 
+	defer setupLocalDistoForTesting()()
+
 	execCommand = fakeExecCommand
 	defer func() { execCommand = exec.Command }()
 
@@ -610,6 +622,8 @@ func Test_DiscoverPluginsFromLocalSourceWithLegacyDirectoryStructure(t *testing.
 func Test_InstallPluginsFromLocalSourceWithLegacyDirectoryStructure(t *testing.T) {
 	assert := assert.New(t)
 
+	defer setupLocalDistoForTesting()()
+
 	execCommand = fakeExecCommand
 	defer func() { execCommand = exec.Command }()
 
@@ -776,3 +790,153 @@ func TestVerifyPluginPostDownload(t *testing.T) {
 		})
 	}
 }
+
+func Test_InstallPlugin_WithSignatureVerification(t *testing.T) {
+	assert := assert.New(t)
+
+	defer setupLocalDistoForTesting()()
+	execCommand = fakeExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(err)
+	configurePluginSignatureKey(assert, "test-key", &key.PublicKey)
+
+	// Unsigned plugins are refused
+	err = InstallPlugin("", "login", "v0.2.0")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "\"login\" plugin signature verification failed")
+
+	// Unsigned plugins are installed when explicitly allowed
+	os.Setenv(constants.AllowUnsignedPlugins, "true")
+	err = InstallPlugin("", "login", "v0.2.0")
+	os.Unsetenv(constants.AllowUnsignedPlugins)
+	assert.Nil(err)
+	descriptor, err := DescribePlugin("", "login")
+	assert.Nil(err)
+	assert.Equal("", descriptor.Signer)
+
+	// Sign the plugin binary with a detached signature next to the binary
+	binaryPath := filepath.Join(common.DefaultLocalPluginDistroDir, "distribution", "v0.2.0", fmt.Sprintf("tanzu-login-%s_%s", runtime.GOOS, runtime.GOARCH))
+	b, err := os.ReadFile(binaryPath)
+	assert.Nil(err)
+	digest := sha256.Sum256(b)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	assert.Nil(err)
+	err = os.WriteFile(binaryPath+".sig", []byte(base64.StdEncoding.EncodeToString(sig)), 0644)
+	assert.Nil(err)
+
+	err = InstallPlugin("", "login", "v0.2.0")
+	assert.Nil(err)
+	descriptor, err = DescribePlugin("", "login")
+	assert.Nil(err)
+	assert.Equal("test-key", descriptor.Signer)
+
+	// Signatures from untrusted keys are refused
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(err)
+	configurePluginSignatureKey(assert, "other-key", &otherKey.PublicKey)
+	err = InstallPlugin("", "login", "v0.2.0")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "signature could not be verified with any of the configured public keys")
+}
+
+func configurePluginSignatureKey(assert *assert.Assertions, name string, key *ecdsa.PublicKey) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	assert.Nil(err)
+
+	configlib.AcquireTanzuConfigLock()
+	defer configlib.ReleaseTanzuConfigLock()
+	cfg, err := configlib.GetClientConfigNoLock()
+	assert.Nil(err)
+	cfg.ClientOptions.CLI.PluginSignature = &configapi.PluginSignatureOptions{
+		PublicKeys: []configapi.PluginSigningKey{
+			{
+				Name: name,
+				Data: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+			},
+		},
+	}
+	assert.Nil(configlib.StoreClientConfig(cfg))
+}
+
+func Test_SyncPlugins_AggregatesPluginErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	defer setupLocalDistoForTesting()()
+	execCommand = fakeExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	os.Setenv(constants.PluginInstallConcurrency, "2")
+	defer os.Unsetenv(constants.PluginInstallConcurrency)
+
+	discovered, err := AvailablePlugins("mgmt")
+	assert.Nil(err)
+	assert.Equal(3, len(discovered))
+
+	// Request a version of the cluster plugin which does not exist
+	var requests []pluginInstallRequest
+	for i := range discovered {
+		version := discovered[i].RecommendedVersion
+		if discovered[i].Name == "cluster" {
+			version = "v9.9.9"
+		}
+		requests = append(requests, newPluginInstallRequest("mgmt", &discovered[i], version, false))
+	}
+
+	err = installPlugins(requests)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "unable to install plugin 'cluster:v9.9.9'")
+
+	// The remaining plugins are installed despite the failure
+	installedServerPlugins, installedStandalonePlugins, err := InstalledPlugins("mgmt")
+	assert.Nil(err)
+	assert.Equal(0, len(installedServerPlugins))
+	assert.Equal(2, len(installedStandalonePlugins))
+}
+
+func Test_InstallPlugin_WithPluginPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	defer setupLocalDistoForTesting()()
+	execCommand = fakeExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// Plugin versions denied by the client configuration are refused
+	configlib.AcquireTanzuConfigLock()
+	cfg, err := configlib.GetClientConfigNoLock()
+	assert.Nil(err)
+	cfg.ClientOptions.CLI.PluginPolicy = &configapi.PluginPolicy{
+		Rules: []configapi.PluginPolicyRule{
+			{Name: "no-old-login", Action: configapi.PluginPolicyActionDeny, Plugin: "login", Versions: "< 0.20.0"},
+		},
+	}
+	assert.Nil(configlib.StoreClientConfig(cfg))
+	configlib.ReleaseTanzuConfigLock()
+
+	err = InstallPlugin("", "login", "v0.2.0")
+	assert.EqualError(err, "plugin 'login:v0.2.0' is denied by rule \"no-old-login\" of the plugin policy of the client configuration")
+	_, err = DescribePlugin("", "login")
+	assert.NotNil(err)
+
+	// Plugins denied by the policy served by the current server are refused during sync
+	err = config.StoreServerPluginPolicy("mgmt", &configapi.PluginPolicy{
+		DefaultAction: configapi.PluginPolicyActionDeny,
+		Rules: []configapi.PluginPolicyRule{
+			{Name: "tmc-cluster", Action: configapi.PluginPolicyActionAllow, Plugin: "cluster", Target: configapi.CtxTypeTMC, DiscoverySource: "fake-*"},
+		},
+	})
+	assert.Nil(err)
+
+	err = SyncPlugins("mgmt")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "plugin 'login:v0.2.0' is denied by rule \"no-old-login\" of the plugin policy of the client configuration")
+	assert.Contains(err.Error(), "plugin 'management-cluster:v0.2.0' does not match any rule and is denied by default by the plugin policy of server \"mgmt\"")
+	assert.NotContains(err.Error(), "'cluster:")
+
+	installedServerPlugins, installedStandalonePlugins, err := InstalledPlugins("mgmt")
+	assert.Nil(err)
+	assert.Equal(1, len(installedServerPlugins))
+	assert.Equal("cluster", installedServerPlugins[0].Name)
+	assert.Equal(0, len(installedStandalonePlugins))
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"github.com/aunum/log"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/cluster"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/config"
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/discovery"
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+)
+
+// refreshServerPluginPolicy caches the plugin policy served by the kubernetes
+// discovery sources of the server. The previously cached policy is kept if
+// the server has no kubernetes discovery source or if the policy cannot be
+// fetched, so that it is still enforced when the server is unreachable.
+func refreshServerPluginPolicy(serverName string, discoverySources []configapi.PluginDiscovery) {
+	var policy *configapi.PluginPolicy
+	refreshed := false
+	for _, ds := range discoverySources {
+		if ds.Kubernetes == nil {
+			continue
+		}
+		refreshed = true
+		kd := discovery.NewKubernetesDiscovery(ds.Kubernetes.Name, ds.Kubernetes.Path, ds.Kubernetes.Context).(*discovery.KubernetesDiscovery)
+		p, err := kd.Policy()
+		if err != nil {
+			log.Warningf("unable to get the plugin policy from discovery '%v': %v", kd.Name(), err.Error())
+			return
+		}
+		policy = cluster.MergePluginPolicies(policy, p)
+	}
+	if !refreshed {
+		return
+	}
+
+	if err := config.StoreServerPluginPolicy(serverName, policy); err != nil {
+		log.Warningf("unable to cache the plugin policy of server '%v': %v", serverName, err.Error())
+	}
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/cppforlife/go-cli-ui/ui"
 	regname "github.com/google/go-containerregistry/pkg/name"
 	regv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/k14s/imgpkg/pkg/imgpkg/bundle"
 	"github.com/k14s/imgpkg/pkg/imgpkg/cmd"
+	"github.com/k14s/imgpkg/pkg/imgpkg/plainimage"
 	ctlimg "github.com/k14s/imgpkg/pkg/imgpkg/registry"
 	"github.com/pkg/errors"
 )
@@ -182,3 +184,29 @@ func (r *registry) DownloadBundle(imageName, outputDir string) error {
 
 	return pullOptions.Run()
 }
+
+// PushImage pushes the file as a plain OCI image similar to `imgpkg push -i`
+// and returns the digest reference of the pushed image.
+func (r *registry) PushImage(imageWithTag, filePath string) (string, error) {
+	ref, err := regname.NewTag(imageWithTag, regname.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+	return plainimage.NewContents([]string{filePath}, nil).Push(ref, nil, r.registry, newNoopUI())
+}
+
+// PushBundle pushes the directory as an OCI bundle similar to `imgpkg push -b`
+// and returns the digest reference of the pushed bundle.
+func (r *registry) PushBundle(imageWithTag, inputDir string) (string, error) {
+	ref, err := regname.NewTag(imageWithTag, regname.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+	return bundle.NewContents([]string{inputDir}, nil).Push(ref, r.registry, newNoopUI())
+}
+
+// newNoopUI returns a UI discarding the logs of the imgpkg operations
+func newNoopUI() ui.UI {
+	var outputBuf, errorBuf bytes.Buffer
+	return ui.NewWriterUI(&outputBuf, &errorBuf, nil)
+}
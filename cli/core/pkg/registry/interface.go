@@ -17,4 +17,11 @@ type Registry interface {
 	// DownloadBundle downloads OCI bundle similar to `imgpkg pull -b` command
 	// It is recommended to use this function when downloading imgpkg bundle
 	DownloadBundle(imageName, outputDir string) error
+	// PushImage pushes the file as a plain OCI image similar to `imgpkg push -i`
+	// and returns the digest reference of the pushed image.
+	PushImage(imageWithTag, filePath string) (string, error)
+	// PushBundle pushes the directory as an OCI bundle similar to `imgpkg push -b`
+	// and returns the digest reference of the pushed bundle.
+	// The directory must contain the `.imgpkg/images.yml` ImagesLock file
+	PushBundle(imageWithTag, inputDir string) (string, error)
 }
@@ -0,0 +1,40 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package signature
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/core/pkg/constants"
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	configlib "github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+)
+
+// GetOptions returns the configured plugin signature verification options.
+// It returns nil if plugin signature verification is not configured and an error
+// if the client configuration cannot be read, so that the callers do not skip the
+// verification because of an unreadable configuration
+func GetOptions() (*configapi.PluginSignatureOptions, error) {
+	cfg, err := configlib.GetClientConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the plugin signature options")
+	}
+	if cfg == nil || cfg.ClientOptions == nil || cfg.ClientOptions.CLI == nil {
+		return nil, nil
+	}
+	return cfg.ClientOptions.CLI.PluginSignature, nil
+}
+
+// IsUnsignedAllowed returns true if plugins can be installed even when their
+// signature is missing or cannot be verified. This can be allowed either through the
+// client configuration or through the TANZU_CLI_ALLOW_UNSIGNED_PLUGINS environment variable
+func IsUnsignedAllowed(opts *configapi.PluginSignatureOptions) bool {
+	if allowUnsigned, err := strconv.ParseBool(os.Getenv(constants.AllowUnsignedPlugins)); err == nil {
+		return allowUnsigned
+	}
+	return opts != nil && opts.AllowUnsigned
+}
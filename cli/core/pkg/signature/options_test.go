@@ -0,0 +1,39 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package signature
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOptions(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("TANZU_CONFIG", configFile)
+
+	require.NoError(t, os.WriteFile(configFile, []byte(`apiVersion: config.tanzu.vmware.com/v1alpha1
+kind: ClientConfig
+metadata:
+  creationTimestamp: null
+clientOptions:
+  cli:
+    pluginSignature:
+      publicKeys:
+      - name: default
+        path: /etc/tanzu/cosign.pub
+`), 0600))
+	opts, err := GetOptions()
+	require.NoError(t, err)
+	require.NotNil(t, opts)
+	assert.Equal(t, "default", opts.PublicKeys[0].Name)
+
+	require.NoError(t, os.WriteFile(configFile, []byte("clientOptions: [invalid"), 0600))
+	opts, err = GetOptions()
+	assert.ErrorContains(t, err, "unable to read the plugin signature options")
+	assert.Nil(t, opts)
+}
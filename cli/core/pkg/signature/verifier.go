@@ -0,0 +1,123 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package signature implements the verification of detached plugin binary signatures
+// The signatures are compatible with the ones produced by `cosign sign-blob --key`,
+// i.e. a (base64 encoded) signature of the SHA256 digest of the binary.
+package signature
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+
+	"github.com/pkg/errors"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+)
+
+// publicKey is a trusted public key along with its name
+type publicKey struct {
+	name string
+	key  crypto.PublicKey
+}
+
+// Verifier verifies the detached signatures of plugin binaries against a set
+// of trusted public keys
+type Verifier struct {
+	keys []publicKey
+}
+
+// NewVerifier creates a Verifier trusting the given public keys
+func NewVerifier(signingKeys []configapi.PluginSigningKey) (*Verifier, error) {
+	v := &Verifier{}
+	for i := range signingKeys {
+		key, err := loadPublicKey(&signingKeys[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to load plugin signing key %q", signingKeys[i].Name)
+		}
+		v.keys = append(v.keys, publicKey{name: signingKeys[i].Name, key: key})
+	}
+	return v, nil
+}
+
+// Verify verifies the detached signature of the binary and returns the name
+// of the public key that verified the signature
+func (v *Verifier) Verify(binary, signature []byte) (string, error) {
+	if len(v.keys) == 0 {
+		return "", errors.New("no public keys are configured to verify the signature")
+	}
+	sig := decodeSignature(signature)
+	digest := sha256.Sum256(binary)
+
+	for _, k := range v.keys {
+		if verifyWithKey(k.key, binary, digest[:], sig) {
+			return k.name, nil
+		}
+	}
+	return "", errors.New("signature could not be verified with any of the configured public keys")
+}
+
+func loadPublicKey(sk *configapi.PluginSigningKey) (crypto.PublicKey, error) {
+	if sk.Path != "" && sk.Data != "" {
+		return nil, errors.New("only one of path or data can be set")
+	}
+	if sk.Path == "" && sk.Data == "" {
+		return nil, errors.New("one of path or data must be set")
+	}
+	data := []byte(sk.Data)
+	if sk.Path != "" {
+		b, err := os.ReadFile(sk.Path)
+		if err != nil {
+			return nil, err
+		}
+		data = b
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, errors.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// decodeSignature returns the raw signature. Signatures are accepted both
+// base64 encoded (as produced by cosign) and raw.
+func decodeSignature(signature []byte) []byte {
+	trimmed := bytes.TrimSpace(signature)
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(trimmed)))
+	n, err := base64.StdEncoding.Decode(decoded, trimmed)
+	if err != nil {
+		return signature
+	}
+	return decoded[:n]
+}
+
+func verifyWithKey(key crypto.PublicKey, binary, digest, sig []byte) bool {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, digest, sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest, sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, binary, sig)
+	}
+	return false
+}
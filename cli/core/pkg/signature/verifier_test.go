@@ -0,0 +1,138 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+)
+
+var testBinary = []byte("tanzu-foo plugin binary")
+
+func encodePublicKey(t *testing.T, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestVerify(t *testing.T) {
+	digest := sha256.Sum256(testBinary)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecSig, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaSig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edSig := ed25519.Sign(edKey, testBinary)
+
+	keyFile := filepath.Join(t.TempDir(), "cosign.pub")
+	require.NoError(t, os.WriteFile(keyFile, []byte(encodePublicKey(t, &ecKey.PublicKey)), 0600))
+
+	keys := []configapi.PluginSigningKey{
+		{Name: "ecdsa", Path: keyFile},
+		{Name: "rsa", Data: encodePublicKey(t, &rsaKey.PublicKey)},
+		{Name: "ed25519", Data: encodePublicKey(t, edPub)},
+	}
+	v, err := NewVerifier(keys)
+	require.NoError(t, err)
+
+	tcs := []struct {
+		name   string
+		binary []byte
+		sig    []byte
+		signer string
+		err    string
+	}{
+		{
+			name:   "ecdsa base64 signature",
+			binary: testBinary,
+			sig:    []byte(base64.StdEncoding.EncodeToString(ecSig) + "\n"),
+			signer: "ecdsa",
+		},
+		{
+			name:   "ecdsa raw signature",
+			binary: testBinary,
+			sig:    ecSig,
+			signer: "ecdsa",
+		},
+		{
+			name:   "rsa signature",
+			binary: testBinary,
+			sig:    []byte(base64.StdEncoding.EncodeToString(rsaSig)),
+			signer: "rsa",
+		},
+		{
+			name:   "ed25519 signature",
+			binary: testBinary,
+			sig:    []byte(base64.StdEncoding.EncodeToString(edSig)),
+			signer: "ed25519",
+		},
+		{
+			name:   "tampered binary",
+			binary: []byte("tampered plugin binary"),
+			sig:    []byte(base64.StdEncoding.EncodeToString(ecSig)),
+			err:    "signature could not be verified with any of the configured public keys",
+		},
+		{
+			name:   "invalid signature",
+			binary: testBinary,
+			sig:    []byte("invalid"),
+			err:    "signature could not be verified with any of the configured public keys",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := v.Verify(tc.binary, tc.sig)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.signer, signer)
+		})
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	_, err := NewVerifier([]configapi.PluginSigningKey{{Name: "bad", Data: "not a key"}})
+	assert.EqualError(t, err, "unable to load plugin signing key \"bad\": no PEM encoded public key found")
+
+	_, err = NewVerifier([]configapi.PluginSigningKey{{Name: "missing", Path: filepath.Join(t.TempDir(), "missing.pub")}})
+	assert.ErrorContains(t, err, "unable to load plugin signing key \"missing\"")
+
+	_, err = NewVerifier([]configapi.PluginSigningKey{{Name: "both", Path: filepath.Join(t.TempDir(), "cosign.pub"), Data: "key"}})
+	assert.EqualError(t, err, "unable to load plugin signing key \"both\": only one of path or data can be set")
+
+	_, err = NewVerifier([]configapi.PluginSigningKey{{Name: "none"}})
+	assert.EqualError(t, err, "unable to load plugin signing key \"none\": one of path or data must be set")
+
+	v, err := NewVerifier(nil)
+	assert.NoError(t, err)
+	_, err = v.Verify(testBinary, []byte("sig"))
+	assert.EqualError(t, err, "no public keys are configured to verify the signature")
+}
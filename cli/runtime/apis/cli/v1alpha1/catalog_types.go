@@ -154,6 +154,10 @@ type PluginDescriptor struct {
 	// DiscoveredRecommendedVersion specifies the recommended version of the plugin that was discovered
 	DiscoveredRecommendedVersion string `json:"discoveredRecommendedVersion"`
 
+	// Signer is the name of the public key which verified the signature of the plugin binary.
+	// Empty if the plugin signature was not verified.
+	Signer string `json:"signer,omitempty" yaml:"signer,omitempty"`
+
 	// PostInstallHook is function to be run post install of a plugin.
 	PostInstallHook Hook `json:"-" yaml:"-"`
 
@@ -136,6 +136,10 @@ type GlobalServerAuth struct {
 
 	// Type of the token (user or client).
 	Type string `json:"type" yaml:"type"`
+
+	// CredentialRef is the reference to the tokens kept in the credential store.
+	// The tokens are not persisted in the configuration file when it is set.
+	CredentialRef string `json:"credentialRef,omitempty" yaml:"credentialRef"`
 }
 
 // ClientOptions are the client specific options.
@@ -171,6 +175,75 @@ type CLIOptions struct {
 	// CompatibilityFilePath is the path, from the BOM repo, to download and access the compatibility file.
 	// the compatibility file is used for resolving the bill of materials for creating clusters.
 	CompatibilityFilePath string `json:"compatibilityFilePath,omitempty" yaml:"compatibilityFilePath"`
+	// PluginSignature configures the verification of plugin binary signatures
+	// before the plugins are installed
+	PluginSignature *PluginSignatureOptions `json:"pluginSignature,omitempty" yaml:"pluginSignature"`
+	// CredentialStore is the name of the credential helper storing the tokens,
+	// i.e. the `tanzu-credential-<name>` binary, or `file` for the built-in file
+	// store, whose encryption key is kept next to the credentials. The tokens are
+	// kept in the configuration file if it is not set.
+	CredentialStore string `json:"credentialStore,omitempty" yaml:"credentialStore"`
+	// PluginPolicy restricts the plugins and versions which can be installed and run
+	PluginPolicy *PluginPolicy `json:"pluginPolicy,omitempty" yaml:"pluginPolicy"`
+}
+
+// PluginPolicyAction is the action of a plugin policy rule.
+type PluginPolicyAction string
+
+const (
+	// PluginPolicyActionAllow allows the matching plugins.
+	PluginPolicyActionAllow PluginPolicyAction = "allow"
+	// PluginPolicyActionDeny denies the matching plugins.
+	PluginPolicyActionDeny PluginPolicyAction = "deny"
+)
+
+// PluginPolicy restricts the plugins and versions which can be installed and
+// run. The first rule matching a plugin decides whether the plugin is allowed.
+type PluginPolicy struct {
+	// DefaultAction applies to the plugins not matching any rule. Defaults to allow.
+	DefaultAction PluginPolicyAction `json:"defaultAction,omitempty" yaml:"defaultAction"`
+	// Rules are the ordered allow and deny rules.
+	Rules []PluginPolicyRule `json:"rules,omitempty" yaml:"rules"`
+}
+
+// PluginPolicyRule allows or denies the plugins matching all of its criteria.
+// Empty criteria match any plugin.
+type PluginPolicyRule struct {
+	// Name of the rule, reported when the rule denies a plugin.
+	Name string `json:"name" yaml:"name"`
+	// Action of the rule. Either allow or deny.
+	Action PluginPolicyAction `json:"action" yaml:"action"`
+	// Plugin is the name of the plugin. Shell file name patterns are supported, e.g. `cluster*`.
+	Plugin string `json:"plugin,omitempty" yaml:"plugin"`
+	// Target is the type of the context the plugin is used with, e.g. k8s or tmc.
+	// Standalone plugins are not associated with any target.
+	Target ContextType `json:"target,omitempty" yaml:"target"`
+	// Versions is a semver range of the plugin versions, e.g. `>= 0.25.0, < 0.28.0`.
+	Versions string `json:"versions,omitempty" yaml:"versions"`
+	// DiscoverySource is the name of the discovery source of the plugin.
+	// Shell file name patterns are supported.
+	DiscoverySource string `json:"discoverySource,omitempty" yaml:"discoverySource"`
+}
+
+// PluginSignatureOptions are the options used to verify the signature of the
+// plugin binaries. Verification is enforced once at least one public key is configured.
+type PluginSignatureOptions struct {
+	// PublicKeys are the keys trusted to sign plugin binaries.
+	PublicKeys []PluginSigningKey `json:"publicKeys,omitempty" yaml:"publicKeys"`
+	// AllowUnsigned allows the installation of plugins which are not signed or
+	// whose signature could not be verified.
+	AllowUnsigned bool `json:"allowUnsigned,omitempty" yaml:"allowUnsigned"`
+}
+
+// PluginSigningKey is a public key trusted to sign plugin binaries. Only one of
+// Path or Data must be set.
+type PluginSigningKey struct {
+	// Name of the key. It is recorded as the signer of the verified plugins.
+	Name string `json:"name" yaml:"name"`
+	// Path to a PEM encoded public key.
+	Path string `json:"path,omitempty" yaml:"path"`
+	// Data is a PEM encoded public key.
+	Data string `json:"data,omitempty" yaml:"data"`
 }
 
 // PluginDiscovery contains a specific distribution mechanism. Only one of the
@@ -182,6 +255,8 @@ type PluginDiscovery struct {
 	OCI *OCIDiscovery `json:"oci,omitempty"`
 	// GenericRESTDiscovery is set if the plugins are to be discovered via a REST API endpoint.
 	REST *GenericRESTDiscovery `json:"rest,omitempty"`
+	// HTTPDiscovery is set if the plugins are to be discovered via an inventory index hosted on an HTTP server.
+	HTTP *HTTPDiscovery `json:"http,omitempty"`
 	// KubernetesDiscovery is set if the plugins are to be discovered via the Kubernetes API server.
 	Kubernetes *KubernetesDiscovery `json:"k8s,omitempty"`
 	// LocalDiscovery is set if the plugins are to be discovered via Local Manifest fast.
@@ -230,6 +305,21 @@ type GenericRESTDiscovery struct {
 	BasePath string `json:"basePath"`
 }
 
+// HTTPDiscovery provides a plugin discovery mechanism via a static inventory
+// index hosted on any HTTP server or artifact repository. The index is a JSON
+// or YAML document listing the plugins along with the artifacts of every
+// supported version. Relative artifact URIs are resolved against the URL of the index.
+type HTTPDiscovery struct {
+	// Name is a name of the discovery
+	Name string `json:"name"`
+	// URL of the inventory index.
+	// E.g., https://artifactory.my-domain.local/tanzu-cli/plugins/index.yaml
+	URL string `json:"url"`
+	// RefreshInterval is the duration for which the cached index is used
+	// without checking the server for updates. Defaults to 1h.
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
 // KubernetesDiscovery provides a plugin discovery mechanism via the Kubernetes API server.
 type KubernetesDiscovery struct {
 	// Name is a name of the discovery
@@ -9,6 +9,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -29,6 +30,16 @@ func (in *CLIOptions) DeepCopyInto(out *CLIOptions) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PluginSignature != nil {
+		in, out := &in.PluginSignature, &out.PluginSignature
+		*out = new(PluginSignatureOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginPolicy != nil {
+		in, out := &in.PluginPolicy, &out.PluginPolicy
+		*out = new(PluginPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CLIOptions.
@@ -347,6 +358,26 @@ func (in *GlobalServerAuth) DeepCopy() *GlobalServerAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPDiscovery) DeepCopyInto(out *HTTPDiscovery) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPDiscovery.
+func (in *HTTPDiscovery) DeepCopy() *HTTPDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesDiscovery) DeepCopyInto(out *KubernetesDiscovery) {
 	*out = *in
@@ -425,6 +456,11 @@ func (in *PluginDiscovery) DeepCopyInto(out *PluginDiscovery) {
 		*out = new(GenericRESTDiscovery)
 		**out = **in
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Kubernetes != nil {
 		in, out := &in.Kubernetes, &out.Kubernetes
 		*out = new(KubernetesDiscovery)
@@ -447,6 +483,41 @@ func (in *PluginDiscovery) DeepCopy() *PluginDiscovery {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginPolicy) DeepCopyInto(out *PluginPolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PluginPolicyRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginPolicy.
+func (in *PluginPolicy) DeepCopy() *PluginPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginPolicyRule) DeepCopyInto(out *PluginPolicyRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginPolicyRule.
+func (in *PluginPolicyRule) DeepCopy() *PluginPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PluginRepository) DeepCopyInto(out *PluginRepository) {
 	*out = *in
@@ -467,6 +538,41 @@ func (in *PluginRepository) DeepCopy() *PluginRepository {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginSignatureOptions) DeepCopyInto(out *PluginSignatureOptions) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]PluginSigningKey, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSignatureOptions.
+func (in *PluginSignatureOptions) DeepCopy() *PluginSignatureOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginSignatureOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginSigningKey) DeepCopyInto(out *PluginSigningKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSigningKey.
+func (in *PluginSigningKey) DeepCopy() *PluginSigningKey {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginSigningKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Server) DeepCopyInto(out *Server) {
 	*out = *in
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -20,11 +22,20 @@ type OutputWriterSpinner interface {
 	StopSpinner()
 }
 
+// OutputWriterSpinnerWithText is OutputWriterSpinner whose spinner text can be
+// updated and which can print output while the spinner is running.
+type OutputWriterSpinnerWithText interface {
+	OutputWriterSpinner
+	SetSpinnerText(spinnerText string)
+	Printf(format string, a ...interface{})
+}
+
 // outputwriterspinner is our internal implementation.
 type outputwriterspinner struct {
 	outputwriter
 	spinnerText string
 	spinner     *spinner.Spinner
+	printMutex  sync.Mutex
 }
 
 // NewOutputWriterWithSpinner returns implementation of OutputWriterSpinner.
@@ -66,3 +77,34 @@ func (ows *outputwriterspinner) StopSpinner() {
 		fmt.Fprintln(ows.out)
 	}
 }
+
+// SetSpinnerText updates the text displayed next to the spinner
+func (ows *outputwriterspinner) SetSpinnerText(spinnerText string) {
+	if ows.spinner == nil {
+		return
+	}
+	ows.spinner.Lock()
+	defer ows.spinner.Unlock()
+	ows.spinnerText = spinnerText
+	ows.spinner.Suffix = fmt.Sprintf(" %s", spinnerText)
+}
+
+// Printf prints a line of output while the spinner is running without interleaving
+// it with the spinner text. The spinner is resumed after the line is printed.
+func (ows *outputwriterspinner) Printf(format string, a ...interface{}) {
+	ows.printMutex.Lock()
+	defer ows.printMutex.Unlock()
+
+	active := ows.spinner != nil && ows.spinner.Active()
+	if active {
+		ows.spinner.Stop()
+	}
+	msg := fmt.Sprintf(format, a...)
+	if !strings.HasSuffix(msg, "\n") {
+		msg += "\n"
+	}
+	fmt.Fprint(ows.out, msg)
+	if active {
+		ows.spinner.Start()
+	}
+}
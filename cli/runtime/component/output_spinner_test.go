@@ -0,0 +1,49 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package component
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputWriterSpinnerSetSpinnerText(t *testing.T) {
+	var b bytes.Buffer
+	ows, err := NewOutputWriterWithSpinner(&b, string(TableOutputType), "Installing plugins (0/2)...", false)
+	require.NoError(t, err)
+	spinner, ok := ows.(OutputWriterSpinnerWithText)
+	require.True(t, ok)
+
+	spinner.SetSpinnerText("Installing plugins (1/2)...")
+	require.Equal(t, "Installing plugins (1/2)...", spinner.(*outputwriterspinner).spinnerText)
+	require.Equal(t, " Installing plugins (1/2)...", spinner.(*outputwriterspinner).spinner.Suffix)
+	require.Empty(t, b.String())
+}
+
+func TestOutputWriterSpinnerSetSpinnerTextWithoutSpinner(t *testing.T) {
+	var b bytes.Buffer
+	ows, err := NewOutputWriterWithSpinner(&b, string(JSONOutputType), "Installing plugins (0/2)...", false)
+	require.NoError(t, err)
+	spinner, ok := ows.(OutputWriterSpinnerWithText)
+	require.True(t, ok)
+
+	// The spinner text is ignored as there is no spinner for the JSON output
+	spinner.SetSpinnerText("Installing plugins (1/2)...")
+	require.Empty(t, spinner.(*outputwriterspinner).spinnerText)
+	require.Empty(t, b.String())
+}
+
+func TestOutputWriterSpinnerPrintf(t *testing.T) {
+	var b bytes.Buffer
+	ows, err := NewOutputWriterWithSpinner(&b, string(TableOutputType), "Installing plugins (0/2)...", false)
+	require.NoError(t, err)
+	spinner, ok := ows.(OutputWriterSpinnerWithText)
+	require.True(t, ok)
+
+	spinner.Printf("Installing plugin '%v:%v'", "login", "v0.2.0")
+	spinner.Printf("Installing plugin '%v:%v'\n", "cluster", "v0.2.0")
+	require.Equal(t, "Installing plugin 'login:v0.2.0'\nInstalling plugin 'cluster:v0.2.0'\n", b.String())
+}
@@ -164,6 +164,16 @@ func GetClientConfigNoLock() (cfg *configapi.ClientConfig, err error) {
 		return cfg, nil
 	}
 
+	c, err := decodeClientConfig(b)
+	if err != nil {
+		return nil, err
+	}
+	resolveCredentials(c)
+	return c, nil
+}
+
+// decodeClientConfig decodes the config without resolving the credentials.
+func decodeClientConfig(b []byte) (*configapi.ClientConfig, error) {
 	scheme, err := configapi.SchemeBuilder.Build()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create scheme")
@@ -212,6 +222,8 @@ func storeConfigToLegacyDir(data []byte) {
 }
 
 // StoreClientConfig stores the config in the local directory.
+// The tokens of the global servers and contexts are kept in the credential
+// store, if one is configured, and only their references are written to the config file.
 // Make sure to Acquire and Release tanzu lock when reading/writing to the
 // tanzu client configuration
 func StoreClientConfig(cfg *configapi.ClientConfig) error {
@@ -229,6 +241,7 @@ func StoreClientConfig(cfg *configapi.ClientConfig) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to check config path existence")
 	}
+	var previousCfg *configapi.ClientConfig
 	if !cfgPathExists {
 		localDir, err := LocalDir()
 		if err != nil {
@@ -237,6 +250,19 @@ func StoreClientConfig(cfg *configapi.ClientConfig) error {
 		if err := os.MkdirAll(localDir, 0755); err != nil {
 			return errors.Wrap(err, "could not make local tanzu directory")
 		}
+	} else if b, err := os.ReadFile(cfgPath); err == nil {
+		// The previous config is used to erase the credentials which are no longer referenced
+		previousCfg, _ = decodeClientConfig(b)
+	}
+
+	if !IsTanzuConfigLockAcquired() {
+		return errors.New("error while updating the tanzu config file, lock is not acquired for updating tanzu config file")
+	}
+
+	// Only the references to the tokens are written to the config file
+	storeCfg, err := storeCredentials(cfg, previousCfg)
+	if err != nil {
+		return err
 	}
 
 	scheme, err := configapi.SchemeBuilder.Build()
@@ -247,16 +273,12 @@ func StoreClientConfig(cfg *configapi.ClientConfig) error {
 	s := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme,
 		json.SerializerOptions{Yaml: true, Pretty: false, Strict: false})
 	// Set GVK explicitly as encoder does not do it.
-	cfg.GetObjectKind().SetGroupVersionKind(configapi.GroupVersionKind)
+	storeCfg.GetObjectKind().SetGroupVersionKind(configapi.GroupVersionKind)
 	buf := new(bytes.Buffer)
-	if err := s.Encode(cfg, buf); err != nil {
+	if err := s.Encode(storeCfg, buf); err != nil {
 		return errors.Wrap(err, "failed to encode config file")
 	}
 
-	if !IsTanzuConfigLockAcquired() {
-		return errors.New("error while updating the tanzu config file, lock is not acquired for updating tanzu config file")
-	}
-
 	if err = os.WriteFile(cfgPath, buf.Bytes(), 0644); err != nil {
 		return errors.Wrap(err, "failed to write config file")
 	}
@@ -0,0 +1,463 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/aunum/log"
+	"github.com/pkg/errors"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+)
+
+const (
+	// FileCredentialStoreName is the name of the built-in file credential store.
+	FileCredentialStoreName = "file"
+
+	// CredentialHelperPrefix is the prefix of the credential helper binaries.
+	CredentialHelperPrefix = "tanzu-credential-"
+
+	// credentialsFileName is the name of the file of the built-in credential store.
+	credentialsFileName = "credentials.enc"
+
+	// credentialsKeyFileName is the name of the file holding the encryption key of the built-in credential store.
+	credentialsKeyFileName = "credentials.key"
+)
+
+// execCommand is used to run the credential helpers and can be replaced in tests
+var execCommand = exec.Command
+
+// helperCredentialsCache caches the credentials of the credential helpers for
+// the lifetime of the process, so that the helpers are not run every time the
+// config is read
+var helperCredentialsCache = &credentialsCache{creds: map[string]Credentials{}}
+
+// credentialsCache is a concurrency safe cache of credentials
+type credentialsCache struct {
+	mutex sync.Mutex
+	creds map[string]Credentials
+}
+
+func (c *credentialsCache) get(key string) (*Credentials, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	creds, ok := c.creds[key]
+	return &creds, ok
+}
+
+func (c *credentialsCache) put(key string, creds *Credentials) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.creds[key] = *creds
+}
+
+func (c *credentialsCache) remove(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.creds, key)
+}
+
+// Credentials are the tokens of a server or context kept in a credential store.
+type Credentials struct {
+	// Ref is the reference of the credentials in the store.
+	Ref string `json:"Ref"`
+	// AccessToken is the access token.
+	AccessToken string `json:"AccessToken,omitempty"`
+	// IDToken is the id token.
+	IDToken string `json:"IDToken,omitempty"`
+	// RefreshToken is the refresh token.
+	RefreshToken string `json:"RefreshToken,omitempty"`
+}
+
+// CredentialStore stores the tokens outside of the client configuration.
+type CredentialStore interface {
+	// Store adds or updates the credentials.
+	Store(creds *Credentials) error
+	// Get returns the credentials with the given reference.
+	Get(ref string) (*Credentials, error)
+	// Erase deletes the credentials with the given reference.
+	Erase(ref string) error
+}
+
+// NewCredentialStore returns the credential store with the given name.
+// The built-in file store, kept next to the configuration file, is returned
+// if the name is empty("") or `file`. Any other name refers to a
+// `tanzu-credential-<name>` helper binary found in the PATH, which is invoked
+// with the `store`, `get` or `erase` action:
+//   - store reads the credentials as JSON from stdin
+//   - get reads the reference from stdin and writes the credentials as JSON to stdout
+//   - erase reads the reference from stdin
+func NewCredentialStore(name string) (CredentialStore, error) {
+	if name == "" || name == FileCredentialStoreName {
+		cfgPath, err := ClientConfigPath()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not find config path")
+		}
+		dir := filepath.Dir(cfgPath)
+		return &fileCredentialStore{
+			path:    filepath.Join(dir, credentialsFileName),
+			keyPath: filepath.Join(dir, credentialsKeyFileName),
+		}, nil
+	}
+	return &helperCredentialStore{binary: CredentialHelperPrefix + name}, nil
+}
+
+// helperCredentialStore delegates to an external credential helper binary.
+// The credentials are cached for the lifetime of the process.
+type helperCredentialStore struct {
+	binary string
+}
+
+// Store adds or updates the credentials using the credential helper.
+func (h *helperCredentialStore) Store(creds *Credentials) error {
+	if cached, ok := helperCredentialsCache.get(h.cacheKey(creds.Ref)); ok && *cached == *creds {
+		return nil
+	}
+	b, err := json.Marshal(creds)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal credentials")
+	}
+	if _, err := h.run("store", b); err != nil {
+		return err
+	}
+	helperCredentialsCache.put(h.cacheKey(creds.Ref), creds)
+	return nil
+}
+
+// Get returns the credentials from the credential helper.
+func (h *helperCredentialStore) Get(ref string) (*Credentials, error) {
+	if cached, ok := helperCredentialsCache.get(h.cacheKey(ref)); ok {
+		return cached, nil
+	}
+	out, err := h.run("get", []byte(ref))
+	if err != nil {
+		return nil, err
+	}
+	var creds Credentials
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal the credentials returned by %q", h.binary)
+	}
+	helperCredentialsCache.put(h.cacheKey(ref), &creds)
+	return &creds, nil
+}
+
+// Erase deletes the credentials using the credential helper.
+func (h *helperCredentialStore) Erase(ref string) error {
+	helperCredentialsCache.remove(h.cacheKey(ref))
+	_, err := h.run("erase", []byte(ref))
+	return err
+}
+
+func (h *helperCredentialStore) cacheKey(ref string) string {
+	return h.binary + ":" + ref
+}
+
+func (h *helperCredentialStore) run(action string, input []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := execCommand(h.binary, action)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "credential helper %q failed to %s credentials: %s", h.binary, action, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// fileCredentialStore keeps the credentials in a file encrypted with AES-GCM
+// using a generated key which is only readable by the user.
+// As the key is kept next to the credentials file, the credentials must be
+// considered unencrypted at rest: the encryption only keeps the tokens out of
+// the config file and of copies of the credentials file alone. A credential
+// helper backed by the keyring of the OS should be used to protect the tokens
+// from anyone able to read the files of the user.
+type fileCredentialStore struct {
+	path    string
+	keyPath string
+}
+
+// Store adds or updates the credentials in the encrypted file.
+func (f *fileCredentialStore) Store(creds *Credentials) error {
+	all, err := f.load()
+	if err != nil {
+		return err
+	}
+	if existing, ok := all[creds.Ref]; ok && *existing == *creds {
+		return nil
+	}
+	all[creds.Ref] = creds
+	return f.save(all)
+}
+
+// Get returns the credentials from the encrypted file.
+func (f *fileCredentialStore) Get(ref string) (*Credentials, error) {
+	all, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	creds, ok := all[ref]
+	if !ok {
+		return nil, errors.Errorf("credentials %q not found", ref)
+	}
+	return creds, nil
+}
+
+// Erase deletes the credentials from the encrypted file.
+func (f *fileCredentialStore) Erase(ref string) error {
+	all, err := f.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[ref]; !ok {
+		return nil
+	}
+	delete(all, ref)
+	return f.save(all)
+}
+
+func (f *fileCredentialStore) load() (map[string]*Credentials, error) {
+	all := map[string]*Credentials{}
+	b, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read credentials file")
+	}
+
+	gcm, err := f.cipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < gcm.NonceSize() {
+		return nil, errors.New("could not decrypt credentials file: file is truncated")
+	}
+	plaintext, err := gcm.Open(nil, b[:gcm.NonceSize()], b[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt credentials file")
+	}
+	if err := json.Unmarshal(plaintext, &all); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal credentials file")
+	}
+	return all, nil
+}
+
+func (f *fileCredentialStore) save(all map[string]*Credentials) error {
+	plaintext, err := json.Marshal(all)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal credentials")
+	}
+	gcm, err := f.cipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return errors.Wrap(err, "could not generate nonce")
+	}
+	if err := os.WriteFile(f.path, gcm.Seal(nonce, nonce, plaintext, nil), 0600); err != nil {
+		return errors.Wrap(err, "could not write credentials file")
+	}
+	return nil
+}
+
+// cipher returns the AES-GCM cipher using the key of the store, which is
+// generated on first use
+func (f *fileCredentialStore) cipher() (cipher.AEAD, error) {
+	key, err := os.ReadFile(f.keyPath)
+	if os.IsNotExist(err) {
+		key = make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return nil, errors.Wrap(err, "could not generate credentials key")
+		}
+		if err := os.MkdirAll(filepath.Dir(f.keyPath), 0755); err != nil {
+			return nil, errors.Wrap(err, "could not make credentials key directory")
+		}
+		if err := os.WriteFile(f.keyPath, key, 0600); err != nil {
+			return nil, errors.Wrap(err, "could not write credentials key")
+		}
+	} else if err != nil {
+		return nil, errors.Wrap(err, "could not read credentials key")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid credentials key")
+	}
+	return cipher.NewGCM(block)
+}
+
+// serverCredentialRef returns the credential reference of the server
+func serverCredentialRef(name string) string {
+	return fmt.Sprintf("tanzu/servers/%s", name)
+}
+
+// contextCredentialRef returns the credential reference of the context
+func contextCredentialRef(name string) string {
+	return fmt.Sprintf("tanzu/contexts/%s", name)
+}
+
+func credentialStoreName(cfg *configapi.ClientConfig) string {
+	if cfg.ClientOptions == nil || cfg.ClientOptions.CLI == nil {
+		return ""
+	}
+	return cfg.ClientOptions.CLI.CredentialStore
+}
+
+func hasTokens(auth *configapi.GlobalServerAuth) bool {
+	return auth.AccessToken != "" || auth.IDToken != "" || auth.RefreshToken != ""
+}
+
+// globalAuths returns the authentication of the global servers and contexts
+// by credential reference. Servers and contexts converted from each other
+// share their options, in which case the authentication is only returned once.
+func globalAuths(cfg *configapi.ClientConfig) map[string]*configapi.GlobalServerAuth {
+	auths := map[string]*configapi.GlobalServerAuth{}
+	seen := map[*configapi.GlobalServer]bool{}
+	for _, s := range cfg.KnownServers {
+		if s != nil && s.GlobalOpts != nil && !seen[s.GlobalOpts] {
+			seen[s.GlobalOpts] = true
+			auths[serverCredentialRef(s.Name)] = &s.GlobalOpts.Auth
+		}
+	}
+	for _, c := range cfg.KnownContexts {
+		if c != nil && c.GlobalOpts != nil && !seen[c.GlobalOpts] {
+			seen[c.GlobalOpts] = true
+			auths[contextCredentialRef(c.Name)] = &c.GlobalOpts.Auth
+		}
+	}
+	return auths
+}
+
+// copyClientConfig returns a deep copy of the config in which the servers and
+// contexts converted from each other still share their global options
+func copyClientConfig(cfg *configapi.ClientConfig) *configapi.ClientConfig {
+	c := cfg.DeepCopy()
+	copies := map[*configapi.GlobalServer]*configapi.GlobalServer{}
+	for i, s := range cfg.KnownServers {
+		if s == nil || s.GlobalOpts == nil {
+			continue
+		}
+		if shared, ok := copies[s.GlobalOpts]; ok {
+			c.KnownServers[i].GlobalOpts = shared
+		} else {
+			copies[s.GlobalOpts] = c.KnownServers[i].GlobalOpts
+		}
+	}
+	for i, ctx := range cfg.KnownContexts {
+		if ctx == nil || ctx.GlobalOpts == nil {
+			continue
+		}
+		if shared, ok := copies[ctx.GlobalOpts]; ok {
+			c.KnownContexts[i].GlobalOpts = shared
+		} else {
+			copies[ctx.GlobalOpts] = c.KnownContexts[i].GlobalOpts
+		}
+	}
+	return c
+}
+
+// storeCredentials moves the tokens of the config to the configured credential
+// store and returns a copy of the config holding only the credential references.
+// The tokens are kept in the config until a credential store is configured, the
+// tokens of existing configs being migrated the first time the config is written
+// after that. The credential references without tokens are cleared, and the
+// credentials no longer referenced by the config are erased from the store.
+func storeCredentials(cfg *configapi.ClientConfig, previous *configapi.ClientConfig) (*configapi.ClientConfig, error) {
+	var store CredentialStore
+	referenced := map[string]bool{}
+	storeCfg := copyClientConfig(cfg)
+	storeEnabled := credentialStoreName(cfg) != ""
+	for ref, auth := range globalAuths(storeCfg) {
+		if !storeEnabled || !hasTokens(auth) {
+			auth.CredentialRef = ""
+			continue
+		}
+		if store == nil {
+			var err error
+			if store, err = NewCredentialStore(credentialStoreName(cfg)); err != nil {
+				return nil, err
+			}
+		}
+		err := store.Store(&Credentials{
+			Ref:          ref,
+			AccessToken:  auth.AccessToken,
+			IDToken:      auth.IDToken,
+			RefreshToken: auth.RefreshToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not store credentials")
+		}
+		auth.CredentialRef = ref
+		auth.AccessToken = ""
+		auth.IDToken = ""
+		auth.RefreshToken = ""
+		referenced[ref] = true
+	}
+
+	if previous != nil {
+		pruneCredentials(previous, referenced)
+	}
+	return storeCfg, nil
+}
+
+// pruneCredentials erases the credentials referenced by the previous config
+// which are no longer referenced
+func pruneCredentials(previous *configapi.ClientConfig, referenced map[string]bool) {
+	var store CredentialStore
+	for _, auth := range globalAuths(previous) {
+		if auth.CredentialRef == "" || referenced[auth.CredentialRef] {
+			continue
+		}
+		if store == nil {
+			var err error
+			if store, err = NewCredentialStore(credentialStoreName(previous)); err != nil {
+				log.Warningf("unable to erase unused credentials: %v", err)
+				return
+			}
+		}
+		if err := store.Erase(auth.CredentialRef); err != nil {
+			log.Warningf("unable to erase credentials %q: %v", auth.CredentialRef, err)
+		}
+		referenced[auth.CredentialRef] = true
+	}
+}
+
+// resolveCredentials populates the tokens of the config from the credential
+// store. Credentials which cannot be resolved are left empty.
+func resolveCredentials(cfg *configapi.ClientConfig) {
+	var store CredentialStore
+	for _, auth := range globalAuths(cfg) {
+		if auth.CredentialRef == "" {
+			continue
+		}
+		if store == nil {
+			var err error
+			if store, err = NewCredentialStore(credentialStoreName(cfg)); err != nil {
+				log.Warningf("unable to resolve credentials: %v", err)
+				return
+			}
+		}
+		creds, err := store.Get(auth.CredentialRef)
+		if err != nil {
+			log.Warningf("unable to resolve credentials %q: %v", auth.CredentialRef, err)
+			continue
+		}
+		auth.AccessToken = creds.AccessToken
+		auth.IDToken = creds.IDToken
+		auth.RefreshToken = creds.RefreshToken
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+)
+
+const plaintextTokensConfig = `apiVersion: config.tanzu.vmware.com/v1alpha1
+kind: ClientConfig
+metadata:
+  creationTimestamp: null
+current: tmc-test
+servers:
+- globalOpts:
+    endpoint: test.cloud.vmware.com:443
+    auth:
+      accessToken: plaintext-access-token
+      IDToken: plaintext-id-token
+      refresh_token: plaintext-refresh-token
+  name: tmc-test
+  type: global
+`
+
+const fileCredentialStoreConfig = `apiVersion: config.tanzu.vmware.com/v1alpha1
+kind: ClientConfig
+metadata:
+  creationTimestamp: null
+clientOptions:
+  cli:
+    credentialStore: file
+`
+
+func setupCredentialsTestConfig(t *testing.T, content string) string {
+	cfgPath := filepath.Join(t.TempDir(), ConfigName)
+	if content != "" {
+		require.NoError(t, os.WriteFile(cfgPath, []byte(content), 0644))
+	}
+	os.Setenv(EnvConfigKey, cfgPath)
+	return cfgPath
+}
+
+func globalServer(name, accessToken string) *configapi.Server {
+	return &configapi.Server{
+		Name: name,
+		Type: configapi.GlobalServerType,
+		GlobalOpts: &configapi.GlobalServer{
+			Endpoint: "test.cloud.vmware.com:443",
+			Auth: configapi.GlobalServerAuth{
+				AccessToken:  accessToken,
+				IDToken:      "id-token",
+				RefreshToken: "refresh-token",
+			},
+		},
+	}
+}
+
+func TestStoreClientConfigWithoutCredentialStore(t *testing.T) {
+	cfgPath := setupCredentialsTestConfig(t, plaintextTokensConfig)
+	defer os.Unsetenv(EnvConfigKey)
+
+	err := PutServer(globalServer("tmc-test-2", "access-token"), false)
+	require.NoError(t, err)
+
+	// The tokens are kept in the config file until a credential store is configured
+	b, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.Contains(t, string(b), "plaintext-access-token")
+	require.Contains(t, string(b), "accessToken: access-token")
+	require.NotContains(t, string(b), "credentialRef")
+	require.NoFileExists(t, filepath.Join(filepath.Dir(cfgPath), credentialsFileName))
+
+	s, err := GetServer("tmc-test-2")
+	require.NoError(t, err)
+	require.Equal(t, "access-token", s.GlobalOpts.Auth.AccessToken)
+}
+
+func TestStoreClientConfigWithFileCredentialStore(t *testing.T) {
+	cfgPath := setupCredentialsTestConfig(t, fileCredentialStoreConfig)
+	defer os.Unsetenv(EnvConfigKey)
+
+	err := PutServer(globalServer("tmc-test", "access-token"), true)
+	require.NoError(t, err)
+
+	// Only the references are written to the config file
+	b, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "access-token")
+	require.NotContains(t, string(b), "refresh-token")
+	require.Contains(t, string(b), "credentialRef: tanzu/servers/tmc-test")
+
+	// The credentials are encrypted
+	b, err = os.ReadFile(filepath.Join(filepath.Dir(cfgPath), credentialsFileName))
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "access-token")
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(filepath.Join(filepath.Dir(cfgPath), credentialsKeyFileName))
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// The tokens are resolved transparently
+	s, err := GetServer("tmc-test")
+	require.NoError(t, err)
+	require.Equal(t, "access-token", s.GlobalOpts.Auth.AccessToken)
+	require.Equal(t, "id-token", s.GlobalOpts.Auth.IDToken)
+	require.Equal(t, "refresh-token", s.GlobalOpts.Auth.RefreshToken)
+	c, err := GetContext("tmc-test")
+	require.NoError(t, err)
+	require.Equal(t, "access-token", c.GlobalOpts.Auth.AccessToken)
+
+	// Updated tokens replace the stored credentials
+	err = PutServer(globalServer("tmc-test", "new-access-token"), true)
+	require.NoError(t, err)
+	s, err = GetServer("tmc-test")
+	require.NoError(t, err)
+	require.Equal(t, "new-access-token", s.GlobalOpts.Auth.AccessToken)
+
+	// Clearing the tokens erases the credentials
+	s = globalServer("tmc-test", "")
+	s.GlobalOpts.Auth.IDToken = ""
+	s.GlobalOpts.Auth.RefreshToken = ""
+	err = PutServer(s, true)
+	require.NoError(t, err)
+	b, err = os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "credentialRef")
+	store, err := NewCredentialStore("")
+	require.NoError(t, err)
+	_, err = store.Get(serverCredentialRef("tmc-test"))
+	require.Error(t, err)
+
+	// The credentials are erased with the server and context
+	err = PutServer(globalServer("tmc-test", "access-token"), true)
+	require.NoError(t, err)
+	err = RemoveServer("tmc-test")
+	require.NoError(t, err)
+	_, err = store.Get(serverCredentialRef("tmc-test"))
+	require.Error(t, err)
+	_, err = store.Get(contextCredentialRef("tmc-test"))
+	require.Error(t, err)
+}
+
+func TestStoreClientConfigMigratesPlaintextTokens(t *testing.T) {
+	cfgPath := setupCredentialsTestConfig(t, plaintextTokensConfig)
+	defer os.Unsetenv(EnvConfigKey)
+
+	// The tokens are migrated once the credential store is enabled
+	AcquireTanzuConfigLock()
+	cfg, err := GetClientConfigNoLock()
+	require.NoError(t, err)
+	require.Equal(t, "plaintext-access-token", cfg.KnownServers[0].GlobalOpts.Auth.AccessToken)
+	cfg.ClientOptions = &configapi.ClientOptions{CLI: &configapi.CLIOptions{CredentialStore: FileCredentialStoreName}}
+	err = StoreClientConfig(cfg)
+	ReleaseTanzuConfigLock()
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "plaintext-")
+
+	s, err := GetServer("tmc-test")
+	require.NoError(t, err)
+	require.Equal(t, "plaintext-access-token", s.GlobalOpts.Auth.AccessToken)
+	require.Equal(t, "plaintext-id-token", s.GlobalOpts.Auth.IDToken)
+	require.Equal(t, "plaintext-refresh-token", s.GlobalOpts.Auth.RefreshToken)
+
+	// Disabling the credential store moves the tokens back to the config file
+	AcquireTanzuConfigLock()
+	cfg, err = GetClientConfigNoLock()
+	require.NoError(t, err)
+	cfg.ClientOptions.CLI.CredentialStore = ""
+	err = StoreClientConfig(cfg)
+	ReleaseTanzuConfigLock()
+	require.NoError(t, err)
+
+	b, err = os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.Contains(t, string(b), "plaintext-access-token")
+	require.NotContains(t, string(b), "credentialRef")
+	store, err := NewCredentialStore(FileCredentialStoreName)
+	require.NoError(t, err)
+	_, err = store.Get(serverCredentialRef("tmc-test"))
+	require.Error(t, err)
+}
+
+func TestStoreClientConfigWithCredentialHelper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake credential helper is a shell script")
+	}
+
+	// Fake credential helper keeping the credentials in one file per reference
+	helperDir := t.TempDir()
+	helper := `#!/bin/sh
+store="` + helperDir + `/store"
+mkdir -p "$store"
+case "$1" in
+store) input=$(cat); ref=$(echo "$input" | sed 's/.*"Ref":"\([^"]*\)".*/\1/' | tr '/' '_'); echo "$input" > "$store/$ref" ;;
+get) ref=$(cat | tr '/' '_'); cat "$store/$ref" ;;
+erase) ref=$(cat | tr '/' '_'); rm -f "$store/$ref" ;;
+*) echo "unknown action $1" >&2; exit 1 ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(helperDir, CredentialHelperPrefix+"fake"), []byte(helper), 0755))
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", helperDir+string(os.PathListSeparator)+path)
+	defer os.Setenv("PATH", path)
+
+	cfgPath := setupCredentialsTestConfig(t, "")
+	defer os.Unsetenv(EnvConfigKey)
+
+	AcquireTanzuConfigLock()
+	cfg := &configapi.ClientConfig{
+		ClientOptions: &configapi.ClientOptions{
+			CLI: &configapi.CLIOptions{CredentialStore: "fake"},
+		},
+		KnownServers:  []*configapi.Server{globalServer("tmc-test", "access-token")},
+		CurrentServer: "tmc-test",
+	}
+	err := StoreClientConfig(cfg)
+	ReleaseTanzuConfigLock()
+	require.NoError(t, err)
+
+	// The given config is not modified
+	require.Equal(t, "access-token", cfg.KnownServers[0].GlobalOpts.Auth.AccessToken)
+	require.Empty(t, cfg.KnownServers[0].GlobalOpts.Auth.CredentialRef)
+
+	b, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "access-token")
+	require.FileExists(t, filepath.Join(helperDir, "store", "tanzu_servers_tmc-test"))
+	require.NoFileExists(t, filepath.Join(filepath.Dir(cfgPath), credentialsFileName))
+
+	s, err := GetServer("tmc-test")
+	require.NoError(t, err)
+	require.Equal(t, "access-token", s.GlobalOpts.Auth.AccessToken)
+
+	// The credentials are cached instead of running the credential helper again
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		t.Fatalf("unexpected run of credential helper %q", name)
+		return nil
+	}
+	s, err = GetServer("tmc-test")
+	execCommand = exec.Command
+	require.NoError(t, err)
+	require.Equal(t, "access-token", s.GlobalOpts.Auth.AccessToken)
+
+	err = RemoveServer("tmc-test")
+	require.NoError(t, err)
+	require.NoFileExists(t, filepath.Join(helperDir, "store", "tanzu_servers_tmc-test"))
+
+	// A missing credential helper fails the update of the config
+	store, err := NewCredentialStore("missing")
+	require.NoError(t, err)
+	err = store.Store(&Credentials{Ref: "tanzu/servers/tmc-test", AccessToken: "access-token"})
+	require.ErrorContains(t, err, `credential helper "tanzu-credential-missing" failed to store credentials`)
+}
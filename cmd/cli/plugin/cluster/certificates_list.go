@@ -0,0 +1,114 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type listCertificatesOptions struct {
+	namespace    string
+	outputFormat string
+}
+
+var lcerts = &listCertificatesOptions{}
+
+var listCertificatesCmd = &cobra.Command{
+	Use:   "list CLUSTER_NAME",
+	Short: "List the certificates of a cluster and their expiry",
+	Long: `List the certificate authorities of a cluster and the kubeadm issued certificates of its control plane
+machines, along with their expiry. The expiry of the machine certificates is estimated from the creation time
+of the machines when it is not tracked by Cluster API, such estimates are marked with a '~'. The number of days
+before expiry at which the control plane machines are rolled out by Cluster API, set by the
+spec.rolloutBefore.certificatesExpiryDays of the KubeadmControlPlane, is reported as well.`,
+	Example: `
+  # List the certificates of a workload cluster
+  tanzu cluster certificates list workload1 --namespace dev`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         listCertificates,
+	SilenceUsage: true,
+}
+
+func init() {
+	listCertificatesCmd.Flags().StringVarP(&lcerts.namespace, "namespace", "n", "", "The namespace of the cluster")
+	listCertificatesCmd.Flags().StringVarP(&lcerts.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	certificatesCmd.AddCommand(listCertificatesCmd)
+}
+
+func listCertificates(cmd *cobra.Command, args []string) error {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("listing cluster certificates with a global server is not implemented yet")
+	}
+	return listCertificatesInternal(cmd, server, args[0])
+}
+
+//nolint:gocritic
+func listCertificatesInternal(cmd *cobra.Command, server *configapi.Server, clusterName string) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	certificates, err := tkgctlClient.GetClusterCertificates(tkgctl.ListClusterCertificatesOptions{
+		ClusterName: clusterName,
+		Namespace:   lcerts.namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	var t component.OutputWriter
+	if lcerts.outputFormat == string(component.JSONOutputType) || lcerts.outputFormat == string(component.YAMLOutputType) {
+		t = component.NewObjectWriter(cmd.OutOrStdout(), lcerts.outputFormat, certificates)
+	} else {
+		t = component.NewOutputWriter(cmd.OutOrStdout(), lcerts.outputFormat, "NAME", "TYPE", "SOURCE", "EXPIRES", "DAYS-LEFT")
+		for i := range certificates.Certificates {
+			expires, daysLeft := formatCertificateExpiry(&certificates.Certificates[i], time.Now())
+			t.AddRow(certificates.Certificates[i].Name, certificates.Certificates[i].Type, certificates.Certificates[i].Source, expires, daysLeft)
+		}
+	}
+	t.Render()
+
+	if lcerts.outputFormat == "" || lcerts.outputFormat == string(component.TableOutputType) {
+		if certificates.CertificatesExpiryDays != nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "\nControl plane machines are rolled out %d days before their certificates expire\n", *certificates.CertificatesExpiryDays)
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "\nControl plane machines are not rolled out automatically before their certificates expire")
+		}
+	}
+	return nil
+}
+
+// formatCertificateExpiry returns the expiry date of a certificate and the number of days left before it expires
+func formatCertificateExpiry(certificate *client.ClusterCertificate, now time.Time) (expires, daysLeft string) {
+	if certificate.NotAfter == nil {
+		return "never", ""
+	}
+	prefix := ""
+	if certificate.Estimated {
+		prefix = "~"
+	}
+	days := int(math.Floor(certificate.NotAfter.Sub(now).Hours() / 24))
+	if days < 0 {
+		return prefix + certificate.NotAfter.Format(time.RFC3339), "expired"
+	}
+	return prefix + certificate.NotAfter.Format(time.RFC3339), fmt.Sprintf("%s%d", prefix, days)
+}
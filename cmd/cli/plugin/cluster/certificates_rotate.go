@@ -0,0 +1,78 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type rotateCertificatesOptions struct {
+	namespace      string
+	includeWorkers bool
+	unattended     bool
+	timeout        time.Duration
+}
+
+var rcerts = &rotateCertificatesOptions{}
+
+var rotateCertificatesCmd = &cobra.Command{
+	Use:   "rotate CLUSTER_NAME",
+	Short: "Rotate the certificates of the machines of a cluster",
+	Long: `Rotate the kubeadm issued certificates of the control plane machines of a cluster by rolling them out,
+and optionally the worker machines. The certificate authorities of the cluster are not renewed.`,
+	Example: `
+  # Rotate the certificates of the control plane machines of a workload cluster
+  tanzu cluster certificates rotate workload1
+
+  # Rotate the certificates of the control plane and worker machines of a workload cluster
+  tanzu cluster certificates rotate workload1 --include-workers`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         rotateCertificates,
+	SilenceUsage: true,
+}
+
+func init() {
+	rotateCertificatesCmd.Flags().StringVarP(&rcerts.namespace, "namespace", "n", "", "The namespace of the cluster")
+	rotateCertificatesCmd.Flags().BoolVarP(&rcerts.includeWorkers, "include-workers", "", false, "Also roll out the worker machines of the cluster")
+	rotateCertificatesCmd.Flags().BoolVarP(&rcerts.unattended, "yes", "y", false, "Rotate the certificates without asking for confirmation")
+	rotateCertificatesCmd.Flags().DurationVarP(&rcerts.timeout, "timeout", "t", constants.DefaultLongRunningOperationTimeout, "Time duration to wait for an operation before timeout. Timeout duration in hours(h)/minutes(m)/seconds(s) units or as some combination of them (e.g. 2h, 30m, 2h30m10s)")
+	certificatesCmd.AddCommand(rotateCertificatesCmd)
+}
+
+func rotateCertificates(cmd *cobra.Command, args []string) error {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("rotating cluster certificates with a global server is not implemented yet")
+	}
+	return rotateCertificatesInternal(server, args[0])
+}
+
+//nolint:gocritic
+func rotateCertificatesInternal(server *configapi.Server, clusterName string) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	return tkgctlClient.RotateClusterCertificates(tkgctl.RotateClusterCertificatesOptions{
+		ClusterName:    clusterName,
+		Namespace:      rcerts.namespace,
+		IncludeWorkers: rcerts.includeWorkers,
+		SkipPrompt:     rcerts.unattended,
+		Timeout:        rcerts.timeout,
+	})
+}
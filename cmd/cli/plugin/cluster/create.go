@@ -74,6 +74,7 @@ func init() {
 	createClusterCmd.Flags().BoolVarP(&cc.unattended, "yes", "y", false, "Create workload cluster without asking for confirmation")
 	createClusterCmd.Flags().StringVarP(&cc.enableClusterOptions, "enable-cluster-options", "", "", "List of comma separated cluster options to be enabled")
 	createClusterCmd.Flags().StringVarP(&cc.infrastructureProvider, "infrastructure", "i", "", "The target infrastructure on which to deploy the workload cluster.")
+	createClusterCmd.Flags().StringVar(&eventsFile, "events-file", "", eventsFileUsage)
 
 	// Hide some of the variables not relevant to tanzu cli at the moment
 	createClusterCmd.Flags().MarkHidden("plan")                          //nolint
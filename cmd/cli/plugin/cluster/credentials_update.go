@@ -16,9 +16,14 @@ import (
 )
 
 type updateCredentialsOptions struct {
-	namespace       string
-	vSphereUser     string
-	vSpherePassword string
+	namespace          string
+	vSphereUser        string
+	vSpherePassword    string
+	awsAccessKeyID     string
+	awsSecretAccessKey string
+	awsSessionToken    string
+	azureClientID      string
+	azureClientSecret  string
 }
 
 var updateCredentialsOpts = updateCredentialsOptions{}
@@ -35,6 +40,11 @@ func init() {
 	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.namespace, "namespace", "n", "", "The namespace of the cluster to be updated")
 	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.vSphereUser, "vsphere-user", "", "", "Username for vSphere provider")
 	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.vSpherePassword, "vsphere-password", "", "", "Password for vSphere provider")
+	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.awsAccessKeyID, "aws-access-key-id", "", "", "Access key ID for AWS provider")
+	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.awsSecretAccessKey, "aws-secret-access-key", "", "", "Secret access key for AWS provider")
+	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.awsSessionToken, "aws-session-token", "", "", "Session token for AWS provider, if temporary credentials are used")
+	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.azureClientID, "azure-client-id", "", "", "Client ID of the service principal for Azure provider, only required when the service principal is replaced")
+	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.azureClientSecret, "azure-client-secret", "", "", "Client secret of the service principal for Azure provider")
 
 	credentialsCmd.AddCommand(credentialsUpdateCmd)
 }
@@ -73,39 +83,59 @@ func updateClusterCredentials(clusterName string, server *configapi.Server) erro
 		}
 	}
 
-	if updateCredentialsOpts.vSphereUser == "" {
-		err = component.Prompt(
-			&component.PromptConfig{
-				Message: "Enter vSphere username",
-			},
-			&updateCredentialsOpts.vSphereUser,
-			promptOpts...,
-		)
-		if err != nil {
-			return err
-		}
-	}
-
-	if updateCredentialsOpts.vSpherePassword == "" {
-		err = component.Prompt(
-			&component.PromptConfig{
-				Message:   "Enter vSphere password",
-				Sensitive: true,
-			},
-			&updateCredentialsOpts.vSpherePassword,
-			promptOpts...,
-		)
-		if err != nil {
-			return err
-		}
+	if err := promptForCredentials(); err != nil {
+		return err
 	}
 
 	uccOptions := tkgctl.UpdateCredentialsClusterOptions{
-		ClusterName:     clusterName,
-		Namespace:       updateCredentialsOpts.namespace,
-		VSphereUsername: updateCredentialsOpts.vSphereUser,
-		VSpherePassword: updateCredentialsOpts.vSpherePassword,
+		ClusterName:        clusterName,
+		Namespace:          updateCredentialsOpts.namespace,
+		VSphereUsername:    updateCredentialsOpts.vSphereUser,
+		VSpherePassword:    updateCredentialsOpts.vSpherePassword,
+		AWSAccessKeyID:     updateCredentialsOpts.awsAccessKeyID,
+		AWSSecretAccessKey: updateCredentialsOpts.awsSecretAccessKey,
+		AWSSessionToken:    updateCredentialsOpts.awsSessionToken,
+		AzureClientID:      updateCredentialsOpts.azureClientID,
+		AzureClientSecret:  updateCredentialsOpts.azureClientSecret,
 	}
 
 	return tkgctlClient.UpdateCredentialsCluster(uccOptions)
 }
+
+// promptForCredentials prompts for the missing credentials of the infrastructure provider
+// whose flags are set, defaulting to vSphere when no AWS or Azure flag is provided.
+func promptForCredentials() error {
+	var promptOpts []component.PromptOpt
+
+	switch {
+	case updateCredentialsOpts.awsAccessKeyID != "" || updateCredentialsOpts.awsSecretAccessKey != "" || updateCredentialsOpts.awsSessionToken != "":
+		if updateCredentialsOpts.awsAccessKeyID == "" {
+			if err := component.Prompt(&component.PromptConfig{Message: "Enter AWS access key ID"}, &updateCredentialsOpts.awsAccessKeyID, promptOpts...); err != nil {
+				return err
+			}
+		}
+		if updateCredentialsOpts.awsSecretAccessKey == "" {
+			if err := component.Prompt(&component.PromptConfig{Message: "Enter AWS secret access key", Sensitive: true}, &updateCredentialsOpts.awsSecretAccessKey, promptOpts...); err != nil {
+				return err
+			}
+		}
+	case updateCredentialsOpts.azureClientID != "" || updateCredentialsOpts.azureClientSecret != "":
+		if updateCredentialsOpts.azureClientSecret == "" {
+			if err := component.Prompt(&component.PromptConfig{Message: "Enter Azure client secret", Sensitive: true}, &updateCredentialsOpts.azureClientSecret, promptOpts...); err != nil {
+				return err
+			}
+		}
+	default:
+		if updateCredentialsOpts.vSphereUser == "" {
+			if err := component.Prompt(&component.PromptConfig{Message: "Enter vSphere username"}, &updateCredentialsOpts.vSphereUser, promptOpts...); err != nil {
+				return err
+			}
+		}
+		if updateCredentialsOpts.vSpherePassword == "" {
+			if err := component.Prompt(&component.PromptConfig{Message: "Enter vSphere password", Sensitive: true}, &updateCredentialsOpts.vSpherePassword, promptOpts...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ var deleteClusterCmd = &cobra.Command{
 func init() {
 	deleteClusterCmd.Flags().StringVarP(&dc.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
 	deleteClusterCmd.Flags().BoolVarP(&dc.unattended, "yes", "y", false, "Delete workload cluster without asking for confirmation")
+	deleteClusterCmd.Flags().StringVar(&eventsFile, "events-file", "", eventsFileUsage)
 }
 
 func deleteCmd(cmd *cobra.Command, args []string) error {
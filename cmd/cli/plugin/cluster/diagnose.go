@@ -0,0 +1,80 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type diagnoseClusterOptions struct {
+	namespace           string
+	outputFile          string
+	skipWorkloadCluster bool
+	logTailLines        int64
+}
+
+var dco = &diagnoseClusterOptions{}
+
+var diagnoseClusterCmd = &cobra.Command{
+	Use:   "diagnose CLUSTER_NAME",
+	Short: "Collect a support bundle of a cluster",
+	Long: `Collect the Cluster API objects, ClusterBootstrap and PackageInstall statuses, events and controller logs
+of a cluster into a single tar.gz support bundle. Secrets and credentials are redacted.`,
+	Example: `
+  # Collect the support bundle of a workload cluster
+  tanzu cluster diagnose workload1
+
+  # Collect the support bundle of an unreachable workload cluster from its management cluster only
+  tanzu cluster diagnose workload1 --skip-workload-cluster -o workload1.tar.gz`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         diagnose,
+	SilenceUsage: true,
+}
+
+func init() {
+	diagnoseClusterCmd.Flags().StringVarP(&dco.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+	diagnoseClusterCmd.Flags().StringVarP(&dco.outputFile, "output-file", "o", "", "Path of the support bundle. Defaults to CLUSTER_NAME-diagnostics-TIMESTAMP.tar.gz")
+	diagnoseClusterCmd.Flags().BoolVarP(&dco.skipWorkloadCluster, "skip-workload-cluster", "", false, "Only collect the diagnostics from the management cluster")
+	diagnoseClusterCmd.Flags().Int64VarP(&dco.logTailLines, "log-tail-lines", "", 0, "Number of lines of logs to collect per container. Assumes 2000 if not specified")
+}
+
+func diagnose(cmd *cobra.Command, args []string) error {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("diagnosing cluster with a global server is not implemented yet")
+	}
+	return diagnoseCluster(server, args[0])
+}
+
+func diagnoseCluster(server *configapi.Server, clusterName string) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	index, err := tkgctlClient.DiagnoseCluster(tkgctl.DiagnoseClusterOptions{
+		ClusterName:         clusterName,
+		Namespace:           dco.namespace,
+		OutputFile:          dco.outputFile,
+		SkipWorkloadCluster: dco.skipWorkloadCluster,
+		LogTailLines:        dco.logTailLines,
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range index.Errors {
+		log.Warningf("Warning: %s\n", e)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type diffClusterOptions struct {
+	clusterConfigFile string
+	namespace         string
+	apply             bool
+	outputFormat      string
+}
+
+var dfc = &diffClusterOptions{}
+
+var diffClusterCmd = &cobra.Command{
+	Use:   "diff CLUSTER_NAME",
+	Short: "Compare a cluster with its configuration file",
+	Long: `Compare the topology and the machine health checks of a ClusterClass based workload cluster with the
+ones generated from its configuration file, the same way as when the cluster is created.
+Only the fields set by the configuration are compared, values defaulted on the cluster are ignored.
+With --apply, the cluster topology and machine health checks are patched to match the configuration.`,
+	Example: `
+  # Show the differences between a workload cluster and its configuration file
+  tanzu cluster diff workload1 --file ~/clusterconfigs/workload1.yaml
+
+  # Update the workload cluster to match its configuration file
+  tanzu cluster diff workload1 --file ~/clusterconfigs/workload1.yaml --apply`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         diff,
+	SilenceUsage: true,
+}
+
+func init() {
+	diffClusterCmd.Flags().StringVarP(&dfc.clusterConfigFile, "file", "f", "", "Configuration file of the cluster")
+	diffClusterCmd.Flags().StringVarP(&dfc.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+	diffClusterCmd.Flags().BoolVarP(&dfc.apply, "apply", "", false, "Patch the cluster to match its configuration file")
+	diffClusterCmd.Flags().StringVarP(&dfc.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	diffClusterCmd.MarkFlagRequired("file") //nolint
+}
+
+func diff(cmd *cobra.Command, args []string) error {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("diffing cluster with a global server is not implemented yet")
+	}
+	return diffCluster(cmd, args[0], server)
+}
+
+func diffCluster(cmd *cobra.Command, clusterName string, server *configapi.Server) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	edition, err := config.GetEdition()
+	if err != nil {
+		return err
+	}
+
+	clusterDiff, err := tkgctlClient.DiffCluster(tkgctl.DiffClusterOptions{
+		ClusterConfigFile: dfc.clusterConfigFile,
+		ClusterName:       clusterName,
+		Namespace:         dfc.namespace,
+		Apply:             dfc.apply,
+		Edition:           edition,
+	})
+	if err != nil {
+		return err
+	}
+
+	return renderClusterDiff(cmd, clusterDiff, dfc.outputFormat)
+}
+
+func renderClusterDiff(cmd *cobra.Command, clusterDiff *client.ClusterDiff, outputFormat string) error {
+	if outputFormat == string(component.JSONOutputType) || outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(cmd.OutOrStdout(), outputFormat, clusterDiff).Render()
+		return nil
+	}
+
+	if len(clusterDiff.Entries) == 0 {
+		cmd.Printf("Cluster %s/%s matches its configuration\n", clusterDiff.Namespace, clusterDiff.ClusterName)
+		return nil
+	}
+	t := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "PATH", "OPERATION", "LIVE", "DESIRED")
+	for _, entry := range clusterDiff.Entries {
+		t.AddRow(entry.Path, entry.Operation, formatDiffValue(entry.Live), formatDiffValue(entry.Desired))
+	}
+	t.Render()
+	if clusterDiff.Applied {
+		cmd.Printf("\nCluster %s/%s was updated to match its configuration\n", clusterDiff.Namespace, clusterDiff.ClusterName)
+	}
+	return nil
+}
+
+func formatDiffValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(b)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	clusterctltree "sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	runv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
 	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/command"
 	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
@@ -39,6 +40,7 @@ type getClustersOptions struct {
 	disableGroupObjects bool
 	showDetails         bool
 	showGroupMembers    bool
+	showPackages        bool
 }
 
 var cd = &getClustersOptions{}
@@ -66,6 +68,8 @@ func init() {
 	getClustersCmd.Flags().BoolVar(&cd.disableGroupObjects, "disable-grouping", false, "Disable grouping machines when ready condition has the same Status, Severity and Reason")
 	command.DeprecateFlagWithAlternative(getClustersCmd, "disable-grouping", "1.6.0", "--show-group-members")
 	getClustersCmd.Flags().BoolVar(&cd.showGroupMembers, "show-group-members", false, "Expand machine groups whose ready condition has the same Status, Severity and Reason")
+
+	getClustersCmd.Flags().BoolVar(&cd.showPackages, "show-packages", false, "Show the status of the core and additional packages installed on the cluster")
 }
 
 func get(cmd *cobra.Command, args []string) error {
@@ -124,6 +128,7 @@ func getCluster(server *configapi.Server, clusterName string) error {
 		ShowOtherConditions: cd.showOtherConditions,
 		ShowDetails:         cd.showDetails,
 		ShowGroupMembers:    cd.showGroupMembers,
+		ShowPackages:        cd.showPackages,
 	}
 
 	results, err := tkgctlClient.DescribeCluster(describeClusterOptions)
@@ -162,10 +167,41 @@ func getCluster(server *configapi.Server, clusterName string) error {
 		p.Render()
 	}
 
+	if cd.showPackages {
+		packagesView(results.Packages)
+	}
+
 	return nil
 
 }
 
+// packagesView prints the status of the packages of the cluster reported by its ClusterBootstrap
+func packagesView(packages []runv1alpha3.ClusterBootstrapPackageStatus) {
+	maxMessage := 100
+	log.Infof("\n\nPackages:\n\n")
+	if len(packages) == 0 {
+		log.Infof("No package status reported for the cluster yet\n")
+		return
+	}
+	t := component.NewOutputWriter(cmdOutput, "table", "NAME", "VERSION", "STATE", "SINCE", "MESSAGE")
+	for i := range packages {
+		version := packages[i].Version
+		if version == "" {
+			version = noneTag
+		}
+		since := ""
+		if !packages[i].LastTransitionTime.IsZero() {
+			since = duration.HumanDuration(time.Since(packages[i].LastTransitionTime.Time))
+		}
+		message := packages[i].LastError
+		if len(message) > maxMessage {
+			message = fmt.Sprintf("%s ...", message[:maxMessage])
+		}
+		t.AddRow(packages[i].Name, version, packages[i].State, since, message)
+	}
+	t.Render()
+}
+
 const (
 	firstElemPrefix = `├─`
 	lastElemPrefix  = `└─`
@@ -4,6 +4,8 @@
 package main
 
 import (
+	"strconv"
+
 	"github.com/pkg/errors"
 
 	"github.com/spf13/cobra"
@@ -69,12 +71,28 @@ func listNodePoolsInternal(cmd *cobra.Command, server *configapi.Server, cluster
 	if lnp.outputFormat == string(component.JSONOutputType) || lnp.outputFormat == string(component.YAMLOutputType) {
 		t = component.NewObjectWriter(cmd.OutOrStdout(), lnp.outputFormat, machineDeployments)
 	} else {
-		t = component.NewOutputWriter(cmd.OutOrStdout(), lnp.outputFormat, "NAME", "NAMESPACE", "PHASE", "REPLICAS", "READY", "UPDATED", "UNAVAILABLE")
+		t = component.NewOutputWriter(cmd.OutOrStdout(), lnp.outputFormat, "NAME", "NAMESPACE", "PHASE", "REPLICAS", "READY", "UPDATED", "UNAVAILABLE", "AUTOSCALE-MIN", "AUTOSCALE-MAX")
 		for _, md := range machineDeployments {
-			t.AddRow(md.Name, md.Namespace, md.Status.Phase, md.Status.Replicas, md.Status.ReadyReplicas, md.Status.UpdatedReplicas, md.Status.UnavailableReplicas)
+			autoscaleMin, autoscaleMax := getAutoscalerSizes(md.Annotations)
+			t.AddRow(md.Name, md.Namespace, md.Status.Phase, md.Status.Replicas, md.Status.ReadyReplicas, md.Status.UpdatedReplicas, md.Status.UnavailableReplicas, autoscaleMin, autoscaleMax)
 		}
 	}
 	t.Render()
 
 	return nil
 }
+
+// getAutoscalerSizes returns the autoscaler min and max sizes of a node pool, or empty strings if it is not autoscaled
+func getAutoscalerSizes(annotations map[string]string) (autoscaleMin, autoscaleMax string) {
+	minSize, maxSize, autoscaled, err := client.GetAutoscalerSizes(annotations)
+	if err != nil || !autoscaled {
+		return "", ""
+	}
+	if minSize != nil {
+		autoscaleMin = strconv.Itoa(int(*minSize))
+	}
+	if maxSize != nil {
+		autoscaleMax = strconv.Itoa(int(*maxSize))
+	}
+	return autoscaleMin, autoscaleMax
+}
@@ -29,6 +29,10 @@ var descriptor = cliapi.PluginDescriptor{
 
 var logLevel int32
 var logFile string
+var eventsFile string
+
+// eventsFileUsage is the usage of the events-file flag of the long-running commands
+const eventsFileUsage = "File to write the progress events of the operation to, as JSON lines"
 
 func main() {
 	p, err := plugin.NewPlugin(&descriptor)
@@ -52,6 +56,10 @@ func main() {
 		availableUpgradesCmd,
 		clusterNodePoolCmd,
 		osImageCmd,
+		validateClusterCmd,
+		diagnoseClusterCmd,
+		diffClusterCmd,
+		certificatesCmd,
 	)
 	if err := p.Execute(); err != nil {
 		os.Exit(1)
@@ -75,6 +83,6 @@ func createTKGClient(kubeconfig, kubecontext string) (tkgctl.TKGClient, error) {
 		ConfigDir:   configDir,
 		KubeConfig:  kubeconfig,
 		KubeContext: kubecontext,
-		LogOptions:  tkgctl.LoggingOptions{Verbosity: logLevel, File: logFile},
+		LogOptions:  tkgctl.LoggingOptions{Verbosity: logLevel, File: logFile, EventsFile: eventsFile},
 	})
 }
@@ -36,6 +36,7 @@ func init() {
 	scaleClusterCmd.Flags().Int32VarP(&sc.controlPlaneCount, "controlplane-machine-count", "c", 0, "The number of control plane nodes to scale to. Assumes unchanged if not specified")
 	scaleClusterCmd.Flags().StringVarP(&sc.nodePoolName, "node-pool-name", "p", "", "The name of the node-pool to scale")
 	scaleClusterCmd.Flags().StringVarP(&sc.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+	scaleClusterCmd.Flags().StringVar(&eventsFile, "events-file", "", eventsFileUsage)
 }
 
 func scale(cmd *cobra.Command, args []string) error {
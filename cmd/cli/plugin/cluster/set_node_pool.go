@@ -21,6 +21,9 @@ type clusterSetNodePoolCmdOptions struct {
 	FilePath              string
 	Namespace             string
 	BaseMachineDeployment string
+	AutoscaleMin          int32
+	AutoscaleMax          int32
+	DisableAutoscale      bool
 }
 
 var setNodePoolOptions clusterSetNodePoolCmdOptions
@@ -37,6 +40,9 @@ func init() {
 	clusterSetNodePoolCmd.Flags().StringVarP(&setNodePoolOptions.FilePath, "file", "f", "", "The file describing the node pool (required)")
 	clusterSetNodePoolCmd.Flags().StringVar(&setNodePoolOptions.Namespace, "namespace", "default", "The namespace the cluster is found in.")
 	clusterSetNodePoolCmd.Flags().StringVar(&setNodePoolOptions.BaseMachineDeployment, "base-machine-deployment", "", "The machine deployment to use as a base for creating a new node pool (ignored for TKGs)")
+	clusterSetNodePoolCmd.Flags().Int32Var(&setNodePoolOptions.AutoscaleMin, "autoscale-min", 0, "The minimum size of the node pool when autoscaled by the cluster autoscaler (ClusterClass based clusters only)")
+	clusterSetNodePoolCmd.Flags().Int32Var(&setNodePoolOptions.AutoscaleMax, "autoscale-max", 0, "The maximum size of the node pool when autoscaled by the cluster autoscaler (ClusterClass based clusters only)")
+	clusterSetNodePoolCmd.Flags().BoolVar(&setNodePoolOptions.DisableAutoscale, "disable-autoscale", false, "Stop autoscaling the node pool, keeping its current size (ClusterClass based clusters only)")
 	_ = clusterSetNodePoolCmd.MarkFlagRequired("file")
 	clusterNodePoolCmd.AddCommand(clusterSetNodePoolCmd)
 }
@@ -50,11 +56,11 @@ func runSetNodePool(cmd *cobra.Command, args []string) error {
 	if server.IsGlobal() {
 		return errors.New("setting node pool with a global server is not implemented yet")
 	}
-	return SetNodePool(server, args[0])
+	return SetNodePool(cmd, server, args[0])
 }
 
 // SetNodePool creates or updates a node pool
-func SetNodePool(server *configapi.Server, clusterName string) error {
+func SetNodePool(cmd *cobra.Command, server *configapi.Server, clusterName string) error {
 	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
 	if err != nil {
 		return err
@@ -70,10 +76,25 @@ func SetNodePool(server *configapi.Server, clusterName string) error {
 		return errors.Wrap(err, "Could not parse file contents")
 	}
 	nodePool.BaseMachineDeployment = setNodePoolOptions.BaseMachineDeployment
+	if cmd.Flags().Changed("autoscale-min") {
+		nodePool.AutoscaleMin = &setNodePoolOptions.AutoscaleMin
+	}
+	if cmd.Flags().Changed("autoscale-max") {
+		nodePool.AutoscaleMax = &setNodePoolOptions.AutoscaleMax
+	}
+	if setNodePoolOptions.DisableAutoscale {
+		nodePool.DisableAutoscale = true
+	}
+
+	edition, err := config.GetEdition()
+	if err != nil {
+		return err
+	}
 
 	options := client.SetMachineDeploymentOptions{
 		ClusterName: clusterName,
 		Namespace:   setNodePoolOptions.Namespace,
+		Edition:     edition,
 		NodePool:    nodePool,
 	}
 
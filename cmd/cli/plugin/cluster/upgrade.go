@@ -6,6 +6,7 @@ package main
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	runv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha1"
 	runv1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
 	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
 	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
 	tkrutils "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/pkg/utils"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
@@ -37,6 +39,11 @@ type upgradeClustersOptions struct {
 	osVersion           string
 	osArch              string
 	vSphereTemplateName string
+	selector            string
+	maxConcurrent       int
+	maxFailures         int
+	planFile            string
+	dryRun              bool
 }
 
 const (
@@ -46,9 +53,15 @@ const (
 var uc = &upgradeClustersOptions{}
 
 var upgradeClusterCmd = &cobra.Command{
-	Use:   "upgrade CLUSTER_NAME",
+	Use:   "upgrade [CLUSTER_NAME]",
 	Short: "Upgrade a cluster",
-	Args:  cobra.ExactArgs(1),
+	Long: `Upgrade a cluster.
+With --selector, all the workload clusters matching the label selector are upgraded in waves of --max-concurrent
+clusters, each to the latest of its available upgrades matching --tkr. Each wave waits for the upgraded clusters
+and their packages to be healthy, and the upgrade halts once more than --max-failures clusters have failed.
+The plan and the status of the upgrade are written to --plan-file, running the command again with an existing
+plan file resumes the upgrade.`,
+	Args: cobra.MaximumNArgs(1),
 	Example: `
   # Upgrade a workload cluster
   tanzu cluster upgrade wc-1
@@ -59,6 +72,12 @@ var upgradeClusterCmd = &cobra.Command{
   # Upgrade a workload cluster with tkr prefix v1.20.1
   tanzu cluster upgrade wc-1 --tkr v1.20.1
 
+  # Upgrade all workload clusters labeled env=dev to tkr prefix v1.23.8, three clusters at a time
+  tanzu cluster upgrade --selector env=dev --tkr v1.23.8 --max-concurrent 3 --plan-file ~/dev-upgrade.yaml
+
+  # Show the upgrade plan of the workload clusters labeled env=dev
+  tanzu cluster upgrade --selector env=dev --dry-run
+
   # Upgrade a workload cluster using specific os name (vsphere)
   tanzu cluster upgrade wc-1 --os-name photon
 
@@ -95,6 +114,13 @@ func init() {
 
 	upgradeClusterCmd.Flags().StringVarP(&uc.vSphereTemplateName, "vsphere-vm-template-name", "", "", "The vSphere VM template to be used with upgraded kubernetes version. Discovered automatically if not provided")
 	upgradeClusterCmd.Flags().MarkHidden("vsphere-vm-template-name") // nolint
+
+	upgradeClusterCmd.Flags().StringVarP(&uc.selector, "selector", "l", "", "Label selector of the workload clusters to upgrade, in all namespaces unless --namespace is provided")
+	upgradeClusterCmd.Flags().IntVar(&uc.maxConcurrent, "max-concurrent", 1, "Number of workload clusters upgraded concurrently in each wave, with --selector")
+	upgradeClusterCmd.Flags().IntVar(&uc.maxFailures, "max-failures", 0, "Number of failed workload clusters tolerated before the upgrade halts, with --selector")
+	upgradeClusterCmd.Flags().StringVar(&uc.planFile, "plan-file", "", "File recording the plan and the status of the upgrade of the workload clusters, resumed if it exists")
+	upgradeClusterCmd.Flags().BoolVar(&uc.dryRun, "dry-run", false, "Only show the upgrade plan of the workload clusters matching --selector")
+	upgradeClusterCmd.Flags().StringVar(&eventsFile, "events-file", "", eventsFileUsage)
 }
 
 func upgrade(cmd *cobra.Command, args []string) error {
@@ -106,9 +132,59 @@ func upgrade(cmd *cobra.Command, args []string) error {
 	if server.IsGlobal() {
 		return errors.New("upgrading cluster with a global server is not implemented yet")
 	}
+	if uc.selector != "" || uc.planFile != "" {
+		if len(args) != 0 {
+			return errors.New("a cluster name cannot be provided with --selector or --plan-file")
+		}
+		return upgradeFleet(cmd, server)
+	}
+	if len(args) != 1 {
+		return errors.New("a cluster name or --selector is required")
+	}
 	return upgradeCluster(server, args[0])
 }
 
+func upgradeFleet(cmd *cobra.Command, server *configapi.Server) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	edition, err := config.GetEdition()
+	if err != nil {
+		return err
+	}
+
+	plan, err := tkgctlClient.UpgradeFleet(tkgctl.UpgradeFleetOptions{
+		Selector:      uc.selector,
+		Namespace:     uc.namespace,
+		TkrName:       uc.tkrName,
+		MaxConcurrent: uc.maxConcurrent,
+		MaxFailures:   uc.maxFailures,
+		PlanFile:      uc.planFile,
+		DryRun:        uc.dryRun,
+		SkipPrompt:    uc.unattended,
+		Timeout:       uc.timeout,
+		OSName:        uc.osName,
+		OSVersion:     uc.osVersion,
+		OSArch:        uc.osArch,
+		Edition:       edition,
+	})
+	if plan != nil {
+		t := component.NewOutputWriter(cmd.OutOrStdout(), "table", "NAMESPACE", "NAME", "CURRENT-TKR", "TARGET-TKR", "WAVE", "STATUS", "MESSAGE")
+		for i := range plan.Clusters {
+			cluster := &plan.Clusters[i]
+			wave := ""
+			if cluster.Wave != 0 {
+				wave = strconv.Itoa(cluster.Wave)
+			}
+			t.AddRow(cluster.Namespace, cluster.Name, cluster.CurrentTKR, cluster.TargetTKR, wave, cluster.Status, cluster.Message)
+		}
+		t.Render()
+	}
+	return err
+}
+
 func upgradeCluster(server *configapi.Server, clusterName string) error {
 	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
 	if err != nil {
@@ -0,0 +1,134 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+const junitOutputType = "junit"
+
+type validateClusterOptions struct {
+	clusterConfigFile      string
+	plan                   string
+	infrastructureProvider string
+	tkrName                string
+	outputFormat           string
+}
+
+var vc = &validateClusterOptions{}
+
+var validateClusterCmd = &cobra.Command{
+	Use:   "validate [CLUSTER_NAME]",
+	Short: "Run the preflight checks of a cluster configuration",
+	Long: `Run all the applicable preflight checks of a cluster configuration file without creating anything.
+All the failures and warnings are reported, and the command fails if any check fails.`,
+	Example: `
+  # Validate the configuration of a workload cluster
+  tanzu cluster validate --file ~/clusterconfigs/workload1.yaml
+
+  # Validate the configuration of a workload cluster and report the results as JUnit for CI
+  tanzu cluster validate --file ~/clusterconfigs/workload1.yaml -o junit > preflight.xml`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         validate,
+	SilenceUsage: true,
+}
+
+func init() {
+	validateClusterCmd.Flags().StringVarP(&vc.clusterConfigFile, "file", "f", "", "Configuration file of the cluster to validate")
+	validateClusterCmd.Flags().StringVarP(&vc.tkrName, "tkr", "", "", "TanzuKubernetesRelease(TKr) to be used for creating the workload cluster")
+	validateClusterCmd.Flags().StringVarP(&vc.plan, "plan", "p", "", "The plan to be used for creating the workload cluster")
+	validateClusterCmd.Flags().StringVarP(&vc.infrastructureProvider, "infrastructure", "i", "", "The target infrastructure on which to deploy the workload cluster.")
+	validateClusterCmd.Flags().StringVarP(&vc.outputFormat, "output", "o", "", "Output format (yaml|json|table|junit)")
+
+	validateClusterCmd.Flags().MarkHidden("plan")           //nolint
+	validateClusterCmd.Flags().MarkHidden("infrastructure") //nolint // Usually not needed as they are implied from configuration of the management cluster.
+}
+
+func validate(cmd *cobra.Command, args []string) error {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+	clusterName := ""
+	if len(args) > 0 {
+		clusterName = args[0]
+	}
+
+	if server.IsGlobal() {
+		return errors.New("validating cluster with a global server is not implemented yet")
+	}
+	return validateCluster(cmd, clusterName, server)
+}
+
+func validateCluster(cmd *cobra.Command, clusterName string, server *configapi.Server) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	tkrVersion := ""
+	if vc.tkrName != "" {
+		clusterClientOptions := clusterclient.Options{GetClientInterval: 2 * time.Second, GetClientTimeout: 5 * time.Second}
+		clusterClient, err := clusterclient.NewClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context, clusterClientOptions)
+		if err != nil {
+			return err
+		}
+
+		tkrVersion, err = getTkrVersionForMatchingTkr(clusterClient, vc.tkrName)
+		if err != nil {
+			return err
+		}
+	}
+
+	edition, err := config.GetEdition()
+	if err != nil {
+		return err
+	}
+
+	report, err := tkgctlClient.Preflight(tkgctl.PreflightOptions{
+		ClusterConfigFile:      vc.clusterConfigFile,
+		ClusterName:            clusterName,
+		Plan:                   vc.plan,
+		InfrastructureProvider: vc.infrastructureProvider,
+		TkrVersion:             tkrVersion,
+		Edition:                edition,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := renderPreflightReport(cmd, report, vc.outputFormat); err != nil {
+		return err
+	}
+	return report.Error()
+}
+
+func renderPreflightReport(cmd *cobra.Command, report *client.PreflightReport, outputFormat string) error {
+	if outputFormat == junitOutputType {
+		return report.WriteJUnit(cmd.OutOrStdout())
+	}
+
+	var t component.OutputWriter
+	if outputFormat == string(component.JSONOutputType) || outputFormat == string(component.YAMLOutputType) {
+		t = component.NewObjectWriter(cmd.OutOrStdout(), outputFormat, report)
+	} else {
+		t = component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "CODE", "CHECK", "PROVIDER", "STATUS", "MESSAGE")
+		for _, result := range report.Results {
+			t.AddRow(result.Code, result.Name, result.Provider, result.Status, result.Message)
+		}
+	}
+	t.Render()
+	return nil
+}
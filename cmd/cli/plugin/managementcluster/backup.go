@@ -0,0 +1,64 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type backupRegionOptions struct {
+	outputDir  string
+	namespaces []string
+}
+
+var bro = &backupRegionOptions{}
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [CLUSTER_NAME]",
+	Short: "Back up the workload clusters of a management cluster",
+	Long: cmd.LongDesc(`
+			Save the Cluster API objects of the workload clusters of a management cluster in move order, along with
+			the ClusterClasses, TKRs, OSImages, ClusterBootstraps and secrets they depend on, to a directory.
+			The clusters are paused while their objects are saved. The backup can be restored into a new management
+			cluster with 'tanzu management-cluster restore'.
+		`),
+	Example: `
+    # Back up the workload clusters of the current management cluster
+    tanzu management-cluster backup -o ~/backups/mc-1
+    # Back up the workload clusters of some namespaces only
+    tanzu management-cluster backup -o ~/backups/mc-1 --namespace ns-1 --namespace ns-2`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var clusterName string
+		if len(args) > 0 {
+			clusterName = args[0]
+		}
+		return runBackup(clusterName)
+	},
+	SilenceUsage: true,
+}
+
+func init() {
+	backupCmd.Flags().StringVarP(&bro.outputDir, "output-dir", "o", "", "Directory to save the backup to")
+	backupCmd.Flags().StringSliceVarP(&bro.namespaces, "namespace", "n", nil, "Namespace of the workload clusters to back up. All the namespaces with workload clusters are backed up if not specified")
+	backupCmd.MarkFlagRequired("output-dir") //nolint
+}
+
+func runBackup(clusterName string) error {
+	forceUpdateTKGCompatibilityImage := false
+	tkgctlClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	_, err = tkgctlClient.BackupRegion(tkgctl.BackupRegionOptions{
+		ClusterName: clusterName,
+		Directory:   bro.outputDir,
+		Namespaces:  bro.namespaces,
+	})
+	return err
+}
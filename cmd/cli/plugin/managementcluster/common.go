@@ -27,7 +27,7 @@ func newTKGCtlClient(forceUpdateTKGCompatibilityImage bool) (tkgctl.TKGClient, e
 			RegionManagerFactory: NewFactory(),
 		},
 
-		LogOptions:                       tkgctl.LoggingOptions{Verbosity: logLevel, File: logFile},
+		LogOptions:                       tkgctl.LoggingOptions{Verbosity: logLevel, File: logFile, EventsFile: eventsFile},
 		ForceUpdateTKGCompatibilityImage: forceUpdateTKGCompatibilityImage,
 	})
 }
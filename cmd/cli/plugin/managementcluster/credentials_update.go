@@ -13,9 +13,14 @@ import (
 )
 
 type updateCredentialsOptions struct {
-	vSphereUser     string
-	vSpherePassword string
-	isCascading     bool
+	vSphereUser        string
+	vSpherePassword    string
+	awsAccessKeyID     string
+	awsSecretAccessKey string
+	awsSessionToken    string
+	azureClientID      string
+	azureClientSecret  string
+	isCascading        bool
 }
 
 var updateCredentialsOpts = updateCredentialsOptions{}
@@ -40,6 +45,11 @@ var credentialsUpdateCmd = &cobra.Command{
 func init() {
 	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.vSphereUser, "vsphere-user", "", "", "Username for vSphere provider")
 	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.vSpherePassword, "vsphere-password", "", "", "Password for vSphere provider")
+	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.awsAccessKeyID, "aws-access-key-id", "", "", "Access key ID for AWS provider")
+	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.awsSecretAccessKey, "aws-secret-access-key", "", "", "Secret access key for AWS provider")
+	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.awsSessionToken, "aws-session-token", "", "", "Session token for AWS provider, if temporary credentials are used")
+	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.azureClientID, "azure-client-id", "", "", "Client ID of the service principal for Azure provider, only required when the service principal is replaced")
+	credentialsUpdateCmd.Flags().StringVarP(&updateCredentialsOpts.azureClientSecret, "azure-client-secret", "", "", "Client secret of the service principal for Azure provider")
 	credentialsUpdateCmd.Flags().BoolVarP(&updateCredentialsOpts.isCascading, "cascading", "", false, "Update credentials for all workload clusters under the management cluster")
 
 	credentialsCmd.AddCommand(credentialsUpdateCmd)
@@ -55,47 +65,65 @@ func updateClusterCredentials(clusterName string) error {
 		clusterName = server.Name
 	}
 
-	var promptOpts []component.PromptOpt
-
 	forceUpdateTKGCompatibilityImage := false
 	tkgctlClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
 	if err != nil {
 		return err
 	}
 
-	if updateCredentialsOpts.vSphereUser == "" {
-		err = component.Prompt(
-			&component.PromptConfig{
-				Message: "Enter vSphere username",
-			},
-			&updateCredentialsOpts.vSphereUser,
-			promptOpts...,
-		)
-		if err != nil {
-			return err
-		}
-	}
-
-	if updateCredentialsOpts.vSpherePassword == "" {
-		err = component.Prompt(
-			&component.PromptConfig{
-				Message:   "Enter vSphere password",
-				Sensitive: true,
-			},
-			&updateCredentialsOpts.vSpherePassword,
-			promptOpts...,
-		)
-		if err != nil {
-			return err
-		}
+	if err := promptForCredentials(); err != nil {
+		return err
 	}
 
 	options := tkgctl.UpdateCredentialsRegionOptions{
-		ClusterName:     clusterName,
-		VSphereUsername: updateCredentialsOpts.vSphereUser,
-		VSpherePassword: updateCredentialsOpts.vSpherePassword,
-		IsCascading:     updateCredentialsOpts.isCascading,
+		ClusterName:        clusterName,
+		VSphereUsername:    updateCredentialsOpts.vSphereUser,
+		VSpherePassword:    updateCredentialsOpts.vSpherePassword,
+		AWSAccessKeyID:     updateCredentialsOpts.awsAccessKeyID,
+		AWSSecretAccessKey: updateCredentialsOpts.awsSecretAccessKey,
+		AWSSessionToken:    updateCredentialsOpts.awsSessionToken,
+		AzureClientID:      updateCredentialsOpts.azureClientID,
+		AzureClientSecret:  updateCredentialsOpts.azureClientSecret,
+		IsCascading:        updateCredentialsOpts.isCascading,
 	}
 
 	return tkgctlClient.UpdateCredentialsRegion(options)
 }
+
+// promptForCredentials prompts for the missing credentials of the infrastructure provider
+// whose flags are set, defaulting to vSphere when no AWS or Azure flag is provided.
+func promptForCredentials() error {
+	var promptOpts []component.PromptOpt
+
+	switch {
+	case updateCredentialsOpts.awsAccessKeyID != "" || updateCredentialsOpts.awsSecretAccessKey != "" || updateCredentialsOpts.awsSessionToken != "":
+		if updateCredentialsOpts.awsAccessKeyID == "" {
+			if err := component.Prompt(&component.PromptConfig{Message: "Enter AWS access key ID"}, &updateCredentialsOpts.awsAccessKeyID, promptOpts...); err != nil {
+				return err
+			}
+		}
+		if updateCredentialsOpts.awsSecretAccessKey == "" {
+			if err := component.Prompt(&component.PromptConfig{Message: "Enter AWS secret access key", Sensitive: true}, &updateCredentialsOpts.awsSecretAccessKey, promptOpts...); err != nil {
+				return err
+			}
+		}
+	case updateCredentialsOpts.azureClientID != "" || updateCredentialsOpts.azureClientSecret != "":
+		if updateCredentialsOpts.azureClientSecret == "" {
+			if err := component.Prompt(&component.PromptConfig{Message: "Enter Azure client secret", Sensitive: true}, &updateCredentialsOpts.azureClientSecret, promptOpts...); err != nil {
+				return err
+			}
+		}
+	default:
+		if updateCredentialsOpts.vSphereUser == "" {
+			if err := component.Prompt(&component.PromptConfig{Message: "Enter vSphere username"}, &updateCredentialsOpts.vSphereUser, promptOpts...); err != nil {
+				return err
+			}
+		}
+		if updateCredentialsOpts.vSpherePassword == "" {
+			if err := component.Prompt(&component.PromptConfig{Message: "Enter vSphere password", Sensitive: true}, &updateCredentialsOpts.vSpherePassword, promptOpts...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
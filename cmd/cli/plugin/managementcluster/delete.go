@@ -41,6 +41,7 @@ func init() {
 	deleteRegionCmd.Flags().BoolVar(&dr.force, "force", false, "Force deletion of the management cluster even if it is managing active Tanzu Kubernetes clusters")
 	deleteRegionCmd.Flags().BoolVarP(&dr.useExistingCluster, "use-existing-cleanup-cluster", "e", false, "Use an existing cleanup cluster to delete the management cluster")
 	deleteRegionCmd.Flags().BoolVarP(&dr.unattended, "yes", "y", false, "Delete management cluster without asking for confirmation")
+	deleteRegionCmd.Flags().StringVar(&eventsFile, "events-file", "", eventsFileUsage)
 	deleteRegionCmd.Flags().DurationVarP(&dr.timeout, "timeout", "t", constants.DefaultLongRunningOperationTimeout, "Time duration to wait for an operation before timeout. Timeout duration in hours(h)/minutes(m)/seconds(s) units or as some combination of them (e.g. 2h, 30m, 2h30m10s)")
 }
 
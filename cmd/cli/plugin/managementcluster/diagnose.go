@@ -0,0 +1,83 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type diagnoseRegionOptions struct {
+	outputFile          string
+	useBootstrapCluster bool
+	logTailLines        int64
+}
+
+var dro = &diagnoseRegionOptions{}
+
+var diagnoseCmd = &cobra.Command{
+	Use:   "diagnose [CLUSTER_NAME]",
+	Short: "Collect a support bundle of a management cluster",
+	Long: cmd.LongDesc(`
+			Collect the Cluster API objects, ClusterBootstrap and PackageInstall statuses, events and controller logs
+			of a management cluster into a single tar.gz support bundle. Secrets and credentials are redacted.
+			Use --bootstrap to diagnose the bootstrap cluster left behind by a failed management cluster creation.
+		`),
+	Example: `
+    # Collect the support bundle of the current management cluster
+    tanzu management-cluster diagnose
+    # Collect the support bundle of the bootstrap cluster of a failed management cluster creation
+    tanzu management-cluster diagnose --bootstrap -o mc-1.tar.gz`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var clusterName string
+		if len(args) > 0 {
+			clusterName = args[0]
+		}
+		return runDiagnose(clusterName)
+	},
+	SilenceUsage: true,
+}
+
+func init() {
+	diagnoseCmd.Flags().StringVarP(&dro.outputFile, "output-file", "o", "", "Path of the support bundle. Defaults to CLUSTER_NAME-diagnostics-TIMESTAMP.tar.gz")
+	diagnoseCmd.Flags().BoolVarP(&dro.useBootstrapCluster, "bootstrap", "", false, "Diagnose the bootstrap cluster left behind by a failed management cluster creation")
+	diagnoseCmd.Flags().Int64VarP(&dro.logTailLines, "log-tail-lines", "", 0, "Number of lines of logs to collect per container. Assumes 2000 if not specified")
+}
+
+func runDiagnose(clusterName string) error {
+	// the bootstrap cluster of a failed creation is found from the creation journal, not the current server
+	if clusterName == "" && !dro.useBootstrapCluster {
+		server, err := config.GetCurrentServer()
+		if err != nil {
+			return err
+		}
+		clusterName = server.Name
+	}
+
+	forceUpdateTKGCompatibilityImage := false
+	tkgctlClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	index, err := tkgctlClient.DiagnoseCluster(tkgctl.DiagnoseClusterOptions{
+		ClusterName:         clusterName,
+		OutputFile:          dro.outputFile,
+		IsManagementCluster: true,
+		UseBootstrapCluster: dro.useBootstrapCluster,
+		LogTailLines:        dro.logTailLines,
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range index.Errors {
+		log.Warningf("Warning: %s\n", e)
+	}
+	return nil
+}
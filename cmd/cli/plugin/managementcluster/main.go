@@ -27,9 +27,13 @@ var descriptor = cliapi.PluginDescriptor{
 var (
 	logLevel     int32
 	logFile      string
+	eventsFile   string
 	outputFormat string
 )
 
+// eventsFileUsage is the usage of the events-file flag of the long-running commands
+const eventsFileUsage = "File to write the progress events of the operation to, as JSON lines"
+
 func main() {
 	p, err := plugin.NewPlugin(&descriptor)
 	if err != nil {
@@ -49,6 +53,10 @@ func main() {
 		permissionsCmd,
 		importCmd,
 		clusterKubeconfigCmd,
+		validateCmd,
+		diagnoseCmd,
+		backupCmd,
+		restoreCmd,
 	)
 
 	if err = p.Execute(); err != nil {
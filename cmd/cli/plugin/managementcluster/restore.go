@@ -0,0 +1,58 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type restoreRegionOptions struct {
+	inputDir string
+}
+
+var rro = &restoreRegionOptions{}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore [CLUSTER_NAME]",
+	Short: "Restore a backup of workload clusters into a management cluster",
+	Long: cmd.LongDesc(`
+			Restore a backup taken with 'tanzu management-cluster backup' into a management cluster, e.g. a new
+			management cluster created after a disaster. The restored clusters and their ClusterBootstraps are
+			paused until all their objects are restored.
+		`),
+	Example: `
+    # Restore a backup into the current management cluster
+    tanzu management-cluster restore -i ~/backups/mc-1`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var clusterName string
+		if len(args) > 0 {
+			clusterName = args[0]
+		}
+		return runRestore(clusterName)
+	},
+	SilenceUsage: true,
+}
+
+func init() {
+	restoreCmd.Flags().StringVarP(&rro.inputDir, "input-dir", "i", "", "Directory of the backup to restore")
+	restoreCmd.MarkFlagRequired("input-dir") //nolint
+}
+
+func runRestore(clusterName string) error {
+	forceUpdateTKGCompatibilityImage := false
+	tkgctlClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	_, err = tkgctlClient.RestoreRegion(tkgctl.RestoreRegionOptions{
+		ClusterName: clusterName,
+		Directory:   rro.inputDir,
+	})
+	return err
+}
@@ -69,6 +69,7 @@ func init() {
 
 	upgradeRegionCmd.Flags().DurationVarP(&ur.timeout, "timeout", "t", constants.DefaultLongRunningOperationTimeout, "Time duration to wait for an operation before timeout. Timeout duration in hours(h)/minutes(m)/seconds(s) units or as some combination of them (e.g. 2h, 30m, 2h30m10s)")
 	upgradeRegionCmd.Flags().BoolVarP(&ur.unattended, "yes", "y", false, "Upgrade management cluster without asking for confirmation")
+	upgradeRegionCmd.Flags().StringVar(&eventsFile, "events-file", "", eventsFileUsage)
 	upgradeRegionCmd.Flags().StringVar(&ur.osName, "os-name", "", "OS name to use during management cluster upgrade. Discovered automatically if not provided (See [+])")
 	upgradeRegionCmd.Flags().StringVar(&ur.osVersion, "os-version", "", "OS version to use during management cluster upgrade. Discovered automatically if not provided (See [+])")
 	upgradeRegionCmd.Flags().StringVar(&ur.osArch, "os-arch", "", "OS arch to use during management cluster upgrade. Discovered automatically if not provided (See [+])")
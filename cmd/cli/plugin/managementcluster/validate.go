@@ -0,0 +1,101 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+const junitOutputType = "junit"
+
+type validateRegionOptions struct {
+	clusterConfigFile      string
+	clusterName            string
+	plan                   string
+	infrastructureProvider string
+}
+
+var vro = &validateRegionOptions{}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Run the preflight checks of a management cluster configuration",
+	Long: cmd.LongDesc(`
+			Run all the applicable preflight checks of a management cluster configuration file without creating anything.
+			All the failures and warnings are reported, and the command fails if any check fails.
+		`),
+	Example: `
+    # Validate the configuration of a management cluster on AWS infrastructure
+    tanzu management-cluster validate --file ~/clusterconfigs/aws-mc-1.yaml
+    # Validate the configuration of a management cluster and report the results
+    # as JUnit for CI
+    tanzu management-cluster validate --file ~/clusterconfigs/aws-mc-1.yaml -o junit > preflight.xml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runValidate(cmd)
+	},
+	SilenceUsage: true,
+}
+
+func init() {
+	validateCmd.Flags().StringVarP(&vro.clusterConfigFile, "file", "f", "", "Configuration file of the management cluster to validate")
+	validateCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table|junit)")
+
+	validateCmd.Flags().StringVarP(&vro.infrastructureProvider, "infrastructure", "i", "", "Infrastructure to deploy the management cluster on ['aws', 'vsphere', 'azure']")
+	validateCmd.Flags().MarkHidden("infrastructure") //nolint
+
+	validateCmd.Flags().StringVarP(&vro.plan, "plan", "p", "", "Cluster plan to use to deploy the management cluster")
+	validateCmd.Flags().MarkHidden("plan") //nolint
+
+	validateCmd.Flags().StringVarP(&vro.clusterName, "name", "", "", "Name of the management cluster")
+	validateCmd.Flags().MarkHidden("name") //nolint
+}
+
+func runValidate(cmd *cobra.Command) error {
+	tkgClient, err := newTKGCtlClient(false)
+	if err != nil {
+		return err
+	}
+
+	edition, err := config.GetEdition()
+	if err != nil {
+		return err
+	}
+
+	report, err := tkgClient.Preflight(tkgctl.PreflightOptions{
+		ClusterConfigFile:      vro.clusterConfigFile,
+		ClusterName:            vro.clusterName,
+		Plan:                   vro.plan,
+		InfrastructureProvider: vro.infrastructureProvider,
+		Edition:                edition,
+		IsManagementCluster:    true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == junitOutputType {
+		if err := report.WriteJUnit(cmd.OutOrStdout()); err != nil {
+			return err
+		}
+		return report.Error()
+	}
+
+	var t component.OutputWriter
+	if outputFormat == string(component.JSONOutputType) || outputFormat == string(component.YAMLOutputType) {
+		t = component.NewObjectWriter(cmd.OutOrStdout(), outputFormat, report)
+	} else {
+		t = component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "CODE", "CHECK", "PROVIDER", "STATUS", "MESSAGE")
+		for _, result := range report.Results {
+			t.AddRow(result.Code, result.Name, result.Provider, result.Status, result.Message)
+		}
+	}
+	t.Render()
+
+	return report.Error()
+}
@@ -13,7 +13,7 @@ var packageInstalledOp = packagedatamodel.NewPackageOptions()
 
 var packageInstalledCmd = &cobra.Command{
 	Use:               "installed",
-	ValidArgs:         []string{"list", "create", "delete", "update", "get"},
+	ValidArgs:         []string{"list", "create", "delete", "update", "get", "apply"},
 	Short:             "Manage installed packages",
 	Args:              cobra.RangeArgs(1, 2),
 	PersistentPreRunE: packagingAvailabilityCheck,
@@ -0,0 +1,85 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+	"github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/packageclient"
+	"github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/packagedatamodel"
+)
+
+var packageStackOp = &packagedatamodel.PackageStackOptions{}
+
+var packageStackFile string
+
+var packageInstalledApplyCmd = &cobra.Command{
+	Use:   "apply --file STACK_FILE",
+	Short: "Install or update a stack of packages",
+	Long: `Install or update a stack of packages. Independent packages are installed concurrently,
+while a package is installed only once the packages it depends on have been successfully reconciled.`,
+	Args: cobra.NoArgs,
+	Example: `
+    # Install the packages of stack.yaml in namespace 'test-ns'
+    tanzu package installed apply -f stack.yaml --namespace test-ns --create-namespace
+
+    # An example stack.yaml is as follows:
+    packages:
+    - name: cert-manager
+      packageName: cert-manager.tanzu.vmware.com
+      version: 1.5.3+vmware.2-tkg.1
+    - name: contour
+      packageName: contour.tanzu.vmware.com
+      version: 1.18.2+vmware.1-tkg.1
+      valuesFile: contour-values.yaml
+      dependsOn: [cert-manager]
+    - name: harbor
+      packageName: harbor.tanzu.vmware.com
+      version: 2.3.3+vmware.1-tkg.1
+      namespace: harbor
+      valuesFile: harbor-values.yaml
+      dependsOn: [cert-manager, contour]`,
+	RunE:         packageInstalledApply,
+	SilenceUsage: true,
+}
+
+func init() {
+	packageInstalledApplyCmd.Flags().StringVarP(&packageStackFile, "file", "f", "", "The path to the package stack file")
+	packageInstalledApplyCmd.Flags().StringVarP(&packageStackOp.Namespace, "namespace", "n", "default", "Target namespace of the packages which do not specify one, optional")
+	packageInstalledApplyCmd.Flags().BoolVarP(&packageStackOp.CreateNamespace, "create-namespace", "", false, "Create the target namespaces if they do not exist, optional")
+	packageInstalledApplyCmd.Flags().BoolVarP(&packageStackOp.Wait, "wait", "", true, "Wait for the reconciliation of all the packages to complete, optional. Packages with dependents are always waited for")
+	packageInstalledApplyCmd.Flags().DurationVarP(&packageStackOp.PollInterval, "poll-interval", "", packagedatamodel.DefaultPollInterval, "Time interval between subsequent polls of package reconciliation status, optional")
+	packageInstalledApplyCmd.Flags().DurationVarP(&packageStackOp.PollTimeout, "poll-timeout", "", packagedatamodel.DefaultPollTimeout, "Timeout value for polls of package reconciliation status, optional")
+	packageInstalledApplyCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table), optional")
+	packageInstalledApplyCmd.MarkFlagRequired("file") //nolint
+	packageInstalledCmd.AddCommand(packageInstalledApplyCmd)
+}
+
+func packageInstalledApply(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if packageStackOp.Stack, err = packageclient.ReadPackageStack(packageStackFile); err != nil {
+		return err
+	}
+
+	pkgClient, err := packageclient.NewPackageClient(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	results, err := pkgClient.ApplyPackageStackSync(packageStackOp)
+	if len(results) != 0 {
+		t := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "NAME", "NAMESPACE", "STATUS", "MESSAGE")
+		for _, r := range results {
+			msg := ""
+			if r.Err != nil {
+				msg = r.Err.Error()
+			}
+			t.AddRow(r.Name, r.Namespace, string(r.Status), msg)
+		}
+		t.Render()
+	}
+	return err
+}
@@ -4,6 +4,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -17,7 +19,10 @@ var packageInstalledUpdateCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Example: `
     # Update installed package with name 'mypkg' with some version to version '3.0.0-rc.1' in specified namespace 	
-    tanzu package installed update mypkg --version 3.0.0-rc.1 --namespace test-ns`,
+    tanzu package installed update mypkg --version 3.0.0-rc.1 --namespace test-ns
+
+    # Preview the changes to the data values and to the PackageInstall of installed package 'mypkg' without applying them
+    tanzu package installed update mypkg --version 3.0.0-rc.1 --values-file values.yaml --namespace test-ns --dry-run`,
 	RunE:         packageUpdate,
 	SilenceUsage: true,
 }
@@ -31,6 +36,7 @@ func init() {
 	packageInstalledUpdateCmd.Flags().BoolVarP(&packageInstalledOp.Wait, "wait", "", true, "Wait for the package reconciliation to complete, optional. To disable wait, specify --wait=false")
 	packageInstalledUpdateCmd.Flags().DurationVarP(&packageInstalledOp.PollInterval, "poll-interval", "", packagedatamodel.DefaultPollInterval, "Time interval between subsequent polls of package reconciliation status, optional")
 	packageInstalledUpdateCmd.Flags().DurationVarP(&packageInstalledOp.PollTimeout, "poll-timeout", "", packagedatamodel.DefaultPollTimeout, "Timeout value for polls of package reconciliation status, optional")
+	packageInstalledUpdateCmd.Flags().BoolVarP(&packageInstalledOp.DryRun, "dry-run", "", false, "Validate the update and print the diff of the data values and of the PackageInstall spec without applying it, optional")
 	packageInstalledCmd.AddCommand(packageInstalledUpdateCmd)
 }
 
@@ -55,5 +61,17 @@ func packageUpdate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if packageInstalledOp.DryRun {
+		diff, err := pkgClient.UpdatePackageDryRun(packageInstalledOp)
+		if err != nil {
+			return err
+		}
+		if diff == "" {
+			diff = fmt.Sprintf("No changes to installed package '%s' in namespace '%s'\n", packageInstalledOp.PkgInstallName, packageInstalledOp.Namespace)
+		}
+		_, err = fmt.Fprint(cmd.OutOrStdout(), diff)
+		return err
+	}
+
 	return pkgClient.UpdatePackageSync(packageInstalledOp, packagedatamodel.OperationTypeUpdate)
 }
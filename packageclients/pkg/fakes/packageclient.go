@@ -44,6 +44,25 @@ type PackageClient struct {
 	addRepositorySyncReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ApplyPackageStackStub        func(*packagedatamodel.PackageStackOptions, *packagedatamodel.PackageProgress)
+	applyPackageStackMutex       sync.RWMutex
+	applyPackageStackArgsForCall []struct {
+		arg1 *packagedatamodel.PackageStackOptions
+		arg2 *packagedatamodel.PackageProgress
+	}
+	ApplyPackageStackSyncStub        func(*packagedatamodel.PackageStackOptions) ([]packagedatamodel.StackPackageResult, error)
+	applyPackageStackSyncMutex       sync.RWMutex
+	applyPackageStackSyncArgsForCall []struct {
+		arg1 *packagedatamodel.PackageStackOptions
+	}
+	applyPackageStackSyncReturns struct {
+		result1 []packagedatamodel.StackPackageResult
+		result2 error
+	}
+	applyPackageStackSyncReturnsOnCall map[int]struct {
+		result1 []packagedatamodel.StackPackageResult
+		result2 error
+	}
 	DeleteRegistrySecretStub        func(*packagedatamodel.RegistrySecretOptions) (bool, error)
 	deleteRegistrySecretMutex       sync.RWMutex
 	deleteRegistrySecretArgsForCall []struct {
@@ -249,6 +268,19 @@ type PackageClient struct {
 		arg2 *packagedatamodel.PackageProgress
 		arg3 packagedatamodel.OperationType
 	}
+	UpdatePackageDryRunStub        func(*packagedatamodel.PackageOptions) (string, error)
+	updatePackageDryRunMutex       sync.RWMutex
+	updatePackageDryRunArgsForCall []struct {
+		arg1 *packagedatamodel.PackageOptions
+	}
+	updatePackageDryRunReturns struct {
+		result1 string
+		result2 error
+	}
+	updatePackageDryRunReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	UpdatePackageSyncStub        func(*packagedatamodel.PackageOptions, packagedatamodel.OperationType) error
 	updatePackageSyncMutex       sync.RWMutex
 	updatePackageSyncArgsForCall []struct {
@@ -413,6 +445,10 @@ func (fake *PackageClient) AddRepositorySync(arg1 *packagedatamodel.RepositoryOp
 func (fake *PackageClient) AddRepositorySyncCallCount() int {
 	fake.addRepositorySyncMutex.RLock()
 	defer fake.addRepositorySyncMutex.RUnlock()
+	fake.applyPackageStackMutex.RLock()
+	defer fake.applyPackageStackMutex.RUnlock()
+	fake.applyPackageStackSyncMutex.RLock()
+	defer fake.applyPackageStackSyncMutex.RUnlock()
 	return len(fake.addRepositorySyncArgsForCall)
 }
 
@@ -452,6 +488,103 @@ func (fake *PackageClient) AddRepositorySyncReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *PackageClient) ApplyPackageStack(arg1 *packagedatamodel.PackageStackOptions, arg2 *packagedatamodel.PackageProgress) {
+	fake.applyPackageStackMutex.Lock()
+	fake.applyPackageStackArgsForCall = append(fake.applyPackageStackArgsForCall, struct {
+		arg1 *packagedatamodel.PackageStackOptions
+		arg2 *packagedatamodel.PackageProgress
+	}{arg1, arg2})
+	stub := fake.ApplyPackageStackStub
+	fake.recordInvocation("ApplyPackageStack", []interface{}{arg1, arg2})
+	fake.applyPackageStackMutex.Unlock()
+	if stub != nil {
+		fake.ApplyPackageStackStub(arg1, arg2)
+	}
+}
+
+func (fake *PackageClient) ApplyPackageStackCallCount() int {
+	fake.applyPackageStackMutex.RLock()
+	defer fake.applyPackageStackMutex.RUnlock()
+	return len(fake.applyPackageStackArgsForCall)
+}
+
+func (fake *PackageClient) ApplyPackageStackCalls(stub func(*packagedatamodel.PackageStackOptions, *packagedatamodel.PackageProgress)) {
+	fake.applyPackageStackMutex.Lock()
+	defer fake.applyPackageStackMutex.Unlock()
+	fake.ApplyPackageStackStub = stub
+}
+
+func (fake *PackageClient) ApplyPackageStackArgsForCall(i int) (*packagedatamodel.PackageStackOptions, *packagedatamodel.PackageProgress) {
+	fake.applyPackageStackMutex.RLock()
+	defer fake.applyPackageStackMutex.RUnlock()
+	argsForCall := fake.applyPackageStackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PackageClient) ApplyPackageStackSync(arg1 *packagedatamodel.PackageStackOptions) ([]packagedatamodel.StackPackageResult, error) {
+	fake.applyPackageStackSyncMutex.Lock()
+	ret, specificReturn := fake.applyPackageStackSyncReturnsOnCall[len(fake.applyPackageStackSyncArgsForCall)]
+	fake.applyPackageStackSyncArgsForCall = append(fake.applyPackageStackSyncArgsForCall, struct {
+		arg1 *packagedatamodel.PackageStackOptions
+	}{arg1})
+	stub := fake.ApplyPackageStackSyncStub
+	fakeReturns := fake.applyPackageStackSyncReturns
+	fake.recordInvocation("ApplyPackageStackSync", []interface{}{arg1})
+	fake.applyPackageStackSyncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PackageClient) ApplyPackageStackSyncCallCount() int {
+	fake.applyPackageStackSyncMutex.RLock()
+	defer fake.applyPackageStackSyncMutex.RUnlock()
+	return len(fake.applyPackageStackSyncArgsForCall)
+}
+
+func (fake *PackageClient) ApplyPackageStackSyncCalls(stub func(*packagedatamodel.PackageStackOptions) ([]packagedatamodel.StackPackageResult, error)) {
+	fake.applyPackageStackSyncMutex.Lock()
+	defer fake.applyPackageStackSyncMutex.Unlock()
+	fake.ApplyPackageStackSyncStub = stub
+}
+
+func (fake *PackageClient) ApplyPackageStackSyncArgsForCall(i int) *packagedatamodel.PackageStackOptions {
+	fake.applyPackageStackSyncMutex.RLock()
+	defer fake.applyPackageStackSyncMutex.RUnlock()
+	argsForCall := fake.applyPackageStackSyncArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PackageClient) ApplyPackageStackSyncReturns(result1 []packagedatamodel.StackPackageResult, result2 error) {
+	fake.applyPackageStackSyncMutex.Lock()
+	defer fake.applyPackageStackSyncMutex.Unlock()
+	fake.ApplyPackageStackSyncStub = nil
+	fake.applyPackageStackSyncReturns = struct {
+		result1 []packagedatamodel.StackPackageResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PackageClient) ApplyPackageStackSyncReturnsOnCall(i int, result1 []packagedatamodel.StackPackageResult, result2 error) {
+	fake.applyPackageStackSyncMutex.Lock()
+	defer fake.applyPackageStackSyncMutex.Unlock()
+	fake.ApplyPackageStackSyncStub = nil
+	if fake.applyPackageStackSyncReturnsOnCall == nil {
+		fake.applyPackageStackSyncReturnsOnCall = make(map[int]struct {
+			result1 []packagedatamodel.StackPackageResult
+			result2 error
+		})
+	}
+	fake.applyPackageStackSyncReturnsOnCall[i] = struct {
+		result1 []packagedatamodel.StackPackageResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PackageClient) DeleteRegistrySecret(arg1 *packagedatamodel.RegistrySecretOptions) (bool, error) {
 	fake.deleteRegistrySecretMutex.Lock()
 	ret, specificReturn := fake.deleteRegistrySecretReturnsOnCall[len(fake.deleteRegistrySecretArgsForCall)]
@@ -1477,6 +1610,70 @@ func (fake *PackageClient) UpdatePackageArgsForCall(i int) (*packagedatamodel.Pa
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *PackageClient) UpdatePackageDryRun(arg1 *packagedatamodel.PackageOptions) (string, error) {
+	fake.updatePackageDryRunMutex.Lock()
+	ret, specificReturn := fake.updatePackageDryRunReturnsOnCall[len(fake.updatePackageDryRunArgsForCall)]
+	fake.updatePackageDryRunArgsForCall = append(fake.updatePackageDryRunArgsForCall, struct {
+		arg1 *packagedatamodel.PackageOptions
+	}{arg1})
+	stub := fake.UpdatePackageDryRunStub
+	fakeReturns := fake.updatePackageDryRunReturns
+	fake.recordInvocation("UpdatePackageDryRun", []interface{}{arg1})
+	fake.updatePackageDryRunMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PackageClient) UpdatePackageDryRunCallCount() int {
+	fake.updatePackageDryRunMutex.RLock()
+	defer fake.updatePackageDryRunMutex.RUnlock()
+	return len(fake.updatePackageDryRunArgsForCall)
+}
+
+func (fake *PackageClient) UpdatePackageDryRunCalls(stub func(*packagedatamodel.PackageOptions) (string, error)) {
+	fake.updatePackageDryRunMutex.Lock()
+	defer fake.updatePackageDryRunMutex.Unlock()
+	fake.UpdatePackageDryRunStub = stub
+}
+
+func (fake *PackageClient) UpdatePackageDryRunArgsForCall(i int) *packagedatamodel.PackageOptions {
+	fake.updatePackageDryRunMutex.RLock()
+	defer fake.updatePackageDryRunMutex.RUnlock()
+	argsForCall := fake.updatePackageDryRunArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PackageClient) UpdatePackageDryRunReturns(result1 string, result2 error) {
+	fake.updatePackageDryRunMutex.Lock()
+	defer fake.updatePackageDryRunMutex.Unlock()
+	fake.UpdatePackageDryRunStub = nil
+	fake.updatePackageDryRunReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PackageClient) UpdatePackageDryRunReturnsOnCall(i int, result1 string, result2 error) {
+	fake.updatePackageDryRunMutex.Lock()
+	defer fake.updatePackageDryRunMutex.Unlock()
+	fake.UpdatePackageDryRunStub = nil
+	if fake.updatePackageDryRunReturnsOnCall == nil {
+		fake.updatePackageDryRunReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.updatePackageDryRunReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PackageClient) UpdatePackageSync(arg1 *packagedatamodel.PackageOptions, arg2 packagedatamodel.OperationType) error {
 	fake.updatePackageSyncMutex.Lock()
 	ret, specificReturn := fake.updatePackageSyncReturnsOnCall[len(fake.updatePackageSyncArgsForCall)]
@@ -1741,6 +1938,8 @@ func (fake *PackageClient) Invocations() map[string][][]interface{} {
 	defer fake.uninstallPackageSyncMutex.RUnlock()
 	fake.updatePackageMutex.RLock()
 	defer fake.updatePackageMutex.RUnlock()
+	fake.updatePackageDryRunMutex.RLock()
+	defer fake.updatePackageDryRunMutex.RUnlock()
 	fake.updatePackageSyncMutex.RLock()
 	defer fake.updatePackageSyncMutex.RUnlock()
 	fake.updateRegistrySecretMutex.RLock()
@@ -23,6 +23,8 @@ type PackageClient interface {
 	AddRegistrySecret(o *packagedatamodel.RegistrySecretOptions) error
 	AddRepository(o *packagedatamodel.RepositoryOptions, packageProgress *packagedatamodel.PackageProgress, operationType packagedatamodel.OperationType)
 	AddRepositorySync(o *packagedatamodel.RepositoryOptions, operationType packagedatamodel.OperationType) error
+	ApplyPackageStack(o *packagedatamodel.PackageStackOptions, packageProgress *packagedatamodel.PackageProgress)
+	ApplyPackageStackSync(o *packagedatamodel.PackageStackOptions) ([]packagedatamodel.StackPackageResult, error)
 	DeleteRegistrySecret(o *packagedatamodel.RegistrySecretOptions) (bool, error)
 	DeleteRepository(o *packagedatamodel.RepositoryOptions, packageProgress *packagedatamodel.PackageProgress)
 	DeleteRepositorySync(o *packagedatamodel.RepositoryOptions) error
@@ -42,6 +44,7 @@ type PackageClient interface {
 	UninstallPackageSync(o *packagedatamodel.PackageOptions) error
 	UpdateRegistrySecret(o *packagedatamodel.RegistrySecretOptions) error
 	UpdatePackage(o *packagedatamodel.PackageOptions, packageProgress *packagedatamodel.PackageProgress, operationType packagedatamodel.OperationType)
+	UpdatePackageDryRun(o *packagedatamodel.PackageOptions) (string, error)
 	UpdatePackageSync(o *packagedatamodel.PackageOptions, operationType packagedatamodel.OperationType) error
 	UpdateRepository(o *packagedatamodel.RepositoryOptions, progress *packagedatamodel.PackageProgress, operationType packagedatamodel.OperationType)
 	UpdateRepositorySync(o *packagedatamodel.RepositoryOptions, operationType packagedatamodel.OperationType) error
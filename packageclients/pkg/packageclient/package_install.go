@@ -21,6 +21,7 @@ import (
 
 	kappctrl "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	kapppkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
 	versions "github.com/vmware-tanzu/carvel-vendir/pkg/vendir/versions/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/packagedatamodel"
 )
@@ -57,6 +58,7 @@ func (p *pkgClient) InstallPackageSync(o *packagedatamodel.PackageOptions, opera
 func (p *pkgClient) installPackage(o *packagedatamodel.PackageOptions, progress *packagedatamodel.PackageProgress, operationType packagedatamodel.OperationType) {
 	var (
 		pkgInstall                      *kappipkg.PackageInstall
+		pkg                             *kapppkg.Package
 		pkgPluginResourceCreationStatus *packagedatamodel.PkgPluginResourceCreationStatus
 		err                             error
 	)
@@ -99,7 +101,11 @@ func (p *pkgClient) installPackage(o *packagedatamodel.PackageOptions, progress
 	}
 
 	progress.ProgressMsg <- fmt.Sprintf("Getting package metadata for '%s'", o.PackageName)
-	if _, _, err = p.GetPackage(o); err != nil {
+	if _, pkg, err = p.GetPackage(o); err != nil {
+		return
+	}
+
+	if err = validateDataValues(pkg, o.ValuesFile); err != nil {
 		return
 	}
 
@@ -278,7 +284,17 @@ func (p *pkgClient) createNamespace(namespace string) error {
 
 // createPackageInstall creates the PackageInstall CR
 func (p *pkgClient) createPackageInstall(o *packagedatamodel.PackageOptions, pkgPluginResourceCreationStatus *packagedatamodel.PkgPluginResourceCreationStatus) error {
-	// construct the PackageInstall CR
+	packageInstall := newPackageInstall(o, pkgPluginResourceCreationStatus.IsSecretCreated)
+
+	if err := p.kappClient.CreatePackageInstall(packageInstall, pkgPluginResourceCreationStatus); err != nil {
+		return errors.Wrap(err, "failed to create PackageInstall resource")
+	}
+
+	return nil
+}
+
+// newPackageInstall constructs the PackageInstall CR
+func newPackageInstall(o *packagedatamodel.PackageOptions, isSecretCreated bool) *kappipkg.PackageInstall {
 	packageInstall := &kappipkg.PackageInstall{
 		ObjectMeta: metav1.ObjectMeta{Name: o.PkgInstallName,
 			Namespace: o.Namespace,
@@ -297,7 +313,7 @@ func (p *pkgClient) createPackageInstall(o *packagedatamodel.PackageOptions, pkg
 	}
 
 	// if configuration data file was provided, reference the secret name in the PackageInstall
-	if pkgPluginResourceCreationStatus.IsSecretCreated {
+	if isSecretCreated {
 		packageInstall.Spec.Values = []kappipkg.PackageInstallValues{
 			{
 				SecretRef: &kappipkg.PackageInstallValuesSecretRef{
@@ -307,11 +323,7 @@ func (p *pkgClient) createPackageInstall(o *packagedatamodel.PackageOptions, pkg
 		}
 	}
 
-	if err := p.kappClient.CreatePackageInstall(packageInstall, pkgPluginResourceCreationStatus); err != nil {
-		return errors.Wrap(err, "failed to create PackageInstall resource")
-	}
-
-	return nil
+	return packageInstall
 }
 
 // createOrUpdateServiceAccount creates or updates a ServiceAccount resource
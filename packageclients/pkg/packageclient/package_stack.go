@@ -0,0 +1,290 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package packageclient
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/aunum/log"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/packagedatamodel"
+)
+
+// ReadPackageStack reads a package stack from a YAML file. Relative values file paths are resolved against the directory of the stack file
+func ReadPackageStack(stackFile string) (*packagedatamodel.PackageStack, error) {
+	b, err := os.ReadFile(stackFile)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to read from package stack file '%s'", stackFile))
+	}
+
+	stack := &packagedatamodel.PackageStack{}
+	if err := yaml.UnmarshalStrict(b, stack); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse package stack file '%s'", stackFile))
+	}
+
+	for i := range stack.Packages {
+		if stack.Packages[i].ValuesFile != "" && !filepath.IsAbs(stack.Packages[i].ValuesFile) {
+			stack.Packages[i].ValuesFile = filepath.Join(filepath.Dir(stackFile), stack.Packages[i].ValuesFile)
+		}
+	}
+
+	return stack, nil
+}
+
+// ApplyPackageStack installs or updates the packages of the stack. Independent packages are installed concurrently,
+// while a package is installed only once its dependencies have been successfully reconciled
+func (p *pkgClient) ApplyPackageStack(o *packagedatamodel.PackageStackOptions, progress *packagedatamodel.PackageProgress) {
+	p.applyPackageStack(o, progress, nil)
+}
+
+// ApplyPackageStackSync installs or updates the packages of the stack and returns the result of every package along with an error if any
+func (p *pkgClient) ApplyPackageStackSync(o *packagedatamodel.PackageStackOptions) ([]packagedatamodel.StackPackageResult, error) {
+	pp := newPackageProgress()
+	resultsCh := make(chan []packagedatamodel.StackPackageResult, 1)
+
+	go p.applyPackageStack(o, pp, resultsCh)
+
+	initialMsg := "Applying package stack"
+	err := DisplayProgress(initialMsg, pp)
+	results := <-resultsCh
+	if err != nil {
+		return results, err
+	}
+
+	log.Infof("\n %s", fmt.Sprintf("Applied package stack with %d package(s)", len(results)))
+	return results, nil
+}
+
+func (p *pkgClient) applyPackageStack(o *packagedatamodel.PackageStackOptions, progress *packagedatamodel.PackageProgress, resultsCh chan []packagedatamodel.StackPackageResult) {
+	var (
+		results []packagedatamodel.StackPackageResult
+		err     error
+	)
+
+	defer func() {
+		if err != nil {
+			progress.Err <- err
+		}
+		if resultsCh != nil {
+			resultsCh <- results
+		}
+		close(progress.ProgressMsg)
+		close(progress.Done)
+	}()
+
+	if o.Stack == nil || len(o.Stack.Packages) == 0 {
+		err = errors.New("package stack does not contain any package")
+		return
+	}
+	if err = validatePackageStack(o.Stack); err != nil {
+		return
+	}
+
+	if o.CreateNamespace {
+		for _, ns := range stackNamespaces(o) {
+			progress.ProgressMsg <- fmt.Sprintf("Creating namespace '%s'", ns)
+			if err = p.createNamespace(ns); err != nil {
+				return
+			}
+		}
+	}
+
+	results = p.installStackPackages(o, progress)
+	for i := range results {
+		if results[i].Err != nil {
+			err = &packagedatamodel.PackageStackError{Results: results}
+			return
+		}
+	}
+}
+
+// installStackPackages installs every package of the stack in its own goroutine, which waits for the goroutines of
+// the dependencies to complete before installing the package
+func (p *pkgClient) installStackPackages(o *packagedatamodel.PackageStackOptions, progress *packagedatamodel.PackageProgress) []packagedatamodel.StackPackageResult {
+	pkgs := o.Stack.Packages
+	results := make([]packagedatamodel.StackPackageResult, len(pkgs))
+	done := make(map[string]chan struct{}, len(pkgs))
+	index := make(map[string]int, len(pkgs))
+	hasDependents := make(map[string]bool)
+	for i := range pkgs {
+		done[pkgs[i].Name] = make(chan struct{})
+		index[pkgs[i].Name] = i
+		for _, dep := range pkgs[i].DependsOn {
+			hasDependents[dep] = true
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range pkgs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(done[pkgs[i].Name])
+
+			pkg := &pkgs[i]
+			results[i].Name = pkg.Name
+			results[i].Namespace = stackPackageNamespace(o, pkg)
+
+			for _, dep := range pkg.DependsOn {
+				<-done[dep]
+				if results[index[dep]].Err != nil {
+					results[i].Status = packagedatamodel.StackPackageSkipped
+					results[i].Err = fmt.Errorf("dependency '%s' was not installed", dep)
+					progress.ProgressMsg <- fmt.Sprintf("Skipping package '%s' as dependency '%s' was not installed", pkg.Name, dep)
+					return
+				}
+			}
+
+			results[i].Status, results[i].Err = p.installStackPackage(o, pkg, hasDependents[pkg.Name], progress)
+		}(i)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// installStackPackage installs or updates a package of the stack. The package is waited for to be successfully
+// reconciled if other packages depend on it
+func (p *pkgClient) installStackPackage(o *packagedatamodel.PackageStackOptions, pkg *packagedatamodel.StackPackage, hasDependents bool, progress *packagedatamodel.PackageProgress) (packagedatamodel.StackPackageStatus, error) {
+	var err error
+
+	opts := &packagedatamodel.PackageOptions{
+		PkgInstallName:     pkg.Name,
+		PackageName:        pkg.PackageName,
+		Version:            pkg.Version,
+		Namespace:          stackPackageNamespace(o, pkg),
+		ValuesFile:         pkg.ValuesFile,
+		ServiceAccountName: pkg.ServiceAccountName,
+		PollInterval:       o.PollInterval,
+		PollTimeout:        o.PollTimeout,
+		Wait:               o.Wait || hasDependents,
+	}
+	status := packagedatamodel.StackPackageInstalled
+
+	pp := newPackageProgress()
+	go p.installPackage(opts, pp, packagedatamodel.OperationTypeInstall)
+
+	progress.ProgressMsg <- fmt.Sprintf("Installing package '%s'", pkg.Name)
+	for pp.Done != nil {
+		select {
+		case pkgErr := <-pp.Err:
+			if pkgErr.Error() == packagedatamodel.ErrPackageAlreadyExists {
+				status = packagedatamodel.StackPackageUpdated
+			} else if err == nil {
+				err = pkgErr
+			}
+		case msg, ok := <-pp.ProgressMsg:
+			if !ok {
+				pp.ProgressMsg = nil
+				continue
+			}
+			progress.ProgressMsg <- fmt.Sprintf("%s: %s", pkg.Name, msg)
+		case <-pp.Done:
+			pp.Done = nil
+		}
+	}
+	if err != nil {
+		return packagedatamodel.StackPackageFailed, err
+	}
+
+	// An installed package which is left unchanged is not waited for by the update, while the dependents may only
+	// proceed once it is successfully reconciled
+	if hasDependents {
+		if err = p.waitForResourceInstallation(opts.PkgInstallName, opts.Namespace, opts.PollInterval, opts.PollTimeout, progress.ProgressMsg, packagedatamodel.ResourceTypePackageInstall); err != nil {
+			return packagedatamodel.StackPackageFailed, err
+		}
+	}
+
+	progress.ProgressMsg <- fmt.Sprintf("Package '%s' %s", pkg.Name, status)
+	return status, nil
+}
+
+// validatePackageStack verifies that the packages of the stack are uniquely named, that they only depend on packages of
+// the stack and that the dependencies are acyclic
+func validatePackageStack(stack *packagedatamodel.PackageStack) error {
+	pkgs := make(map[string]*packagedatamodel.StackPackage, len(stack.Packages))
+	for i := range stack.Packages {
+		pkg := &stack.Packages[i]
+		if pkg.Name == "" || pkg.PackageName == "" || pkg.Version == "" {
+			return fmt.Errorf("package #%d of the package stack must have a name, a packageName and a version", i+1)
+		}
+		if _, ok := pkgs[pkg.Name]; ok {
+			return fmt.Errorf("package '%s' is defined more than once in the package stack", pkg.Name)
+		}
+		pkgs[pkg.Name] = pkg
+	}
+
+	for i := range stack.Packages {
+		for _, dep := range stack.Packages[i].DependsOn {
+			if _, ok := pkgs[dep]; !ok {
+				return fmt.Errorf("package '%s' depends on package '%s' which is not defined in the package stack", stack.Packages[i].Name, dep)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(pkgs))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			for i := range path {
+				if path[i] == name {
+					return fmt.Errorf("dependency cycle detected in the package stack: %s", strings.Join(append(path[i:], name), " -> "))
+				}
+			}
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range pkgs[name].DependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	for i := range stack.Packages {
+		if err := visit(stack.Packages[i].Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stackNamespaces returns the distinct namespaces of the packages of the stack
+func stackNamespaces(o *packagedatamodel.PackageStackOptions) []string {
+	var namespaces []string
+	seen := make(map[string]bool)
+	for i := range o.Stack.Packages {
+		ns := stackPackageNamespace(o, &o.Stack.Packages[i])
+		if !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+func stackPackageNamespace(o *packagedatamodel.PackageStackOptions, pkg *packagedatamodel.StackPackage) string {
+	if pkg.Namespace != "" {
+		return pkg.Namespace
+	}
+	return o.Namespace
+}
@@ -0,0 +1,246 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package packageclient_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kappctrl "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/fakes"
+	. "github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/packageclient"
+	"github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/packagedatamodel"
+)
+
+const testPackageStack = `packages:
+- name: cert-manager
+  packageName: test-pkg.com
+  version: 1.0.0
+- name: contour
+  packageName: test-pkg.com
+  version: 1.0.0
+  valuesFile: contour-values.yaml
+  dependsOn: [cert-manager]
+- name: harbor
+  packageName: test-pkg.com
+  version: 1.0.0
+  namespace: harbor-ns
+  dependsOn: [cert-manager, contour]
+`
+
+// stackKappClient is a fake kapp client recording the order in which the package installs are created.
+// Package installs are reconciled as soon as they are created, unless their reconciliation is set to fail
+type stackKappClient struct {
+	*fakes.KappClient
+	mutex     sync.Mutex
+	created   []string
+	installs  map[string]*kappipkg.PackageInstall
+	failNames map[string]bool
+}
+
+func newStackKappClient(failNames ...string) *stackKappClient {
+	k := &stackKappClient{
+		KappClient: &fakes.KappClient{},
+		installs:   make(map[string]*kappipkg.PackageInstall),
+		failNames:  make(map[string]bool),
+	}
+	for _, name := range failNames {
+		k.failNames[name] = true
+	}
+	k.GetClientReturns(&fakes.CrtClient{})
+	k.ListPackagesReturns(testPkgVersionList, nil)
+	k.CreatePackageInstallStub = func(pkgInstall *kappipkg.PackageInstall, _ *packagedatamodel.PkgPluginResourceCreationStatus) error {
+		k.mutex.Lock()
+		defer k.mutex.Unlock()
+		condition := kappctrl.ReconcileSucceeded
+		if k.failNames[pkgInstall.Name] {
+			condition = kappctrl.ReconcileFailed
+		}
+		installed := pkgInstall.DeepCopy()
+		installed.Generation = 1
+		installed.Status.ObservedGeneration = 1
+		installed.Status.Conditions = []kappctrl.AppCondition{{Type: condition, Status: corev1.ConditionTrue}}
+		k.installs[pkgInstall.Name] = installed
+		k.created = append(k.created, pkgInstall.Name)
+		return nil
+	}
+	k.GetPackageInstallStub = func(name, namespace string) (*kappipkg.PackageInstall, error) {
+		k.mutex.Lock()
+		defer k.mutex.Unlock()
+		if pkgInstall, ok := k.installs[name]; ok {
+			return pkgInstall, nil
+		}
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: packagedatamodel.KindPackageInstall}, name)
+	}
+	return k
+}
+
+func (k *stackKappClient) createdIndex(name string) int {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	for i := range k.created {
+		if k.created[i] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+var _ = Describe("Apply Package Stack", func() {
+	var (
+		ctl      PackageClient
+		kappCtl  *stackKappClient
+		err      error
+		stack    *packagedatamodel.PackageStack
+		progress *packagedatamodel.PackageProgress
+		tmpDir   string
+	)
+
+	BeforeEach(func() {
+		tmpDir, err = os.MkdirTemp("", "package-stack")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(tmpDir, "stack.yaml"), []byte(testPackageStack), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(tmpDir, "contour-values.yaml"), []byte("test"), 0644)).To(Succeed())
+		stack, err = ReadPackageStack(filepath.Join(tmpDir, "stack.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		kappCtl = newStackKappClient()
+	})
+
+	JustBeforeEach(func() {
+		progress = &packagedatamodel.PackageProgress{
+			ProgressMsg: make(chan string, 10),
+			Err:         make(chan error),
+			Done:        make(chan struct{}),
+		}
+		ctl, err = NewPackageClientWithKappClient(kappCtl)
+		Expect(err).NotTo(HaveOccurred())
+		go ctl.ApplyPackageStack(&packagedatamodel.PackageStackOptions{
+			Stack:        stack,
+			Namespace:    testNamespaceName,
+			PollInterval: testPollInterval,
+			PollTimeout:  testPollTimeout,
+		}, progress)
+		err = testReceive(progress)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	Context("reading the package stack file", func() {
+		It("should resolve the values files against the directory of the stack file", func() {
+			Expect(stack.Packages).To(HaveLen(3))
+			Expect(stack.Packages[1].ValuesFile).To(Equal(filepath.Join(tmpDir, "contour-values.yaml")))
+			Expect(stack.Packages[2].DependsOn).To(Equal([]string{"cert-manager", "contour"}))
+		})
+	})
+
+	Context("success in installing the packages in the order of their dependencies", func() {
+		It(testSuccessMsg, func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kappCtl.CreatePackageInstallCallCount()).To(Equal(3))
+			Expect(kappCtl.createdIndex("cert-manager")).To(Equal(0))
+			Expect(kappCtl.createdIndex("contour")).To(Equal(1))
+			Expect(kappCtl.createdIndex("harbor")).To(Equal(2))
+			pkgInstall, _ := kappCtl.CreatePackageInstallArgsForCall(2)
+			Expect(pkgInstall.Namespace).To(Equal("harbor-ns"))
+		})
+	})
+
+	Context("failure in reconciling a dependency", func() {
+		BeforeEach(func() {
+			kappCtl = newStackKappClient("contour")
+		})
+		It(testFailureMsg, func() {
+			Expect(err).To(HaveOccurred())
+			stackErr, ok := err.(*packagedatamodel.PackageStackError)
+			Expect(ok).To(BeTrue())
+			Expect(stackErr.Results).To(HaveLen(3))
+			Expect(stackErr.Results[0].Status).To(Equal(packagedatamodel.StackPackageInstalled))
+			Expect(stackErr.Results[1].Status).To(Equal(packagedatamodel.StackPackageFailed))
+			Expect(stackErr.Results[2].Status).To(Equal(packagedatamodel.StackPackageSkipped))
+			Expect(err.Error()).To(ContainSubstring("'harbor' (dependency 'contour' was not installed)"))
+			Expect(kappCtl.createdIndex("harbor")).To(Equal(-1))
+		})
+	})
+
+	Context("failure in creating the package install of an independent package", func() {
+		BeforeEach(func() {
+			stack.Packages[1].DependsOn = nil
+			stack.Packages[2].DependsOn = nil
+			kappCtl.CreatePackageInstallStub = func(pkgInstall *kappipkg.PackageInstall, _ *packagedatamodel.PkgPluginResourceCreationStatus) error {
+				if pkgInstall.Name == "contour" {
+					return errors.New("failure in CreatePackageInstall")
+				}
+				return nil
+			}
+		})
+		It(testFailureMsg, func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'contour' (failed to create PackageInstall resource: failure in CreatePackageInstall)"))
+			Expect(err.Error()).ToNot(ContainSubstring("harbor"))
+			Expect(kappCtl.CreatePackageInstallCallCount()).To(Equal(3))
+		})
+	})
+
+	Context("success in updating an already installed package", func() {
+		BeforeEach(func() {
+			stack.Packages = stack.Packages[:1]
+			kappCtl.installs["cert-manager"] = &kappipkg.PackageInstall{
+				ObjectMeta: metav1.ObjectMeta{Name: "cert-manager", Namespace: testNamespaceName},
+				Spec: kappipkg.PackageInstallSpec{
+					ServiceAccountName: testServiceAccountName,
+					PackageRef:         &kappipkg.PackageRef{RefName: testPkgName, VersionSelection: testVersionSelection.DeepCopy()},
+				},
+			}
+		})
+		It(testSuccessMsg, func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kappCtl.CreatePackageInstallCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("failure in validating a package stack with a dependency cycle", func() {
+		BeforeEach(func() {
+			stack.Packages[0].DependsOn = []string{"harbor"}
+		})
+		It(testFailureMsg, func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("dependency cycle detected in the package stack: cert-manager -> harbor -> cert-manager"))
+			Expect(kappCtl.CreatePackageInstallCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("failure in validating a package stack with an unknown dependency", func() {
+		BeforeEach(func() {
+			stack.Packages[1].DependsOn = []string{"unknown"}
+		})
+		It(testFailureMsg, func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("package 'contour' depends on package 'unknown' which is not defined in the package stack"))
+		})
+	})
+
+	Context("failure in validating a package stack with duplicated packages", func() {
+		BeforeEach(func() {
+			stack.Packages[1].Name = "cert-manager"
+			stack.Packages[2].DependsOn = []string{"cert-manager"}
+		})
+		It(testFailureMsg, func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("package 'cert-manager' is defined more than once in the package stack"))
+		})
+	})
+})
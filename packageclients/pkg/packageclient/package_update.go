@@ -8,14 +8,20 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/aunum/log"
 	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
 	corev1 "k8s.io/api/core/v1"
 	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	kapppkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/packagedatamodel"
 )
 
@@ -45,6 +51,7 @@ func (p *pkgClient) updatePackage(o *packagedatamodel.PackageOptions, progress *
 	var (
 		pkgInstall                      *kappipkg.PackageInstall
 		pkgInstallToUpdate              *kappipkg.PackageInstall
+		pkg                             *kapppkg.Package
 		pkgPluginResourceCreationStatus packagedatamodel.PkgPluginResourceCreationStatus
 		err                             error
 		changed                         bool
@@ -83,7 +90,11 @@ func (p *pkgClient) updatePackage(o *packagedatamodel.PackageOptions, progress *
 	}
 
 	progress.ProgressMsg <- fmt.Sprintf("Getting package metadata for '%s'", pkgInstallToUpdate.Spec.PackageRef.RefName)
-	if _, _, err = p.GetPackage(o); err != nil {
+	if _, pkg, err = p.GetPackage(o); err != nil {
+		return
+	}
+
+	if err = validateDataValues(pkg, o.ValuesFile); err != nil {
 		return
 	}
 
@@ -196,3 +207,142 @@ func (p *pkgClient) updateDataValuesSecret(o *packagedatamodel.PackageOptions) e
 
 	return nil
 }
+
+// UpdatePackageDryRun validates the update of the installed package without applying it and returns a unified diff
+// between the current and the proposed data values and PackageInstall spec. An empty diff is returned if nothing would change
+func (p *pkgClient) UpdatePackageDryRun(o *packagedatamodel.PackageOptions) (string, error) {
+	var (
+		pkgInstall         *kappipkg.PackageInstall
+		pkgInstallToUpdate *kappipkg.PackageInstall
+		pkg                *kapppkg.Package
+		currentValues      string
+		currentSpec        string
+		err                error
+	)
+
+	// the options are copied as preparing the update fills the package name and version of the options
+	opts := *o
+
+	if pkgInstall, err = p.kappClient.GetPackageInstall(opts.PkgInstallName, opts.Namespace); err != nil {
+		if !k8serror.IsNotFound(err) {
+			return "", err
+		}
+		pkgInstall = nil
+	}
+
+	if pkgInstall == nil {
+		if !opts.Install {
+			return "", &packagedatamodel.PackagePluginNonCriticalError{Reason: packagedatamodel.ErrPackageNotInstalled}
+		}
+		if err = p.validateValuesFile(&opts); err != nil {
+			return "", err
+		}
+		if opts.ServiceAccountName == "" {
+			opts.ServiceAccountName = fmt.Sprintf(packagedatamodel.ServiceAccountName, opts.PkgInstallName, opts.Namespace)
+		}
+		pkgInstallToUpdate = newPackageInstall(&opts, false)
+	} else {
+		if pkgInstallToUpdate, _, err = p.preparePackageInstallForUpdate(&opts, pkgInstall); err != nil {
+			return "", err
+		}
+		if currentValues, err = p.getDataValues(pkgInstall); err != nil {
+			return "", err
+		}
+		if currentSpec, err = marshalPackageInstallSpec(pkgInstall); err != nil {
+			return "", err
+		}
+	}
+
+	if _, pkg, err = p.GetPackage(&opts); err != nil {
+		return "", err
+	}
+	if err = validateDataValues(pkg, opts.ValuesFile); err != nil {
+		return "", err
+	}
+
+	proposedValues := currentValues
+	if opts.ValuesFile != "" {
+		secretName := fmt.Sprintf(packagedatamodel.SecretName, opts.PkgInstallName, opts.Namespace)
+		b, err := os.ReadFile(opts.ValuesFile)
+		if err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("failed to read from data values file '%s'", opts.ValuesFile))
+		}
+		proposedValues = formatDataValues(secretName, filepath.Base(opts.ValuesFile), b)
+		pkgInstallToUpdate.Spec.Values = []kappipkg.PackageInstallValues{
+			{SecretRef: &kappipkg.PackageInstallValuesSecretRef{Name: secretName}},
+		}
+	}
+
+	proposedSpec, err := marshalPackageInstallSpec(pkgInstallToUpdate)
+	if err != nil {
+		return "", err
+	}
+
+	valuesDiff, err := unifiedDiff(currentValues, proposedValues, "current data values", "proposed data values")
+	if err != nil {
+		return "", err
+	}
+	specDiff, err := unifiedDiff(currentSpec, proposedSpec, "current PackageInstall spec", "proposed PackageInstall spec")
+	if err != nil {
+		return "", err
+	}
+
+	return valuesDiff + specDiff, nil
+}
+
+// getDataValues returns the data values of the secrets referenced by the PackageInstall
+func (p *pkgClient) getDataValues(pkgInstall *kappipkg.PackageInstall) (string, error) {
+	var values strings.Builder
+
+	for _, v := range pkgInstall.Spec.Values {
+		if v.SecretRef == nil {
+			continue
+		}
+		secret := &corev1.Secret{}
+		objKey := crtclient.ObjectKey{Name: v.SecretRef.Name, Namespace: pkgInstall.Namespace}
+		if err := p.kappClient.GetClient().Get(context.Background(), objKey, secret); err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("failed to get the data values secret '%s'", v.SecretRef.Name))
+		}
+		keys := make([]string, 0, len(secret.Data))
+		for k := range secret.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			values.WriteString(formatDataValues(secret.Name, k, secret.Data[k]))
+		}
+	}
+
+	return values.String(), nil
+}
+
+// formatDataValues formats the data values stored under the key of the secret, prefixed with their origin
+func formatDataValues(secretName, key string, data []byte) string {
+	values := fmt.Sprintf("# secret '%s', key '%s'\n%s", secretName, key, data)
+	if !strings.HasSuffix(values, "\n") {
+		values += "\n"
+	}
+	return values
+}
+
+func marshalPackageInstallSpec(pkgInstall *kappipkg.PackageInstall) (string, error) {
+	b, err := yaml.Marshal(pkgInstall.Spec)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal PackageInstall spec")
+	}
+	return string(b), nil
+}
+
+// unifiedDiff returns the unified diff between a and b, or an empty string if they are equal
+func unifiedDiff(a, b, fromFile, toFile string) (string, error) {
+	if a == b {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package packageclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	kapppkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/packagedatamodel"
+)
+
+// validateDataValues validates the data values of the values file against the values schema of the package.
+// The validation is skipped if no values file is provided or if the package does not define a values schema
+func validateDataValues(pkg *kapppkg.Package, valuesFile string) error {
+	if valuesFile == "" || pkg == nil || len(pkg.Spec.ValuesSchema.OpenAPIv3.Raw) == 0 {
+		return nil
+	}
+
+	schemaJSON, err := yaml.YAMLToJSON(pkg.Spec.ValuesSchema.OpenAPIv3.Raw)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to parse the values schema of package '%s'", pkg.Spec.RefName))
+	}
+	schema := &openapi3.Schema{}
+	if err := json.Unmarshal(schemaJSON, schema); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to parse the values schema of package '%s'", pkg.Spec.RefName))
+	}
+
+	values, err := readDataValues(valuesFile)
+	if err != nil {
+		return err
+	}
+
+	if err := schema.VisitJSON(values, openapi3.MultiErrors()); err != nil {
+		return &packagedatamodel.ValuesValidationError{
+			PackageName: pkg.Spec.RefName,
+			Version:     pkg.Spec.Version,
+			Errors:      valuesFieldErrors(err),
+		}
+	}
+
+	return nil
+}
+
+// readDataValues reads the data values of the values file. Multiple YAML documents are merged in order,
+// as they would be by ytt
+func readDataValues(valuesFile string) (map[string]interface{}, error) {
+	b, err := os.ReadFile(valuesFile)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to read from data values file '%s'", valuesFile))
+	}
+
+	values := make(map[string]interface{})
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(b), len(b))
+	for {
+		doc := make(map[string]interface{})
+		if err := decoder.Decode(&doc); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to parse data values file '%s'", valuesFile))
+		}
+		mergeDataValues(values, doc)
+	}
+
+	return values, nil
+}
+
+// mergeDataValues deep merges the src data values into dst
+func mergeDataValues(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeDataValues(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// valuesFieldErrors flattens the schema validation errors into field level errors
+func valuesFieldErrors(err error) []packagedatamodel.ValuesFieldError {
+	var fieldErrs []packagedatamodel.ValuesFieldError
+
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		for _, nested := range e {
+			fieldErrs = append(fieldErrs, valuesFieldErrors(nested)...)
+		}
+	case *openapi3.SchemaError:
+		reason := e.Reason
+		switch {
+		case e.Origin != nil:
+			reason = e.Origin.Error()
+		case reason == "":
+			reason = fmt.Sprintf("doesn't match schema %q", e.SchemaField)
+		}
+		fieldErrs = append(fieldErrs, packagedatamodel.ValuesFieldError{
+			Field:  strings.Join(e.JSONPointer(), "."),
+			Reason: reason,
+		})
+	default:
+		fieldErrs = append(fieldErrs, packagedatamodel.ValuesFieldError{Reason: err.Error()})
+	}
+
+	return fieldErrs
+}
@@ -0,0 +1,267 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package packageclient_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	kapppkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
+	versions "github.com/vmware-tanzu/carvel-vendir/pkg/vendir/versions/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/fakes"
+	. "github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/packageclient"
+	"github.com/vmware-tanzu/tanzu-framework/packageclients/pkg/packagedatamodel"
+)
+
+const (
+	testValuesSchema = `type: object
+additionalProperties: false
+properties:
+  namespace:
+    type: string
+    default: test-ns
+  replicas:
+    type: integer
+    default: 1
+  service:
+    type: object
+    additionalProperties: false
+    properties:
+      type:
+        type: string
+        enum: [ClusterIP, LoadBalancer]
+`
+	testValidValues = `#@data/values
+---
+replicas: 2
+---
+service:
+  type: LoadBalancer
+`
+	testInvalidValues = `replicas: two
+service:
+  type: NodePort
+unknown: true
+`
+	testSecretValues = "replicas: 1\n"
+)
+
+var testSchemaPkgVersionList = &kapppkg.PackageList{
+	Items: []kapppkg.Package{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: testPkgName + "." + testPkgVersion, Namespace: testNamespaceName},
+			Spec: kapppkg.PackageSpec{
+				RefName:      testPkgName,
+				Version:      testPkgVersion,
+				ValuesSchema: kapppkg.ValuesSchema{OpenAPIv3: runtime.RawExtension{Raw: []byte(testValuesSchema)}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: testPkgName + ".2.0.0", Namespace: testNamespaceName},
+			Spec: kapppkg.PackageSpec{
+				RefName:      testPkgName,
+				Version:      "2.0.0",
+				ValuesSchema: kapppkg.ValuesSchema{OpenAPIv3: runtime.RawExtension{Raw: []byte(testValuesSchema)}},
+			},
+		},
+	},
+}
+
+func writeTestValuesFile(dir, content string) string {
+	valuesFile := filepath.Join(dir, "values.yaml")
+	Expect(os.WriteFile(valuesFile, []byte(content), 0644)).To(Succeed())
+	return valuesFile
+}
+
+var _ = Describe("Install Package with values schema validation", func() {
+	var (
+		ctl      PackageClient
+		kappCtl  *fakes.KappClient
+		err      error
+		options  packagedatamodel.PackageOptions
+		progress *packagedatamodel.PackageProgress
+		tmpDir   string
+	)
+
+	BeforeEach(func() {
+		tmpDir, err = os.MkdirTemp("", "values-schema")
+		Expect(err).ToNot(HaveOccurred())
+		options = packagedatamodel.PackageOptions{
+			PkgInstallName: testPkgInstallName,
+			Namespace:      testNamespaceName,
+			PackageName:    testPkgName,
+			Version:        testPkgVersion,
+		}
+		kappCtl = &fakes.KappClient{}
+		kappCtl.GetClientReturns(&fakes.CrtClient{})
+		kappCtl.ListPackagesReturns(testSchemaPkgVersionList, nil)
+		kappCtl.GetPackageInstallReturns(nil, apierrors.NewNotFound(schema.GroupResource{Resource: packagedatamodel.KindPackageInstall}, testPkgInstallName))
+	})
+
+	JustBeforeEach(func() {
+		progress = &packagedatamodel.PackageProgress{
+			ProgressMsg: make(chan string, 10),
+			Err:         make(chan error),
+			Done:        make(chan struct{}),
+		}
+		ctl, err = NewPackageClientWithKappClient(kappCtl)
+		Expect(err).NotTo(HaveOccurred())
+		go ctl.InstallPackage(&options, progress, packagedatamodel.OperationTypeInstall)
+		err = testReceive(progress)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	Context("success in installing the package with data values conforming to the values schema", func() {
+		BeforeEach(func() {
+			options.ValuesFile = writeTestValuesFile(tmpDir, testValidValues)
+		})
+		It(testSuccessMsg, func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kappCtl.CreatePackageInstallCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("failure in installing the package with data values violating the values schema", func() {
+		BeforeEach(func() {
+			options.ValuesFile = writeTestValuesFile(tmpDir, testInvalidValues)
+		})
+		It(testFailureMsg, func() {
+			Expect(err).To(HaveOccurred())
+			validationErr, ok := err.(*packagedatamodel.ValuesValidationError)
+			Expect(ok).To(BeTrue())
+			Expect(validationErr.PackageName).To(Equal(testPkgName))
+			fields := make(map[string]string)
+			for _, fieldErr := range validationErr.Errors {
+				fields[fieldErr.Field] = fieldErr.Reason
+			}
+			Expect(fields).To(HaveKey("replicas"))
+			Expect(fields).To(HaveKey("service.type"))
+			Expect(fields).To(HaveKeyWithValue("", ContainSubstring("unknown")))
+			Expect(kappCtl.CreatePackageInstallCallCount()).To(Equal(0))
+		})
+	})
+})
+
+var _ = Describe("Update Package Dry Run", func() {
+	var (
+		ctl        PackageClient
+		crtCtl     *fakes.CrtClient
+		kappCtl    *fakes.KappClient
+		err        error
+		diff       string
+		options    packagedatamodel.PackageOptions
+		pkgInstall *kappipkg.PackageInstall
+		tmpDir     string
+	)
+
+	BeforeEach(func() {
+		tmpDir, err = os.MkdirTemp("", "update-dry-run")
+		Expect(err).ToNot(HaveOccurred())
+		options = packagedatamodel.PackageOptions{
+			PkgInstallName: testPkgInstallName,
+			Namespace:      testNamespaceName,
+			Version:        "2.0.0",
+		}
+		pkgInstall = &kappipkg.PackageInstall{
+			ObjectMeta: metav1.ObjectMeta{Name: testPkgInstallName, Namespace: testNamespaceName},
+			Spec: kappipkg.PackageInstallSpec{
+				ServiceAccountName: testServiceAccountName,
+				PackageRef: &kappipkg.PackageRef{
+					RefName:          testPkgName,
+					VersionSelection: &versions.VersionSelectionSemver{Constraints: testPkgVersion},
+				},
+				Values: []kappipkg.PackageInstallValues{{SecretRef: &kappipkg.PackageInstallValuesSecretRef{Name: testSecretValuesName}}},
+			},
+		}
+		kappCtl = &fakes.KappClient{}
+		crtCtl = &fakes.CrtClient{}
+		kappCtl.GetClientReturns(crtCtl)
+		kappCtl.ListPackagesReturns(testSchemaPkgVersionList, nil)
+		kappCtl.GetPackageInstallReturns(pkgInstall, nil)
+		crtCtl.GetStub = func(_ context.Context, key types.NamespacedName, obj crtclient.Object) error {
+			secret := obj.(*corev1.Secret)
+			secret.Name = key.Name
+			secret.Namespace = key.Namespace
+			secret.Data = map[string][]byte{"values.yaml": []byte(testSecretValues)}
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctl, err = NewPackageClientWithKappClient(kappCtl)
+		Expect(err).NotTo(HaveOccurred())
+		diff, err = ctl.UpdatePackageDryRun(&options)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	Context("success in previewing the update of the version and of the data values", func() {
+		BeforeEach(func() {
+			options.ValuesFile = writeTestValuesFile(tmpDir, "replicas: 3\n")
+		})
+		It(testSuccessMsg, func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff).To(ContainSubstring("--- current data values\n+++ proposed data values\n"))
+			Expect(diff).To(ContainSubstring("-replicas: 1\n+replicas: 3\n"))
+			Expect(diff).To(ContainSubstring("--- current PackageInstall spec\n+++ proposed PackageInstall spec\n"))
+			Expect(diff).To(ContainSubstring("-    constraints: 1.0.0\n+    constraints: 2.0.0\n"))
+			Expect(options.PackageName).To(BeEmpty())
+			Expect(kappCtl.UpdatePackageInstallCallCount()).To(Equal(0))
+			Expect(crtCtl.UpdateCallCount()).To(Equal(0))
+			Expect(crtCtl.CreateCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("success in previewing an update without any change", func() {
+		BeforeEach(func() {
+			options.Version = testPkgVersion
+		})
+		It(testSuccessMsg, func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff).To(BeEmpty())
+		})
+	})
+
+	Context("failure in previewing an update with data values violating the values schema", func() {
+		BeforeEach(func() {
+			options.ValuesFile = writeTestValuesFile(tmpDir, testInvalidValues)
+		})
+		It(testFailureMsg, func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("data values do not conform to the values schema of package 'test-pkg.com' with version '2.0.0'"))
+			Expect(err.Error()).To(ContainSubstring("'service.type'"))
+		})
+	})
+
+	Context("success in previewing the installation of a package which is not installed", func() {
+		BeforeEach(func() {
+			options.Install = true
+			options.PackageName = testPkgName
+			kappCtl.GetPackageInstallReturns(nil, apierrors.NewNotFound(schema.GroupResource{Resource: packagedatamodel.KindPackageInstall}, testPkgInstallName))
+		})
+		It(testSuccessMsg, func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff).ToNot(ContainSubstring("data values"))
+			Expect(diff).To(ContainSubstring("+serviceAccountName: " + testServiceAccountName))
+		})
+	})
+})
@@ -21,6 +21,7 @@ type PackageOptions struct {
 	PollTimeout            time.Duration
 	AllNamespaces          bool
 	CreateNamespace        bool
+	DryRun                 bool
 	Install                bool
 	Wait                   bool
 	SkipPrompt             bool
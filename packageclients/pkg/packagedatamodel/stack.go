@@ -0,0 +1,76 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package packagedatamodel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PackageStack is a list of packages installed together, in the order defined by their dependencies
+type PackageStack struct {
+	Packages []StackPackage `json:"packages"`
+}
+
+// StackPackage is a package of a PackageStack
+type StackPackage struct {
+	// Name of the installed package
+	Name string `json:"name"`
+	// PackageName is the public name of the package
+	PackageName string `json:"packageName"`
+	// Version of the package to be installed
+	Version string `json:"version"`
+	// Namespace to install the package, defaults to the namespace of the stack options
+	Namespace string `json:"namespace,omitempty"`
+	// ValuesFile is the path to the configuration values file, relative to the stack file
+	ValuesFile string `json:"valuesFile,omitempty"`
+	// ServiceAccountName is the name of an existing service account used to install the package
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// DependsOn lists the names of the packages of the stack which must be reconciled before installing this package
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// PackageStackOptions includes fields for package stack operations
+type PackageStackOptions struct {
+	Stack           *PackageStack
+	Namespace       string
+	PollInterval    time.Duration
+	PollTimeout     time.Duration
+	CreateNamespace bool
+	Wait            bool
+}
+
+// StackPackageStatus is the result of the installation of a package of a stack
+type StackPackageStatus string
+
+const (
+	StackPackageInstalled StackPackageStatus = "installed"
+	StackPackageUpdated   StackPackageStatus = "updated"
+	StackPackageFailed    StackPackageStatus = "failed"
+	StackPackageSkipped   StackPackageStatus = "skipped"
+)
+
+// StackPackageResult is the result of the installation of a package of a stack
+type StackPackageResult struct {
+	Name      string
+	Namespace string
+	Status    StackPackageStatus
+	Err       error
+}
+
+// PackageStackError is reported when the installation of some packages of a stack failed
+type PackageStackError struct {
+	Results []StackPackageResult
+}
+
+func (e *PackageStackError) Error() string {
+	var failed []string
+	for _, r := range e.Results {
+		if r.Err != nil {
+			failed = append(failed, fmt.Sprintf("'%s' (%s)", r.Name, r.Err.Error()))
+		}
+	}
+	return fmt.Sprintf("failed to install packages: %s", strings.Join(failed, ", "))
+}
@@ -0,0 +1,37 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package packagedatamodel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValuesFieldError is a violation of the values schema of a package by a field of the data values
+type ValuesFieldError struct {
+	// Field is the dot separated path of the field, empty for the root of the data values
+	Field string
+	// Reason describes why the field does not conform to the values schema
+	Reason string
+}
+
+// ValuesValidationError is reported when the data values do not conform to the values schema of the package
+type ValuesValidationError struct {
+	PackageName string
+	Version     string
+	Errors      []ValuesFieldError
+}
+
+func (e *ValuesValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "data values do not conform to the values schema of package '%s' with version '%s':", e.PackageName, e.Version)
+	for _, fieldErr := range e.Errors {
+		field := fieldErr.Field
+		if field == "" {
+			field = "<root>"
+		}
+		fmt.Fprintf(&b, "\n  - '%s': %s", field, fieldErr.Reason)
+	}
+	return b.String()
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"github.com/go-openapi/swag"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctl "sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/utils"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
+)
+
+// EnsureAutoscalerDeployment deploys the cluster autoscaler of a ClusterClass based cluster on the management
+// cluster if it is not deployed yet, or updates its image to match the kubernetes version of the cluster.
+// The autoscaler objects are rendered by clusterConfigurationGetter with the autoscaler enabled, as they are
+// at cluster creation, and are deleted along with the cluster by DeleteWorkloadCluster.
+func (c *TkgClient) EnsureAutoscalerDeployment(clusterClient clusterclient.Client, cluster *capi.Cluster, edition string,
+	clusterConfigurationGetter func(*CreateClusterOptions) ([]byte, error)) error {
+	k8sVersion := cluster.Spec.Topology.Version
+	deploymentName := cluster.Name + constants.AutoscalerDeploymentNameSuffix
+
+	err := clusterClient.GetResource(&appsv1.Deployment{}, deploymentName, cluster.Namespace, nil, nil)
+	if err == nil {
+		return clusterClient.ApplyPatchForAutoScalerDeployment(c.tkgBomClient, cluster.Name, k8sVersion, cluster.Namespace)
+	}
+	if !apierrors.IsNotFound(errors.Cause(err)) {
+		return errors.Wrapf(err, "unable to get autoscaler deployment '%s'", deploymentName)
+	}
+
+	objs, err := c.getAutoscalerObjects(cluster, edition, clusterConfigurationGetter)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Deploying cluster autoscaler '%s'", deploymentName)
+	for i := range objs {
+		err := clusterClient.CreateResource(&objs[i], objs[i].GetName(), objs[i].GetNamespace())
+		if err != nil && !apierrors.IsAlreadyExists(errors.Cause(err)) {
+			return errors.Wrapf(err, "unable to create autoscaler resource '%s'", objs[i].GetName())
+		}
+	}
+
+	log.Wait("Waiting for cluster autoscaler to be available...")
+	if err := clusterClient.WaitForAutoscalerDeployment(deploymentName, cluster.Namespace); err != nil {
+		log.Warningf("Unable to wait for autoscaler deployment to be ready. reason: %v", err)
+	}
+	return nil
+}
+
+// getAutoscalerObjects renders the configuration of the cluster with the autoscaler enabled and returns
+// the objects deployed on the management cluster to autoscale it
+func (c *TkgClient) getAutoscalerObjects(cluster *capi.Cluster, edition string, clusterConfigurationGetter func(*CreateClusterOptions) ([]byte, error)) ([]unstructured.Unstructured, error) {
+	tkrName := getClusterTKR(cluster.Labels)
+	if tkrName == "" {
+		return nil, errors.Errorf("unable to determine the TKr of cluster %s/%s", cluster.Namespace, cluster.Name)
+	}
+
+	// the dev plan is sufficient as only the autoscaler objects, which are the same for all plans,
+	// are kept from the generated configuration
+	createClusterOptions := CreateClusterOptions{
+		ClusterConfigOptions: ClusterConfigOptions{
+			ClusterName:              cluster.Name,
+			TargetNamespace:          cluster.Namespace,
+			KubernetesVersion:        cluster.Spec.Topology.Version,
+			ControlPlaneMachineCount: swag.Int64(int64(1)),
+			WorkerMachineCount:       swag.Int64(int64(1)),
+			ProviderRepositorySource: &clusterctl.ProviderRepositorySourceOptions{Flavor: constants.PlanDev},
+			YamlProcessor:            yamlprocessor.NewYttProcessorWithConfigDir(c.tkgConfigDir),
+		},
+		TKRVersion:     utils.GetTKRVersionFromTKRName(tkrName),
+		Edition:        edition,
+		ClusterType:    WorkloadCluster,
+		SkipValidation: true,
+	}
+	if cluster.Spec.InfrastructureRef != nil && cluster.Spec.InfrastructureRef.Kind == constants.InfrastructureRefVSphere {
+		// the control plane endpoint is required to generate the configuration of a vSphere cluster,
+		// it is not used by the autoscaler objects
+		createClusterOptions.VsphereControlPlaneEndpoint = "unused"
+	}
+	c.TKGConfigReaderWriter().Set(constants.ConfigVariableEnableAutoscaler, trueString)
+
+	yaml, err := clusterConfigurationGetter(&createClusterOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to generate the autoscaler configuration")
+	}
+	objs, err := utilyaml.ToUnstructured(yaml)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse the autoscaler configuration")
+	}
+
+	autoscalerObjects := map[string]bool{
+		"Deployment/" + cluster.Name + constants.AutoscalerDeploymentNameSuffix: true,
+		"ServiceAccount/" + cluster.Name + "-autoscaler":                        true,
+		"ClusterRoleBinding/" + cluster.Name + "-autoscaler-workload":           true,
+		"ClusterRoleBinding/" + cluster.Name + "-autoscaler-management":         true,
+		"ClusterRole/cluster-autoscaler-workload":                               true,
+		"ClusterRole/cluster-autoscaler-management":                             true,
+	}
+	var res []unstructured.Unstructured
+	for i := range objs {
+		if autoscalerObjects[objs[i].GetKind()+"/"+objs[i].GetName()] {
+			res = append(res, objs[i])
+		}
+	}
+	if len(res) != len(autoscalerObjects) {
+		return nil, errors.Errorf("the configuration of cluster %s/%s does not contain the autoscaler objects", cluster.Namespace, cluster.Name)
+	}
+	return res, nil
+}
@@ -0,0 +1,338 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	runv1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+	. "github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+)
+
+var _ = Describe("Node pool autoscaling", func() {
+	var (
+		err           error
+		clusterClient *fakes.ClusterClient
+		cluster       *capi.Cluster
+		options       *SetMachineDeploymentOptions
+	)
+
+	BeforeEach(func() {
+		clusterClient = &fakes.ClusterClient{}
+		cluster = multiNodeCluster()
+		cluster.Name = "test-cluster"
+		cluster.Namespace = "default"
+		cluster.Spec.Topology.Version = "v1.19.1+vmware.1"
+		options = &SetMachineDeploymentOptions{
+			ClusterName: "test-cluster",
+			Namespace:   "default",
+			NodePool:    NodePool{Name: md0Name},
+		}
+	})
+
+	Describe("DoSetMachineDeploymentCC", func() {
+		JustBeforeEach(func() {
+			err = DoSetMachineDeploymentCC(clusterClient, cluster, options)
+		})
+
+		Context("when autoscaling is enabled", func() {
+			BeforeEach(func() {
+				options.AutoscaleMin = pointer.Int32(1)
+				options.AutoscaleMax = pointer.Int32(5)
+			})
+
+			It("should set the autoscaler annotations and unset the replicas", func() {
+				Expect(err).NotTo(HaveOccurred())
+				md := cluster.Spec.Topology.Workers.MachineDeployments[0]
+				Expect(md.Metadata.Annotations).To(HaveKeyWithValue(constants.AutoscalerMinSizeAnnotation, "1"))
+				Expect(md.Metadata.Annotations).To(HaveKeyWithValue(constants.AutoscalerMaxSizeAnnotation, "5"))
+				Expect(md.Replicas).To(BeNil())
+				Expect(clusterClient.UpdateResourceCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when only one size of an autoscaled node pool is updated", func() {
+			BeforeEach(func() {
+				cluster.Spec.Topology.Workers.MachineDeployments[0].Metadata.Annotations = map[string]string{
+					constants.AutoscalerMinSizeAnnotation: "1",
+					constants.AutoscalerMaxSizeAnnotation: "5",
+				}
+				options.AutoscaleMax = pointer.Int32(10)
+			})
+
+			It("should keep the other size", func() {
+				Expect(err).NotTo(HaveOccurred())
+				md := cluster.Spec.Topology.Workers.MachineDeployments[0]
+				Expect(md.Metadata.Annotations).To(HaveKeyWithValue(constants.AutoscalerMinSizeAnnotation, "1"))
+				Expect(md.Metadata.Annotations).To(HaveKeyWithValue(constants.AutoscalerMaxSizeAnnotation, "10"))
+			})
+		})
+
+		Context("when only one size is given for a node pool that is not autoscaled", func() {
+			BeforeEach(func() {
+				options.AutoscaleMax = pointer.Int32(10)
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("both the autoscaler min and max sizes are required")))
+				Expect(clusterClient.UpdateResourceCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the min size is greater than the max size", func() {
+			BeforeEach(func() {
+				options.AutoscaleMin = pointer.Int32(6)
+				options.AutoscaleMax = pointer.Int32(5)
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("invalid autoscaler sizes")))
+			})
+		})
+
+		Context("when the replicas of an autoscaled node pool are set", func() {
+			BeforeEach(func() {
+				cluster.Spec.Topology.Workers.MachineDeployments[0].Metadata.Annotations = map[string]string{
+					constants.AutoscalerMinSizeAnnotation: "1",
+					constants.AutoscalerMaxSizeAnnotation: "5",
+				}
+				options.Replicas = pointer.Int32(3)
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("disable autoscaling to set its replicas")))
+			})
+		})
+
+		Context("when autoscaling is disabled", func() {
+			BeforeEach(func() {
+				md := &cluster.Spec.Topology.Workers.MachineDeployments[0]
+				md.Replicas = nil
+				md.Metadata.Annotations = map[string]string{
+					constants.AutoscalerMinSizeAnnotation: "1",
+					constants.AutoscalerMaxSizeAnnotation: "5",
+				}
+				options.DisableAutoscale = true
+
+				workerMD := capi.MachineDeployment{}
+				workerMD.Spec.Replicas = pointer.Int32(4)
+				workerMD.Spec.Template.Labels = map[string]string{"topology.cluster.x-k8s.io/deployment-name": md0Name}
+				clusterClient.GetMDObjectForClusterReturns([]capi.MachineDeployment{workerMD}, nil)
+			})
+
+			It("should remove the autoscaler annotations and pin the node pool to its current size", func() {
+				Expect(err).NotTo(HaveOccurred())
+				md := cluster.Spec.Topology.Workers.MachineDeployments[0]
+				Expect(md.Metadata.Annotations).NotTo(HaveKey(constants.AutoscalerMinSizeAnnotation))
+				Expect(md.Metadata.Annotations).NotTo(HaveKey(constants.AutoscalerMaxSizeAnnotation))
+				Expect(*md.Replicas).To(Equal(int32(4)))
+			})
+		})
+	})
+
+	Describe("SetMachineDeployment", func() {
+		var (
+			tkgClient *TkgClient
+			created   []crtclient.Object
+		)
+
+		BeforeEach(func() {
+			clusterClientFactory := &fakes.ClusterClientFactory{}
+			clusterClientFactory.NewClientReturns(clusterClient, nil)
+			clusterClient.IsClusterClassBasedReturns(true, nil)
+			tkgClient, err = CreateTKGClientOptsMutator("../fakes/config/config2.yaml", testingDir, "../fakes/config/bom/tkg-bom-v1.3.1.yaml", 2*time.Second, func(o Options) Options {
+				o.ClusterClientFactory = clusterClientFactory
+				o.FeatureFlagClient = &fakes.FeatureFlagClient{}
+				return o
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			created = nil
+			clusterClient.CreateResourceCalls(func(obj interface{}, name, namespace string, opts ...crtclient.CreateOption) error {
+				created = append(created, obj.(crtclient.Object))
+				return nil
+			})
+			options.AutoscaleMin = pointer.Int32(1)
+			options.AutoscaleMax = pointer.Int32(5)
+		})
+
+		JustBeforeEach(func() {
+			err = tkgClient.SetMachineDeployment(options)
+		})
+
+		Context("when the autoscaler is already deployed", func() {
+			BeforeEach(func() {
+				clusterClient.GetResourceCalls(func(obj interface{}, name, namespace string, pv clusterclient.PostVerifyrFunc, opt *clusterclient.PollOptions) error {
+					switch o := obj.(type) {
+					case *capi.Cluster:
+						cluster.DeepCopyInto(o)
+					case *appsv1.Deployment:
+						o.ObjectMeta = metav1.ObjectMeta{Name: name, Namespace: namespace}
+					}
+					return nil
+				})
+			})
+
+			It("should update the autoscaler image", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeEmpty())
+				Expect(clusterClient.ApplyPatchForAutoScalerDeploymentCallCount()).To(Equal(1))
+				_, clusterName, k8sVersion, namespace := clusterClient.ApplyPatchForAutoScalerDeploymentArgsForCall(0)
+				Expect(clusterName).To(Equal("test-cluster"))
+				Expect(k8sVersion).To(Equal("v1.19.1+vmware.1"))
+				Expect(namespace).To(Equal("default"))
+			})
+		})
+
+		Context("when the cluster is not ClusterClass based", func() {
+			BeforeEach(func() {
+				clusterClient.IsClusterClassBasedReturns(false, nil)
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("can only be set on ClusterClass based clusters")))
+			})
+		})
+	})
+
+	Describe("EnsureAutoscalerDeployment", func() {
+		var (
+			tkgClient     *TkgClient
+			created       []crtclient.Object
+			configuration string
+			ccOptions     *CreateClusterOptions
+		)
+
+		BeforeEach(func() {
+			tkgClient, err = CreateTKGClient("../fakes/config/config2.yaml", testingDir, "../fakes/config/bom/tkg-bom-v1.3.1.yaml", 2*time.Second)
+			Expect(err).NotTo(HaveOccurred())
+
+			cluster.Labels = map[string]string{runv1.LabelTKR: "v1.19.1---vmware.1-tkg.1"}
+			configuration = autoscalerConfiguration
+			ccOptions = nil
+			created = nil
+			clusterClient.CreateResourceCalls(func(obj interface{}, name, namespace string, opts ...crtclient.CreateOption) error {
+				created = append(created, obj.(crtclient.Object))
+				return nil
+			})
+			clusterClient.GetResourceReturns(apierrors.NewNotFound(schema.GroupResource{Resource: "deployments"}, "test-cluster-cluster-autoscaler"))
+		})
+
+		JustBeforeEach(func() {
+			err = tkgClient.EnsureAutoscalerDeployment(clusterClient, cluster, "tkg", func(o *CreateClusterOptions) ([]byte, error) {
+				ccOptions = o
+				return []byte(configuration), nil
+			})
+		})
+
+		Context("when the autoscaler is not deployed", func() {
+			It("should deploy the autoscaler objects of the cluster configuration", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ccOptions.ClusterName).To(Equal("test-cluster"))
+				Expect(ccOptions.TargetNamespace).To(Equal("default"))
+				Expect(ccOptions.TKRVersion).To(Equal("v1.19.1+vmware.1-tkg.1"))
+				Expect(ccOptions.Edition).To(Equal("tkg"))
+				enabled, err := tkgClient.TKGConfigReaderWriter().Get(constants.ConfigVariableEnableAutoscaler)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(enabled).To(Equal("true"))
+
+				var names []string
+				for _, obj := range created {
+					names = append(names, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+				}
+				Expect(names).To(ConsistOf(
+					"Deployment/test-cluster-cluster-autoscaler",
+					"ServiceAccount/test-cluster-autoscaler",
+					"ClusterRoleBinding/test-cluster-autoscaler-workload",
+					"ClusterRoleBinding/test-cluster-autoscaler-management",
+					"ClusterRole/cluster-autoscaler-workload",
+					"ClusterRole/cluster-autoscaler-management",
+				))
+				Expect(clusterClient.WaitForAutoscalerDeploymentCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the cluster configuration does not contain the autoscaler", func() {
+			BeforeEach(func() {
+				configuration = clusterObjectConfiguration
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("does not contain the autoscaler objects")))
+				Expect(created).To(BeEmpty())
+			})
+		})
+
+		Context("when the TKr of the cluster is unknown", func() {
+			BeforeEach(func() {
+				cluster.Labels = nil
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("unable to determine the TKr of cluster default/test-cluster")))
+				Expect(created).To(BeEmpty())
+			})
+		})
+	})
+})
+
+const clusterObjectConfiguration = `apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: test-cluster
+  namespace: default
+`
+
+const autoscalerConfiguration = clusterObjectConfiguration + `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-cluster-cluster-autoscaler
+  namespace: default
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-cluster-other
+  namespace: default
+---
+kind: ClusterRoleBinding
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: test-cluster-autoscaler-workload
+---
+kind: ClusterRoleBinding
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: test-cluster-autoscaler-management
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: test-cluster-autoscaler
+  namespace: default
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: cluster-autoscaler-workload
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: cluster-autoscaler-management
+`
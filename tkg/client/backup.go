@@ -0,0 +1,418 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctl "sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	runv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/utils"
+)
+
+const (
+	backupManifestFile  = "backup.yaml"
+	backupClusterAPIDir = "cluster-api"
+	backupTanzuDir      = "tanzu"
+
+	backupTKRsFile              = "tanzukubernetesreleases.yaml"
+	backupOSImagesFile          = "osimages.yaml"
+	backupSecretsFile           = "secrets.yaml"
+	backupProvidersFile         = "providers.yaml"
+	backupClusterBootstrapsFile = "clusterbootstraps.yaml"
+)
+
+// tanzuBackupFiles are the files of the Tanzu objects of a backup, in the order in which they are restored.
+// ClusterBootstraps and the objects they reference are restored before the clusters, so that the addons
+// manager adopts them instead of cloning new ones from the ClusterBootstrapTemplate.
+var tanzuBackupFiles = []string{backupTKRsFile, backupOSImagesFile, backupSecretsFile, backupProvidersFile, backupClusterBootstrapsFile}
+
+// BackupManagementClusterOptions options to back up the workload clusters of a management cluster
+type BackupManagementClusterOptions struct {
+	// ClusterName is the name of the management cluster, the current management cluster is used if empty
+	ClusterName string
+	Directory   string
+	// Namespaces to back up, all the namespaces with workload clusters are backed up if empty
+	Namespaces []string
+}
+
+// RestoreManagementClusterOptions options to restore a backup into a management cluster
+type RestoreManagementClusterOptions struct {
+	// ClusterName is the name of the target management cluster, the current management cluster is used if empty
+	ClusterName string
+	Directory   string
+}
+
+// ManagementClusterBackup describes the content of a management cluster backup
+type ManagementClusterBackup struct {
+	ClusterName string    `json:"clusterName"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Namespaces  []string  `json:"namespaces"`
+	Clusters    []string  `json:"clusters"`
+}
+
+// BackupManagementCluster saves the Cluster API objects of the workload clusters of a management cluster in move
+// order, along with the TKRs, OSImages, ClusterBootstraps and secrets they depend on, to a directory.
+// The clusters are paused while their objects are saved.
+func (c *TkgClient) BackupManagementCluster(options *BackupManagementClusterOptions) (*ManagementClusterBackup, error) {
+	if options == nil || options.Directory == "" {
+		return nil, errors.New("a backup directory is required")
+	}
+
+	regionContext, err := c.getRegionContextOrCurrent(options.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	clusterClient, err := c.clusterClientFactory.NewClient(regionContext.SourceFilePath, regionContext.ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get management cluster client")
+	}
+
+	clusters, err := clusterClient.ListClusters("")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list clusters")
+	}
+	backup := &ManagementClusterBackup{
+		ClusterName: regionContext.ClusterName,
+		CreatedAt:   time.Now().UTC(),
+	}
+	backup.Namespaces, err = getBackupNamespaces(clusters, options.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clusters {
+		if utils.ContainsString(backup.Namespaces, clusters[i].Namespace) {
+			backup.Clusters = append(backup.Clusters, fmt.Sprintf("%s/%s", clusters[i].Namespace, clusters[i].Name))
+		}
+	}
+
+	for _, namespace := range backup.Namespaces {
+		directory := filepath.Join(options.Directory, backupClusterAPIDir, namespace)
+		if err := os.MkdirAll(directory, constants.DefaultDirectoryPermissions); err != nil {
+			return nil, errors.Wrapf(err, "unable to create backup directory %s", directory)
+		}
+		log.Infof("Backing up Cluster API objects of namespace %s...", namespace)
+		backupOptions := clusterctl.BackupOptions{
+			FromKubeconfig: clusterctl.Kubeconfig{Path: regionContext.SourceFilePath, Context: regionContext.ContextName},
+			Namespace:      namespace,
+			Directory:      directory,
+		}
+		if err := c.clusterctlClient.Backup(backupOptions); err != nil {
+			return nil, errors.Wrapf(err, "unable to back up Cluster API objects of namespace %s", namespace)
+		}
+	}
+
+	log.Info("Backing up Tanzu objects...")
+	if err := BackupTanzuObjects(clusterClient, backup.Namespaces, filepath.Join(options.Directory, backupTanzuDir)); err != nil {
+		return nil, err
+	}
+
+	content, err := yaml.Marshal(backup)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to marshal backup manifest")
+	}
+	if err := utils.SaveFile(filepath.Join(options.Directory, backupManifestFile), content); err != nil {
+		return nil, err
+	}
+	log.Infof("Backup of %d cluster(s) of management cluster %q saved to %s", len(backup.Clusters), backup.ClusterName, options.Directory)
+	return backup, nil
+}
+
+// RestoreManagementCluster restores a backup taken by BackupManagementCluster into a management cluster.
+// The restored clusters and ClusterBootstraps are paused until all their objects are restored.
+func (c *TkgClient) RestoreManagementCluster(options *RestoreManagementClusterOptions) (*ManagementClusterBackup, error) {
+	if options == nil || options.Directory == "" {
+		return nil, errors.New("a backup directory is required")
+	}
+
+	backup, err := ReadManagementClusterBackup(options.Directory)
+	if err != nil {
+		return nil, err
+	}
+
+	regionContext, err := c.getRegionContextOrCurrent(options.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	clusterClient, err := c.clusterClientFactory.NewClient(regionContext.SourceFilePath, regionContext.ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get management cluster client")
+	}
+
+	for _, namespace := range backup.Namespaces {
+		if err := clusterClient.CreateNamespace(namespace); err != nil {
+			return nil, errors.Wrapf(err, "unable to create namespace %s", namespace)
+		}
+	}
+
+	log.Info("Restoring Tanzu objects...")
+	pausedClusterBootstraps, err := RestoreTanzuObjects(clusterClient, filepath.Join(options.Directory, backupTanzuDir))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, namespace := range backup.Namespaces {
+		log.Infof("Restoring Cluster API objects of namespace %s...", namespace)
+		restoreOptions := clusterctl.RestoreOptions{
+			ToKubeconfig: clusterctl.Kubeconfig{Path: regionContext.SourceFilePath, Context: regionContext.ContextName},
+			Directory:    filepath.Join(options.Directory, backupClusterAPIDir, namespace),
+		}
+		if err := c.clusterctlClient.Restore(restoreOptions); err != nil {
+			return nil, errors.Wrapf(err, "unable to restore Cluster API objects of namespace %s", namespace)
+		}
+	}
+
+	if err := ResumeClusterBootstraps(clusterClient, pausedClusterBootstraps); err != nil {
+		return nil, err
+	}
+	log.Infof("Backup of %d cluster(s) of management cluster %q restored into management cluster %q", len(backup.Clusters), backup.ClusterName, regionContext.ClusterName)
+	return backup, nil
+}
+
+// ReadManagementClusterBackup reads the manifest of a management cluster backup
+func ReadManagementClusterBackup(directory string) (*ManagementClusterBackup, error) {
+	content, err := os.ReadFile(filepath.Join(directory, backupManifestFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read the backup manifest of %s", directory)
+	}
+	backup := &ManagementClusterBackup{}
+	if err := yaml.Unmarshal(content, backup); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the backup manifest of %s", directory)
+	}
+	return backup, nil
+}
+
+// getBackupNamespaces returns the namespaces to back up, which cannot include the namespace of the
+// management cluster itself as restoring it would overwrite the target management cluster
+func getBackupNamespaces(clusters []capi.Cluster, namespaces []string) ([]string, error) {
+	if len(namespaces) != 0 {
+		if utils.ContainsString(namespaces, defaultTkgNamespace) {
+			return nil, errors.Errorf("namespace %s of the management cluster cannot be backed up", defaultTkgNamespace)
+		}
+		return namespaces, nil
+	}
+
+	result := []string{}
+	for i := range clusters {
+		namespace := clusters[i].Namespace
+		if namespace == defaultTkgNamespace {
+			continue
+		}
+		if !utils.ContainsString(result, namespace) {
+			result = append(result, namespace)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// BackupTanzuObjects saves the TKRs, OSImages and the ClusterBootstraps of the namespaces, along with the secrets
+// and provider objects referenced by their packages, which are not part of the Cluster API move graph
+func BackupTanzuObjects(clusterClient clusterclient.Client, namespaces []string, directory string) error {
+	tkrs := &runv1alpha3.TanzuKubernetesReleaseList{}
+	if err := clusterClient.ListResources(tkrs); err != nil {
+		return errors.Wrap(err, "unable to list TanzuKubernetesReleases")
+	}
+	objects := []interface{}{}
+	for i := range tkrs.Items {
+		if err := appendBackupObject(&objects, &tkrs.Items[i], runv1alpha3.GroupVersion.WithKind("TanzuKubernetesRelease")); err != nil {
+			return err
+		}
+	}
+	if err := writeBackupObjects(filepath.Join(directory, backupTKRsFile), objects); err != nil {
+		return err
+	}
+
+	osImages := &runv1alpha3.OSImageList{}
+	if err := clusterClient.ListResources(osImages); err != nil {
+		return errors.Wrap(err, "unable to list OSImages")
+	}
+	objects = []interface{}{}
+	for i := range osImages.Items {
+		if err := appendBackupObject(&objects, &osImages.Items[i], runv1alpha3.GroupVersion.WithKind("OSImage")); err != nil {
+			return err
+		}
+	}
+	if err := writeBackupObjects(filepath.Join(directory, backupOSImagesFile), objects); err != nil {
+		return err
+	}
+
+	secrets, providers, clusterBootstraps := []interface{}{}, []interface{}{}, []interface{}{}
+	for _, namespace := range namespaces {
+		clusterBootstrapList := &runv1alpha3.ClusterBootstrapList{}
+		if err := clusterClient.ListResources(clusterBootstrapList, crtclient.InNamespace(namespace)); err != nil {
+			return errors.Wrapf(err, "unable to list ClusterBootstraps in namespace %s", namespace)
+		}
+		for i := range clusterBootstrapList.Items {
+			clusterBootstrap := &clusterBootstrapList.Items[i]
+			if err := appendClusterBootstrapReferences(clusterClient, clusterBootstrap, &secrets, &providers); err != nil {
+				return err
+			}
+			if err := appendBackupObject(&clusterBootstraps, clusterBootstrap, runv1alpha3.GroupVersion.WithKind("ClusterBootstrap")); err != nil {
+				return err
+			}
+		}
+	}
+	if err := writeBackupObjects(filepath.Join(directory, backupSecretsFile), secrets); err != nil {
+		return err
+	}
+	if err := writeBackupObjects(filepath.Join(directory, backupProvidersFile), providers); err != nil {
+		return err
+	}
+	return writeBackupObjects(filepath.Join(directory, backupClusterBootstrapsFile), clusterBootstraps)
+}
+
+// appendClusterBootstrapReferences appends the secrets and provider objects referenced by the packages of a ClusterBootstrap
+func appendClusterBootstrapReferences(clusterClient clusterclient.Client, clusterBootstrap *runv1alpha3.ClusterBootstrap, secrets, providers *[]interface{}) error {
+	if clusterBootstrap.Spec == nil {
+		return nil
+	}
+	packages := append([]*runv1alpha3.ClusterBootstrapPackage{clusterBootstrap.Spec.CNI, clusterBootstrap.Spec.CPI, clusterBootstrap.Spec.CSI, clusterBootstrap.Spec.Kapp},
+		clusterBootstrap.Spec.AdditionalPackages...)
+	for _, cbPackage := range packages {
+		if cbPackage == nil || cbPackage.ValuesFrom == nil {
+			continue
+		}
+
+		if cbPackage.ValuesFrom.SecretRef != "" {
+			secret := &corev1.Secret{}
+			if err := clusterClient.GetResource(secret, cbPackage.ValuesFrom.SecretRef, clusterBootstrap.Namespace, nil, nil); err != nil {
+				return errors.Wrapf(err, "unable to get secret %s/%s of package %s", clusterBootstrap.Namespace, cbPackage.ValuesFrom.SecretRef, cbPackage.RefName)
+			}
+			if err := appendBackupObject(secrets, secret, corev1.SchemeGroupVersion.WithKind("Secret")); err != nil {
+				return err
+			}
+		}
+
+		if providerRef := cbPackage.ValuesFrom.ProviderRef; providerRef != nil {
+			groupKind := schema.GroupKind{Kind: providerRef.Kind}
+			if providerRef.APIGroup != nil {
+				groupKind.Group = *providerRef.APIGroup
+			}
+			gvk, err := clusterClient.GetPreferredGroupVersionKind(groupKind)
+			if err != nil {
+				return err
+			}
+			provider := &unstructured.Unstructured{}
+			provider.SetGroupVersionKind(gvk)
+			if err := clusterClient.GetResource(provider, providerRef.Name, clusterBootstrap.Namespace, nil, nil); err != nil {
+				return errors.Wrapf(err, "unable to get %s %s/%s of package %s", providerRef.Kind, clusterBootstrap.Namespace, providerRef.Name, cbPackage.RefName)
+			}
+			if err := appendBackupObject(providers, provider, gvk); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendBackupObject appends an object stripped of its status and of the metadata specific to the source cluster
+func appendBackupObject(objects *[]interface{}, obj runtime.Object, gvk schema.GroupVersionKind) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return errors.Wrapf(err, "unable to convert %s", gvk.Kind)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	u.SetUID("")
+	u.SetResourceVersion("")
+	u.SetGeneration(0)
+	u.SetManagedFields(nil)
+	u.SetOwnerReferences(nil)
+	unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(u.Object, "status")
+	*objects = append(*objects, u.Object)
+	return nil
+}
+
+func writeBackupObjects(filePath string, objects []interface{}) error {
+	content, err := yaml.Marshal(map[string]interface{}{"apiVersion": "v1", "kind": "List", "items": objects})
+	if err != nil {
+		return errors.Wrapf(err, "unable to marshal %s", filepath.Base(filePath))
+	}
+	return utils.SaveFile(filePath, content)
+}
+
+// RestoreTanzuObjects creates the Tanzu objects saved by BackupTanzuObjects which do not exist yet. The restored
+// ClusterBootstraps are paused, and their keys are returned so that they can be resumed once the clusters are restored.
+func RestoreTanzuObjects(clusterClient clusterclient.Client, directory string) ([]crtclient.ObjectKey, error) {
+	pausedClusterBootstraps := []crtclient.ObjectKey{}
+	for _, file := range tanzuBackupFiles {
+		content, err := os.ReadFile(filepath.Join(directory, file))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read %s", file)
+		}
+		jsonContent, err := yaml.YAMLToJSON(content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse %s", file)
+		}
+		list := &unstructured.UnstructuredList{}
+		if err := list.UnmarshalJSON(jsonContent); err != nil {
+			return nil, errors.Wrapf(err, "unable to parse %s", file)
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			pause := false
+			if file == backupClusterBootstrapsFile {
+				paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused")
+				if !paused {
+					pause = true
+					if err := unstructured.SetNestedField(obj.Object, true, "spec", "paused"); err != nil {
+						return nil, errors.Wrapf(err, "unable to pause ClusterBootstrap %s/%s", obj.GetNamespace(), obj.GetName())
+					}
+				}
+			}
+
+			err := clusterClient.CreateResource(obj, obj.GetName(), obj.GetNamespace())
+			if apierrors.IsAlreadyExists(errors.Cause(err)) {
+				log.V(3).Infof("%s %s already exists, skipping", obj.GetKind(), getObjectKey(obj))
+				continue
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to restore %s %s", obj.GetKind(), getObjectKey(obj))
+			}
+			if pause {
+				pausedClusterBootstraps = append(pausedClusterBootstraps, crtclient.ObjectKeyFromObject(obj))
+			}
+		}
+	}
+	return pausedClusterBootstraps, nil
+}
+
+// ResumeClusterBootstraps resumes the ClusterBootstraps paused by RestoreTanzuObjects
+func ResumeClusterBootstraps(clusterClient clusterclient.Client, clusterBootstraps []crtclient.ObjectKey) error {
+	for _, key := range clusterBootstraps {
+		patch := `{"spec":{"paused":false}}`
+		if err := clusterClient.PatchResource(&runv1alpha3.ClusterBootstrap{}, key.Name, key.Namespace, patch, types.MergePatchType, nil); err != nil {
+			return errors.Wrapf(err, "unable to resume ClusterBootstrap %s", key)
+		}
+	}
+	return nil
+}
+
+func getObjectKey(obj crtclient.Object) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
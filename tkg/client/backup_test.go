@@ -0,0 +1,182 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	runv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+	. "github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+)
+
+var _ = Describe("Management cluster backup", func() {
+	var (
+		clusterClient *fakes.ClusterClient
+		directory     string
+		err           error
+	)
+
+	BeforeEach(func() {
+		directory, err = os.MkdirTemp("", "mc-backup")
+		Expect(err).NotTo(HaveOccurred())
+
+		clusterClient = &fakes.ClusterClient{}
+		clusterClient.ListResourcesCalls(func(list interface{}, opts ...crtclient.ListOption) error {
+			switch l := list.(type) {
+			case *runv1alpha3.TanzuKubernetesReleaseList:
+				l.Items = []runv1alpha3.TanzuKubernetesRelease{{ObjectMeta: metav1.ObjectMeta{Name: "v1.23.8---vmware.2-tkg.1", UID: "tkr-uid"}}}
+			case *runv1alpha3.OSImageList:
+				l.Items = []runv1alpha3.OSImage{{ObjectMeta: metav1.ObjectMeta{Name: "v1.23.8---vmware.2-tkg.1-ubuntu"}}}
+			case *runv1alpha3.ClusterBootstrapList:
+				apiGroup := "cni.tanzu.vmware.com"
+				l.Items = []runv1alpha3.ClusterBootstrap{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "wc-1",
+						Namespace:       "default",
+						ResourceVersion: "42",
+						OwnerReferences: []metav1.OwnerReference{{Kind: "Cluster", Name: "wc-1", UID: "cluster-uid"}},
+					},
+					Spec: &runv1alpha3.ClusterBootstrapTemplateSpec{
+						CNI: &runv1alpha3.ClusterBootstrapPackage{
+							RefName:    "antrea.tanzu.vmware.com.1.5.3+tkg.2-tkg.1",
+							ValuesFrom: &runv1alpha3.ValuesFrom{ProviderRef: &corev1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: "AntreaConfig", Name: "wc-1-antrea-package"}},
+						},
+						AdditionalPackages: []*runv1alpha3.ClusterBootstrapPackage{{
+							RefName:    "pinniped.tanzu.vmware.com.0.12.1+vmware.1-tkg.1",
+							ValuesFrom: &runv1alpha3.ValuesFrom{SecretRef: "wc-1-pinniped-package"},
+						}},
+					},
+					Status: runv1alpha3.ClusterBootstrapStatus{ResolvedTKR: "v1.23.8---vmware.2-tkg.1"},
+				}}
+			}
+			return nil
+		})
+		clusterClient.GetResourceCalls(func(obj interface{}, name, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+			switch o := obj.(type) {
+			case *corev1.Secret:
+				o.Name = name
+				o.Namespace = namespace
+				o.Data = map[string][]byte{"values.yaml": []byte("identity_management_type: oidc")}
+			case *unstructured.Unstructured:
+				o.SetName(name)
+				o.SetNamespace(namespace)
+				_ = unstructured.SetNestedField(o.Object, "encap", "spec", "antrea", "config", "trafficEncapMode")
+				_ = unstructured.SetNestedField(o.Object, "wc-1-antrea-data-values", "status", "secretRef")
+			}
+			return nil
+		})
+		clusterClient.GetPreferredGroupVersionKindCalls(func(groupKind schema.GroupKind) (schema.GroupVersionKind, error) {
+			return groupKind.WithVersion("v1alpha1"), nil
+		})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	Describe("BackupTanzuObjects", func() {
+		JustBeforeEach(func() {
+			err = BackupTanzuObjects(clusterClient, []string{"default"}, directory)
+		})
+
+		It("should save the Tanzu objects and the objects referenced by the ClusterBootstraps", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := os.ReadFile(filepath.Join(directory, "tanzukubernetesreleases.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("kind: TanzuKubernetesRelease"))
+			Expect(string(content)).NotTo(ContainSubstring("tkr-uid"))
+
+			content, err = os.ReadFile(filepath.Join(directory, "secrets.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("name: wc-1-pinniped-package"))
+
+			content, err = os.ReadFile(filepath.Join(directory, "providers.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("apiVersion: cni.tanzu.vmware.com/v1alpha1"))
+			Expect(string(content)).To(ContainSubstring("trafficEncapMode: encap"))
+			Expect(string(content)).NotTo(ContainSubstring("wc-1-antrea-data-values"))
+
+			content, err = os.ReadFile(filepath.Join(directory, "clusterbootstraps.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("kind: ClusterBootstrap"))
+			Expect(string(content)).NotTo(ContainSubstring("resourceVersion"))
+			Expect(string(content)).NotTo(ContainSubstring("cluster-uid"))
+			Expect(string(content)).NotTo(ContainSubstring("resolvedTKR"))
+		})
+	})
+
+	Describe("RestoreTanzuObjects", func() {
+		var (
+			targetClusterClient *fakes.ClusterClient
+			paused              []crtclient.ObjectKey
+			created             []*unstructured.Unstructured
+		)
+
+		BeforeEach(func() {
+			Expect(BackupTanzuObjects(clusterClient, []string{"default"}, directory)).To(Succeed())
+
+			created = nil
+			targetClusterClient = &fakes.ClusterClient{}
+			targetClusterClient.CreateResourceCalls(func(obj interface{}, name, namespace string, opts ...crtclient.CreateOption) error {
+				u := obj.(*unstructured.Unstructured)
+				if u.GetKind() == "TanzuKubernetesRelease" {
+					return apierrors.NewAlreadyExists(schema.GroupResource{Resource: "tanzukubernetesreleases"}, name)
+				}
+				created = append(created, u)
+				return nil
+			})
+		})
+
+		JustBeforeEach(func() {
+			paused, err = RestoreTanzuObjects(targetClusterClient, directory)
+		})
+
+		It("should create the missing objects in restore order with the ClusterBootstraps paused", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(HaveLen(4))
+			Expect(created[0].GetKind()).To(Equal("OSImage"))
+			Expect(created[1].GetKind()).To(Equal("Secret"))
+			Expect(created[2].GetKind()).To(Equal("AntreaConfig"))
+			Expect(created[3].GetKind()).To(Equal("ClusterBootstrap"))
+			isPaused, _, _ := unstructured.NestedBool(created[3].Object, "spec", "paused")
+			Expect(isPaused).To(BeTrue())
+			Expect(paused).To(ConsistOf(crtclient.ObjectKey{Namespace: "default", Name: "wc-1"}))
+		})
+
+		It("should resume the paused ClusterBootstraps", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ResumeClusterBootstraps(targetClusterClient, paused)).To(Succeed())
+			Expect(targetClusterClient.PatchResourceCallCount()).To(Equal(1))
+			_, name, namespace, patch, patchType, _ := targetClusterClient.PatchResourceArgsForCall(0)
+			Expect(name).To(Equal("wc-1"))
+			Expect(namespace).To(Equal("default"))
+			Expect(patch).To(Equal(`{"spec":{"paused":false}}`))
+			Expect(patchType).To(Equal(types.MergePatchType))
+		})
+
+		Context("when the backup does not contain the Tanzu objects", func() {
+			BeforeEach(func() {
+				Expect(os.Remove(filepath.Join(directory, "clusterbootstraps.yaml"))).To(Succeed())
+			})
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unable to read clusterbootstraps.yaml"))
+			})
+		})
+	})
+})
@@ -0,0 +1,276 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/secret"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// ClusterCertificateType is the type of a certificate of a cluster
+type ClusterCertificateType string
+
+const (
+	// CertificateTypeCA is a certificate authority of the cluster, issued when the cluster is created
+	CertificateTypeCA ClusterCertificateType = "CA"
+	// CertificateTypeServiceAccountKey is the key pair signing the service account tokens, which does not expire
+	CertificateTypeServiceAccountKey ClusterCertificateType = "ServiceAccountKey"
+	// CertificateTypeControlPlaneMachine are the kubeadm issued certificates of a control plane machine
+	CertificateTypeControlPlaneMachine ClusterCertificateType = "ControlPlaneMachine"
+)
+
+// kubeadmCertificateValidity is the validity of the certificates issued by kubeadm on the control plane machines
+const kubeadmCertificateValidity = 365 * 24 * time.Hour
+
+// clusterCertificateSecrets are the purposes of the Cluster API secrets holding the certificates of a cluster
+var clusterCertificateSecrets = []secret.Purpose{secret.ClusterCA, secret.EtcdCA, secret.FrontProxyCA, secret.ServiceAccount}
+
+// ClusterCertificatesOptions options to list or rotate the certificates of a workload cluster
+type ClusterCertificatesOptions struct {
+	ClusterName string
+	Namespace   string
+	// IncludeWorkers also rolls out the worker machines when rotating the certificates
+	IncludeWorkers bool
+}
+
+// ClusterCertificate describes a certificate of a cluster and its expiry
+type ClusterCertificate struct {
+	Name string                 `json:"name"`
+	Type ClusterCertificateType `json:"type"`
+	// Source is the secret or the machine the certificate is read from
+	Source string `json:"source"`
+	// NotAfter is the expiry of the certificate, nil if the certificate does not expire
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+	// Estimated is true when the expiry is estimated from the creation time of the machine
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// ClusterCertificates are the certificates of a cluster
+type ClusterCertificates struct {
+	ClusterName  string               `json:"clusterName"`
+	Namespace    string               `json:"namespace"`
+	Certificates []ClusterCertificate `json:"certificates"`
+	// CertificatesExpiryDays is the spec.rolloutBefore.certificatesExpiryDays of the KubeadmControlPlane, the control
+	// plane machines are rolled out by Cluster API when their certificates expire within that many days. Nil if unset.
+	CertificatesExpiryDays *int64 `json:"certificatesExpiryDays,omitempty"`
+}
+
+// GetClusterCertificates returns the expiry of the certificate authorities and of the control plane machine
+// certificates of a cluster, along with the automatic rotation of the control plane machine certificates
+func (c *TkgClient) GetClusterCertificates(options ClusterCertificatesOptions) (*ClusterCertificates, error) {
+	clusterClient, err := c.getClusterCertificatesClusterClient(&options)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := &capi.Cluster{}
+	if err := clusterClient.GetResource(cluster, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to get cluster %s/%s", options.Namespace, options.ClusterName)
+	}
+
+	certificates := &ClusterCertificates{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+	}
+	for _, purpose := range clusterCertificateSecrets {
+		certificate, err := getSecretCertificate(clusterClient, cluster, purpose)
+		if err != nil {
+			return nil, err
+		}
+		certificates.Certificates = append(certificates.Certificates, *certificate)
+	}
+
+	cpMachines, _, err := clusterClient.GetMachineObjectsForCluster(options.ClusterName, options.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get the machines of the cluster")
+	}
+	machineCertificates := []ClusterCertificate{}
+	for key := range cpMachines {
+		machine := cpMachines[key]
+		certificate, err := getMachineCertificate(clusterClient, cluster, &machine)
+		if err != nil {
+			return nil, err
+		}
+		machineCertificates = append(machineCertificates, certificate)
+	}
+	sort.Slice(machineCertificates, func(i, j int) bool {
+		return machineCertificates[i].Name < machineCertificates[j].Name
+	})
+	certificates.Certificates = append(certificates.Certificates, machineCertificates...)
+
+	if certificates.CertificatesExpiryDays, err = getCertificatesExpiryDays(clusterClient, cluster); err != nil {
+		return nil, err
+	}
+	return certificates, nil
+}
+
+// RotateClusterCertificates renews the kubeadm issued certificates of the control plane machines of a cluster by
+// rolling out the control plane, and optionally the worker machines. The certificate authorities of the cluster
+// are not renewed.
+func (c *TkgClient) RotateClusterCertificates(options ClusterCertificatesOptions) error {
+	clusterClient, err := c.getClusterCertificatesClusterClient(&options)
+	if err != nil {
+		return err
+	}
+
+	kcp, err := clusterClient.GetKCPObjectForCluster(options.ClusterName, options.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "unable to get the control plane of the cluster")
+	}
+
+	// the rollout only replaces the machines created before rolloutAfter, hence the time is truncated to the
+	// precision of the creation timestamps
+	rolloutAfter := time.Now().UTC().Truncate(time.Second)
+	log.Infof("Rolling out the control plane nodes of cluster '%s'...", options.ClusterName)
+	patch := fmt.Sprintf(`{"spec":{"rolloutAfter":"%s"}}`, rolloutAfter.Format(time.RFC3339))
+	if err := clusterClient.PatchResource(&controlplanev1.KubeadmControlPlane{}, kcp.Name, kcp.Namespace, patch, types.MergePatchType, nil); err != nil {
+		return errors.Wrap(err, "unable to trigger the rollout of the control plane")
+	}
+	if err := clusterClient.WaitRolloutForCPNodes(options.ClusterName, options.Namespace, rolloutAfter); err != nil {
+		return errors.Wrap(err, "error waiting for the rollout of the control plane nodes")
+	}
+
+	if !options.IncludeWorkers {
+		return nil
+	}
+
+	mds, err := clusterClient.GetMDObjectForCluster(options.ClusterName, options.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "unable to get the machine deployments of the cluster")
+	}
+	log.Infof("Rolling out the worker nodes of cluster '%s'...", options.ClusterName)
+	patch = fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"cluster.x-k8s.io/restartedAt":"%s"}}}}}`, rolloutAfter.Format(time.RFC3339))
+	for i := range mds {
+		if err := clusterClient.PatchResource(&capi.MachineDeployment{}, mds[i].Name, mds[i].Namespace, patch, types.MergePatchType, nil); err != nil {
+			return errors.Wrapf(err, "unable to trigger the rollout of machine deployment '%s'", mds[i].Name)
+		}
+	}
+	if err := clusterClient.WaitRolloutForWorkerNodes(options.ClusterName, options.Namespace, rolloutAfter); err != nil {
+		return errors.Wrap(err, "error waiting for the rollout of the worker nodes")
+	}
+	return nil
+}
+
+func (c *TkgClient) getClusterCertificatesClusterClient(options *ClusterCertificatesOptions) (clusterclient.Client, error) {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+		if currentRegion.ClusterName == options.ClusterName {
+			options.Namespace = TKGsystemNamespace
+		}
+	}
+
+	clusterClient, err := c.clusterClientFactory.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get management cluster client")
+	}
+	return clusterClient, nil
+}
+
+// getCertificatesExpiryDays returns the spec.rolloutBefore.certificatesExpiryDays of the KubeadmControlPlane of a
+// cluster. The control plane is read as unstructured as the field is not known to all Cluster API versions.
+func getCertificatesExpiryDays(clusterClient clusterclient.Client, cluster *capi.Cluster) (*int64, error) {
+	ref := cluster.Spec.ControlPlaneRef
+	if ref == nil || ref.Kind != constants.KindKubeadmControlPlane {
+		return nil, nil
+	}
+	kcp := &unstructured.Unstructured{}
+	kcp.SetAPIVersion(ref.APIVersion)
+	kcp.SetKind(ref.Kind)
+	if err := clusterClient.GetResource(kcp, ref.Name, cluster.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to get KubeadmControlPlane '%s'", ref.Name)
+	}
+	days, found, err := unstructured.NestedInt64(kcp.Object, "spec", "rolloutBefore", "certificatesExpiryDays")
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid rolloutBefore of KubeadmControlPlane '%s'", ref.Name)
+	}
+	if !found {
+		return nil, nil
+	}
+	return &days, nil
+}
+
+func getSecretCertificate(clusterClient clusterclient.Client, cluster *capi.Cluster, purpose secret.Purpose) (*ClusterCertificate, error) {
+	name := secret.Name(cluster.Name, purpose)
+	certificate := &ClusterCertificate{
+		Name:   string(purpose),
+		Type:   CertificateTypeCA,
+		Source: "Secret/" + name,
+	}
+	if purpose == secret.ServiceAccount {
+		certificate.Type = CertificateTypeServiceAccountKey
+		return certificate, nil
+	}
+
+	s := &corev1.Secret{}
+	if err := clusterClient.GetResource(s, name, cluster.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to get secret '%s'", name)
+	}
+	block, _ := pem.Decode(s.Data[secret.TLSCrtDataName])
+	if block == nil {
+		return nil, errors.Errorf("no certificate found in secret '%s'", name)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the certificate of secret '%s'", name)
+	}
+	notAfter := cert.NotAfter.UTC()
+	certificate.NotAfter = &notAfter
+	return certificate, nil
+}
+
+// getMachineCertificate returns the expiry of the certificates of a control plane machine. The expiry is read from the
+// status.certificatesExpiryDate of the machine, which is read as unstructured as the field is not known to all Cluster
+// API versions, then from the annotation users can set to override it. It is estimated from the creation time of the
+// machine when neither is set
+func getMachineCertificate(clusterClient clusterclient.Client, cluster *capi.Cluster, machine *capi.Machine) (ClusterCertificate, error) {
+	certificate := ClusterCertificate{
+		Name:   machine.Name,
+		Type:   CertificateTypeControlPlaneMachine,
+		Source: "Machine/" + machine.Name,
+	}
+
+	m := &unstructured.Unstructured{}
+	m.SetGroupVersionKind(capi.GroupVersion.WithKind("Machine"))
+	if err := clusterClient.GetResource(m, machine.Name, cluster.Namespace, nil, nil); err != nil {
+		return certificate, errors.Wrapf(err, "unable to get machine '%s'", machine.Name)
+	}
+	expiry, found, err := unstructured.NestedString(m.Object, "status", "certificatesExpiryDate")
+	if err != nil {
+		return certificate, errors.Wrapf(err, "invalid certificatesExpiryDate of machine '%s'", machine.Name)
+	}
+	if !found {
+		expiry, found = machine.Annotations[constants.MachineCertificatesExpiryAnnotation]
+	}
+	if found {
+		if notAfter, err := time.Parse(time.RFC3339, expiry); err == nil {
+			notAfter = notAfter.UTC()
+			certificate.NotAfter = &notAfter
+			return certificate, nil
+		}
+		log.V(3).Infof("unable to parse the certificates expiry '%s' of machine '%s'", expiry, machine.Name)
+	}
+	notAfter := machine.CreationTimestamp.Time.Add(kubeadmCertificateValidity).UTC()
+	certificate.NotAfter = &notAfter
+	certificate.Estimated = true
+	return certificate, nil
+}
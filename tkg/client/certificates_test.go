@@ -0,0 +1,269 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	. "github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
+)
+
+var _ = Describe("Cluster certificates", func() {
+	var (
+		tkgClient     *TkgClient
+		clusterClient *fakes.ClusterClient
+		options       ClusterCertificatesOptions
+		caExpiry      time.Time
+		machineTime   time.Time
+		kcpSpec       map[string]interface{}
+		machineStatus map[string]interface{}
+		err           error
+	)
+
+	BeforeEach(func() {
+		caExpiry = time.Date(2032, 9, 1, 0, 0, 0, 0, time.UTC)
+		machineTime = time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+		caCertificate := generateCACertificate(caExpiry)
+		kcpSpec = map[string]interface{}{}
+		machineStatus = map[string]interface{}{
+			"wc-1-cp-c": map[string]interface{}{"certificatesExpiryDate": "2023-09-01T12:00:00Z"},
+		}
+
+		clusterClient = &fakes.ClusterClient{}
+		clusterClient.GetResourceCalls(func(obj interface{}, name, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+			switch o := obj.(type) {
+			case *capi.Cluster:
+				o.Name = name
+				o.Namespace = namespace
+				o.Spec.ControlPlaneRef = &corev1.ObjectReference{
+					APIVersion: controlplanev1.GroupVersion.String(),
+					Kind:       constants.KindKubeadmControlPlane,
+					Name:       name + "-control-plane",
+				}
+				return nil
+			case *unstructured.Unstructured:
+				if name == "wc-1-control-plane" {
+					o.Object["spec"] = kcpSpec
+					return nil
+				}
+				if o.GetKind() == "Machine" {
+					if status, ok := machineStatus[name]; ok {
+						o.Object["status"] = status
+					}
+					return nil
+				}
+			case *corev1.Secret:
+				if name == "wc-1-ca" || name == "wc-1-etcd" || name == "wc-1-proxy" {
+					o.Data = map[string][]byte{"tls.crt": caCertificate}
+					return nil
+				}
+			}
+			return apierrors.NewNotFound(schema.GroupResource{}, name)
+		})
+		clusterClient.GetMachineObjectsForClusterReturns(map[string]capi.Machine{
+			"wc-1-cp-b-Running": {
+				ObjectMeta: metav1.ObjectMeta{Name: "wc-1-cp-b", CreationTimestamp: metav1.NewTime(machineTime)},
+			},
+			"wc-1-cp-a-Running": {
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "wc-1-cp-a",
+					CreationTimestamp: metav1.NewTime(machineTime),
+					Annotations:       map[string]string{constants.MachineCertificatesExpiryAnnotation: "2023-08-15T10:00:00Z"},
+				},
+			},
+			"wc-1-cp-c-Running": {
+				ObjectMeta: metav1.ObjectMeta{Name: "wc-1-cp-c", CreationTimestamp: metav1.NewTime(machineTime)},
+			},
+		}, map[string]capi.Machine{}, nil)
+		clusterClient.GetKCPObjectForClusterReturns(&controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "wc-1-control-plane", Namespace: "default"},
+		}, nil)
+		clusterClient.GetMDObjectForClusterReturns([]capi.MachineDeployment{
+			{ObjectMeta: metav1.ObjectMeta{Name: "wc-1-md-0", Namespace: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "wc-1-md-1", Namespace: "default"}},
+		}, nil)
+
+		clusterClientFactory := &fakes.ClusterClientFactory{}
+		clusterClientFactory.NewClientReturns(clusterClient, nil)
+		regionManager := &fakes.RegionManager{}
+		regionManager.GetCurrentContextReturns(region.RegionContext{ClusterName: "mc-1", ContextName: "mc-1-admin@mc-1"}, nil)
+
+		tkgClient, err = New(Options{
+			TKGConfigUpdater:     &fakes.TKGConfigUpdaterClient{},
+			RegionManager:        regionManager,
+			ClusterClientFactory: clusterClientFactory,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		options = ClusterCertificatesOptions{ClusterName: "wc-1"}
+	})
+
+	Describe("GetClusterCertificates", func() {
+		var certificates *ClusterCertificates
+
+		JustBeforeEach(func() {
+			certificates, err = tkgClient.GetClusterCertificates(options)
+		})
+
+		It("should return the expiry of the CA and the control plane machine certificates", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certificates.Namespace).To(Equal(constants.DefaultNamespace))
+
+			annotatedExpiry := time.Date(2023, 8, 15, 10, 0, 0, 0, time.UTC)
+			statusExpiry := time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)
+			estimatedExpiry := machineTime.Add(365 * 24 * time.Hour)
+			Expect(certificates.Certificates).To(Equal([]ClusterCertificate{
+				{Name: "ca", Type: CertificateTypeCA, Source: "Secret/wc-1-ca", NotAfter: &caExpiry},
+				{Name: "etcd", Type: CertificateTypeCA, Source: "Secret/wc-1-etcd", NotAfter: &caExpiry},
+				{Name: "proxy", Type: CertificateTypeCA, Source: "Secret/wc-1-proxy", NotAfter: &caExpiry},
+				{Name: "sa", Type: CertificateTypeServiceAccountKey, Source: "Secret/wc-1-sa"},
+				{Name: "wc-1-cp-a", Type: CertificateTypeControlPlaneMachine, Source: "Machine/wc-1-cp-a", NotAfter: &annotatedExpiry},
+				{Name: "wc-1-cp-b", Type: CertificateTypeControlPlaneMachine, Source: "Machine/wc-1-cp-b", NotAfter: &estimatedExpiry, Estimated: true},
+				{Name: "wc-1-cp-c", Type: CertificateTypeControlPlaneMachine, Source: "Machine/wc-1-cp-c", NotAfter: &statusExpiry},
+			}))
+			Expect(certificates.CertificatesExpiryDays).To(BeNil())
+		})
+
+		Context("when the status of an annotated machine tracks the certificates expiry", func() {
+			BeforeEach(func() {
+				machineStatus["wc-1-cp-a"] = map[string]interface{}{"certificatesExpiryDate": "2023-10-01T08:00:00Z"}
+			})
+
+			It("should return the expiry from the status of the machine", func() {
+				Expect(err).NotTo(HaveOccurred())
+				statusExpiry := time.Date(2023, 10, 1, 8, 0, 0, 0, time.UTC)
+				Expect(certificates.Certificates[4]).To(Equal(ClusterCertificate{
+					Name: "wc-1-cp-a", Type: CertificateTypeControlPlaneMachine, Source: "Machine/wc-1-cp-a", NotAfter: &statusExpiry,
+				}))
+			})
+		})
+
+		Context("when the control plane rolls out machines before their certificates expire", func() {
+			BeforeEach(func() {
+				kcpSpec["rolloutBefore"] = map[string]interface{}{"certificatesExpiryDays": int64(30)}
+			})
+
+			It("should return the certificates expiry days of the control plane", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(certificates.CertificatesExpiryDays).To(Equal(pointer.Int64(30)))
+			})
+		})
+
+		Context("when the cluster is the management cluster", func() {
+			BeforeEach(func() {
+				options.ClusterName = "mc-1"
+			})
+
+			It("should look for the cluster in the tkg-system namespace", func() {
+				Expect(err).To(HaveOccurred())
+				_, _, namespace, _, _ := clusterClient.GetResourceArgsForCall(0)
+				Expect(namespace).To(Equal(constants.TkgNamespace))
+			})
+		})
+
+		Context("when a CA secret is missing", func() {
+			BeforeEach(func() {
+				options.ClusterName = "wc-2"
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("unable to get secret 'wc-2-ca'")))
+			})
+		})
+	})
+
+	Describe("RotateClusterCertificates", func() {
+		JustBeforeEach(func() {
+			err = tkgClient.RotateClusterCertificates(options)
+		})
+
+		It("should roll out the control plane only", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterClient.PatchResourceCallCount()).To(Equal(1))
+			obj, name, namespace, patch, patchType, _ := clusterClient.PatchResourceArgsForCall(0)
+			Expect(obj).To(BeAssignableToTypeOf(&controlplanev1.KubeadmControlPlane{}))
+			Expect(name).To(Equal("wc-1-control-plane"))
+			Expect(namespace).To(Equal("default"))
+			Expect(patch).To(ContainSubstring(`{"spec":{"rolloutAfter":"`))
+			Expect(patchType).To(Equal(types.MergePatchType))
+
+			Expect(clusterClient.WaitRolloutForCPNodesCallCount()).To(Equal(1))
+			Expect(clusterClient.WaitRolloutForWorkerNodesCallCount()).To(Equal(0))
+		})
+
+		Context("when the workers are included", func() {
+			BeforeEach(func() {
+				options.IncludeWorkers = true
+			})
+
+			It("should restart the machine deployments", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(clusterClient.PatchResourceCallCount()).To(Equal(3))
+				for i, mdName := range []string{"wc-1-md-0", "wc-1-md-1"} {
+					obj, name, _, patch, _, _ := clusterClient.PatchResourceArgsForCall(i + 1)
+					Expect(obj).To(BeAssignableToTypeOf(&capi.MachineDeployment{}))
+					Expect(name).To(Equal(mdName))
+					Expect(patch).To(ContainSubstring(`"cluster.x-k8s.io/restartedAt"`))
+				}
+
+				_, _, cpRolloutAfter := clusterClient.WaitRolloutForCPNodesArgsForCall(0)
+				_, _, workerRolloutAfter := clusterClient.WaitRolloutForWorkerNodesArgsForCall(0)
+				Expect(workerRolloutAfter).To(Equal(cpRolloutAfter))
+			})
+		})
+
+		Context("when the control plane rollout fails", func() {
+			BeforeEach(func() {
+				options.IncludeWorkers = true
+				clusterClient.WaitRolloutForCPNodesReturns(errors.New("timed out waiting for rollout to complete"))
+			})
+
+			It("should not roll out the workers", func() {
+				Expect(err).To(MatchError(ContainSubstring("timed out waiting for rollout to complete")))
+				Expect(clusterClient.PatchResourceCallCount()).To(Equal(1))
+				Expect(clusterClient.WaitRolloutForWorkerNodesCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
+
+func generateCACertificate(notAfter time.Time) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kubernetes"},
+		NotBefore:             notAfter.Add(-24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
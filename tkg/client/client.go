@@ -16,6 +16,7 @@ import (
 
 	runv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha1"
 	tkgsv1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha2"
+	runv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
 
 	clusterctlconfig "sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
@@ -82,6 +83,7 @@ type InitRegionOptions struct {
 	CeipOptIn                    bool
 	UseExistingCluster           bool
 	IsInputFileClusterClassBased bool
+	Resume                       bool
 }
 
 // DeleteRegionOptions contains options supported by DeleteRegion
@@ -145,9 +147,28 @@ type Client interface {
 	ScaleCluster(options ScaleClusterOptions) error
 	// UpgradeCluster upgrades tkg cluster to specific kubernetes version
 	UpgradeCluster(options *UpgradeClusterOptions) error
+	// PlanFleetUpgrade plans the upgrade of the workload clusters matching a label selector, or loads the plan of an interrupted fleet upgrade
+	PlanFleetUpgrade(options *FleetUpgradeOptions) (*FleetUpgradePlan, error)
+	// UpgradeFleet upgrades the workload clusters of a fleet upgrade plan in waves
+	UpgradeFleet(options *FleetUpgradeOptions, plan *FleetUpgradePlan) error
+	// GetClusterCertificates returns the expiry of the certificate authorities and control plane machine certificates of a cluster
+	GetClusterCertificates(options ClusterCertificatesOptions) (*ClusterCertificates, error)
+	// RotateClusterCertificates renews the control plane machine certificates of a cluster by rolling out its machines
+	RotateClusterCertificates(options ClusterCertificatesOptions) error
 	// ConfigureAndValidateManagementClusterConfiguration validates the management cluster configuration
 	// User is expected to validate the configuration before creating management cluster using init operation
 	ConfigureAndValidateManagementClusterConfiguration(options *InitRegionOptions, skipValidation bool) *ValidationError
+	// RunPreflightChecks runs all the applicable validations of a cluster configuration without creating anything
+	// and reports all the failures and warnings
+	RunPreflightChecks(options *PreflightOptions) (*PreflightReport, error)
+	// DiagnoseCluster collects the diagnostics of a cluster into a support bundle
+	DiagnoseCluster(options *DiagnoseClusterOptions) (*DiagnosticsIndex, error)
+	// BackupManagementCluster saves the Cluster API objects of the workload clusters of a management cluster to a directory
+	BackupManagementCluster(options *BackupManagementClusterOptions) (*ManagementClusterBackup, error)
+	// RestoreManagementCluster restores a management cluster backup into a management cluster
+	RestoreManagementCluster(options *RestoreManagementClusterOptions) (*ManagementClusterBackup, error)
+	// DiffCluster compares a live ClusterClass based cluster with its configuration and optionally applies the configuration
+	DiffCluster(options *DiffClusterOptions) (*ClusterDiff, error)
 	// UpgradeManagementCluster upgrades tkg cluster to specific kubernetes version
 	UpgradeManagementCluster(options *UpgradeClusterOptions) error
 	// Opt-in/out to CEIP on Management Cluster
@@ -196,6 +217,8 @@ type Client interface {
 	GetClusterPinnipedInfo(options GetClusterPinnipedInfoOptions) (*ClusterPinnipedInfo, error)
 	// DescribeCluster describes all the objects in the Cluster
 	DescribeCluster(options DescribeTKGClustersOptions) (*clusterctltree.ObjectTree, *capi.Cluster, *clusterctlv1.ProviderList, error)
+	// GetClusterPackages returns the status of the core and additional packages of a cluster
+	GetClusterPackages(options ClusterPackagesOptions) ([]runv1alpha3.ClusterBootstrapPackageStatus, error)
 	// DescribeProvider describes all the installed providers
 	DescribeProvider() (*clusterctlv1.ProviderList, error)
 	// DownloadBomFile downloads BomFile from management cluster's config map
@@ -235,7 +235,7 @@ func getContentFromInputFile(fileName string) ([]byte, error) {
 }
 
 func (c *TkgClient) waitForClusterCreation(regionalClusterClient clusterclient.Client, options *CreateClusterOptions) error {
-	log.Info("waiting for cluster to be initialized...")
+	log.Wait("waiting for cluster to be initialized...")
 	kubeConfigBytes, err := c.WaitForClusterInitializedAndGetKubeConfig(regionalClusterClient, options.ClusterName, options.TargetNamespace)
 	if err != nil {
 		return errors.Wrap(err, "unable to wait for cluster and get the cluster kubeconfig")
@@ -254,7 +254,7 @@ func (c *TkgClient) waitForClusterCreation(regionalClusterClient clusterclient.C
 		return errors.Wrap(err, "unable to save management cluster kubeconfig to TKG managed kubeconfig")
 	}
 
-	log.Info("waiting for cluster nodes to be available...")
+	log.Wait("waiting for cluster nodes to be available...")
 	if err := c.WaitForClusterReadyAfterCreate(regionalClusterClient, options.ClusterName, options.TargetNamespace); err != nil {
 		return errors.Wrap(err, "unable to wait for cluster nodes to be available")
 	}
@@ -275,7 +275,7 @@ func (c *TkgClient) waitForClusterCreation(regionalClusterClient clusterclient.C
 		return err
 	}
 	if isClusterClassBased {
-		log.Info("waiting for addons core packages installation...")
+		log.Wait("waiting for addons core packages installation...")
 		if err := c.WaitForAddonsCorePackagesInstallation(waitForAddonsOptions{
 			regionalClusterClient: regionalClusterClient,
 			workloadClusterClient: workloadClusterClient,
@@ -287,7 +287,7 @@ func (c *TkgClient) waitForClusterCreation(regionalClusterClient clusterclient.C
 			return errors.Wrap(err, "error waiting for addons to get installed")
 		}
 	} else {
-		log.Info("waiting for addons installation...")
+		log.Wait("waiting for addons installation...")
 		if err := c.WaitForAddons(waitForAddonsOptions{
 			regionalClusterClient: regionalClusterClient,
 			workloadClusterClient: workloadClusterClient,
@@ -297,7 +297,7 @@ func (c *TkgClient) waitForClusterCreation(regionalClusterClient clusterclient.C
 		}); err != nil {
 			return errors.Wrap(err, "error waiting for addons to get installed")
 		}
-		log.Info("waiting for packages to be up and running...")
+		log.Wait("waiting for packages to be up and running...")
 		if err := c.WaitForPackages(regionalClusterClient, workloadClusterClient, options.ClusterName, options.TargetNamespace, false); err != nil {
 			log.Warningf("warning: Cluster is created successfully, but some packages are failing. %v", err)
 		}
@@ -0,0 +1,40 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	runv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+)
+
+// ClusterPackagesOptions options to get the packages of a cluster
+type ClusterPackagesOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// GetClusterPackages returns the status of the core and additional packages of a cluster, as reported by the
+// addons manager in the status of the ClusterBootstrap of the cluster
+func (c *TkgClient) GetClusterPackages(options ClusterPackagesOptions) ([]runv1alpha3.ClusterBootstrapPackageStatus, error) {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterClient, err := c.clusterClientFactory.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get management cluster client")
+	}
+
+	clusterBootstrap := &runv1alpha3.ClusterBootstrap{}
+	if err := clusterClient.GetResource(clusterBootstrap, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "cluster '%s' has no ClusterBootstrap, the packages are only reported for ClusterClass based clusters", options.ClusterName)
+		}
+		return nil, errors.Wrapf(err, "unable to get the ClusterBootstrap of cluster %s/%s", options.Namespace, options.ClusterName)
+	}
+	return clusterBootstrap.Status.Packages, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	runv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+	. "github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
+)
+
+var _ = Describe("GetClusterPackages", func() {
+	var (
+		tkgClient     *TkgClient
+		clusterClient *fakes.ClusterClient
+		options       ClusterPackagesOptions
+		packages      []runv1alpha3.ClusterBootstrapPackageStatus
+		err           error
+	)
+
+	BeforeEach(func() {
+		clusterClient = &fakes.ClusterClient{}
+		clusterClient.GetResourceCalls(func(obj interface{}, name, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+			clusterBootstrap, ok := obj.(*runv1alpha3.ClusterBootstrap)
+			if !ok || name != "wc-1" || namespace != "default" {
+				return apierrors.NewNotFound(schema.GroupResource{}, name)
+			}
+			clusterBootstrap.Status.Packages = []runv1alpha3.ClusterBootstrapPackageStatus{
+				{Name: "antrea.tanzu.vmware.com", RefName: "antrea.tanzu.vmware.com.1.5.3--vmware.1-tkg.1", Version: "1.5.3+vmware.1-tkg.1", State: "ReconcileSucceeded"},
+				{Name: "metrics-server.tanzu.vmware.com", RefName: "metrics-server.tanzu.vmware.com.0.5.1--vmware.1-tkg.2", State: "ReconcileFailed", LastError: "Deploying: Error (see .status.usefulErrorMessage for details)"},
+			}
+			return nil
+		})
+
+		clusterClientFactory := &fakes.ClusterClientFactory{}
+		clusterClientFactory.NewClientReturns(clusterClient, nil)
+		regionManager := &fakes.RegionManager{}
+		regionManager.GetCurrentContextReturns(region.RegionContext{ClusterName: "mc-1", ContextName: "mc-1-admin@mc-1"}, nil)
+
+		tkgClient, err = New(Options{
+			TKGConfigUpdater:     &fakes.TKGConfigUpdaterClient{},
+			RegionManager:        regionManager,
+			ClusterClientFactory: clusterClientFactory,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		options = ClusterPackagesOptions{ClusterName: "wc-1", Namespace: "default"}
+	})
+
+	JustBeforeEach(func() {
+		packages, err = tkgClient.GetClusterPackages(options)
+	})
+
+	It("should return the status of the packages of the ClusterBootstrap", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(packages).To(HaveLen(2))
+		Expect(packages[0].Name).To(Equal("antrea.tanzu.vmware.com"))
+		Expect(packages[1].State).To(Equal("ReconcileFailed"))
+	})
+
+	Context("when the cluster has no ClusterBootstrap", func() {
+		BeforeEach(func() {
+			options.ClusterName = "legacy-wc"
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("cluster 'legacy-wc' has no ClusterBootstrap")))
+		})
+	})
+
+	Context("when the ClusterBootstrap cannot be fetched", func() {
+		BeforeEach(func() {
+			clusterClient.GetResourceReturns(errors.New("connection refused"))
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("unable to get the ClusterBootstrap of cluster default/wc-1")))
+		})
+	})
+})
@@ -187,7 +187,7 @@ func (c *TkgClient) DeleteRegion(options DeleteRegionOptions) error { //nolint:f
 			return errors.Wrap(err, "unable to move Cluster API objects from management cluster to cleanup cluster")
 		}
 
-		log.Info("Waiting for the Cluster API objects to get ready after move...")
+		log.Wait("Waiting for the Cluster API objects to get ready after move...")
 		if err := c.WaitForClusterReadyAfterReverseMove(cleanupClusterClient, options.ClusterName, regionalClusterNamespace); err != nil {
 			return errors.Wrap(err, "unable to wait for cluster getting ready for move")
 		}
@@ -0,0 +1,561 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	runv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// Names of the diagnostics sources, used as the top level directories of the support bundle
+const (
+	DiagnosticsSourceManagement = "management"
+	DiagnosticsSourceWorkload   = "workload"
+	DiagnosticsSourceBootstrap  = "bootstrap"
+)
+
+const (
+	diagnosticsIndexFile       = "index.yaml"
+	defaultDiagnosticsLogLines = 2000
+	redactedValue              = "REDACTED"
+)
+
+// diagnosticsControllerNamespaces are the namespaces of the controllers whose logs are collected
+// from the cluster running the Cluster API controllers
+var diagnosticsControllerNamespaces = []string{
+	"capi-system",
+	"capi-kubeadm-bootstrap-system",
+	"capi-kubeadm-control-plane-system",
+	"capv-system",
+	"capa-system",
+	"capz-system",
+	"capd-system",
+	constants.TkgNamespace,
+	constants.TkrNamespace,
+}
+
+// diagnosticsScheme is used to set the kind of the typed objects collected in the support bundle
+var diagnosticsScheme = runtime.NewScheme()
+
+func init() {
+	_ = corev1.AddToScheme(diagnosticsScheme)
+	_ = capi.AddToScheme(diagnosticsScheme)
+	_ = kappipkg.AddToScheme(diagnosticsScheme)
+	_ = runv1alpha3.AddToScheme(diagnosticsScheme)
+}
+
+var (
+	sensitiveKeyRegex     = regexp.MustCompile(`(?i)(password|passwd|secret|token|credentials|privatekey|secretaccesskey)$`)
+	sensitiveLogLineRegex = regexp.MustCompile(`(?i)((password|passwd|secret|token)["']?\s*[:=]\s*["']?)[^\s"',}]+`)
+)
+
+// DiagnoseClusterOptions options for collecting the support bundle of a cluster
+type DiagnoseClusterOptions struct {
+	ClusterName string
+	Namespace   string
+	// OutputFile is the path of the tar.gz support bundle. Defaults to <cluster name>-diagnostics-<timestamp>.tar.gz
+	OutputFile          string
+	IsManagementCluster bool
+	// UseBootstrapCluster collects the diagnostics from the bootstrap cluster left behind by a
+	// failed management cluster creation
+	UseBootstrapCluster bool
+	SkipWorkloadCluster bool
+	// LogTailLines is the number of lines of logs collected per container, 0 uses the default
+	LogTailLines int64
+}
+
+// DiagnosticsSource is a cluster from which diagnostics are collected
+type DiagnosticsSource struct {
+	// Name of the source, used as the top level directory of its files in the support bundle
+	Name          string
+	ClusterClient clusterclient.Client
+	// CollectClusterAPIObjects collects the Cluster API objects of the cluster and the logs of the
+	// controllers; set for the cluster managing the diagnosed cluster
+	CollectClusterAPIObjects bool
+	// CollectClusterState collects the nodes, pods, package installs and events of the diagnosed cluster itself
+	CollectClusterState bool
+	// Err is the reason why the source could not be reached, if any
+	Err error
+}
+
+// DiagnosticsIndex describes the content of a support bundle
+type DiagnosticsIndex struct {
+	ClusterName string            `json:"clusterName"`
+	Namespace   string            `json:"namespace"`
+	CollectedAt time.Time         `json:"collectedAt"`
+	Files       []DiagnosticsFile `json:"files"`
+	Errors      []string          `json:"errors,omitempty"`
+}
+
+// DiagnosticsFile is a file of the support bundle
+type DiagnosticsFile struct {
+	Path        string `json:"path"`
+	Source      string `json:"source"`
+	Description string `json:"description"`
+}
+
+// DiagnoseCluster collects the Cluster API objects, package install statuses, events and controller logs
+// of a cluster and writes them to a tar.gz support bundle
+func (c *TkgClient) DiagnoseCluster(options *DiagnoseClusterOptions) (*DiagnosticsIndex, error) {
+	if options == nil {
+		return nil, errors.New("invalid diagnose cluster options")
+	}
+
+	sources, err := c.getDiagnosticsSources(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.OutputFile == "" {
+		options.OutputFile = fmt.Sprintf("%s-diagnostics-%s.tar.gz", options.ClusterName, time.Now().Format("20060102150405"))
+	}
+	f, err := os.OpenFile(options.OutputFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, constants.ConfigFilePermissions)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create support bundle %s", options.OutputFile)
+	}
+	defer f.Close()
+
+	index, err := CollectDiagnostics(f, options, sources)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Support bundle of cluster %q written to %s", options.ClusterName, options.OutputFile)
+	return index, nil
+}
+
+func (c *TkgClient) getDiagnosticsSources(options *DiagnoseClusterOptions) ([]DiagnosticsSource, error) {
+	if options.UseBootstrapCluster {
+		journal, err := c.GetInitRegionJournal(options.ClusterName)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to find the bootstrap cluster of a failed management cluster creation")
+		}
+		options.ClusterName = journal.ClusterName
+		options.Namespace = journal.Namespace
+		if options.Namespace == "" {
+			options.Namespace = defaultTkgNamespace
+		}
+		bootstrapClusterClient, err := c.clusterClientFactory.NewClient(journal.BootstrapClusterKubeconfigPath, "", clusterclient.Options{OperationTimeout: c.timeout})
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get bootstrap cluster client")
+		}
+		return []DiagnosticsSource{{
+			Name:                     DiagnosticsSourceBootstrap,
+			ClusterClient:            bootstrapClusterClient,
+			CollectClusterAPIObjects: true,
+			CollectClusterState:      true,
+		}}, nil
+	}
+
+	if options.IsManagementCluster {
+		regionContext, err := c.getRegionContextOrCurrent(options.ClusterName)
+		if err != nil {
+			return nil, err
+		}
+		options.ClusterName = regionContext.ClusterName
+		if options.Namespace == "" {
+			options.Namespace = defaultTkgNamespace
+		}
+		regionalClusterClient, err := c.clusterClientFactory.NewClient(regionContext.SourceFilePath, regionContext.ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get management cluster client")
+		}
+		return []DiagnosticsSource{{
+			Name:                     DiagnosticsSourceManagement,
+			ClusterClient:            regionalClusterClient,
+			CollectClusterAPIObjects: true,
+			CollectClusterState:      true,
+		}}, nil
+	}
+
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+	regionalClusterClient, err := c.clusterClientFactory.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get management cluster client")
+	}
+	sources := []DiagnosticsSource{{
+		Name:                     DiagnosticsSourceManagement,
+		ClusterClient:            regionalClusterClient,
+		CollectClusterAPIObjects: true,
+	}}
+	if options.SkipWorkloadCluster {
+		return sources, nil
+	}
+
+	// an unhealthy workload cluster may not be reachable, which is reported in the support bundle
+	workloadSource := DiagnosticsSource{Name: DiagnosticsSourceWorkload, CollectClusterState: true}
+	workloadSource.ClusterClient, workloadSource.Err = c.getWorkloadClusterClientFromManagementCluster(regionalClusterClient, options.ClusterName, options.Namespace)
+	return append(sources, workloadSource), nil
+}
+
+// CollectDiagnostics collects the diagnostics of a cluster from the given sources and writes them
+// as a tar.gz archive to out. Failures to collect individual items are recorded in the index of the
+// archive instead of failing the collection, as the diagnosed cluster is usually unhealthy.
+func CollectDiagnostics(out io.Writer, options *DiagnoseClusterOptions, sources []DiagnosticsSource) (*DiagnosticsIndex, error) {
+	gzipWriter := gzip.NewWriter(out)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	collector := &diagnosticsCollector{
+		tarWriter: tarWriter,
+		options:   options,
+		index: &DiagnosticsIndex{
+			ClusterName: options.ClusterName,
+			Namespace:   options.Namespace,
+			CollectedAt: time.Now().UTC(),
+		},
+	}
+	if collector.options.LogTailLines == 0 {
+		collector.options.LogTailLines = defaultDiagnosticsLogLines
+	}
+
+	for i := range sources {
+		if sources[i].Err != nil {
+			collector.addError(sources[i].Name, sources[i].Err)
+			continue
+		}
+		log.Infof("Collecting diagnostics from the %s cluster...", sources[i].Name)
+		if err := collector.collect(&sources[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	indexBytes, err := yaml.Marshal(collector.index)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to marshal support bundle index")
+	}
+	if err := collector.writeFile(diagnosticsIndexFile, indexBytes); err != nil {
+		return nil, err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, errors.Wrap(err, "unable to write support bundle")
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, errors.Wrap(err, "unable to write support bundle")
+	}
+	return collector.index, nil
+}
+
+type diagnosticsCollector struct {
+	tarWriter *tar.Writer
+	options   *DiagnoseClusterOptions
+	index     *DiagnosticsIndex
+}
+
+func (d *diagnosticsCollector) collect(source *DiagnosticsSource) error {
+	if source.CollectClusterAPIObjects {
+		if err := d.collectClusterAPIObjects(source); err != nil {
+			return err
+		}
+		for _, namespace := range diagnosticsControllerNamespaces {
+			if err := d.collectPodLogs(source, namespace); err != nil {
+				return err
+			}
+		}
+		if err := d.collectList(source, "events/"+d.options.Namespace+".yaml", "events of the cluster namespace", &corev1.EventList{}, crtclient.InNamespace(d.options.Namespace)); err != nil {
+			return err
+		}
+	}
+
+	if source.CollectClusterState {
+		if err := d.collectList(source, "objects/nodes.yaml", "nodes", &corev1.NodeList{}); err != nil {
+			return err
+		}
+		if err := d.collectList(source, "objects/pods.yaml", "pods of all namespaces", &corev1.PodList{}); err != nil {
+			return err
+		}
+		if err := d.collectList(source, "objects/packageinstalls.yaml", "package installs of all namespaces", &kappipkg.PackageInstallList{}); err != nil {
+			return err
+		}
+		if err := d.collectList(source, "events/all.yaml", "events of all namespaces", &corev1.EventList{}); err != nil {
+			return err
+		}
+		if !source.CollectClusterAPIObjects {
+			if err := d.collectPodLogs(source, constants.TkgNamespace); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *diagnosticsCollector) collectClusterAPIObjects(source *DiagnosticsSource) error {
+	clusterName, namespace := d.options.ClusterName, d.options.Namespace
+
+	cluster := &capi.Cluster{}
+	if err := source.ClusterClient.GetResource(cluster, clusterName, namespace, nil, nil); err != nil {
+		d.addError(source.Name, errors.Wrapf(err, "unable to get cluster %s/%s", namespace, clusterName))
+	} else {
+		if err := d.writeObjects(source, "objects/cluster.yaml", "cluster", cluster); err != nil {
+			return err
+		}
+		if err := d.collectReference(source, "objects/infrastructurecluster.yaml", "infrastructure cluster", cluster.Spec.InfrastructureRef, namespace); err != nil {
+			return err
+		}
+		if err := d.collectReference(source, "objects/controlplane.yaml", "control plane", cluster.Spec.ControlPlaneRef, namespace); err != nil {
+			return err
+		}
+	}
+
+	clusterSelector := crtclient.MatchingLabels{capi.ClusterLabelName: clusterName}
+	if err := d.collectList(source, "objects/machinedeployments.yaml", "machine deployments", &capi.MachineDeploymentList{}, crtclient.InNamespace(namespace), clusterSelector); err != nil {
+		return err
+	}
+	if err := d.collectList(source, "objects/machinesets.yaml", "machine sets", &capi.MachineSetList{}, crtclient.InNamespace(namespace), clusterSelector); err != nil {
+		return err
+	}
+	if err := d.collectList(source, "objects/machinehealthchecks.yaml", "machine health checks", &capi.MachineHealthCheckList{}, crtclient.InNamespace(namespace), clusterSelector); err != nil {
+		return err
+	}
+
+	machines := &capi.MachineList{}
+	if err := source.ClusterClient.ListResources(machines, crtclient.InNamespace(namespace), clusterSelector); err != nil {
+		d.addError(source.Name, errors.Wrap(err, "unable to list machines"))
+	} else {
+		if err := d.writeObjects(source, "objects/machines.yaml", "machines", machines); err != nil {
+			return err
+		}
+		infraMachines := []runtime.Object{}
+		for i := range machines.Items {
+			infraMachine, err := getReferencedObject(source.ClusterClient, &machines.Items[i].Spec.InfrastructureRef, namespace)
+			if err != nil {
+				d.addError(source.Name, err)
+				continue
+			}
+			infraMachines = append(infraMachines, infraMachine)
+		}
+		if err := d.writeObjects(source, "objects/infrastructuremachines.yaml", "infrastructure machines", infraMachines...); err != nil {
+			return err
+		}
+	}
+
+	clusterBootstrap := &runv1alpha3.ClusterBootstrap{}
+	if err := source.ClusterClient.GetResource(clusterBootstrap, clusterName, namespace, nil, nil); err != nil {
+		if !apierrors.IsNotFound(err) {
+			d.addError(source.Name, errors.Wrapf(err, "unable to get cluster bootstrap %s/%s", namespace, clusterName))
+		}
+		return nil
+	}
+	return d.writeObjects(source, "objects/clusterbootstrap.yaml", "cluster bootstrap", clusterBootstrap)
+}
+
+func (d *diagnosticsCollector) collectReference(source *DiagnosticsSource, file, description string, ref *corev1.ObjectReference, namespace string) error {
+	if ref == nil {
+		return nil
+	}
+	obj, err := getReferencedObject(source.ClusterClient, ref, namespace)
+	if err != nil {
+		d.addError(source.Name, err)
+		return nil
+	}
+	return d.writeObjects(source, file, description, obj)
+}
+
+func getReferencedObject(clusterClient clusterclient.Client, ref *corev1.ObjectReference, namespace string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	if err := clusterClient.GetResource(obj, ref.Name, namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to get %s %s/%s", ref.Kind, namespace, ref.Name)
+	}
+	return obj, nil
+}
+
+func (d *diagnosticsCollector) collectList(source *DiagnosticsSource, file, description string, list crtclient.ObjectList, options ...crtclient.ListOption) error {
+	if err := source.ClusterClient.ListResources(list, options...); err != nil {
+		d.addError(source.Name, errors.Wrapf(err, "unable to list %s", description))
+		return nil
+	}
+	return d.writeObjects(source, file, description, list)
+}
+
+func (d *diagnosticsCollector) collectPodLogs(source *DiagnosticsSource, namespace string) error {
+	pods := &corev1.PodList{}
+	if err := source.ClusterClient.ListResources(pods, crtclient.InNamespace(namespace)); err != nil {
+		d.addError(source.Name, errors.Wrapf(err, "unable to list pods in namespace %s", namespace))
+		return nil
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		for j := range pod.Spec.Containers {
+			container := pod.Spec.Containers[j].Name
+			logs, err := source.ClusterClient.GetPodLogs(pod.Name, namespace, container, d.options.LogTailLines)
+			if err != nil {
+				d.addError(source.Name, err)
+				continue
+			}
+			file := path.Join("logs", namespace, pod.Name, container+".log")
+			description := fmt.Sprintf("logs of container %s of pod %s/%s", container, namespace, pod.Name)
+			if err := d.addFile(source.Name, file, description, redactLogs(logs)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeObjects writes the redacted objects, or the items of a list object, as a YAML list
+func (d *diagnosticsCollector) writeObjects(source *DiagnosticsSource, file, description string, objs ...runtime.Object) error {
+	items := []interface{}{}
+	for _, obj := range objs {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			d.addError(source.Name, errors.Wrapf(err, "unable to convert %s", description))
+			return nil
+		}
+		apiVersion, kind := "", ""
+		if gvk, err := apiutil.GVKForObject(obj, diagnosticsScheme); err == nil {
+			apiVersion, kind = gvk.GroupVersion().String(), strings.TrimSuffix(gvk.Kind, "List")
+		}
+		if isList(obj) {
+			listItems, _ := content["items"].([]interface{})
+			for _, item := range listItems {
+				setTypeMeta(item, apiVersion, kind)
+			}
+			items = append(items, listItems...)
+			continue
+		}
+		setTypeMeta(content, apiVersion, kind)
+		items = append(items, content)
+	}
+
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+				delete(metadata, "managedFields")
+			}
+			redactObject(object)
+		}
+	}
+
+	b, err := yaml.Marshal(map[string]interface{}{"apiVersion": "v1", "kind": "List", "items": items})
+	if err != nil {
+		d.addError(source.Name, errors.Wrapf(err, "unable to marshal %s", description))
+		return nil
+	}
+	return d.addFile(source.Name, file, description, b)
+}
+
+// setTypeMeta sets the apiVersion and kind of a collected object, which are not set on typed objects
+func setTypeMeta(item interface{}, apiVersion, kind string) {
+	object, ok := item.(map[string]interface{})
+	if !ok || kind == "" {
+		return
+	}
+	if _, ok := object["kind"]; !ok {
+		object["apiVersion"] = apiVersion
+		object["kind"] = kind
+	}
+}
+
+func isList(obj runtime.Object) bool {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.IsList()
+	}
+	return meta.IsListType(obj)
+}
+
+func (d *diagnosticsCollector) addFile(sourceName, file, description string, content []byte) error {
+	filePath := path.Join(sourceName, file)
+	if err := d.writeFile(filePath, content); err != nil {
+		return err
+	}
+	d.index.Files = append(d.index.Files, DiagnosticsFile{Path: filePath, Source: sourceName, Description: description})
+	return nil
+}
+
+func (d *diagnosticsCollector) writeFile(filePath string, content []byte) error {
+	header := &tar.Header{
+		Name:    filePath,
+		Mode:    0o600,
+		Size:    int64(len(content)),
+		ModTime: d.index.CollectedAt,
+	}
+	if err := d.tarWriter.WriteHeader(header); err != nil {
+		return errors.Wrapf(err, "unable to write %s to support bundle", filePath)
+	}
+	if _, err := d.tarWriter.Write(content); err != nil {
+		return errors.Wrapf(err, "unable to write %s to support bundle", filePath)
+	}
+	return nil
+}
+
+func (d *diagnosticsCollector) addError(sourceName string, err error) {
+	log.V(3).Infof("Unable to collect diagnostics from the %s cluster: %v", sourceName, err)
+	d.index.Errors = append(d.index.Errors, fmt.Sprintf("%s: %s", sourceName, err.Error()))
+}
+
+// redactObject replaces the values of sensitive fields, the data of secrets and the values of
+// sensitive cluster topology variables
+func redactObject(object map[string]interface{}) {
+	if kind, _ := object["kind"].(string); kind == "Secret" {
+		for _, field := range []string{"data", "stringData"} {
+			if data, ok := object[field].(map[string]interface{}); ok {
+				for key := range data {
+					data[key] = redactedValue
+				}
+			}
+		}
+	}
+	redactValue(object)
+}
+
+func redactValue(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// name/value pairs, e.g. cluster topology variables
+		if name, ok := v["name"].(string); ok && sensitiveKeyRegex.MatchString(name) {
+			if _, ok := v["value"]; ok {
+				v["value"] = redactedValue
+			}
+		}
+		for key, fieldValue := range v {
+			if _, isString := fieldValue.(string); isString && sensitiveKeyRegex.MatchString(key) {
+				v[key] = redactedValue
+				continue
+			}
+			redactValue(fieldValue)
+		}
+	case []interface{}:
+		for i := range v {
+			redactValue(v[i])
+		}
+	}
+}
+
+func redactLogs(logs []byte) []byte {
+	return sensitiveLogLineRegex.ReplaceAll(logs, []byte("${1}"+redactedValue))
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+)
+
+func readSupportBundle(b []byte) map[string]string {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(b))
+	Expect(err).NotTo(HaveOccurred())
+	tarReader := tar.NewReader(gzipReader)
+
+	files := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		Expect(err).NotTo(HaveOccurred())
+		content, err := io.ReadAll(tarReader)
+		Expect(err).NotTo(HaveOccurred())
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+var _ = Describe("CollectDiagnostics", func() {
+	var (
+		clusterClient *fakes.ClusterClient
+		options       *DiagnoseClusterOptions
+		sources       []DiagnosticsSource
+		index         *DiagnosticsIndex
+		files         map[string]string
+		err           error
+	)
+
+	BeforeEach(func() {
+		clusterClient = &fakes.ClusterClient{}
+		options = &DiagnoseClusterOptions{ClusterName: "wc-1", Namespace: "default"}
+
+		clusterClient.GetResourceCalls(func(obj interface{}, name, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+			switch o := obj.(type) {
+			case *capi.Cluster:
+				o.Name = name
+				o.Namespace = namespace
+				o.Spec.InfrastructureRef = &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "VSphereCluster", Name: name}
+				o.Spec.Topology = &capi.Topology{
+					Class: "tkg-vsphere-default",
+					Variables: []capi.ClusterVariable{
+						{Name: "vcenterPassword", Value: apiextensionsv1.JSON{Raw: []byte(`"p@ssw0rd"`)}},
+						{Name: "controlPlaneEndpoint", Value: apiextensionsv1.JSON{Raw: []byte(`"10.0.0.1"`)}},
+					},
+				}
+			case *unstructured.Unstructured:
+				o.SetName(name)
+				o.SetNamespace(namespace)
+				_ = unstructured.SetNestedField(o.Object, "vcenter.local", "spec", "server")
+			default:
+				return apierrors.NewNotFound(schema.GroupResource{}, name)
+			}
+			return nil
+		})
+		clusterClient.ListResourcesCalls(func(list interface{}, opts ...crtclient.ListOption) error {
+			listOptions := &crtclient.ListOptions{}
+			listOptions.ApplyOptions(opts)
+			switch l := list.(type) {
+			case *corev1.PodList:
+				if listOptions.Namespace == "capi-system" {
+					l.Items = []corev1.Pod{{
+						ObjectMeta: metav1.ObjectMeta{Name: "capi-controller-manager-0", Namespace: "capi-system"},
+						Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "manager"}}},
+					}}
+				}
+			case *capi.MachineList:
+				l.Items = []capi.Machine{{
+					ObjectMeta: metav1.ObjectMeta{Name: "wc-1-md-0-abcde", Namespace: "default"},
+					Spec: capi.MachineSpec{
+						ClusterName:       "wc-1",
+						InfrastructureRef: corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "VSphereMachine", Name: "wc-1-md-0-xyz"},
+					},
+				}}
+			}
+			return nil
+		})
+		clusterClient.GetPodLogsReturns([]byte("reconciling cluster\nusing password=s3cr3t for vcenter\n"), nil)
+
+		sources = []DiagnosticsSource{
+			{Name: DiagnosticsSourceManagement, ClusterClient: clusterClient, CollectClusterAPIObjects: true},
+			{Name: DiagnosticsSourceWorkload, CollectClusterState: true, Err: errors.New("workload cluster is not reachable")},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var b bytes.Buffer
+		index, err = CollectDiagnostics(&b, options, sources)
+		if err == nil {
+			files = readSupportBundle(b.Bytes())
+		}
+	})
+
+	It("should write the cluster API objects, controller logs and an index to the support bundle", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(index.ClusterName).To(Equal("wc-1"))
+		Expect(files).To(HaveKey("index.yaml"))
+		Expect(files["index.yaml"]).To(ContainSubstring("path: management/objects/cluster.yaml"))
+
+		Expect(files).To(HaveKey("management/objects/cluster.yaml"))
+		Expect(files["management/objects/cluster.yaml"]).To(ContainSubstring("kind: Cluster"))
+		Expect(files).To(HaveKey("management/objects/infrastructurecluster.yaml"))
+		Expect(files["management/objects/infrastructurecluster.yaml"]).To(ContainSubstring("server: vcenter.local"))
+		Expect(files).To(HaveKey("management/objects/machines.yaml"))
+		Expect(files["management/objects/infrastructuremachines.yaml"]).To(ContainSubstring("name: wc-1-md-0-xyz"))
+		Expect(files).To(HaveKey("management/logs/capi-system/capi-controller-manager-0/manager.log"))
+		Expect(files).NotTo(HaveKey("management/objects/clusterbootstrap.yaml"))
+
+		podName, namespace, container, tailLines := clusterClient.GetPodLogsArgsForCall(0)
+		Expect(podName).To(Equal("capi-controller-manager-0"))
+		Expect(namespace).To(Equal("capi-system"))
+		Expect(container).To(Equal("manager"))
+		Expect(tailLines).To(BeNumerically(">", 0))
+	})
+
+	It("should redact the sensitive values", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files["management/objects/cluster.yaml"]).NotTo(ContainSubstring("p@ssw0rd"))
+		Expect(files["management/objects/cluster.yaml"]).To(ContainSubstring("value: REDACTED"))
+		Expect(files["management/objects/cluster.yaml"]).To(ContainSubstring("10.0.0.1"))
+		Expect(files["management/logs/capi-system/capi-controller-manager-0/manager.log"]).To(Equal("reconciling cluster\nusing password=REDACTED for vcenter\n"))
+	})
+
+	It("should record the items which could not be collected in the index", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(index.Errors).To(ContainElement("workload: workload cluster is not reachable"))
+		Expect(files["index.yaml"]).To(ContainSubstring("workload cluster is not reachable"))
+	})
+
+	Context("when the cluster object cannot be retrieved", func() {
+		BeforeEach(func() {
+			clusterClient.GetResourceReturns(errors.New("connection refused"))
+			clusterClient.GetResourceStub = nil
+		})
+		It("should continue collecting the other objects", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).NotTo(HaveKey("management/objects/cluster.yaml"))
+			Expect(files).To(HaveKey("management/objects/machinedeployments.yaml"))
+			Expect(index.Errors).To(ContainElement(ContainSubstring("unable to get cluster default/wc-1: connection refused")))
+		})
+	})
+})
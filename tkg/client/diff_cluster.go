@@ -0,0 +1,476 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/utils"
+)
+
+// ClusterDiffOperation is the kind of difference between the live and the desired cluster
+type ClusterDiffOperation string
+
+// Kinds of differences between the live and the desired cluster
+const (
+	// ClusterDiffAdded is a field set in the desired cluster but missing in the live cluster
+	ClusterDiffAdded ClusterDiffOperation = "added"
+	// ClusterDiffRemoved is a field of the live cluster that is no longer in the desired cluster
+	ClusterDiffRemoved ClusterDiffOperation = "removed"
+	// ClusterDiffChanged is a field whose live value differs from the desired value
+	ClusterDiffChanged ClusterDiffOperation = "changed"
+)
+
+const (
+	topologyPath                   = "spec.topology"
+	topologyVersionPath            = "spec.topology.version"
+	topologyMachineDeploymentsPath = "spec.topology.workers.machineDeployments"
+)
+
+// namedListsWithRemovals are the lists of named items whose live items missing from the desired
+// cluster are reported as removed. Live only items of the other lists, e.g. the variables defaulted
+// from the ClusterClass, are not drifts.
+var namedListsWithRemovals = []string{topologyMachineDeploymentsPath}
+
+// DiffClusterOptions options to diff a live cluster against its configuration
+type DiffClusterOptions struct {
+	ClusterName string
+	Namespace   string
+	// DesiredConfiguration is the yaml of the desired cluster, as generated by GetClusterConfiguration
+	DesiredConfiguration []byte
+	// Apply patches the live cluster with the desired topology and machine health checks
+	Apply bool
+}
+
+// ClusterDiffEntry is a difference between the live and the desired cluster
+type ClusterDiffEntry struct {
+	// Path of the field, named list items are referred to by name, e.g. spec.topology.variables[vcenter]
+	Path      string               `json:"path"`
+	Operation ClusterDiffOperation `json:"operation"`
+	Live      interface{}          `json:"live,omitempty"`
+	Desired   interface{}          `json:"desired,omitempty"`
+}
+
+// ClusterDiff is the result of the diff of a live cluster against its configuration
+type ClusterDiff struct {
+	ClusterName string             `json:"clusterName"`
+	Namespace   string             `json:"namespace"`
+	Entries     []ClusterDiffEntry `json:"entries"`
+	// Applied is set when the desired configuration was applied to the live cluster
+	Applied bool `json:"applied"`
+}
+
+// desiredClusterObjects are the objects of the desired configuration compared with the live cluster
+type desiredClusterObjects struct {
+	cluster             *capi.Cluster
+	machineHealthChecks []*capi.MachineHealthCheck
+}
+
+// DiffCluster compares the topology and the machine health checks of a live ClusterClass based cluster with
+// the ones generated from its configuration, and optionally patches the live cluster to match them.
+// Only the fields set in the desired configuration are compared, fields defaulted by the webhooks are ignored.
+func (c *TkgClient) DiffCluster(options *DiffClusterOptions) (*ClusterDiff, error) {
+	if options == nil {
+		return nil, errors.New("invalid diff cluster options")
+	}
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	desired, err := parseDesiredClusterObjects(options.DesiredConfiguration, options.ClusterName, options.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterClient, err := c.clusterClientFactory.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while diffing cluster")
+	}
+
+	live := &capi.Cluster{}
+	if err := clusterClient.GetResource(live, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to get cluster %s/%s", options.Namespace, options.ClusterName)
+	}
+	if live.Spec.Topology == nil {
+		return nil, errors.Errorf("cluster %s/%s is not a ClusterClass based cluster, only ClusterClass based clusters can be diffed", options.Namespace, options.ClusterName)
+	}
+	if desired.cluster.Spec.Topology == nil {
+		return nil, errors.Errorf("the configuration of cluster %s/%s does not define a topology", options.Namespace, options.ClusterName)
+	}
+
+	liveTopology, err := toGeneric(live.Spec.Topology)
+	if err != nil {
+		return nil, err
+	}
+	desiredTopology, err := toGeneric(desired.cluster.Spec.Topology)
+	if err != nil {
+		return nil, err
+	}
+	ignoreAutoscaledReplicas(live.Spec.Topology, desiredTopology)
+
+	d := &clusterDiffer{}
+	d.diff(topologyPath, liveTopology, desiredTopology)
+
+	liveMHCSpecs := make(map[string]interface{})
+	desiredMHCSpecs := make(map[string]interface{})
+	for _, mhc := range desired.machineHealthChecks {
+		desiredSpec, err := toGeneric(mhc.Spec)
+		if err != nil {
+			return nil, err
+		}
+		desiredMHCSpecs[mhc.Name] = desiredSpec
+
+		liveMHC := &capi.MachineHealthCheck{}
+		err = clusterClient.GetResource(liveMHC, mhc.Name, options.Namespace, nil, nil)
+		if err != nil && !apierrors.IsNotFound(errors.Cause(err)) {
+			return nil, errors.Wrapf(err, "unable to get machine health check %s/%s", options.Namespace, mhc.Name)
+		}
+		var liveSpec interface{}
+		if err == nil {
+			if liveSpec, err = toGeneric(liveMHC.Spec); err != nil {
+				return nil, err
+			}
+			liveMHCSpecs[mhc.Name] = liveSpec
+		}
+		d.diff(fmt.Sprintf("machineHealthChecks[%s].spec", mhc.Name), liveSpec, desiredSpec)
+	}
+
+	result := &ClusterDiff{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+		Entries:     d.entries,
+	}
+	if !options.Apply || len(d.entries) == 0 {
+		return result, nil
+	}
+
+	if err := applyDesiredTopology(clusterClient, options, liveTopology, desiredTopology); err != nil {
+		return nil, err
+	}
+	for _, mhc := range desired.machineHealthChecks {
+		if err := applyDesiredMachineHealthCheck(clusterClient, mhc, liveMHCSpecs[mhc.Name], desiredMHCSpecs[mhc.Name]); err != nil {
+			return nil, err
+		}
+	}
+	result.Applied = true
+	return result, nil
+}
+
+// parseDesiredClusterObjects returns the Cluster and its MachineHealthChecks from the desired configuration
+func parseDesiredClusterObjects(configuration []byte, clusterName, namespace string) (*desiredClusterObjects, error) {
+	objs, err := utilyaml.ToUnstructured(configuration)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse the cluster configuration")
+	}
+
+	desired := &desiredClusterObjects{}
+	for i := range objs {
+		obj := &objs[i]
+		if obj.GroupVersionKind().Group != capi.GroupVersion.Group {
+			continue
+		}
+		if obj.GetNamespace() != "" && obj.GetNamespace() != namespace {
+			continue
+		}
+		switch obj.GetKind() {
+		case constants.KindCluster:
+			if obj.GetName() != clusterName {
+				continue
+			}
+			cluster := &capi.Cluster{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, cluster); err != nil {
+				return nil, errors.Wrapf(err, "unable to convert cluster %s", clusterName)
+			}
+			desired.cluster = cluster
+		case constants.KindMachineHealthCheck:
+			mhc := &capi.MachineHealthCheck{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, mhc); err != nil {
+				return nil, errors.Wrapf(err, "unable to convert machine health check %s", obj.GetName())
+			}
+			if mhc.Spec.ClusterName == clusterName {
+				mhc.Namespace = namespace
+				desired.machineHealthChecks = append(desired.machineHealthChecks, mhc)
+			}
+		}
+	}
+	if desired.cluster == nil {
+		return nil, errors.Errorf("cluster %s/%s not found in the cluster configuration", namespace, clusterName)
+	}
+	return desired, nil
+}
+
+// ignoreAutoscaledReplicas removes the replicas of the desired machine deployments scaled by the cluster
+// autoscaler, as their live replicas are expected to differ
+func ignoreAutoscaledReplicas(liveTopology *capi.Topology, desiredTopology interface{}) {
+	if liveTopology.Workers == nil {
+		return
+	}
+	autoscaled := make(map[string]bool)
+	for i := range liveTopology.Workers.MachineDeployments {
+		md := &liveTopology.Workers.MachineDeployments[i]
+		_, hasMin := md.Metadata.Annotations[constants.AutoscalerMinSizeAnnotation]
+		_, hasMax := md.Metadata.Annotations[constants.AutoscalerMaxSizeAnnotation]
+		if hasMin || hasMax {
+			autoscaled[md.Name] = true
+		}
+	}
+
+	topology, _ := desiredTopology.(map[string]interface{})
+	workers, _ := topology["workers"].(map[string]interface{})
+	mds, _ := workers["machineDeployments"].([]interface{})
+	for _, item := range mds {
+		md, _ := item.(map[string]interface{})
+		if name, _ := md["name"].(string); autoscaled[name] {
+			delete(md, "replicas")
+		}
+	}
+}
+
+func applyDesiredTopology(clusterClient clusterclient.Client, options *DiffClusterOptions, liveTopology, desiredTopology interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"topology": mergeDesired(topologyPath, liveTopology, desiredTopology),
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal the cluster topology patch")
+	}
+	log.V(3).Infof("Patching topology of cluster %s/%s", options.Namespace, options.ClusterName)
+	if err := clusterClient.PatchResource(&capi.Cluster{}, options.ClusterName, options.Namespace, string(patch), types.MergePatchType, nil); err != nil {
+		return errors.Wrapf(err, "unable to patch the topology of cluster %s/%s", options.Namespace, options.ClusterName)
+	}
+	return nil
+}
+
+func applyDesiredMachineHealthCheck(clusterClient clusterclient.Client, mhc *capi.MachineHealthCheck, liveSpec, desiredSpec interface{}) error {
+	if liveSpec == nil {
+		log.V(3).Infof("Creating machine health check %s/%s", mhc.Namespace, mhc.Name)
+		if err := clusterClient.CreateResource(mhc, mhc.Name, mhc.Namespace); err != nil {
+			return errors.Wrapf(err, "unable to create machine health check %s/%s", mhc.Namespace, mhc.Name)
+		}
+		return nil
+	}
+	d := &clusterDiffer{}
+	if d.diff("spec", liveSpec, desiredSpec); len(d.entries) == 0 {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{"spec": mergeDesired("spec", liveSpec, desiredSpec)})
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal the machine health check patch")
+	}
+	log.V(3).Infof("Patching machine health check %s/%s", mhc.Namespace, mhc.Name)
+	if err := clusterClient.PatchResource(&capi.MachineHealthCheck{}, mhc.Name, mhc.Namespace, string(patch), types.MergePatchType, nil); err != nil {
+		return errors.Wrapf(err, "unable to patch machine health check %s/%s", mhc.Namespace, mhc.Name)
+	}
+	return nil
+}
+
+// clusterDiffer accumulates the differences of the fields set in the desired objects from the live objects
+type clusterDiffer struct {
+	entries []ClusterDiffEntry
+}
+
+func (d *clusterDiffer) add(path string, operation ClusterDiffOperation, live, desired interface{}) {
+	d.entries = append(d.entries, ClusterDiffEntry{Path: path, Operation: operation, Live: live, Desired: desired})
+}
+
+func (d *clusterDiffer) diff(path string, live, desired interface{}) {
+	if desired == nil {
+		return
+	}
+	if live == nil {
+		d.add(path, ClusterDiffAdded, nil, desired)
+		return
+	}
+
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			d.add(path, ClusterDiffChanged, live, desired)
+			return
+		}
+		for _, key := range sortedKeys(desiredValue) {
+			d.diff(path+"."+key, liveMap[key], desiredValue[key])
+		}
+	case []interface{}:
+		liveList, ok := live.([]interface{})
+		if ok && isNamedList(desiredValue) && isNamedList(liveList) {
+			d.diffNamedList(path, liveList, desiredValue)
+			return
+		}
+		if !reflect.DeepEqual(live, desired) {
+			d.add(path, ClusterDiffChanged, live, desired)
+		}
+	default:
+		if path == topologyVersionPath && isVersionPrefix(desired, live) {
+			return
+		}
+		if !reflect.DeepEqual(live, desired) {
+			d.add(path, ClusterDiffChanged, live, desired)
+		}
+	}
+}
+
+func (d *clusterDiffer) diffNamedList(path string, live, desired []interface{}) {
+	liveItems := itemsByName(live)
+	desiredItems := itemsByName(desired)
+	for _, item := range desired {
+		name := itemName(item)
+		d.diff(fmt.Sprintf("%s[%s]", path, name), liveItems[name], item)
+	}
+	if !utils.ContainsString(namedListsWithRemovals, path) {
+		return
+	}
+	for _, item := range live {
+		name := itemName(item)
+		if _, ok := desiredItems[name]; !ok {
+			d.add(fmt.Sprintf("%s[%s]", path, name), ClusterDiffRemoved, item, nil)
+		}
+	}
+}
+
+// mergeDesired returns the live value updated with the fields set in the desired value
+func mergeDesired(path string, live, desired interface{}) interface{} {
+	if desired == nil {
+		return live
+	}
+	if live == nil {
+		return desired
+	}
+
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return desired
+		}
+		merged := make(map[string]interface{}, len(liveMap))
+		for key, value := range liveMap {
+			merged[key] = value
+		}
+		for key, value := range desiredValue {
+			merged[key] = mergeDesired(path+"."+key, liveMap[key], value)
+		}
+		return merged
+	case []interface{}:
+		liveList, ok := live.([]interface{})
+		if ok && isNamedList(desiredValue) && isNamedList(liveList) {
+			return mergeNamedList(path, liveList, desiredValue)
+		}
+		return desired
+	default:
+		if path == topologyVersionPath && isVersionPrefix(desired, live) {
+			return live
+		}
+		return desired
+	}
+}
+
+func mergeNamedList(path string, live, desired []interface{}) []interface{} {
+	liveItems := itemsByName(live)
+	desiredItems := itemsByName(desired)
+	withRemovals := utils.ContainsString(namedListsWithRemovals, path)
+
+	merged := make([]interface{}, 0, len(desired))
+	for _, item := range live {
+		name := itemName(item)
+		desiredItem, ok := desiredItems[name]
+		switch {
+		case ok:
+			merged = append(merged, mergeDesired(fmt.Sprintf("%s[%s]", path, name), item, desiredItem))
+		case !withRemovals:
+			merged = append(merged, item)
+		}
+	}
+	for _, item := range desired {
+		if _, ok := liveItems[itemName(item)]; !ok {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+// isNamedList returns true if all the items of the list are objects with a name, such as the
+// variables or the machine deployments of the topology
+func isNamedList(list []interface{}) bool {
+	for _, item := range list {
+		if itemName(item) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func itemName(item interface{}) string {
+	m, _ := item.(map[string]interface{})
+	name, _ := m["name"].(string)
+	return name
+}
+
+func itemsByName(list []interface{}) map[string]interface{} {
+	items := make(map[string]interface{}, len(list))
+	for _, item := range list {
+		items[itemName(item)] = item
+	}
+	return items
+}
+
+// isVersionPrefix returns true if the live version is the desired version resolved to a full TKR
+// version by the tkr-resolver, e.g. v1.23.8 and v1.23.8+vmware.2
+func isVersionPrefix(desired, live interface{}) bool {
+	desiredVersion, ok := desired.(string)
+	if !ok {
+		return false
+	}
+	liveVersion, ok := live.(string)
+	if !ok {
+		return false
+	}
+	return liveVersion == desiredVersion ||
+		strings.HasPrefix(liveVersion, desiredVersion+"+") ||
+		strings.HasPrefix(liveVersion, desiredVersion+".") ||
+		strings.HasPrefix(liveVersion, desiredVersion+"-")
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// toGeneric converts a typed object to its json representation so that live and desired objects are
+// compared with the same serialization of durations, quantities and numbers
+func toGeneric(obj interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to marshal object")
+	}
+	var generic interface{}
+	if err := json.Unmarshal(bytes, &generic); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal object")
+	}
+	return generic, nil
+}
@@ -0,0 +1,258 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	. "github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
+)
+
+const desiredClusterConfiguration = `apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: wc-1
+  namespace: default
+spec:
+  topology:
+    class: tkg-vsphere-default
+    version: v1.23.8
+    controlPlane:
+      replicas: 3
+    variables:
+    - name: vcenter
+      value:
+        server: vc.local
+    workers:
+      machineDeployments:
+      - class: tkg-worker
+        name: md-0
+        replicas: 2
+      - class: tkg-worker
+        name: md-1
+        replicas: 1
+      - class: tkg-worker
+        name: md-3
+        replicas: 1
+---
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineHealthCheck
+metadata:
+  name: wc-1
+  namespace: default
+spec:
+  clusterName: wc-1
+  nodeStartupTimeout: 20m
+  unhealthyConditions:
+  - type: Ready
+    status: Unknown
+    timeout: 5m
+---
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineHealthCheck
+metadata:
+  name: wc-1-control-plane
+  namespace: default
+spec:
+  clusterName: wc-1
+  nodeStartupTimeout: 20m
+`
+
+var _ = Describe("DiffCluster", func() {
+	var (
+		tkgClient            *TkgClient
+		clusterClientFactory *fakes.ClusterClientFactory
+		clusterClient        *fakes.ClusterClient
+		regionManager        *fakes.RegionManager
+		liveCluster          *capi.Cluster
+		options              *DiffClusterOptions
+		diff                 *ClusterDiff
+		err                  error
+	)
+
+	BeforeEach(func() {
+		liveCluster = &capi.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "wc-1", Namespace: "default"},
+			Spec: capi.ClusterSpec{
+				Topology: &capi.Topology{
+					Class:   "tkg-vsphere-default",
+					Version: "v1.23.8+vmware.2-tkg.1",
+					ControlPlane: capi.ControlPlaneTopology{
+						Replicas: pointer.Int32(1),
+					},
+					Variables: []capi.ClusterVariable{
+						{Name: "vcenter", Value: apiextensionsv1.JSON{Raw: []byte(`{"server":"vc.local","tlsThumbprint":"ab:cd"}`)}},
+						{Name: "TKR_DATA", Value: apiextensionsv1.JSON{Raw: []byte(`{"v1.23.8+vmware.2-tkg.1":{}}`)}},
+					},
+					Workers: &capi.WorkersTopology{
+						MachineDeployments: []capi.MachineDeploymentTopology{
+							{Class: "tkg-worker", Name: "md-0", Replicas: pointer.Int32(2)},
+							{
+								Class:    "tkg-worker",
+								Name:     "md-1",
+								Replicas: pointer.Int32(5),
+								Metadata: capi.ObjectMeta{Annotations: map[string]string{
+									"cluster.x-k8s.io/cluster-api-autoscaler-node-group-min-size": "1",
+									"cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size": "5",
+								}},
+							},
+							{Class: "tkg-worker", Name: "md-2", Replicas: pointer.Int32(1)},
+						},
+					},
+				},
+			},
+		}
+
+		clusterClient = &fakes.ClusterClient{}
+		clusterClient.GetResourceCalls(func(obj interface{}, name, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+			switch o := obj.(type) {
+			case *capi.Cluster:
+				liveCluster.DeepCopyInto(o)
+				return nil
+			case *capi.MachineHealthCheck:
+				if name == "wc-1" {
+					maxUnhealthy := intstr.FromString("100%")
+					o.Name = name
+					o.Namespace = namespace
+					o.Spec = capi.MachineHealthCheckSpec{
+						ClusterName:        "wc-1",
+						MaxUnhealthy:       &maxUnhealthy,
+						NodeStartupTimeout: &metav1.Duration{Duration: 20 * time.Minute},
+						UnhealthyConditions: []capi.UnhealthyCondition{
+							{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+						},
+					}
+					return nil
+				}
+			}
+			return apierrors.NewNotFound(schema.GroupResource{}, name)
+		})
+		clusterClientFactory = &fakes.ClusterClientFactory{}
+		clusterClientFactory.NewClientReturns(clusterClient, nil)
+		regionManager = &fakes.RegionManager{}
+		regionManager.GetCurrentContextReturns(region.RegionContext{ClusterName: "mc-1", ContextName: "mc-1-admin@mc-1"}, nil)
+
+		tkgClient, err = New(Options{
+			TKGConfigUpdater:     &fakes.TKGConfigUpdaterClient{},
+			RegionManager:        regionManager,
+			ClusterClientFactory: clusterClientFactory,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		options = &DiffClusterOptions{
+			ClusterName:          "wc-1",
+			DesiredConfiguration: []byte(desiredClusterConfiguration),
+		}
+	})
+
+	JustBeforeEach(func() {
+		diff, err = tkgClient.DiffCluster(options)
+	})
+
+	It("should report the drifts of the fields set in the configuration", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Namespace).To(Equal("default"))
+		Expect(diff.Applied).To(BeFalse())
+		Expect(diff.Entries).To(Equal([]ClusterDiffEntry{
+			{Path: "spec.topology.controlPlane.replicas", Operation: ClusterDiffChanged, Live: float64(1), Desired: float64(3)},
+			{Path: "spec.topology.workers.machineDeployments[md-3]", Operation: ClusterDiffAdded, Desired: map[string]interface{}{
+				"class": "tkg-worker", "name": "md-3", "replicas": float64(1), "metadata": map[string]interface{}{},
+			}},
+			{Path: "spec.topology.workers.machineDeployments[md-2]", Operation: ClusterDiffRemoved, Live: map[string]interface{}{
+				"class": "tkg-worker", "name": "md-2", "replicas": float64(1), "metadata": map[string]interface{}{},
+			}},
+			{Path: "machineHealthChecks[wc-1-control-plane].spec", Operation: ClusterDiffAdded, Desired: map[string]interface{}{
+				"clusterName": "wc-1", "nodeStartupTimeout": "20m0s", "selector": map[string]interface{}{}, "unhealthyConditions": nil,
+			}},
+		}))
+		Expect(clusterClient.PatchResourceCallCount()).To(Equal(0))
+		Expect(clusterClient.CreateResourceCallCount()).To(Equal(0))
+	})
+
+	Context("when the configuration is applied", func() {
+		BeforeEach(func() {
+			options.Apply = true
+		})
+
+		It("should patch the topology and create the missing machine health checks", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Applied).To(BeTrue())
+
+			Expect(clusterClient.PatchResourceCallCount()).To(Equal(1))
+			_, name, namespace, patch, patchType, _ := clusterClient.PatchResourceArgsForCall(0)
+			Expect(name).To(Equal("wc-1"))
+			Expect(namespace).To(Equal("default"))
+			Expect(patchType).To(Equal(types.MergePatchType))
+
+			patched := &capi.Cluster{}
+			Expect(json.Unmarshal([]byte(patch), patched)).To(Succeed())
+			topology := patched.Spec.Topology
+			Expect(topology.Version).To(Equal("v1.23.8+vmware.2-tkg.1"))
+			Expect(*topology.ControlPlane.Replicas).To(Equal(int32(3)))
+			Expect(topology.Variables).To(HaveLen(2))
+			Expect(string(topology.Variables[0].Value.Raw)).To(MatchJSON(`{"server":"vc.local","tlsThumbprint":"ab:cd"}`))
+			Expect(topology.Workers.MachineDeployments).To(HaveLen(3))
+			Expect(topology.Workers.MachineDeployments[0].Name).To(Equal("md-0"))
+			Expect(topology.Workers.MachineDeployments[1].Name).To(Equal("md-1"))
+			Expect(*topology.Workers.MachineDeployments[1].Replicas).To(Equal(int32(5)))
+			Expect(topology.Workers.MachineDeployments[2].Name).To(Equal("md-3"))
+
+			Expect(clusterClient.CreateResourceCallCount()).To(Equal(1))
+			obj, name, namespace, _ := clusterClient.CreateResourceArgsForCall(0)
+			Expect(obj).To(BeAssignableToTypeOf(&capi.MachineHealthCheck{}))
+			Expect(name).To(Equal("wc-1-control-plane"))
+			Expect(namespace).To(Equal("default"))
+		})
+	})
+
+	Context("when the live cluster is not ClusterClass based", func() {
+		BeforeEach(func() {
+			liveCluster.Spec.Topology = nil
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("only ClusterClass based clusters can be diffed"))
+		})
+	})
+
+	Context("when the cluster is not in the configuration", func() {
+		BeforeEach(func() {
+			options.ClusterName = "wc-2"
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cluster default/wc-2 not found in the cluster configuration"))
+		})
+	})
+
+	Context("when the live cluster cannot be retrieved", func() {
+		BeforeEach(func() {
+			clusterClient.GetResourceReturns(errors.New("connection refused"))
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to get cluster default/wc-1"))
+		})
+	})
+})
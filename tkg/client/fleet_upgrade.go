@@ -0,0 +1,502 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/run/util/clusters"
+	runv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha1"
+	runv1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/utils"
+)
+
+const tkrInactiveLabel = "inactive"
+
+// FleetUpgradeStatus is the upgrade status of a cluster of a fleet upgrade
+type FleetUpgradeStatus string
+
+const (
+	// FleetUpgradePending the cluster is waiting for its wave to be upgraded
+	FleetUpgradePending FleetUpgradeStatus = "pending"
+	// FleetUpgradeUpgrading the cluster is being upgraded
+	FleetUpgradeUpgrading FleetUpgradeStatus = "upgrading"
+	// FleetUpgradeSucceeded the cluster has been upgraded and is healthy
+	FleetUpgradeSucceeded FleetUpgradeStatus = "succeeded"
+	// FleetUpgradeFailed the upgrade of the cluster or its health check failed
+	FleetUpgradeFailed FleetUpgradeStatus = "failed"
+	// FleetUpgradeSkipped the cluster has no upgrade to the requested TKr
+	FleetUpgradeSkipped FleetUpgradeStatus = "skipped"
+)
+
+// FleetUpgradeOptions options to upgrade the workload clusters matching a label selector
+type FleetUpgradeOptions struct {
+	// Selector is the label selector of the workload clusters to upgrade
+	Selector string
+	// Namespace of the workload clusters, all namespaces if empty
+	Namespace string
+	// TkrName is the name or name prefix of the TKr to upgrade to, the latest available upgrade if empty
+	TkrName string
+	// MaxConcurrent is the number of clusters upgraded concurrently in each wave
+	MaxConcurrent int
+	// MaxFailures is the number of failed clusters tolerated before the upgrade is halted
+	MaxFailures int
+	// PlanFile persists the plan and the status of the upgrade, so that it can be resumed
+	PlanFile         string
+	OSName           string
+	OSVersion        string
+	OSArch           string
+	SkipAddonUpgrade bool
+	// Tanzu edition (either tce or tkg)
+	Edition string
+}
+
+// FleetUpgradeCluster is the upgrade plan and status of a cluster of a fleet upgrade
+type FleetUpgradeCluster struct {
+	Name              string             `json:"name"`
+	Namespace         string             `json:"namespace"`
+	CurrentTKR        string             `json:"currentTKR,omitempty"`
+	TargetTKR         string             `json:"targetTKR,omitempty"`
+	TkrVersion        string             `json:"tkrVersion,omitempty"`
+	KubernetesVersion string             `json:"kubernetesVersion,omitempty"`
+	Wave              int                `json:"wave,omitempty"`
+	Status            FleetUpgradeStatus `json:"status"`
+	Message           string             `json:"message,omitempty"`
+}
+
+// FleetUpgradePlan is the plan of a fleet upgrade, updated with the status of each cluster as the upgrade progresses
+type FleetUpgradePlan struct {
+	Selector      string                `json:"selector"`
+	Namespace     string                `json:"namespace,omitempty"`
+	TkrName       string                `json:"tkrName,omitempty"`
+	MaxConcurrent int                   `json:"maxConcurrent"`
+	Clusters      []FleetUpgradeCluster `json:"clusters"`
+	LastUpdated   time.Time             `json:"lastUpdated"`
+
+	path  string
+	mutex sync.Mutex
+}
+
+// PlanFleetUpgrade plans the upgrade of the workload clusters matching the label selector. The target TKr of
+// each cluster is chosen among its available upgrades, and the clusters to upgrade are split in waves of
+// MaxConcurrent clusters. If the plan file of an interrupted fleet upgrade exists, that plan is returned instead.
+func (c *TkgClient) PlanFleetUpgrade(options *FleetUpgradeOptions) (*FleetUpgradePlan, error) {
+	if options == nil {
+		return nil, errors.New("invalid fleet upgrade options nil")
+	}
+	if options.MaxConcurrent < 1 {
+		options.MaxConcurrent = 1
+	}
+
+	if options.PlanFile != "" {
+		if _, err := os.Stat(options.PlanFile); err == nil {
+			return loadFleetUpgradePlan(options)
+		}
+	}
+
+	selector, err := labels.Parse(options.Selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cluster selector '%s'", options.Selector)
+	}
+
+	regionalClusterClient, err := c.getFleetUpgradeRegionalClusterClient()
+	if err != nil {
+		return nil, err
+	}
+
+	clusterList := &capi.ClusterList{}
+	if err := regionalClusterClient.ListResources(clusterList, crtclient.InNamespace(options.Namespace), crtclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, errors.Wrap(err, "unable to list clusters")
+	}
+	tkrs, err := regionalClusterClient.GetTanzuKubernetesReleases("")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list Tanzu Kubernetes releases")
+	}
+
+	plan := &FleetUpgradePlan{
+		Selector:      options.Selector,
+		Namespace:     options.Namespace,
+		TkrName:       options.TkrName,
+		MaxConcurrent: options.MaxConcurrent,
+		Clusters:      []FleetUpgradeCluster{},
+		path:          options.PlanFile,
+	}
+
+	items := clusterList.Items
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	upgrades := 0
+	for i := range items {
+		if isManagementCluster(&items[i]) || !items[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		entry := planFleetClusterUpgrade(&items[i], tkrs, options.TkrName)
+		if entry.Status == FleetUpgradePending {
+			entry.Wave = upgrades/options.MaxConcurrent + 1
+			upgrades++
+		}
+		plan.Clusters = append(plan.Clusters, entry)
+	}
+	if len(plan.Clusters) == 0 {
+		return nil, errors.Errorf("no workload clusters found matching selector '%s'", options.Selector)
+	}
+
+	if plan.path != "" {
+		if err := plan.save(); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// UpgradeFleet upgrades the clusters of the plan wave by wave, waiting for the upgraded clusters and their
+// packages to be healthy before starting the next wave. The upgrade is halted once more than MaxFailures
+// clusters have failed. Clusters which already succeeded in a previous run of the plan are not upgraded again.
+func (c *TkgClient) UpgradeFleet(options *FleetUpgradeOptions, plan *FleetUpgradePlan) error {
+	if options == nil || plan == nil {
+		return errors.New("invalid fleet upgrade options nil")
+	}
+
+	regionalClusterClient, err := c.getFleetUpgradeRegionalClusterClient()
+	if err != nil {
+		return err
+	}
+
+	// legacy clusters regenerate their addons from the shared TKG configuration, so they are not upgraded concurrently
+	legacyUpgradeMutex := &sync.Mutex{}
+
+	failures := 0
+	for wave := 1; wave <= plan.lastWave(); wave++ {
+		var waveClusters []*FleetUpgradeCluster
+		for i := range plan.Clusters {
+			entry := &plan.Clusters[i]
+			if entry.Wave == wave && entry.Status != FleetUpgradeSucceeded && entry.Status != FleetUpgradeSkipped {
+				waveClusters = append(waveClusters, entry)
+			}
+		}
+		if len(waveClusters) == 0 {
+			continue
+		}
+
+		log.Infof("Upgrading wave %d of %d: %s", wave, plan.lastWave(), strings.Join(fleetClusterNames(waveClusters), ", "))
+		var wg sync.WaitGroup
+		for _, entry := range waveClusters {
+			wg.Add(1)
+			go func(entry *FleetUpgradeCluster) {
+				defer wg.Done()
+				if err := plan.setStatus(entry, FleetUpgradeUpgrading, ""); err != nil {
+					log.Warningf("%v", err)
+				}
+				status, message := FleetUpgradeSucceeded, ""
+				if err := c.upgradeFleetCluster(regionalClusterClient, legacyUpgradeMutex, options, entry); err != nil {
+					status, message = FleetUpgradeFailed, err.Error()
+				}
+				if err := plan.setStatus(entry, status, message); err != nil {
+					log.Warningf("%v", err)
+				}
+			}(entry)
+		}
+		wg.Wait()
+
+		for _, entry := range waveClusters {
+			if entry.Status == FleetUpgradeFailed {
+				failures++
+				log.Warningf("Upgrade of cluster '%s/%s' failed: %s", entry.Namespace, entry.Name, entry.Message)
+			}
+		}
+		if failures > options.MaxFailures {
+			return errors.Errorf("fleet upgrade halted after wave %d: %d clusters failed to upgrade, which exceeds the maximum of %d failures", wave, failures, options.MaxFailures)
+		}
+	}
+	return nil
+}
+
+// upgradeFleetCluster upgrades a cluster of the fleet the same way as UpgradeCluster does, then waits for the
+// cluster and its packages to be healthy
+func (c *TkgClient) upgradeFleetCluster(regionalClusterClient clusterclient.Client, legacyUpgradeMutex *sync.Mutex,
+	options *FleetUpgradeOptions, entry *FleetUpgradeCluster) error {
+
+	upgradeOptions := &UpgradeClusterOptions{
+		ClusterName:       entry.Name,
+		Namespace:         entry.Namespace,
+		KubernetesVersion: entry.KubernetesVersion,
+		TkrVersion:        entry.TkrVersion,
+		OSName:            options.OSName,
+		OSVersion:         options.OSVersion,
+		OSArch:            options.OSArch,
+		SkipAddonUpgrade:  options.SkipAddonUpgrade,
+		SkipPrompt:        true,
+		Edition:           options.Edition,
+	}
+
+	isClusterClassBased, err := regionalClusterClient.IsClusterClassBased(entry.Name, entry.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "unable to determine cluster type")
+	}
+	workloadClusterClient, err := c.getWorkloadClusterClientFromManagementCluster(regionalClusterClient, entry.Name, entry.Namespace)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Upgrading cluster '%s/%s' to TKr %s", entry.Namespace, entry.Name, entry.TargetTKR)
+	if isClusterClassBased {
+		err = c.DoClassyClusterUpgrade(regionalClusterClient, workloadClusterClient, upgradeOptions)
+	} else {
+		legacyUpgradeMutex.Lock()
+		err = c.DoLegacyClusterUpgrade(regionalClusterClient, workloadClusterClient, upgradeOptions)
+		legacyUpgradeMutex.Unlock()
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := regionalClusterClient.WaitForClusterReady(entry.Name, entry.Namespace, false); err != nil {
+		return errors.Wrap(err, "cluster is not healthy after the upgrade")
+	}
+	if err := c.WaitForPackages(regionalClusterClient, workloadClusterClient, entry.Name, entry.Namespace, false); err != nil {
+		return errors.Wrap(err, "packages are not healthy after the upgrade")
+	}
+	return nil
+}
+
+func (c *TkgClient) getFleetUpgradeRegionalClusterClient() (clusterclient.Client, error) {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	regionalClusterClient, err := c.clusterClientFactory.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get management cluster client")
+	}
+	return regionalClusterClient, nil
+}
+
+// planFleetClusterUpgrade chooses the target TKr of the cluster, the latest of its available upgrades
+// matching the requested TKr name or name prefix
+func planFleetClusterUpgrade(cluster *capi.Cluster, tkrs []runv1alpha1.TanzuKubernetesRelease, tkrName string) FleetUpgradeCluster {
+	entry := FleetUpgradeCluster{
+		Name:       cluster.Name,
+		Namespace:  cluster.Namespace,
+		CurrentTKR: getClusterTKR(cluster.Labels),
+		Status:     FleetUpgradePending,
+	}
+	if tkrName != "" && entry.CurrentTKR == tkrName {
+		entry.Status = FleetUpgradeSkipped
+		entry.Message = fmt.Sprintf("cluster is already on TKr %s", tkrName)
+		return entry
+	}
+
+	var target *runv1alpha1.TanzuKubernetesRelease
+	for _, tkr := range getAvailableUpgradeTKRs(cluster, entry.CurrentTKR, tkrs) {
+		if !strings.HasPrefix(tkr.Name, tkrName) {
+			continue
+		}
+		if target == nil || compareTKRVersions(tkr.Spec.Version, target.Spec.Version) > 0 {
+			target = tkr
+		}
+	}
+	if target == nil {
+		entry.Status = FleetUpgradeSkipped
+		entry.Message = "no available upgrades"
+		if tkrName != "" {
+			entry.Message = fmt.Sprintf("no available upgrades matching TKr '%s'", tkrName)
+		}
+		return entry
+	}
+
+	entry.TargetTKR = target.Name
+	entry.TkrVersion = target.Spec.Version
+	entry.KubernetesVersion = target.Spec.KubernetesVersion
+	return entry
+}
+
+// getAvailableUpgradeTKRs returns the active TKrs the cluster can be upgraded to, based on the UpdatesAvailable
+// condition of the cluster, or on the one of its current TKr for clusters created before package based LCM
+func getAvailableUpgradeTKRs(cluster *capi.Cluster, currentTKR string, tkrs []runv1alpha1.TanzuKubernetesRelease) []*runv1alpha1.TanzuKubernetesRelease {
+	var upgrades []*runv1alpha1.TanzuKubernetesRelease
+	if conditions.Has(cluster, runv1.ConditionUpdatesAvailable) {
+		versions := clusters.AvailableUpgrades(cluster)
+		for i := range tkrs {
+			if versions.Has(tkrs[i].Spec.Version) && isTKRActive(&tkrs[i]) {
+				upgrades = append(upgrades, &tkrs[i])
+			}
+		}
+		return upgrades
+	}
+
+	var names []string
+	for i := range tkrs {
+		if tkrs[i].Name == currentTKR {
+			names = getTKRUpdatesAvailable(&tkrs[i])
+			break
+		}
+	}
+	for _, name := range names {
+		for i := range tkrs {
+			if tkrs[i].Name == name && isTKRActive(&tkrs[i]) && isTKRCompatible(&tkrs[i]) {
+				upgrades = append(upgrades, &tkrs[i])
+			}
+		}
+	}
+	return upgrades
+}
+
+// getTKRUpdatesAvailable returns the names of the TKrs listed in the UpdatesAvailable condition of the TKr
+func getTKRUpdatesAvailable(tkr *runv1alpha1.TanzuKubernetesRelease) []string {
+	updatesMsg := ""
+	for _, condition := range tkr.Status.Conditions {
+		if (condition.Type == runv1alpha1.ConditionUpdatesAvailable || condition.Type == runv1alpha1.ConditionUpgradeAvailable) &&
+			condition.Status == corev1.ConditionTrue {
+			updatesMsg = condition.Message
+			break
+		}
+	}
+	if updatesMsg == "" {
+		return nil
+	}
+
+	var updates []string
+	if strings.Contains(updatesMsg, "TKR(s)") {
+		// Example: "Deprecated, TKR(s) with later version is available: <tkr-name-1>,<tkr-name-2>"
+		strs := strings.Split(updatesMsg, ": ")
+		if len(strs) != 2 {
+			return nil
+		}
+		updates = strings.Split(strs[1], ",")
+	} else {
+		// Example: "[<tkr-version-1> <tkr-version-2>]"
+		updates = strings.Split(strings.TrimRight(strings.TrimLeft(updatesMsg, "["), "]"), " ")
+	}
+	for i := range updates {
+		if !strings.HasPrefix(updates[i], "v") {
+			updates[i] = "v" + updates[i]
+		}
+		updates[i] = utils.GetTkrNameFromTkrVersion(updates[i])
+	}
+	return updates
+}
+
+func isTKRActive(tkr *runv1alpha1.TanzuKubernetesRelease) bool {
+	_, inactive := tkr.Labels[tkrInactiveLabel]
+	return !inactive
+}
+
+func isTKRCompatible(tkr *runv1alpha1.TanzuKubernetesRelease) bool {
+	for _, condition := range tkr.Status.Conditions {
+		if condition.Type == runv1alpha1.ConditionCompatible {
+			return strings.EqualFold(string(condition.Status), string(corev1.ConditionTrue))
+		}
+	}
+	return false
+}
+
+func compareTKRVersions(v1, v2 string) int {
+	result, err := utils.CompareVMwareVersionStrings(v1, v2)
+	if err != nil {
+		return strings.Compare(v1, v2)
+	}
+	return result
+}
+
+func isManagementCluster(cluster *capi.Cluster) bool {
+	for _, role := range getClusterRoles(cluster.Labels) {
+		if role == TkgLabelClusterRoleManagement {
+			return true
+		}
+	}
+	return false
+}
+
+func fleetClusterNames(entries []*FleetUpgradeCluster) []string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Namespace+"/"+entry.Name)
+	}
+	return names
+}
+
+func loadFleetUpgradePlan(options *FleetUpgradeOptions) (*FleetUpgradePlan, error) {
+	b, err := os.ReadFile(options.PlanFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read fleet upgrade plan %s", options.PlanFile)
+	}
+	plan := &FleetUpgradePlan{}
+	if err := yaml.Unmarshal(b, plan); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse fleet upgrade plan %s", options.PlanFile)
+	}
+	if options.Selector != "" && options.Selector != plan.Selector {
+		return nil, errors.Errorf("fleet upgrade plan %s was created for selector '%s', not '%s'", options.PlanFile, plan.Selector, options.Selector)
+	}
+	if options.TkrName != "" && options.TkrName != plan.TkrName {
+		return nil, errors.Errorf("fleet upgrade plan %s was created for TKr '%s', not '%s'", options.PlanFile, plan.TkrName, options.TkrName)
+	}
+	plan.path = options.PlanFile
+	log.Infof("Resuming fleet upgrade from plan %s", options.PlanFile)
+	return plan, nil
+}
+
+// Counts returns the number of clusters of the plan in each status
+func (p *FleetUpgradePlan) Counts() map[FleetUpgradeStatus]int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	counts := map[FleetUpgradeStatus]int{}
+	for i := range p.Clusters {
+		counts[p.Clusters[i].Status]++
+	}
+	return counts
+}
+
+func (p *FleetUpgradePlan) lastWave() int {
+	last := 0
+	for i := range p.Clusters {
+		if p.Clusters[i].Wave > last {
+			last = p.Clusters[i].Wave
+		}
+	}
+	return last
+}
+
+// setStatus updates the status of a cluster and persists the plan
+func (p *FleetUpgradePlan) setStatus(entry *FleetUpgradeCluster, status FleetUpgradeStatus, message string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	entry.Status = status
+	entry.Message = message
+	if p.path == "" {
+		return nil
+	}
+	return p.save()
+}
+
+func (p *FleetUpgradePlan) save() error {
+	p.LastUpdated = time.Now().UTC()
+	b, err := yaml.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal fleet upgrade plan")
+	}
+	if err := utils.SaveFile(p.path, b); err != nil {
+		return errors.Wrapf(err, "unable to save fleet upgrade plan %s", p.path)
+	}
+	return nil
+}
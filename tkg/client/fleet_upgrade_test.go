@@ -0,0 +1,281 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	runv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha1"
+	runv1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+	. "github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
+)
+
+func fleetCluster(name string, labels map[string]string, updatesAvailable string) capi.Cluster {
+	cluster := capi.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+	if updatesAvailable != "" {
+		cluster.Status.Conditions = capi.Conditions{{
+			Type:    runv1.ConditionUpdatesAvailable,
+			Status:  corev1.ConditionTrue,
+			Message: updatesAvailable,
+		}}
+	}
+	return cluster
+}
+
+func fleetTKR(name, version string, conditions ...capi.Condition) runv1alpha1.TanzuKubernetesRelease {
+	tkr := runv1alpha1.TanzuKubernetesRelease{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	tkr.Spec.Version = version
+	tkr.Spec.KubernetesVersion = version[:len(version)-len("-tkg.1")]
+	tkr.Status.Conditions = append([]capi.Condition{{Type: runv1alpha1.ConditionCompatible, Status: corev1.ConditionTrue}}, conditions...)
+	return tkr
+}
+
+var _ = Describe("Fleet upgrade", func() {
+	var (
+		tkgClient     *TkgClient
+		clusterClient *fakes.ClusterClient
+		clusters      []capi.Cluster
+		planDir       string
+		options       *FleetUpgradeOptions
+		plan          *FleetUpgradePlan
+		err           error
+	)
+
+	BeforeEach(func() {
+		clusters = []capi.Cluster{
+			fleetCluster("wc-2", map[string]string{"env": "dev", "tanzuKubernetesRelease": "v1.22.9---vmware.1-tkg.1"}, ""),
+			fleetCluster("wc-1", map[string]string{"env": "dev", runv1.LabelTKR: "v1.22.9---vmware.1-tkg.1"}, "[v1.23.8+vmware.2-tkg.1 v1.22.11+vmware.1-tkg.1]"),
+			fleetCluster("wc-3", map[string]string{"env": "dev", runv1.LabelTKR: "v1.23.8---vmware.2-tkg.1"}, ""),
+			fleetCluster("mc", map[string]string{"env": "dev", TkgLabelClusterRolePrefix + TkgLabelClusterRoleManagement: ""}, "[v1.23.8+vmware.2-tkg.1]"),
+		}
+
+		clusterClient = &fakes.ClusterClient{}
+		clusterClient.ListResourcesCalls(func(obj interface{}, opts ...crtclient.ListOption) error {
+			if list, ok := obj.(*capi.ClusterList); ok {
+				list.Items = append([]capi.Cluster{}, clusters...)
+			}
+			return nil
+		})
+		clusterClient.GetTanzuKubernetesReleasesReturns([]runv1alpha1.TanzuKubernetesRelease{
+			fleetTKR("v1.22.9---vmware.1-tkg.1", "v1.22.9+vmware.1-tkg.1", capi.Condition{
+				Type:    runv1alpha1.ConditionUpdatesAvailable,
+				Status:  corev1.ConditionTrue,
+				Message: "Deprecated, TKR(s) with later version is available: v1.23.8---vmware.2-tkg.1",
+			}),
+			fleetTKR("v1.22.11---vmware.1-tkg.1", "v1.22.11+vmware.1-tkg.1"),
+			fleetTKR("v1.23.8---vmware.2-tkg.1", "v1.23.8+vmware.2-tkg.1"),
+		}, nil)
+		clusterClient.IsClusterClassBasedReturns(true, nil)
+		clusterClient.GetKubeConfigForClusterReturns([]byte("kubeconfig"), nil)
+
+		clusterClientFactory := &fakes.ClusterClientFactory{}
+		clusterClientFactory.NewClientReturns(clusterClient, nil)
+		regionManager := &fakes.RegionManager{}
+		regionManager.GetCurrentContextReturns(region.RegionContext{ClusterName: "mc", ContextName: "mc-admin@mc"}, nil)
+		tkgClient, err = CreateTKGClientOptsMutator("../fakes/config/config2.yaml", testingDir, "../fakes/config/bom/tkg-bom-v1.3.1.yaml", 2*time.Second, func(o Options) Options {
+			o.ClusterClientFactory = clusterClientFactory
+			o.RegionManager = regionManager
+			return o
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		planDir, err = os.MkdirTemp("", "fleet-upgrade")
+		Expect(err).NotTo(HaveOccurred())
+		options = &FleetUpgradeOptions{
+			Selector:      "env=dev",
+			MaxConcurrent: 1,
+			PlanFile:      filepath.Join(planDir, "plan.yaml"),
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(planDir)
+	})
+
+	Describe("PlanFleetUpgrade", func() {
+		JustBeforeEach(func() {
+			plan, err = tkgClient.PlanFleetUpgrade(options)
+		})
+
+		It("should plan the upgrade of the workload clusters to their latest available upgrade", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Clusters).To(Equal([]FleetUpgradeCluster{
+				{
+					Name: "wc-1", Namespace: "default", CurrentTKR: "v1.22.9---vmware.1-tkg.1", TargetTKR: "v1.23.8---vmware.2-tkg.1",
+					TkrVersion: "v1.23.8+vmware.2-tkg.1", KubernetesVersion: "v1.23.8+vmware.2", Wave: 1, Status: FleetUpgradePending,
+				},
+				{
+					Name: "wc-2", Namespace: "default", CurrentTKR: "v1.22.9---vmware.1-tkg.1", TargetTKR: "v1.23.8---vmware.2-tkg.1",
+					TkrVersion: "v1.23.8+vmware.2-tkg.1", KubernetesVersion: "v1.23.8+vmware.2", Wave: 2, Status: FleetUpgradePending,
+				},
+				{
+					Name: "wc-3", Namespace: "default", CurrentTKR: "v1.23.8---vmware.2-tkg.1", Status: FleetUpgradeSkipped, Message: "no available upgrades",
+				},
+			}))
+
+			listOptions := &crtclient.ListOptions{}
+			_, opts := clusterClient.ListResourcesArgsForCall(0)
+			for _, opt := range opts {
+				opt.ApplyToList(listOptions)
+			}
+			Expect(listOptions.LabelSelector.String()).To(Equal("env=dev"))
+			Expect(options.PlanFile).To(BeAnExistingFile())
+		})
+
+		Context("when a TKr name prefix is requested", func() {
+			BeforeEach(func() {
+				options.TkrName = "v1.22"
+				options.MaxConcurrent = 2
+			})
+
+			It("should only plan upgrades to the matching TKrs", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan.Clusters[0].TargetTKR).To(Equal("v1.22.11---vmware.1-tkg.1"))
+				Expect(plan.Clusters[0].Wave).To(Equal(1))
+				Expect(plan.Clusters[1].Status).To(Equal(FleetUpgradeSkipped))
+				Expect(plan.Clusters[1].Message).To(Equal("no available upgrades matching TKr 'v1.22'"))
+			})
+		})
+
+		Context("when the plan file of an interrupted fleet upgrade exists", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(options.PlanFile, []byte(`selector: env=dev
+maxConcurrent: 1
+clusters:
+- name: wc-1
+  namespace: default
+  wave: 1
+  status: succeeded
+`), 0o600)).To(Succeed())
+			})
+
+			It("should resume the plan", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan.Clusters).To(HaveLen(1))
+				Expect(plan.Clusters[0].Status).To(Equal(FleetUpgradeSucceeded))
+				Expect(clusterClient.ListResourcesCallCount()).To(Equal(0))
+			})
+
+			Context("when the plan was created for another selector", func() {
+				BeforeEach(func() {
+					options.Selector = "env=prod"
+				})
+
+				It("should return an error", func() {
+					Expect(err).To(MatchError(ContainSubstring("was created for selector 'env=dev', not 'env=prod'")))
+				})
+			})
+
+			Context("when the plan was created for another TKr", func() {
+				BeforeEach(func() {
+					options.TkrName = "v1.22"
+				})
+
+				It("should return an error", func() {
+					Expect(err).To(MatchError(ContainSubstring("was created for TKr '', not 'v1.22'")))
+				})
+			})
+		})
+
+		Context("when no workload clusters match the selector", func() {
+			BeforeEach(func() {
+				clusters = clusters[3:]
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError("no workload clusters found matching selector 'env=dev'"))
+			})
+		})
+	})
+
+	Describe("UpgradeFleet", func() {
+		JustBeforeEach(func() {
+			plan, err = tkgClient.PlanFleetUpgrade(options)
+			Expect(err).NotTo(HaveOccurred())
+			err = tkgClient.UpgradeFleet(options, plan)
+		})
+
+		It("should upgrade the clusters wave by wave and record their status in the plan file", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Counts()).To(Equal(map[FleetUpgradeStatus]int{FleetUpgradeSucceeded: 2, FleetUpgradeSkipped: 1}))
+
+			Expect(clusterClient.PatchClusterObjectCallCount()).To(Equal(2))
+			clusterName, _, patch := clusterClient.PatchClusterObjectArgsForCall(0)
+			Expect(clusterName).To(Equal("wc-1"))
+			Expect(patch).To(ContainSubstring("v1.23.8+vmware.2"))
+			Expect(clusterClient.WaitForClusterReadyCallCount()).To(Equal(2))
+
+			b, err := os.ReadFile(options.PlanFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(ContainSubstring("status: succeeded"))
+			Expect(string(b)).NotTo(ContainSubstring("status: pending"))
+		})
+
+		Context("when more clusters fail than tolerated", func() {
+			BeforeEach(func() {
+				clusterClient.WaitForClusterReadyReturnsOnCall(0, errors.New("control plane is not ready"))
+			})
+
+			It("should halt the upgrade after the failed wave", func() {
+				Expect(err).To(MatchError(ContainSubstring("fleet upgrade halted after wave 1")))
+				Expect(plan.Clusters[0].Status).To(Equal(FleetUpgradeFailed))
+				Expect(plan.Clusters[0].Message).To(ContainSubstring("control plane is not ready"))
+				Expect(plan.Clusters[1].Status).To(Equal(FleetUpgradePending))
+				Expect(clusterClient.PatchClusterObjectCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the failures are tolerated", func() {
+			BeforeEach(func() {
+				options.MaxFailures = 1
+				clusterClient.WaitForClusterReadyReturnsOnCall(0, errors.New("control plane is not ready"))
+			})
+
+			It("should upgrade the next waves", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan.Clusters[0].Status).To(Equal(FleetUpgradeFailed))
+				Expect(plan.Clusters[1].Status).To(Equal(FleetUpgradeSucceeded))
+			})
+		})
+
+		Context("when the plan of a partially upgraded fleet is resumed", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(options.PlanFile, []byte(`selector: env=dev
+maxConcurrent: 1
+clusters:
+- name: wc-1
+  namespace: default
+  wave: 1
+  status: succeeded
+- name: wc-2
+  namespace: default
+  targetTKR: v1.23.8---vmware.2-tkg.1
+  kubernetesVersion: v1.23.8+vmware.2
+  wave: 2
+  status: failed
+`), 0o600)).To(Succeed())
+			})
+
+			It("should only upgrade the clusters which did not succeed", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(clusterClient.PatchClusterObjectCallCount()).To(Equal(1))
+				clusterName, _, _ := clusterClient.PatchClusterObjectArgsForCall(0)
+				Expect(clusterName).To(Equal("wc-2"))
+				Expect(plan.Clusters[1].Status).To(Equal(FleetUpgradeSucceeded))
+			})
+		})
+	})
+})
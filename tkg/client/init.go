@@ -106,12 +106,34 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 	var regionContext region.RegionContext
 	var filelock *fslock.Lock
 	var configFilePath string
+	var journal *InitRegionJournal
+	var bootStrapClusterClient clusterclient.Client
 
 	bootstrapClusterKubeconfigPath, err := getTKGKubeConfigPath(false)
 	if err != nil {
 		return err
 	}
 
+	if options.Resume {
+		if journal, err = c.GetInitRegionJournal(options.ClusterName); err != nil {
+			return err
+		}
+		if bootStrapClusterClient, err = c.ResumeInitRegion(options, journal); err != nil {
+			return errors.Wrap(err, "unable to resume management cluster creation")
+		}
+		bootstrapClusterName = journal.BootstrapClusterName
+		bootstrapClusterKubeconfigPath = journal.BootstrapClusterKubeconfigPath
+		isBootstrapClusterCreated = journal.IsStepCompleted(StepSetupBootstrapCluster)
+		isStartedRegionalClusterCreation = journal.IsStepCompleted(StepInstallProvidersOnBootstrapCluster)
+	} else {
+		if options.ClusterName == "" {
+			options.ClusterName = generateRegionalClusterName(options.InfrastructureProvider, "")
+		}
+		if journal, err = c.newInitRegionJournal(options, bootstrapClusterKubeconfigPath); err != nil {
+			return err
+		}
+	}
+
 	log.SendProgressUpdate(statusRunning, StepValidateConfiguration, InitRegionSteps)
 
 	log.Info("Validating configuration...")
@@ -136,6 +158,7 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 		}
 
 		if isSuccessful {
+			journal.remove()
 			log.SendProgressUpdate(statusSuccessful, "", InitRegionSteps)
 		} else {
 			log.SendProgressUpdate(statusFailed, "", InitRegionSteps)
@@ -147,6 +170,9 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 			return
 		}
 
+		// the creation cannot be resumed once the bootstrap cluster is deleted
+		journal.remove()
+
 		if isBootstrapClusterCreated {
 			if err := c.teardownKindCluster(bootstrapClusterName, bootstrapClusterKubeconfigPath, options.UseExistingCluster); err != nil {
 				log.Warning(err.Error())
@@ -181,78 +207,225 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 	}
 
 	log.Infof("Using infrastructure provider %s", options.InfrastructureProvider)
+	if err := journal.completeStep(StepValidateConfiguration); err != nil {
+		return err
+	}
 	log.SendProgressUpdate(statusRunning, StepGenerateClusterConfiguration, InitRegionSteps)
 	log.Info("Generating cluster configuration...")
+	// Obtain management cluster configuration of a provided flavor
+	if regionalConfigBytes, options.ClusterName, configFilePath, err = c.BuildRegionalClusterConfiguration(options); err != nil {
+		return errors.Wrap(err, "unable to build management cluster configuration")
+	}
+	log.Infof("Management cluster config file has been generated and stored at: '%v'", configFilePath)
+	if err := journal.completeStep(StepGenerateClusterConfiguration); err != nil {
+		return err
+	}
 
 	log.SendProgressUpdate(statusRunning, StepSetupBootstrapCluster, InitRegionSteps)
-	log.Info("Setting up bootstrapper...")
-	// Ensure bootstrap cluster and copy boostrap cluster kubeconfig to ~/kube-tkg directory
-	if bootstrapClusterName, err = c.ensureKindCluster(options.Kubeconfig, options.UseExistingCluster, bootstrapClusterKubeconfigPath); err != nil {
-		return errors.Wrap(err, "unable to create bootstrap cluster")
+	if !journal.IsStepCompleted(StepSetupBootstrapCluster) {
+		log.Info("Setting up bootstrapper...")
+		// Ensure bootstrap cluster and copy boostrap cluster kubeconfig to ~/kube-tkg directory
+		if bootstrapClusterName, err = c.ensureKindCluster(options.Kubeconfig, options.UseExistingCluster, bootstrapClusterKubeconfigPath); err != nil {
+			return errors.Wrap(err, "unable to create bootstrap cluster")
+		}
+
+		isBootstrapClusterCreated = true
+		log.Infof("Bootstrapper created. Kubeconfig: %s", bootstrapClusterKubeconfigPath)
+		bootStrapClusterClient, err = clusterclient.NewClient(bootstrapClusterKubeconfigPath, "", clusterclient.Options{OperationTimeout: c.timeout})
+		if err != nil {
+			return errors.Wrap(err, "unable to get bootstrap cluster client")
+		}
+
+		journal.BootstrapClusterName = bootstrapClusterName
+		if err := journal.completeStep(StepSetupBootstrapCluster); err != nil {
+			return err
+		}
 	}
 
 	// Configure kubeconfig as part of options as bootstrap cluster kubeconfig
 	options.Kubeconfig = bootstrapClusterKubeconfigPath
 
-	isBootstrapClusterCreated = true
-	log.Infof("Bootstrapper created. Kubeconfig: %s", bootstrapClusterKubeconfigPath)
-	bootStrapClusterClient, err := clusterclient.NewClient(bootstrapClusterKubeconfigPath, "", clusterclient.Options{OperationTimeout: c.timeout})
-	if err != nil {
-		return errors.Wrap(err, "unable to get bootstrap cluster client")
-	}
-
 	// configure variables required to deploy providers
 	if err := c.configureVariablesForProvidersInstallation(nil); err != nil {
 		return errors.Wrap(err, "unable to configure variables for provider installation")
 	}
 
+	log.SendProgressUpdate(statusRunning, StepInstallProvidersOnBootstrapCluster, InitRegionSteps)
+	if !journal.IsStepCompleted(StepInstallProvidersOnBootstrapCluster) {
+		if err := c.installProvidersOnBootstrapCluster(options, bootStrapClusterClient, bootstrapClusterKubeconfigPath); err != nil {
+			return err
+		}
+		if err := journal.completeStep(StepInstallProvidersOnBootstrapCluster); err != nil {
+			return err
+		}
+	}
+
+	isStartedRegionalClusterCreation = true
+
+	targetClusterNamespace := defaultTkgNamespace
+	if options.Namespace != "" {
+		targetClusterNamespace = options.Namespace
+	}
+
+	regionalClusterKubeconfigPath, err := getTKGKubeConfigPath(true)
+	if err != nil {
+		return err
+	}
+
+	log.SendProgressUpdate(statusRunning, StepCreateManagementCluster, InitRegionSteps)
+	kubeContext := journal.KubeContext
+	if !journal.IsStepCompleted(StepCreateManagementCluster) {
+		if kubeContext, err = c.createManagementCluster(options, bootStrapClusterClient, &regionContext, bootstrapClusterName, bootstrapClusterKubeconfigPath,
+			regionalClusterKubeconfigPath, targetClusterNamespace, regionalConfigBytes); err != nil {
+			return err
+		}
+		journal.KubeContext = kubeContext
+		if err := journal.completeStep(StepCreateManagementCluster); err != nil {
+			return err
+		}
+	}
+
+	regionalClusterClient, err := clusterclient.NewClient(regionalClusterKubeconfigPath, kubeContext, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return errors.Wrap(err, "unable to get management cluster client")
+	}
+
+	log.SendProgressUpdate(statusRunning, StepInstallProvidersOnRegionalCluster, InitRegionSteps)
+	if !journal.IsStepCompleted(StepInstallProvidersOnRegionalCluster) {
+		if err := c.installProvidersOnRegionalCluster(options, bootStrapClusterClient, regionalClusterClient, regionalClusterKubeconfigPath, kubeContext, targetClusterNamespace); err != nil {
+			return err
+		}
+		if err := journal.completeStep(StepInstallProvidersOnRegionalCluster); err != nil {
+			return err
+		}
+	}
+
+	log.SendProgressUpdate(statusRunning, StepMoveClusterAPIObjects, InitRegionSteps)
+	if !journal.IsStepCompleted(StepMoveClusterAPIObjects) {
+		isMoved := false
+		if options.Resume {
+			if isMoved, err = c.CompleteInterruptedMove(bootStrapClusterClient, regionalClusterClient, options.ClusterName, targetClusterNamespace); err != nil {
+				return err
+			}
+		}
+		if !isMoved {
+			log.Info("Moving all Cluster API objects from bootstrap cluster to management cluster...")
+			// Move all Cluster API objects from bootstrap cluster to created to management cluster for all namespaces
+			if err = c.MoveObjects(bootstrapClusterKubeconfigPath, regionalClusterKubeconfigPath, targetClusterNamespace); err != nil {
+				return errors.Wrap(err, "unable to move Cluster API objects from bootstrap cluster to management cluster")
+			}
+		}
+		if err := journal.completeStep(StepMoveClusterAPIObjects); err != nil {
+			return err
+		}
+	}
+
+	regionContext = region.RegionContext{ClusterName: options.ClusterName, ContextName: kubeContext, SourceFilePath: regionalClusterKubeconfigPath, Status: region.Success}
+
+	err = c.PatchClusterInitOperations(regionalClusterClient, options, targetClusterNamespace)
+	if err != nil {
+		return errors.Wrap(err, "unable to patch cluster object")
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "unable to parse provider name")
+	}
+
+	// start CEIP telemetry cronjob if cluster is opt-in
+	if options.CeipOptIn {
+		bomConfig, err := c.tkgBomClient.GetDefaultTkgBOMConfiguration()
+		if err != nil {
+			return errors.Wrapf(err, "failed to get default bom configuration")
+		}
+
+		httpProxy, httpsProxy, noProxy := "", "", ""
+		if httpProxy, err = c.TKGConfigReaderWriter().Get(constants.TKGHTTPProxy); err == nil && httpProxy != "" {
+			httpsProxy, _ = c.TKGConfigReaderWriter().Get(constants.TKGHTTPSProxy)
+			noProxy, err = c.getFullTKGNoProxy(providerName)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = regionalClusterClient.AddCEIPTelemetryJob(options.ClusterName, providerName, bomConfig, "", "", httpProxy, httpsProxy, noProxy); err != nil {
+			log.Error(err, "Failed to start CEIP telemetry job on management cluster")
+
+			log.Warningf("\nTo have this cluster participate in VMware CEIP:")
+			log.Warningf("\ttanzu management-cluster ceip-participation set true")
+		}
+	}
+
+	if !config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
+		log.Wait("Waiting for additional components to be up and running...")
+		if err := c.WaitForAddonsDeployments(regionalClusterClient); err != nil {
+			return err
+		}
+	}
+
+	// Wait for packages if the feature-flag is disabled
+	// We do not need to wait for packages as we have already installed and waited for all
+	// packages to be deployed during tkg package installation
+	if !config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
+		log.Wait("Waiting for packages to be up and running...")
+		if err := c.WaitForPackages(regionalClusterClient, regionalClusterClient, options.ClusterName, targetClusterNamespace, true); err != nil {
+			log.Warningf("Warning: Management cluster is created successfully, but some packages are failing. %v", err)
+		}
+	}
+
+	log.Infof("You can now access the management cluster %s by running 'kubectl config use-context %s'", options.ClusterName, kubeContext)
+	isSuccessful = true
+	return nil
+}
+
+// installProvidersOnBootstrapCluster installs the providers and the management components on the bootstrap cluster
+func (c *TkgClient) installProvidersOnBootstrapCluster(options *InitRegionOptions, bootStrapClusterClient clusterclient.Client, bootstrapClusterKubeconfigPath string) error {
 	// If clusterclass feature flag is enabled then deploy kapp-controller
 	if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
 		log.Info("Installing kapp-controller on bootstrap cluster...")
-		if err = c.InstallOrUpgradeKappController(bootstrapClusterKubeconfigPath, "", constants.OperationTypeInstall); err != nil {
+		if err := c.InstallOrUpgradeKappController(bootstrapClusterKubeconfigPath, "", constants.OperationTypeInstall); err != nil {
 			return errors.Wrap(err, "unable to install kapp-controller to bootstrap cluster")
 		}
 	}
 
-	log.SendProgressUpdate(statusRunning, StepInstallProvidersOnBootstrapCluster, InitRegionSteps)
 	log.Info("Installing providers on bootstrapper...")
 	// Initialize bootstrap cluster with providers
-	if err = c.InitializeProviders(options, bootStrapClusterClient, bootstrapClusterKubeconfigPath); err != nil {
+	if err := c.InitializeProviders(options, bootStrapClusterClient, bootstrapClusterKubeconfigPath); err != nil {
 		return errors.Wrap(err, "unable to initialize providers")
 	}
 
 	// If clusterclass feature flag is enabled then deploy management components
 	if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
-		if err = c.InstallOrUpgradeManagementComponents(bootstrapClusterKubeconfigPath, "", false); err != nil {
+		if err := c.InstallOrUpgradeManagementComponents(bootstrapClusterKubeconfigPath, "", false); err != nil {
 			return errors.Wrap(err, "unable to install management components to bootstrap cluster")
 		}
 	}
 
 	if options.AdditionalTKGManifests != "" {
 		log.Infof("Apply additional manifests %s for the bootstrap cluster in tkg-system", options.AdditionalTKGManifests)
-		if err = bootStrapClusterClient.ApplyFileRecursively(options.AdditionalTKGManifests, "tkg-system"); err != nil {
+		if err := bootStrapClusterClient.ApplyFileRecursively(options.AdditionalTKGManifests, "tkg-system"); err != nil {
 			return errors.Wrap(err, "unable to apply additional manifests")
 		}
 	}
+	return nil
+}
 
-	// Obtain management cluster configuration of a provided flavor
-	if regionalConfigBytes, options.ClusterName, configFilePath, err = c.BuildRegionalClusterConfiguration(options); err != nil {
-		return errors.Wrap(err, "unable to build management cluster configuration")
-	}
-	log.Infof("Management cluster config file has been generated and stored at: '%v'", configFilePath)
-
-	isStartedRegionalClusterCreation = true
-
-	targetClusterNamespace := defaultTkgNamespace
-	if options.Namespace != "" {
-		targetClusterNamespace = options.Namespace
-	}
-
-	log.SendProgressUpdate(statusRunning, StepCreateManagementCluster, InitRegionSteps)
-	log.Info("Start creating management cluster...")
-	err = c.DoCreateCluster(bootStrapClusterClient, options.ClusterName, targetClusterNamespace, string(regionalConfigBytes))
+// createManagementCluster creates the management cluster from the bootstrap cluster, waits for its initialization
+// and saves its kubeconfig. The management cluster configuration is not applied again if the management cluster
+// already exists on the bootstrap cluster, so that an interrupted creation can be resumed
+func (c *TkgClient) createManagementCluster(options *InitRegionOptions, bootStrapClusterClient clusterclient.Client, regionContext *region.RegionContext, //nolint:funlen
+	bootstrapClusterName, bootstrapClusterKubeconfigPath, regionalClusterKubeconfigPath, targetClusterNamespace string, regionalConfigBytes []byte) (string, error) {
+	isCreationStarted, err := isManagementClusterCreationStarted(bootStrapClusterClient, options.ClusterName, targetClusterNamespace)
 	if err != nil {
-		return errors.Wrap(err, "unable to create management cluster")
+		return "", err
+	}
+	if isCreationStarted {
+		log.Infof("Management cluster %s already exists on the bootstrap cluster, resuming its creation...", options.ClusterName)
+	} else {
+		log.Info("Start creating management cluster...")
+		err = c.DoCreateCluster(bootStrapClusterClient, options.ClusterName, targetClusterNamespace, string(regionalConfigBytes))
+		if err != nil {
+			return "", errors.Wrap(err, "unable to create management cluster")
+		}
 	}
 
 	// save this context to tkg config incase the management cluster creation fails
@@ -260,30 +433,26 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 	if options.UseExistingCluster {
 		bootstrapClusterContext, err = getCurrentContextFromDefaultKubeConfig()
 		if err != nil {
-			return err
+			return "", err
 		}
 	}
-	regionContext = region.RegionContext{ClusterName: options.ClusterName, ContextName: bootstrapClusterContext, SourceFilePath: bootstrapClusterKubeconfigPath, Status: region.Failed}
+	*regionContext = region.RegionContext{ClusterName: options.ClusterName, ContextName: bootstrapClusterContext, SourceFilePath: bootstrapClusterKubeconfigPath, Status: region.Failed}
 
 	err = bootStrapClusterClient.WaitForControlPlaneAvailable(options.ClusterName, targetClusterNamespace)
 	if err != nil {
-		return errors.Wrap(err, "unable to wait for cluster control plane available")
+		return "", errors.Wrap(err, "unable to wait for cluster control plane available")
 	}
 	log.Info("Management cluster control plane is available, means API server is ready to receive requests")
 
 	kubeConfigBytes, err := bootStrapClusterClient.GetKubeConfigForCluster(options.ClusterName, targetClusterNamespace, nil)
 	if err != nil {
-		return errors.Wrapf(err, "unable to extract kube config for cluster %s", options.ClusterName)
+		return "", errors.Wrapf(err, "unable to extract kube config for cluster %s", options.ClusterName)
 	}
 
-	regionalClusterKubeconfigPath, err := getTKGKubeConfigPath(true)
-	if err != nil {
-		return err
-	}
 	// put a filelock to ensure mutual exclusion on updating kubeconfig
-	filelock, err = utils.GetFileLockWithTimeOut(filepath.Join(c.tkgConfigDir, constants.LocalTanzuFileLock), utils.DefaultLockTimeout)
+	filelock, err := utils.GetFileLockWithTimeOut(filepath.Join(c.tkgConfigDir, constants.LocalTanzuFileLock), utils.DefaultLockTimeout)
 	if err != nil {
-		return errors.Wrap(err, "cannot acquire lock for updating management cluster kubeconfig")
+		return "", errors.Wrap(err, "cannot acquire lock for updating management cluster kubeconfig")
 	}
 
 	mergeFile := getDefaultKubeConfigFile()
@@ -291,40 +460,40 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 	// merge the management cluster kubeconfig into user input kubeconfig path/default kubeconfig path
 	err = MergeKubeConfigWithoutSwitchContext(kubeConfigBytes, mergeFile)
 	if err != nil {
-		return errors.Wrap(err, "unable to merge management cluster kubeconfig")
+		return "", errors.Wrap(err, "unable to merge management cluster kubeconfig")
 	}
 
 	// merge the management cluster kubeconfig into tkg managed kubeconfig
 	kubeContext, err := MergeKubeConfigAndSwitchContext(kubeConfigBytes, regionalClusterKubeconfigPath)
 	if err != nil {
-		return errors.Wrap(err, "unable to save management cluster kubeconfig to TKG managed kubeconfig")
+		return "", errors.Wrap(err, "unable to save management cluster kubeconfig to TKG managed kubeconfig")
 	}
 
 	if err := filelock.Unlock(); err != nil {
 		log.Warningf("cannot acquire lock for updating management cluster kubeconfigconfig, reason: %v", err)
 	}
 
-	regionalClusterClient, err := clusterclient.NewClient(regionalClusterKubeconfigPath, kubeContext, clusterclient.Options{OperationTimeout: c.timeout})
-	if err != nil {
-		return errors.Wrap(err, "unable to get management cluster client")
-	}
-
 	// If clusterclass feature flag is enabled then deploy kapp-controller
 	if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
 		log.Info("Installing kapp-controller on management cluster...")
 		if err = c.InstallOrUpgradeKappController(regionalClusterKubeconfigPath, kubeContext, constants.OperationTypeInstall); err != nil {
-			return errors.Wrap(err, "unable to install kapp-controller to management cluster")
+			return "", errors.Wrap(err, "unable to install kapp-controller to management cluster")
 		}
 	}
 
 	err = bootStrapClusterClient.WaitForClusterInitialized(options.ClusterName, targetClusterNamespace)
 	if err != nil {
-		return errors.Wrap(err, "error waiting for cluster to be provisioned (this may take a few minutes)")
+		return "", errors.Wrap(err, "error waiting for cluster to be provisioned (this may take a few minutes)")
 	}
+	return kubeContext, nil
+}
 
-	log.SendProgressUpdate(statusRunning, StepInstallProvidersOnRegionalCluster, InitRegionSteps)
+// installProvidersOnRegionalCluster installs the providers and the management components on the management cluster
+// and waits for the management cluster to get ready for the move of the cluster-api objects
+func (c *TkgClient) installProvidersOnRegionalCluster(options *InitRegionOptions, bootStrapClusterClient, regionalClusterClient clusterclient.Client,
+	regionalClusterKubeconfigPath, kubeContext, targetClusterNamespace string) error {
 	log.Info("Installing providers on management cluster...")
-	if err = c.InitializeProviders(options, regionalClusterClient, regionalClusterKubeconfigPath); err != nil {
+	if err := c.InitializeProviders(options, regionalClusterClient, regionalClusterKubeconfigPath); err != nil {
 		return errors.Wrap(err, "unable to initialize providers on management cluster")
 	}
 
@@ -334,17 +503,17 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 
 	// If clusterclass feature flag is enabled then deploy management components to the cluster
 	if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
-		if err = c.InstallOrUpgradeManagementComponents(regionalClusterKubeconfigPath, kubeContext, false); err != nil {
+		if err := c.InstallOrUpgradeManagementComponents(regionalClusterKubeconfigPath, kubeContext, false); err != nil {
 			return errors.Wrap(err, "unable to install management components to management cluster")
 		}
 	}
 
-	log.Info("Waiting for the management cluster to get ready for move...")
+	log.Wait("Waiting for the management cluster to get ready for move...")
 	if err := c.WaitForClusterReadyForMove(bootStrapClusterClient, options.ClusterName, targetClusterNamespace); err != nil {
 		return errors.Wrap(err, "unable to wait for cluster getting ready for move")
 	}
 
-	log.Info("Waiting for addons installation...")
+	log.Wait("Waiting for addons installation...")
 	if err := c.WaitForAddons(waitForAddonsOptions{
 		regionalClusterClient: bootStrapClusterClient,
 		workloadClusterClient: regionalClusterClient,
@@ -357,72 +526,10 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 
 	if options.AdditionalTKGManifests != "" {
 		log.Infof("Apply additional manifests %s for the management cluster in %s", options.AdditionalTKGManifests, defaultTkgNamespace)
-		if err = regionalClusterClient.ApplyFileRecursively(options.AdditionalTKGManifests, defaultTkgNamespace); err != nil {
+		if err := regionalClusterClient.ApplyFileRecursively(options.AdditionalTKGManifests, defaultTkgNamespace); err != nil {
 			return errors.Wrap(err, "unable to apply additional manifests")
 		}
 	}
-
-	log.SendProgressUpdate(statusRunning, StepMoveClusterAPIObjects, InitRegionSteps)
-	log.Info("Moving all Cluster API objects from bootstrap cluster to management cluster...")
-	// Move all Cluster API objects from bootstrap cluster to created to management cluster for all namespaces
-	if err = c.MoveObjects(bootstrapClusterKubeconfigPath, regionalClusterKubeconfigPath, targetClusterNamespace); err != nil {
-		return errors.Wrap(err, "unable to move Cluster API objects from bootstrap cluster to management cluster")
-	}
-
-	regionContext = region.RegionContext{ClusterName: options.ClusterName, ContextName: kubeContext, SourceFilePath: regionalClusterKubeconfigPath, Status: region.Success}
-
-	err = c.PatchClusterInitOperations(regionalClusterClient, options, targetClusterNamespace)
-	if err != nil {
-		return errors.Wrap(err, "unable to patch cluster object")
-	}
-
-	if err != nil {
-		return errors.Wrap(err, "unable to parse provider name")
-	}
-
-	// start CEIP telemetry cronjob if cluster is opt-in
-	if options.CeipOptIn {
-		bomConfig, err := c.tkgBomClient.GetDefaultTkgBOMConfiguration()
-		if err != nil {
-			return errors.Wrapf(err, "failed to get default bom configuration")
-		}
-
-		httpProxy, httpsProxy, noProxy := "", "", ""
-		if httpProxy, err = c.TKGConfigReaderWriter().Get(constants.TKGHTTPProxy); err == nil && httpProxy != "" {
-			httpsProxy, _ = c.TKGConfigReaderWriter().Get(constants.TKGHTTPSProxy)
-			noProxy, err = c.getFullTKGNoProxy(providerName)
-			if err != nil {
-				return err
-			}
-		}
-
-		if err = regionalClusterClient.AddCEIPTelemetryJob(options.ClusterName, providerName, bomConfig, "", "", httpProxy, httpsProxy, noProxy); err != nil {
-			log.Error(err, "Failed to start CEIP telemetry job on management cluster")
-
-			log.Warningf("\nTo have this cluster participate in VMware CEIP:")
-			log.Warningf("\ttanzu management-cluster ceip-participation set true")
-		}
-	}
-
-	if !config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
-		log.Info("Waiting for additional components to be up and running...")
-		if err := c.WaitForAddonsDeployments(regionalClusterClient); err != nil {
-			return err
-		}
-	}
-
-	// Wait for packages if the feature-flag is disabled
-	// We do not need to wait for packages as we have already installed and waited for all
-	// packages to be deployed during tkg package installation
-	if !config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
-		log.Info("Waiting for packages to be up and running...")
-		if err := c.WaitForPackages(regionalClusterClient, regionalClusterClient, options.ClusterName, targetClusterNamespace, true); err != nil {
-			log.Warningf("Warning: Management cluster is created successfully, but some packages are failing. %v", err)
-		}
-	}
-
-	log.Infof("You can now access the management cluster %s by running 'kubectl config use-context %s'", options.ClusterName, kubeContext)
-	isSuccessful = true
 	return nil
 }
 
@@ -812,6 +919,9 @@ func (c *TkgClient) displayHelpTextOnFailure(options *InitRegionOptions,
 	log.Warningf("    kubectl get po,deploy,cluster,kubeadmcontrolplane,machine,machinedeployment -A --kubeconfig %s", bootstrapClusterKubeconfigPath)
 	log.Warningf("    kubectl logs deployment.apps/<deployment-name> -n <deployment-namespace> manager --kubeconfig %s", bootstrapClusterKubeconfigPath)
 
+	log.Warningf("\nTo resume the creation of the management cluster from the last completed step:")
+	log.Warningf("    tanzu management-cluster create --resume --name %s", options.ClusterName)
+
 	if !options.UseExistingCluster && isBootstrapClusterCreated {
 		log.Warningf("\nTo clean up the resources created by the management cluster:")
 		log.Warningf("	  tanzu management-cluster delete")
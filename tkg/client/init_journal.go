@@ -0,0 +1,340 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/secret"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/utils"
+)
+
+const initRegionJournalFileSuffix = ".journal.yaml"
+
+// InitRegionJournal records the steps of a management cluster creation which have been completed,
+// so that an interrupted creation can be resumed from the last completed step
+type InitRegionJournal struct {
+	ClusterName                    string    `json:"clusterName"`
+	Namespace                      string    `json:"namespace"`
+	BootstrapClusterName           string    `json:"bootstrapClusterName,omitempty"`
+	BootstrapClusterKubeconfigPath string    `json:"bootstrapClusterKubeconfigPath"`
+	UseExistingCluster             bool      `json:"useExistingCluster,omitempty"`
+	KubeContext                    string    `json:"kubeContext,omitempty"`
+	CompletedSteps                 []string  `json:"completedSteps,omitempty"`
+	LastUpdated                    time.Time `json:"lastUpdated"`
+
+	path string
+}
+
+// getInitRegionJournalPath returns the path of the journal of the management cluster creation,
+// stored next to the generated management cluster configuration file
+func (c *TkgClient) getInitRegionJournalPath(clusterName string) (string, error) {
+	clusterConfigDir, err := c.tkgConfigPathsClient.GetClusterConfigurationDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(clusterConfigDir, clusterName+initRegionJournalFileSuffix), nil
+}
+
+// newInitRegionJournal creates and persists the journal of a new management cluster creation
+func (c *TkgClient) newInitRegionJournal(options *InitRegionOptions, bootstrapClusterKubeconfigPath string) (*InitRegionJournal, error) {
+	path, err := c.getInitRegionJournalPath(options.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	journal := &InitRegionJournal{
+		ClusterName:                    options.ClusterName,
+		Namespace:                      options.Namespace,
+		BootstrapClusterKubeconfigPath: bootstrapClusterKubeconfigPath,
+		UseExistingCluster:             options.UseExistingCluster,
+		path:                           path,
+	}
+	if err := journal.save(); err != nil {
+		return nil, err
+	}
+	return journal, nil
+}
+
+// GetInitRegionJournal returns the journal of the interrupted creation of the management cluster.
+// If no cluster name is provided, the journal of the most recently interrupted creation is returned
+func (c *TkgClient) GetInitRegionJournal(clusterName string) (*InitRegionJournal, error) {
+	if clusterName != "" {
+		path, err := c.getInitRegionJournalPath(clusterName)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return nil, errors.Errorf("no interrupted creation of management cluster '%s' found to resume", clusterName)
+		}
+		return loadInitRegionJournal(path)
+	}
+
+	clusterConfigDir, err := c.tkgConfigPathsClient.GetClusterConfigurationDirectory()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(clusterConfigDir, "*"+initRegionJournalFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *InitRegionJournal
+	for _, path := range paths {
+		journal, err := loadInitRegionJournal(path)
+		if err != nil {
+			log.V(3).Infof("Skipping management cluster creation journal %s: %v", path, err)
+			continue
+		}
+		if latest == nil || journal.LastUpdated.After(latest.LastUpdated) {
+			latest = journal
+		}
+	}
+	if latest == nil {
+		return nil, errors.New("no interrupted management cluster creation found to resume")
+	}
+	return latest, nil
+}
+
+func loadInitRegionJournal(path string) (*InitRegionJournal, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read management cluster creation journal %s", path)
+	}
+	journal := &InitRegionJournal{}
+	if err := yaml.Unmarshal(b, journal); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse management cluster creation journal %s", path)
+	}
+	if journal.ClusterName == "" {
+		return nil, errors.Errorf("management cluster creation journal %s does not have a cluster name", path)
+	}
+	journal.path = path
+	return journal, nil
+}
+
+func (j *InitRegionJournal) save() error {
+	j.LastUpdated = time.Now().UTC()
+	b, err := yaml.Marshal(j)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal management cluster creation journal")
+	}
+	if err := utils.SaveFile(j.path, b); err != nil {
+		return errors.Wrapf(err, "unable to save management cluster creation journal %s", j.path)
+	}
+	return nil
+}
+
+// IsStepCompleted returns true if the step of the management cluster creation has been completed
+func (j *InitRegionJournal) IsStepCompleted(step string) bool {
+	for _, s := range j.CompletedSteps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// completeStep records the step as completed and persists the journal
+func (j *InitRegionJournal) completeStep(step string) error {
+	if j.IsStepCompleted(step) {
+		return nil
+	}
+	j.CompletedSteps = append(j.CompletedSteps, step)
+	return j.save()
+}
+
+// LastCompletedStep returns the last completed step of the management cluster creation, following the
+// InitRegionSteps sequence
+func (j *InitRegionJournal) LastCompletedStep() string {
+	last := ""
+	for _, step := range InitRegionSteps {
+		if j.IsStepCompleted(step) {
+			last = step
+		}
+	}
+	return last
+}
+
+func (j *InitRegionJournal) remove() {
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		log.Warningf("unable to remove management cluster creation journal %s, %s", j.path, err.Error())
+	}
+}
+
+// ResumeInitRegion restores the state of the interrupted management cluster creation recorded in the journal.
+// It verifies that the bootstrap cluster survived, when it is still needed, and returns its client
+func (c *TkgClient) ResumeInitRegion(options *InitRegionOptions, journal *InitRegionJournal) (clusterclient.Client, error) {
+	if options.ClusterName != "" && options.ClusterName != journal.ClusterName {
+		return nil, errors.Errorf("unable to resume the creation of management cluster '%s' with the configuration of management cluster '%s'", journal.ClusterName, options.ClusterName)
+	}
+	options.ClusterName = journal.ClusterName
+	options.Namespace = journal.Namespace
+	options.UseExistingCluster = journal.UseExistingCluster
+
+	log.Infof("Resuming the creation of management cluster %s", journal.ClusterName)
+	if last := journal.LastCompletedStep(); last != "" {
+		log.Infof("Last completed step: %s", last)
+	}
+
+	// the bootstrap cluster is no longer needed once the cluster-api objects have been moved
+	if journal.IsStepCompleted(StepMoveClusterAPIObjects) {
+		return nil, nil
+	}
+	if !journal.IsStepCompleted(StepSetupBootstrapCluster) {
+		return nil, nil
+	}
+
+	if _, err := os.Stat(journal.BootstrapClusterKubeconfigPath); err != nil {
+		return nil, errors.Wrapf(err, "unable to find the kubeconfig of the bootstrap cluster")
+	}
+	bootStrapClusterClient, err := c.clusterClientFactory.NewClient(journal.BootstrapClusterKubeconfigPath, "", clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get bootstrap cluster client")
+	}
+	if _, err := bootStrapClusterClient.GetKubernetesVersion(); err != nil {
+		return nil, errors.Wrap(err, "bootstrap cluster of the interrupted management cluster creation is not reachable")
+	}
+	log.Infof("Reusing bootstrap cluster. Kubeconfig: %s", journal.BootstrapClusterKubeconfigPath)
+
+	return bootStrapClusterClient, nil
+}
+
+// isManagementClusterCreationStarted returns true if the management cluster object already exists on the
+// bootstrap cluster, in which case applying the management cluster configuration can be skipped
+func isManagementClusterCreationStarted(bootStrapClusterClient clusterclient.Client, clusterName, namespace string) (bool, error) {
+	cluster := &capi.Cluster{}
+	err := bootStrapClusterClient.GetResource(cluster, clusterName, namespace, nil, nil)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to get cluster %s from the bootstrap cluster", clusterName)
+	}
+	return true, nil
+}
+
+// CompleteInterruptedMove returns true if the cluster-api objects have already been moved from the bootstrap cluster
+// to the management cluster by an interrupted creation, in which case moving them again would fail. The move is only
+// considered complete if the whole move graph of the cluster is on the management cluster and none of its objects is
+// left paused on the bootstrap cluster, otherwise the objects must be moved again. The moved cluster is unpaused last,
+// so the management cluster is unpaused if the move was interrupted before
+func (c *TkgClient) CompleteInterruptedMove(bootStrapClusterClient, regionalClusterClient clusterclient.Client, clusterName, namespace string) (bool, error) {
+	cluster := &capi.Cluster{}
+	err := bootStrapClusterClient.GetResource(cluster, clusterName, namespace, nil, nil)
+	if err == nil {
+		return false, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, errors.Wrapf(err, "unable to get cluster %s from the bootstrap cluster", clusterName)
+	}
+
+	err = regionalClusterClient.GetResource(cluster, clusterName, namespace, nil, nil)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to get cluster %s from the management cluster", clusterName)
+	}
+
+	isMoved, err := isMoveCompleted(bootStrapClusterClient, regionalClusterClient, cluster)
+	if err != nil {
+		return false, err
+	}
+	if !isMoved {
+		log.Info("Cluster API objects have only been partially moved from bootstrap cluster to management cluster")
+		return false, nil
+	}
+
+	log.Info("Cluster API objects have already been moved from bootstrap cluster to management cluster")
+	if cluster.Spec.Paused {
+		if err := regionalClusterClient.PatchResource(&capi.Cluster{}, clusterName, namespace, `{"spec":{"paused":false}}`, types.MergePatchType, nil); err != nil {
+			return false, errors.Wrapf(err, "unable to unpause cluster %s on the management cluster", clusterName)
+		}
+	}
+	return true, nil
+}
+
+// isMoveCompleted returns true if the infrastructure cluster, control plane, machine deployments, machines and
+// secrets of the cluster are on the management cluster and none of them is left on the bootstrap cluster
+func isMoveCompleted(bootStrapClusterClient, regionalClusterClient clusterclient.Client, cluster *capi.Cluster) (bool, error) {
+	for _, ref := range []*corev1.ObjectReference{cluster.Spec.InfrastructureRef, cluster.Spec.ControlPlaneRef} {
+		if ref == nil {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(ref.APIVersion)
+		obj.SetKind(ref.Kind)
+		if isMoved, err := isObjectMoved(bootStrapClusterClient, regionalClusterClient, obj, ref.Name, cluster.Namespace); err != nil || !isMoved {
+			return false, err
+		}
+	}
+
+	for _, purpose := range []secret.Purpose{secret.Kubeconfig, secret.ClusterCA} {
+		if isMoved, err := isObjectMoved(bootStrapClusterClient, regionalClusterClient, &corev1.Secret{}, secret.Name(cluster.Name, purpose), cluster.Namespace); err != nil || !isMoved {
+			return false, err
+		}
+	}
+
+	for _, newList := range []func() crtclient.ObjectList{
+		func() crtclient.ObjectList { return &capi.MachineDeploymentList{} },
+		func() crtclient.ObjectList { return &capi.MachineList{} },
+	} {
+		if isMoved, err := areObjectsMoved(bootStrapClusterClient, regionalClusterClient, newList, cluster.Name, cluster.Namespace); err != nil || !isMoved {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// isObjectMoved returns true if the object is on the management cluster and no longer on the bootstrap cluster
+func isObjectMoved(bootStrapClusterClient, regionalClusterClient clusterclient.Client, obj crtclient.Object, name, namespace string) (bool, error) {
+	err := bootStrapClusterClient.GetResource(obj, name, namespace, nil, nil)
+	if err == nil {
+		return false, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, errors.Wrapf(err, "unable to get %s/%s from the bootstrap cluster", namespace, name)
+	}
+
+	err = regionalClusterClient.GetResource(obj, name, namespace, nil, nil)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to get %s/%s from the management cluster", namespace, name)
+	}
+	return true, nil
+}
+
+// areObjectsMoved returns true if objects of the cluster are on the management cluster and none of them is left on
+// the bootstrap cluster
+func areObjectsMoved(bootStrapClusterClient, regionalClusterClient clusterclient.Client, newList func() crtclient.ObjectList, clusterName, namespace string) (bool, error) {
+	left := newList()
+	if err := bootStrapClusterClient.GetResourceList(left, clusterName, namespace, nil, nil); err != nil {
+		return false, errors.Wrapf(err, "unable to list the objects of cluster %s on the bootstrap cluster", clusterName)
+	}
+	if meta.LenList(left) != 0 {
+		return false, nil
+	}
+
+	moved := newList()
+	if err := regionalClusterClient.GetResourceList(moved, clusterName, namespace, nil, nil); err != nil {
+		return false, errors.Wrapf(err, "unable to list the objects of cluster %s on the management cluster", clusterName)
+	}
+	return meta.LenList(moved) != 0, nil
+}
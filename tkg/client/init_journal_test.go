@@ -0,0 +1,318 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	. "github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+)
+
+const testInitRegionJournalFmt = `clusterName: %s
+namespace: tkg-system
+bootstrapClusterName: tkg-kind-test
+bootstrapClusterKubeconfigPath: %s
+kubeContext: %s-admin@%s
+completedSteps:
+%s
+lastUpdated: "%s"
+`
+
+var _ = Describe("InitRegionJournal", func() {
+	var (
+		tkgClient            *TkgClient
+		clusterClientFactory *fakes.ClusterClientFactory
+		clusterClient        *fakes.ClusterClient
+		clusterConfigDir     string
+		bootstrapKubeconfig  string
+		journal              *InitRegionJournal
+		options              *InitRegionOptions
+		err                  error
+	)
+
+	writeJournal := func(clusterName string, lastUpdated time.Time, steps ...string) {
+		completedSteps := ""
+		for _, step := range steps {
+			completedSteps += fmt.Sprintf("- %s\n", step)
+		}
+		content := fmt.Sprintf(testInitRegionJournalFmt, clusterName, bootstrapKubeconfig, clusterName, clusterName, completedSteps, lastUpdated.Format(time.RFC3339))
+		Expect(os.WriteFile(filepath.Join(clusterConfigDir, clusterName+".journal.yaml"), []byte(content), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		clusterClientFactory = &fakes.ClusterClientFactory{}
+		clusterClient = &fakes.ClusterClient{}
+		clusterClientFactory.NewClientReturns(clusterClient, nil)
+		clusterClient.GetKubernetesVersionReturns("v1.23.5", nil)
+
+		tkgClient, err = CreateTKGClientOptsMutator(configFile2, testingDir, defaultTKGBoMFileForTesting, 2*time.Second, func(o Options) Options {
+			o.ClusterClientFactory = clusterClientFactory
+			return o
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		clusterConfigDir = filepath.Join(testingDir, constants.TKGClusterConfigFileDirForUI)
+		Expect(os.RemoveAll(clusterConfigDir)).To(Succeed())
+		Expect(os.MkdirAll(clusterConfigDir, 0700)).To(Succeed())
+		bootstrapKubeconfig = filepath.Join(testingDir, "bootstrap-kubeconfig")
+		Expect(os.WriteFile(bootstrapKubeconfig, []byte("test"), 0600)).To(Succeed())
+
+		options = &InitRegionOptions{}
+	})
+
+	Describe("GetInitRegionJournal", func() {
+		Context("when the journal of the management cluster exists", func() {
+			BeforeEach(func() {
+				writeJournal("mc-1", time.Now(), StepValidateConfiguration, StepSetupBootstrapCluster, StepInstallProvidersOnBootstrapCluster)
+			})
+			It("should load the journal", func() {
+				journal, err = tkgClient.GetInitRegionJournal("mc-1")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(journal.ClusterName).To(Equal("mc-1"))
+				Expect(journal.BootstrapClusterName).To(Equal("tkg-kind-test"))
+				Expect(journal.IsStepCompleted(StepSetupBootstrapCluster)).To(BeTrue())
+				Expect(journal.IsStepCompleted(StepCreateManagementCluster)).To(BeFalse())
+				Expect(journal.LastCompletedStep()).To(Equal(StepInstallProvidersOnBootstrapCluster))
+			})
+		})
+
+		Context("when the journal of the management cluster does not exist", func() {
+			It("should return an error", func() {
+				_, err = tkgClient.GetInitRegionJournal("mc-1")
+				Expect(err).To(MatchError("no interrupted creation of management cluster 'mc-1' found to resume"))
+			})
+		})
+
+		Context("when no cluster name is provided", func() {
+			BeforeEach(func() {
+				writeJournal("mc-1", time.Now().Add(-time.Hour), StepSetupBootstrapCluster)
+				writeJournal("mc-2", time.Now(), StepSetupBootstrapCluster)
+				writeJournal("mc-3", time.Now().Add(-2*time.Hour), StepSetupBootstrapCluster)
+			})
+			It("should load the journal of the most recently interrupted creation", func() {
+				journal, err = tkgClient.GetInitRegionJournal("")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(journal.ClusterName).To(Equal("mc-2"))
+			})
+		})
+
+		Context("when no cluster name is provided and no journal exists", func() {
+			It("should return an error", func() {
+				_, err = tkgClient.GetInitRegionJournal("")
+				Expect(err).To(MatchError("no interrupted management cluster creation found to resume"))
+			})
+		})
+	})
+
+	Describe("ResumeInitRegion", func() {
+		var bootstrapClusterClient clusterclient.Client
+
+		steps := []string{StepValidateConfiguration, StepGenerateClusterConfiguration, StepSetupBootstrapCluster, StepInstallProvidersOnBootstrapCluster}
+
+		JustBeforeEach(func() {
+			journal, err = tkgClient.GetInitRegionJournal("mc-1")
+			Expect(err).NotTo(HaveOccurred())
+			bootstrapClusterClient, err = tkgClient.ResumeInitRegion(options, journal)
+		})
+
+		Context("when the bootstrap cluster survived", func() {
+			BeforeEach(func() {
+				writeJournal("mc-1", time.Now(), steps...)
+			})
+			It("should reuse the bootstrap cluster and the recorded options", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bootstrapClusterClient).To(Equal(clusterClient))
+				Expect(clusterClientFactory.NewClientCallCount()).To(Equal(1))
+				kubeconfigPath, _, _ := clusterClientFactory.NewClientArgsForCall(0)
+				Expect(kubeconfigPath).To(Equal(bootstrapKubeconfig))
+				Expect(options.ClusterName).To(Equal("mc-1"))
+				Expect(options.Namespace).To(Equal("tkg-system"))
+			})
+		})
+
+		Context("when the bootstrap cluster is not reachable", func() {
+			BeforeEach(func() {
+				writeJournal("mc-1", time.Now(), steps...)
+				clusterClient.GetKubernetesVersionReturns("", errors.New("connection refused"))
+			})
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("bootstrap cluster of the interrupted management cluster creation is not reachable"))
+			})
+		})
+
+		Context("when the kubeconfig of the bootstrap cluster was removed", func() {
+			BeforeEach(func() {
+				writeJournal("mc-1", time.Now(), steps...)
+				Expect(os.Remove(bootstrapKubeconfig)).To(Succeed())
+			})
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unable to find the kubeconfig of the bootstrap cluster"))
+				Expect(clusterClientFactory.NewClientCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the cluster-api objects were already moved to the management cluster", func() {
+			BeforeEach(func() {
+				writeJournal("mc-1", time.Now(), append(steps, StepCreateManagementCluster, StepInstallProvidersOnRegionalCluster, StepMoveClusterAPIObjects)...)
+			})
+			It("should not need the bootstrap cluster", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bootstrapClusterClient).To(BeNil())
+				Expect(clusterClientFactory.NewClientCallCount()).To(Equal(0))
+				Expect(journal.LastCompletedStep()).To(Equal(StepMoveClusterAPIObjects))
+			})
+		})
+
+		Context("when the configuration is of another management cluster", func() {
+			BeforeEach(func() {
+				writeJournal("mc-1", time.Now(), steps...)
+				options.ClusterName = "mc-2"
+			})
+			It("should return an error", func() {
+				Expect(err).To(MatchError("unable to resume the creation of management cluster 'mc-1' with the configuration of management cluster 'mc-2'"))
+			})
+		})
+	})
+
+	Describe("CompleteInterruptedMove", func() {
+		var (
+			regionalClusterClient *fakes.ClusterClient
+			isMoved               bool
+		)
+
+		BeforeEach(func() {
+			regionalClusterClient = &fakes.ClusterClient{}
+		})
+
+		JustBeforeEach(func() {
+			isMoved, err = tkgClient.CompleteInterruptedMove(clusterClient, regionalClusterClient, "mc-1", "tkg-system")
+		})
+
+		Context("when the cluster is still on the bootstrap cluster", func() {
+			It("should move the cluster-api objects", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isMoved).To(BeFalse())
+				Expect(regionalClusterClient.GetResourceCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the cluster has already been moved to the management cluster", func() {
+			var missingOnManagementCluster string
+
+			BeforeEach(func() {
+				missingOnManagementCluster = ""
+				clusterClient.GetResourceReturns(apierrors.NewNotFound(capi.GroupVersion.WithResource("clusters").GroupResource(), "mc-1"))
+				regionalClusterClient.GetResourceStub = func(obj interface{}, name, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+					if name == missingOnManagementCluster {
+						return apierrors.NewNotFound(corev1.Resource("secrets"), name)
+					}
+					if cluster, ok := obj.(*capi.Cluster); ok {
+						cluster.Name = name
+						cluster.Namespace = namespace
+						cluster.Spec.Paused = true
+						cluster.Spec.InfrastructureRef = &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "VSphereCluster", Name: "mc-1-infra"}
+						cluster.Spec.ControlPlaneRef = &corev1.ObjectReference{APIVersion: "controlplane.cluster.x-k8s.io/v1beta1", Kind: "KubeadmControlPlane", Name: "mc-1-control-plane"}
+					}
+					return nil
+				}
+				regionalClusterClient.GetResourceListStub = func(obj interface{}, clusterName, namespace string, postVerify clusterclient.PostVerifyListrFunc, pollOptions *clusterclient.PollOptions) error {
+					switch list := obj.(type) {
+					case *capi.MachineDeploymentList:
+						list.Items = []capi.MachineDeployment{{ObjectMeta: metav1.ObjectMeta{Name: "mc-1-md-0"}}}
+					case *capi.MachineList:
+						list.Items = []capi.Machine{{ObjectMeta: metav1.ObjectMeta{Name: "mc-1-control-plane-abcde"}}}
+					}
+					return nil
+				}
+			})
+			It("should unpause the moved cluster and skip the move", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isMoved).To(BeTrue())
+				var checked []string
+				for i := 0; i < regionalClusterClient.GetResourceCallCount(); i++ {
+					_, name, _, _, _ := regionalClusterClient.GetResourceArgsForCall(i)
+					checked = append(checked, name)
+				}
+				Expect(checked).To(Equal([]string{"mc-1", "mc-1-infra", "mc-1-control-plane", "mc-1-kubeconfig", "mc-1-ca"}))
+				Expect(regionalClusterClient.GetResourceListCallCount()).To(Equal(2))
+				Expect(regionalClusterClient.PatchResourceCallCount()).To(Equal(1))
+				_, name, namespace, patch, _, _ := regionalClusterClient.PatchResourceArgsForCall(0)
+				Expect(name).To(Equal("mc-1"))
+				Expect(namespace).To(Equal("tkg-system"))
+				Expect(patch).To(Equal(`{"spec":{"paused":false}}`))
+			})
+
+			Context("when a secret of the cluster is missing on the management cluster", func() {
+				BeforeEach(func() {
+					missingOnManagementCluster = "mc-1-kubeconfig"
+				})
+				It("should move the cluster-api objects again", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isMoved).To(BeFalse())
+					Expect(regionalClusterClient.PatchResourceCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when machines of the cluster are left paused on the bootstrap cluster", func() {
+				BeforeEach(func() {
+					clusterClient.GetResourceListStub = func(obj interface{}, clusterName, namespace string, postVerify clusterclient.PostVerifyListrFunc, pollOptions *clusterclient.PollOptions) error {
+						if list, ok := obj.(*capi.MachineList); ok {
+							list.Items = []capi.Machine{{ObjectMeta: metav1.ObjectMeta{Name: "mc-1-md-0-fghij"}}}
+						}
+						return nil
+					}
+				})
+				It("should move the cluster-api objects again", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isMoved).To(BeFalse())
+					Expect(regionalClusterClient.PatchResourceCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the infrastructure cluster is left on the bootstrap cluster", func() {
+				BeforeEach(func() {
+					clusterClient.GetResourceStub = func(obj interface{}, name, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+						if name == "mc-1-infra" {
+							return nil
+						}
+						return apierrors.NewNotFound(capi.GroupVersion.WithResource("clusters").GroupResource(), name)
+					}
+				})
+				It("should move the cluster-api objects again", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isMoved).To(BeFalse())
+					Expect(regionalClusterClient.PatchResourceCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the cluster is on neither cluster", func() {
+			BeforeEach(func() {
+				notFound := apierrors.NewNotFound(capi.GroupVersion.WithResource("clusters").GroupResource(), "mc-1")
+				clusterClient.GetResourceReturns(notFound)
+				regionalClusterClient.GetResourceReturns(notFound)
+			})
+			It("should move the cluster-api objects", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isMoved).To(BeFalse())
+			})
+		})
+	})
+})
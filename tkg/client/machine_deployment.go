@@ -44,6 +44,8 @@ type GetMachineDeploymentOptions struct {
 type SetMachineDeploymentOptions struct {
 	ClusterName string
 	Namespace   string
+	// Edition is the Tanzu edition used to generate the cluster autoscaler objects when autoscaling is enabled
+	Edition string
 	NodePool
 }
 
@@ -71,6 +73,11 @@ type NodePool struct {
 	TKR                   tkgsv1alpha2.TKRReference `yaml:"tkr,omitempty"`
 	NodeDrainTimeout      *metav1.Duration          `yaml:"nodeDrainTimeout,omitempty"`
 	BaseMachineDeployment string                    `yaml:"baseMachineDeployment,omitempty"`
+	// AutoscaleMin and AutoscaleMax enable the cluster autoscaler on the node pool, or update its sizes
+	AutoscaleMin *int32 `yaml:"autoscaleMin,omitempty"`
+	AutoscaleMax *int32 `yaml:"autoscaleMax,omitempty"`
+	// DisableAutoscale removes the node pool from the node groups managed by the cluster autoscaler
+	DisableAutoscale bool `yaml:"disableAutoscale,omitempty"`
 }
 
 // VSphereNodePool a struct describing properties necessary for a node pool on vSphere
@@ -117,7 +124,17 @@ func (c *TkgClient) SetMachineDeployment(options *SetMachineDeploymentOptions) e
 		if skip {
 			return nil
 		}
-		return DoSetMachineDeploymentCC(clusterClient, cluster, options)
+		if err := DoSetMachineDeploymentCC(clusterClient, cluster, options); err != nil {
+			return err
+		}
+		if options.AutoscaleMin != nil || options.AutoscaleMax != nil {
+			return c.EnsureAutoscalerDeployment(clusterClient, cluster, options.Edition, c.GetClusterConfiguration)
+		}
+		return nil
+	}
+
+	if options.AutoscaleMin != nil || options.AutoscaleMax != nil || options.DisableAutoscale {
+		return errors.New("node pool autoscaling can only be set on ClusterClass based clusters")
 	}
 
 	isPacific, err := clusterClient.IsPacificRegionalCluster()
@@ -7,12 +7,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	"github.com/pkg/errors"
 
 	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
 	"github.com/vmware-tanzu/tanzu-framework/util/topology"
 )
 
@@ -69,6 +72,10 @@ func DoSetMachineDeploymentCC(clusterClient clusterclient.Client, cluster *capi.
 		base.Replicas = options.Replicas
 	}
 
+	if err := setMachineDeploymentAutoscaling(clusterClient, options, base); err != nil {
+		return err
+	}
+
 	if options.WorkerClass != "" {
 		base.Class = options.WorkerClass
 	}
@@ -361,6 +368,103 @@ func DoGetMachineDeploymentsCC(clusterClient clusterclient.Client, cluster *capi
 	return mds, nil
 }
 
+// setMachineDeploymentAutoscaling sets the cluster autoscaler annotations of a node pool. The replicas of an
+// autoscaled node pool are managed by the autoscaler, so they are left unset in the topology.
+func setMachineDeploymentAutoscaling(clusterClient clusterclient.Client, options *SetMachineDeploymentOptions, md *capi.MachineDeploymentTopology) error {
+	minSize, maxSize, autoscaled, err := GetAutoscalerSizes(md.Metadata.Annotations)
+	if err != nil {
+		return errors.Wrapf(err, "invalid autoscaler annotations on node pool %s", options.Name)
+	}
+
+	if options.DisableAutoscale {
+		if options.AutoscaleMin != nil || options.AutoscaleMax != nil {
+			return errors.New("can not set the autoscaler min and max sizes when disabling autoscaling")
+		}
+		if !autoscaled {
+			return nil
+		}
+		delete(md.Metadata.Annotations, constants.AutoscalerMinSizeAnnotation)
+		delete(md.Metadata.Annotations, constants.AutoscalerMaxSizeAnnotation)
+		if md.Replicas == nil {
+			// pin the node pool to its current size
+			md.Replicas = getMachineDeploymentReplicasCC(clusterClient, options, minSize)
+		}
+		return nil
+	}
+
+	if options.AutoscaleMin == nil && options.AutoscaleMax == nil {
+		if autoscaled && options.Replicas != nil {
+			return errors.Errorf("node pool %s is autoscaled, disable autoscaling to set its replicas", options.Name)
+		}
+		return nil
+	}
+
+	if options.Replicas != nil {
+		return errors.New("can not set the replicas of an autoscaled node pool")
+	}
+	if options.AutoscaleMin != nil {
+		minSize = options.AutoscaleMin
+	}
+	if options.AutoscaleMax != nil {
+		maxSize = options.AutoscaleMax
+	}
+	if minSize == nil || maxSize == nil {
+		return errors.Errorf("both the autoscaler min and max sizes are required to enable autoscaling on node pool %s", options.Name)
+	}
+	if *minSize < 0 || *maxSize < *minSize {
+		return errors.Errorf("invalid autoscaler sizes for node pool %s: min %d, max %d", options.Name, *minSize, *maxSize)
+	}
+
+	if md.Metadata.Annotations == nil {
+		md.Metadata.Annotations = map[string]string{}
+	}
+	md.Metadata.Annotations[constants.AutoscalerMinSizeAnnotation] = strconv.Itoa(int(*minSize))
+	md.Metadata.Annotations[constants.AutoscalerMaxSizeAnnotation] = strconv.Itoa(int(*maxSize))
+	md.Replicas = nil
+	return nil
+}
+
+// getMachineDeploymentReplicasCC returns the replicas of the MachineDeployment of a node pool, or the
+// default replicas if the MachineDeployment can not be found
+func getMachineDeploymentReplicasCC(clusterClient clusterclient.Client, options *SetMachineDeploymentOptions, defaultReplicas *int32) *int32 {
+	workers, err := clusterClient.GetMDObjectForCluster(options.ClusterName, options.Namespace)
+	if err != nil {
+		log.V(3).Infof("unable to retrieve the machine deployments of cluster %s: %v", options.ClusterName, err)
+		return defaultReplicas
+	}
+	for i := range workers {
+		if workers[i].Spec.Template.Labels["topology.cluster.x-k8s.io/deployment-name"] == options.Name && workers[i].Spec.Replicas != nil {
+			return workers[i].Spec.Replicas
+		}
+	}
+	return defaultReplicas
+}
+
+// GetAutoscalerSizes returns the min and max sizes of the node group of the cluster autoscaler set in the
+// annotations of a MachineDeployment, and whether the MachineDeployment is autoscaled
+func GetAutoscalerSizes(annotations map[string]string) (minSize, maxSize *int32, autoscaled bool, err error) {
+	parse := func(key string) (*int32, error) {
+		value, ok := annotations[key]
+		if !ok {
+			return nil, nil
+		}
+		size, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value %q of annotation %s", value, key)
+		}
+		result := int32(size)
+		return &result, nil
+	}
+
+	if minSize, err = parse(constants.AutoscalerMinSizeAnnotation); err != nil {
+		return nil, nil, false, err
+	}
+	if maxSize, err = parse(constants.AutoscalerMaxSizeAnnotation); err != nil {
+		return nil, nil, false, err
+	}
+	return minSize, maxSize, minSize != nil || maxSize != nil, nil
+}
+
 func getClusterVariableByName(name string, variables []capi.ClusterVariable) *capi.ClusterVariable {
 	var variable *capi.ClusterVariable
 	for i := range variables {
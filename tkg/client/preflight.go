@@ -0,0 +1,314 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// PreflightSeverity is the severity of a preflight check failure
+type PreflightSeverity string
+
+// PreflightCheckStatus is the outcome of a preflight check
+type PreflightCheckStatus string
+
+// preflight check severities and statuses
+const (
+	PreflightSeverityError   PreflightSeverity = "error"
+	PreflightSeverityWarning PreflightSeverity = "warning"
+
+	PreflightCheckPassed  PreflightCheckStatus = "passed"
+	PreflightCheckFailed  PreflightCheckStatus = "failed"
+	PreflightCheckWarning PreflightCheckStatus = "warning"
+	PreflightCheckSkipped PreflightCheckStatus = "skipped"
+)
+
+// PreflightOptions contains the options of the preflight validation of a cluster configuration
+type PreflightOptions struct {
+	ClusterName                 string
+	Plan                        string
+	InfrastructureProvider      string
+	TKRVersion                  string
+	CniType                     string
+	Edition                     string
+	VsphereControlPlaneEndpoint string
+	NodeSizeOptions             NodeSizeOptions
+	IsManagementCluster         bool
+	// ClusterClient is the client of the management cluster of a workload cluster. The checks which require the
+	// management cluster are skipped when it is not provided and the current management cluster is not reachable
+	ClusterClient clusterclient.Client
+}
+
+// PreflightContext is the state shared by the preflight checks of a cluster configuration
+type PreflightContext struct {
+	Options            *PreflightOptions
+	ProviderName       string
+	ClusterRole        string
+	TKRVersion         string
+	KubernetesVersion  string
+	IsProdPlan         bool
+	WorkerMachineCount int64
+}
+
+// PreflightCheck is a single check of the preflight validation
+type PreflightCheck struct {
+	Name     string
+	Code     string
+	Severity PreflightSeverity
+	Run      func(c *TkgClient, ctx *PreflightContext) error
+}
+
+// PreflightValidator returns the preflight checks of an infrastructure provider
+type PreflightValidator func(ctx *PreflightContext) []PreflightCheck
+
+// PreflightCheckResult is the result of a preflight check
+type PreflightCheckResult struct {
+	Name     string               `json:"name" yaml:"name"`
+	Code     string               `json:"code" yaml:"code"`
+	Provider string               `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Status   PreflightCheckStatus `json:"status" yaml:"status"`
+	Message  string               `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// PreflightReport is the aggregated result of the preflight validation of a cluster configuration
+type PreflightReport struct {
+	ClusterName            string                 `json:"clusterName" yaml:"clusterName"`
+	InfrastructureProvider string                 `json:"infrastructureProvider" yaml:"infrastructureProvider"`
+	Results                []PreflightCheckResult `json:"results" yaml:"results"`
+}
+
+type preflightSkipError struct {
+	reason string
+}
+
+func (e *preflightSkipError) Error() string {
+	return e.reason
+}
+
+// SkipPreflightCheck returns the error reported by a preflight check which does not apply to the configuration
+func SkipPreflightCheck(format string, args ...interface{}) error {
+	return &preflightSkipError{reason: fmt.Sprintf(format, args...)}
+}
+
+var (
+	preflightValidatorsLock sync.RWMutex
+	preflightValidators     = map[string]PreflightValidator{}
+)
+
+// RegisterPreflightValidator registers the preflight checks of an infrastructure provider. A validator registered
+// for the same provider replaces the previous one
+func RegisterPreflightValidator(providerName string, validator PreflightValidator) {
+	preflightValidatorsLock.Lock()
+	defer preflightValidatorsLock.Unlock()
+	preflightValidators[providerName] = validator
+}
+
+func getPreflightValidator(providerName string) PreflightValidator {
+	preflightValidatorsLock.RLock()
+	defer preflightValidatorsLock.RUnlock()
+	return preflightValidators[providerName]
+}
+
+// RunPreflightChecks runs all the applicable preflight checks of the cluster configuration without creating anything.
+// Unlike the validation done during creation, all the checks are run and all the failures and warnings are reported
+func (c *TkgClient) RunPreflightChecks(options *PreflightOptions) (*PreflightReport, error) {
+	ctx := &PreflightContext{
+		Options:     options,
+		ClusterRole: TkgLabelClusterRoleWorkload,
+		TKRVersion:  options.TKRVersion,
+		IsProdPlan:  IsProdPlan(options.Plan),
+	}
+	if options.IsManagementCluster {
+		ctx.ClusterRole = TkgLabelClusterRoleManagement
+	} else if options.ClusterClient == nil {
+		options.ClusterClient = c.getPreflightManagementClusterClient()
+	}
+
+	if options.InfrastructureProvider == "" && options.ClusterClient != nil {
+		infraProvider, err := options.ClusterClient.GetRegionalClusterDefaultProviderName(clusterctlv1.InfrastructureProviderType)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get the infrastructure provider of the management cluster")
+		}
+		options.InfrastructureProvider = infraProvider
+	}
+	if options.InfrastructureProvider == "" {
+		return nil, errors.New("infrastructure provider is required, please set 'INFRASTRUCTURE_PROVIDER' in the cluster configuration file")
+	}
+	providerName, _, err := ParseProviderName(options.InfrastructureProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse provider name")
+	}
+	ctx.ProviderName = providerName
+
+	c.SetBuildEdition(options.Edition)
+	if options.IsManagementCluster {
+		c.SetTKGClusterRole(ManagementCluster)
+	} else {
+		c.SetTKGClusterRole(WorkloadCluster)
+	}
+	c.SetTKGVersion()
+	_, workerMachineCount := c.getMachineCountForMC(options.Plan)
+	ctx.WorkerMachineCount = int64(workerMachineCount)
+
+	report := &PreflightReport{
+		ClusterName:            options.ClusterName,
+		InfrastructureProvider: providerName,
+	}
+	// the general checks come first as they configure the variables the provider checks depend on
+	for _, check := range generalPreflightChecks() {
+		report.Results = append(report.Results, c.runPreflightCheck(ctx, check))
+	}
+	if validator := getPreflightValidator(providerName); validator != nil {
+		for _, check := range validator(ctx) {
+			result := c.runPreflightCheck(ctx, check)
+			result.Provider = providerName
+			report.Results = append(report.Results, result)
+		}
+	} else {
+		log.Warningf("No preflight validator registered for infrastructure provider %s", providerName)
+	}
+	report.Results = append(report.Results, c.runPreflightCheck(ctx, machineDeploymentsPreflightCheck))
+
+	return report, nil
+}
+
+func (c *TkgClient) runPreflightCheck(ctx *PreflightContext, check PreflightCheck) PreflightCheckResult {
+	result := PreflightCheckResult{Name: check.Name, Code: check.Code, Status: PreflightCheckPassed}
+
+	err := check.Run(c, ctx)
+	if err == nil {
+		return result
+	}
+	if skipErr, ok := err.(*preflightSkipError); ok {
+		result.Status = PreflightCheckSkipped
+		result.Message = skipErr.reason
+		return result
+	}
+	result.Status = failedPreflightCheckStatus(check)
+	result.Message = err.Error()
+	return result
+}
+
+func failedPreflightCheckStatus(check PreflightCheck) PreflightCheckStatus {
+	if check.Severity == PreflightSeverityWarning {
+		return PreflightCheckWarning
+	}
+	return PreflightCheckFailed
+}
+
+// getPreflightManagementClusterClient returns the client of the current management cluster, or nil if it is not reachable
+func (c *TkgClient) getPreflightManagementClusterClient() clusterclient.Client {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		log.V(3).Infof("No current management cluster, the checks requiring the management cluster are skipped: %v", err)
+		return nil
+	}
+	clusterClient, err := c.clusterClientFactory.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		log.V(3).Infof("Management cluster %s is not reachable, the checks requiring the management cluster are skipped: %v", currentRegion.ClusterName, err)
+		return nil
+	}
+	return clusterClient
+}
+
+// Failures returns the number of failed checks
+func (r *PreflightReport) Failures() int {
+	return r.count(PreflightCheckFailed)
+}
+
+// Warnings returns the number of checks which failed with a warning
+func (r *PreflightReport) Warnings() int {
+	return r.count(PreflightCheckWarning)
+}
+
+func (r *PreflightReport) count(status PreflightCheckStatus) int {
+	n := 0
+	for i := range r.Results {
+		if r.Results[i].Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Error returns an error summarizing the failed checks, or nil if no check failed
+func (r *PreflightReport) Error() error {
+	failures := r.Failures()
+	if failures == 0 {
+		return nil
+	}
+	return errors.Errorf("preflight validation of cluster '%s' failed with %d error(s) and %d warning(s)", r.ClusterName, failures, r.Warnings())
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+// WriteJUnit writes the report as a JUnit XML document, with one test case per check. Warnings are reported
+// in the output of the test case and do not fail it
+func (r *PreflightReport) WriteJUnit(w io.Writer) error {
+	suite := junitTestSuite{
+		Name:     fmt.Sprintf("preflight.%s", r.ClusterName),
+		Tests:    len(r.Results),
+		Failures: r.Failures(),
+		Skipped:  r.count(PreflightCheckSkipped),
+	}
+	for _, result := range r.Results {
+		testCase := junitTestCase{
+			Name:      fmt.Sprintf("[%s] %s", result.Code, result.Name),
+			ClassName: fmt.Sprintf("preflight.%s", r.InfrastructureProvider),
+		}
+		switch result.Status {
+		case PreflightCheckFailed:
+			testCase.Failure = &junitMessage{Message: result.Message, Type: result.Code}
+		case PreflightCheckSkipped:
+			testCase.Skipped = &junitMessage{Message: result.Message}
+		case PreflightCheckWarning:
+			testCase.SystemOut = "WARNING: " + result.Message
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return errors.Wrap(err, "unable to encode the preflight report as JUnit")
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,238 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"github.com/pkg/errors"
+)
+
+// preflight check codes
+const (
+	PreflightCodeClusterName              = "GEN001"
+	PreflightCodeClusterNameUniqueness    = "GEN002"
+	PreflightCodeClusterPlan              = "GEN003"
+	PreflightCodeEdition                  = "GEN004"
+	PreflightCodeTKRVersion               = "GEN005"
+	PreflightCodeKubernetesVersionSupport = "GEN006"
+	PreflightCodeIdentityProvider         = "GEN007"
+	PreflightCodeInfrastructureProvider   = "GEN008"
+	PreflightCodeMachineDeployments       = "GEN009"
+	PreflightCodeCNIType                  = "NET001"
+	PreflightCodeIPFamily                 = "NET002"
+	PreflightCodeCoreDNSIP                = "NET003"
+	PreflightCodeServiceCIDR              = "NET004"
+	PreflightCodeHTTPProxy                = "NET005"
+	PreflightCodeNameservers              = "NET006"
+	PreflightCodeAviConfiguration         = "AVI001"
+	PreflightCodeAWSConfiguration         = "AWS001"
+	PreflightCodeAzureConfiguration       = "AZR001"
+	PreflightCodeVsphereConfiguration     = "VSP001"
+	PreflightCodeVsphereEndpoint          = "VSP002"
+	PreflightCodeDockerConfiguration      = "DKR001"
+	PreflightCodeDockerResources          = "DKR002"
+)
+
+func init() {
+	RegisterPreflightValidator(AWSProviderName, awsPreflightChecks)
+	RegisterPreflightValidator(AzureProviderName, azurePreflightChecks)
+	RegisterPreflightValidator(VSphereProviderName, vspherePreflightChecks)
+	RegisterPreflightValidator(DockerProviderName, dockerPreflightChecks)
+}
+
+func generalPreflightChecks() []PreflightCheck {
+	return []PreflightCheck{
+		{Name: "cluster-name", Code: PreflightCodeClusterName, Severity: PreflightSeverityError, Run: preflightClusterName},
+		{Name: "cluster-name-uniqueness", Code: PreflightCodeClusterNameUniqueness, Severity: PreflightSeverityError, Run: preflightClusterNameUniqueness},
+		{Name: "cluster-plan", Code: PreflightCodeClusterPlan, Severity: PreflightSeverityError, Run: preflightClusterPlan},
+		{Name: "edition", Code: PreflightCodeEdition, Severity: PreflightSeverityError, Run: preflightEdition},
+		{Name: "tkr-version", Code: PreflightCodeTKRVersion, Severity: PreflightSeverityError, Run: preflightTKRVersion},
+		{Name: "kubernetes-version-support", Code: PreflightCodeKubernetesVersionSupport, Severity: PreflightSeverityError, Run: preflightKubernetesVersionSupport},
+		{Name: "identity-provider", Code: PreflightCodeIdentityProvider, Severity: PreflightSeverityWarning, Run: preflightIdentityProvider},
+		{Name: "infrastructure-provider-version", Code: PreflightCodeInfrastructureProvider, Severity: PreflightSeverityError, Run: preflightInfrastructureProviderVersion},
+		{Name: "cni-type", Code: PreflightCodeCNIType, Severity: PreflightSeverityError, Run: func(c *TkgClient, ctx *PreflightContext) error {
+			return c.ConfigureAndValidateCNIType(ctx.Options.CniType)
+		}},
+		{Name: "ip-family", Code: PreflightCodeIPFamily, Severity: PreflightSeverityError, Run: func(c *TkgClient, ctx *PreflightContext) error {
+			return c.configureAndValidateIPFamilyConfiguration(ctx.ClusterRole)
+		}},
+		{Name: "coredns-ip", Code: PreflightCodeCoreDNSIP, Severity: PreflightSeverityError, Run: func(c *TkgClient, ctx *PreflightContext) error {
+			return c.configureAndValidateCoreDNSIP()
+		}},
+		{Name: "service-cidr", Code: PreflightCodeServiceCIDR, Severity: PreflightSeverityError, Run: func(c *TkgClient, ctx *PreflightContext) error {
+			return c.validateServiceCIDRNetmask()
+		}},
+		{Name: "http-proxy", Code: PreflightCodeHTTPProxy, Severity: PreflightSeverityError, Run: func(c *TkgClient, ctx *PreflightContext) error {
+			return c.ConfigureAndValidateHTTPProxyConfiguration(ctx.ProviderName)
+		}},
+		{Name: "nameservers", Code: PreflightCodeNameservers, Severity: PreflightSeverityError, Run: func(c *TkgClient, ctx *PreflightContext) error {
+			return c.ConfigureAndValidateNameserverConfiguration(ctx.ClusterRole)
+		}},
+		{Name: "avi-configuration", Code: PreflightCodeAviConfiguration, Severity: PreflightSeverityError, Run: preflightAviConfiguration},
+	}
+}
+
+var machineDeploymentsPreflightCheck = PreflightCheck{
+	Name:     "machine-deployments",
+	Code:     PreflightCodeMachineDeployments,
+	Severity: PreflightSeverityError,
+	Run: func(c *TkgClient, ctx *PreflightContext) error {
+		_, err := c.DistributeMachineDeploymentWorkers(ctx.WorkerMachineCount, ctx.IsProdPlan, ctx.Options.IsManagementCluster, ctx.ProviderName, false)
+		return err
+	},
+}
+
+func preflightClusterName(c *TkgClient, ctx *PreflightContext) error {
+	if ctx.Options.ClusterName == "" {
+		if ctx.Options.IsManagementCluster {
+			return SkipPreflightCheck("the management cluster name will be generated")
+		}
+		return errors.New("cluster name is required")
+	}
+	return CheckClusterNameFormat(ctx.Options.ClusterName, ctx.Options.InfrastructureProvider)
+}
+
+func preflightClusterNameUniqueness(c *TkgClient, ctx *PreflightContext) error {
+	if !ctx.Options.IsManagementCluster || ctx.Options.ClusterName == "" {
+		return SkipPreflightCheck("only applies to named management clusters")
+	}
+	regions, err := c.regionManager.ListRegionContexts()
+	if err != nil {
+		return errors.New("unable to verify cluster name uniqueness")
+	}
+	for _, region := range regions {
+		if region.ClusterName == ctx.Options.ClusterName {
+			return errors.Errorf("cluster name %s matches another management cluster", ctx.Options.ClusterName)
+		}
+	}
+	return nil
+}
+
+func preflightClusterPlan(c *TkgClient, ctx *PreflightContext) error {
+	if ctx.Options.Plan == "" {
+		return errors.New("required config variable 'CLUSTER_PLAN' is not set")
+	}
+	return nil
+}
+
+func preflightEdition(c *TkgClient, ctx *PreflightContext) error {
+	if ctx.Options.Edition == "" {
+		return errors.New("required config variable 'edition' is not set")
+	}
+	return nil
+}
+
+func preflightTKRVersion(c *TkgClient, ctx *PreflightContext) error {
+	k8sVersion, tkrVersion, err := c.ConfigureAndValidateTkrVersion(ctx.Options.TKRVersion)
+	if err != nil {
+		ctx.TKRVersion = ""
+		return err
+	}
+	ctx.TKRVersion = tkrVersion
+	ctx.KubernetesVersion = k8sVersion
+	return nil
+}
+
+func preflightKubernetesVersionSupport(c *TkgClient, ctx *PreflightContext) error {
+	if ctx.Options.IsManagementCluster {
+		return SkipPreflightCheck("only applies to workload clusters")
+	}
+	if ctx.Options.ClusterClient == nil {
+		return SkipPreflightCheck("the management cluster is not reachable")
+	}
+	if ctx.KubernetesVersion == "" {
+		return SkipPreflightCheck("the TKr version could not be resolved")
+	}
+	return c.ValidateSupportOfK8sVersionForManagmentCluster(ctx.Options.ClusterClient, ctx.KubernetesVersion, false)
+}
+
+func preflightIdentityProvider(c *TkgClient, ctx *PreflightContext) error {
+	if !ctx.Options.IsManagementCluster {
+		return SkipPreflightCheck("only applies to management clusters")
+	}
+	idpType, err := c.TKGConfigReaderWriter().Get("IDENTITY_MANAGEMENT_TYPE")
+	if err != nil || idpType == "" || idpType == "none" {
+		return errors.New("identity provider not configured, some authentication features will not work")
+	}
+	return nil
+}
+
+func preflightInfrastructureProviderVersion(c *TkgClient, ctx *PreflightContext) error {
+	if !ctx.Options.IsManagementCluster {
+		return SkipPreflightCheck("only applies to management clusters")
+	}
+	infraProvider, err := c.tkgConfigUpdaterClient.CheckInfrastructureVersion(ctx.Options.InfrastructureProvider)
+	if err != nil {
+		return errors.Wrap(err, "unable to check infrastructure provider version")
+	}
+	ctx.Options.InfrastructureProvider = infraProvider
+	return nil
+}
+
+func preflightAviConfiguration(c *TkgClient, ctx *PreflightContext) error {
+	if !ctx.Options.IsManagementCluster {
+		return SkipPreflightCheck("only applies to management clusters")
+	}
+	return c.ConfigureAndValidateAviConfiguration()
+}
+
+// requireTKRVersion skips the provider checks which depend on the TKr version when it could not be resolved
+func requireTKRVersion(run func(c *TkgClient, ctx *PreflightContext) error) func(c *TkgClient, ctx *PreflightContext) error {
+	return func(c *TkgClient, ctx *PreflightContext) error {
+		if ctx.TKRVersion == "" {
+			return SkipPreflightCheck("the TKr version could not be resolved")
+		}
+		return run(c, ctx)
+	}
+}
+
+func awsPreflightChecks(ctx *PreflightContext) []PreflightCheck {
+	return []PreflightCheck{
+		{Name: "aws-configuration", Code: PreflightCodeAWSConfiguration, Severity: PreflightSeverityError, Run: requireTKRVersion(func(c *TkgClient, ctx *PreflightContext) error {
+			return c.ConfigureAndValidateAWSConfig(ctx.TKRVersion, ctx.Options.NodeSizeOptions, false, ctx.IsProdPlan, ctx.WorkerMachineCount,
+				ctx.Options.ClusterClient, ctx.Options.IsManagementCluster)
+		})},
+	}
+}
+
+func azurePreflightChecks(ctx *PreflightContext) []PreflightCheck {
+	return []PreflightCheck{
+		{Name: "azure-configuration", Code: PreflightCodeAzureConfiguration, Severity: PreflightSeverityError, Run: requireTKRVersion(func(c *TkgClient, ctx *PreflightContext) error {
+			return c.ConfigureAndValidateAzureConfig(ctx.TKRVersion, ctx.Options.NodeSizeOptions, false, nil)
+		})},
+	}
+}
+
+func vspherePreflightChecks(ctx *PreflightContext) []PreflightCheck {
+	return []PreflightCheck{
+		{Name: "vsphere-configuration", Code: PreflightCodeVsphereConfiguration, Severity: PreflightSeverityError, Run: requireTKRVersion(func(c *TkgClient, ctx *PreflightContext) error {
+			if err := c.ConfigureAndValidateVsphereConfig(ctx.TKRVersion, ctx.Options.NodeSizeOptions, ctx.Options.VsphereControlPlaneEndpoint, false, nil); err != nil {
+				return err
+			}
+			return nil
+		})},
+		{Name: "vsphere-control-plane-endpoint", Code: PreflightCodeVsphereEndpoint, Severity: PreflightSeverityWarning, Run: func(c *TkgClient, ctx *PreflightContext) error {
+			if ctx.Options.IsManagementCluster {
+				if err := c.ValidateVsphereControlPlaneEndpointIP(ctx.Options.VsphereControlPlaneEndpoint); err != nil {
+					return errors.Errorf("the control plane endpoint '%s' might already be used by another cluster: %s", ctx.Options.VsphereControlPlaneEndpoint, err.Error())
+				}
+				return nil
+			}
+			if ctx.Options.ClusterClient == nil {
+				return SkipPreflightCheck("the management cluster is not reachable")
+			}
+			return c.ValidateVsphereVipWorkloadCluster(ctx.Options.ClusterClient, ctx.Options.VsphereControlPlaneEndpoint, false)
+		}},
+	}
+}
+
+func dockerPreflightChecks(ctx *PreflightContext) []PreflightCheck {
+	return []PreflightCheck{
+		{Name: "docker-configuration", Code: PreflightCodeDockerConfiguration, Severity: PreflightSeverityError, Run: requireTKRVersion(func(c *TkgClient, ctx *PreflightContext) error {
+			return c.ConfigureAndValidateDockerConfig(ctx.TKRVersion, ctx.Options.NodeSizeOptions, false)
+		})},
+		{Name: "docker-resources", Code: PreflightCodeDockerResources, Severity: PreflightSeverityError, Run: func(c *TkgClient, ctx *PreflightContext) error {
+			return c.ValidateDockerResourcePrerequisites()
+		}},
+	}
+}
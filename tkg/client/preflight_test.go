@@ -0,0 +1,152 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	. "github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+)
+
+const preflightTestProviderName = "preflight-test"
+
+var _ = Describe("RunPreflightChecks", func() {
+	var (
+		tkgClient     *TkgClient
+		clusterClient *fakes.ClusterClient
+		options       *PreflightOptions
+		report        *PreflightReport
+		validatorCtx  *PreflightContext
+		err           error
+	)
+
+	getResult := func(code string) PreflightCheckResult {
+		for _, result := range report.Results {
+			if result.Code == code {
+				return result
+			}
+		}
+		Fail("no result for preflight check " + code)
+		return PreflightCheckResult{}
+	}
+
+	BeforeEach(func() {
+		tkgClient, err = CreateTKGClientOptsMutator(configFile2, testingDir, defaultTKGBoMFileForTesting, 2*time.Second, func(o Options) Options {
+			return o
+		})
+		Expect(err).NotTo(HaveOccurred())
+		clusterClient = &fakes.ClusterClient{}
+
+		RegisterPreflightValidator(preflightTestProviderName, func(ctx *PreflightContext) []PreflightCheck {
+			validatorCtx = ctx
+			return []PreflightCheck{
+				{Name: "test-error", Code: "TST001", Severity: PreflightSeverityError, Run: func(c *TkgClient, ctx *PreflightContext) error {
+					return errors.New("invalid test configuration")
+				}},
+				{Name: "test-warning", Code: "TST002", Severity: PreflightSeverityWarning, Run: func(c *TkgClient, ctx *PreflightContext) error {
+					return errors.New("questionable test configuration")
+				}},
+				{Name: "test-skipped", Code: "TST003", Severity: PreflightSeverityError, Run: func(c *TkgClient, ctx *PreflightContext) error {
+					return SkipPreflightCheck("not applicable to %s", ctx.ProviderName)
+				}},
+				{Name: "test-passed", Code: "TST004", Severity: PreflightSeverityError, Run: func(c *TkgClient, ctx *PreflightContext) error {
+					return nil
+				}},
+			}
+		})
+
+		options = &PreflightOptions{
+			ClusterName:            "mc-1",
+			Plan:                   "dev",
+			InfrastructureProvider: preflightTestProviderName,
+			CniType:                "antrea",
+			Edition:                "tkg",
+			IsManagementCluster:    true,
+		}
+	})
+
+	JustBeforeEach(func() {
+		report, err = tkgClient.RunPreflightChecks(options)
+	})
+
+	Context("when several checks fail", func() {
+		BeforeEach(func() {
+			options.ClusterName = "Invalid_Name"
+			options.Plan = ""
+		})
+		It("should run all the checks and report all the failures", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.InfrastructureProvider).To(Equal(preflightTestProviderName))
+			Expect(getResult(PreflightCodeClusterName).Status).To(Equal(PreflightCheckFailed))
+			Expect(getResult(PreflightCodeClusterPlan).Status).To(Equal(PreflightCheckFailed))
+			Expect(getResult(PreflightCodeClusterPlan).Message).To(Equal("required config variable 'CLUSTER_PLAN' is not set"))
+			Expect(getResult(PreflightCodeEdition).Status).To(Equal(PreflightCheckPassed))
+			Expect(getResult(PreflightCodeMachineDeployments).Status).To(Equal(PreflightCheckPassed))
+			Expect(report.Failures()).To(BeNumerically(">=", 3))
+			Expect(report.Error()).To(HaveOccurred())
+		})
+	})
+
+	Context("when a validator is registered for the infrastructure provider", func() {
+		It("should report the results of the provider checks", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(validatorCtx.ProviderName).To(Equal(preflightTestProviderName))
+			Expect(validatorCtx.ClusterRole).To(Equal(TkgLabelClusterRoleManagement))
+
+			result := getResult("TST001")
+			Expect(result.Provider).To(Equal(preflightTestProviderName))
+			Expect(result.Status).To(Equal(PreflightCheckFailed))
+			Expect(result.Message).To(Equal("invalid test configuration"))
+			Expect(getResult("TST002").Status).To(Equal(PreflightCheckWarning))
+			Expect(getResult("TST003").Status).To(Equal(PreflightCheckSkipped))
+			Expect(getResult("TST003").Message).To(Equal("not applicable to preflight-test"))
+			Expect(getResult("TST004").Status).To(Equal(PreflightCheckPassed))
+			Expect(report.Warnings()).To(BeNumerically(">=", 1))
+		})
+
+		It("should report the results as JUnit", func() {
+			Expect(err).NotTo(HaveOccurred())
+			var b bytes.Buffer
+			Expect(report.WriteJUnit(&b)).To(Succeed())
+			Expect(b.String()).To(ContainSubstring(`<testcase name="[TST001] test-error" classname="preflight.preflight-test">`))
+			Expect(b.String()).To(ContainSubstring(`<failure message="invalid test configuration" type="TST001"></failure>`))
+			Expect(b.String()).To(ContainSubstring(`<skipped message="not applicable to preflight-test"></skipped>`))
+			Expect(b.String()).To(ContainSubstring(`<system-out>WARNING: questionable test configuration</system-out>`))
+		})
+	})
+
+	Context("when validating a workload cluster", func() {
+		BeforeEach(func() {
+			options.ClusterName = "wc-1"
+			options.InfrastructureProvider = ""
+			options.IsManagementCluster = false
+			options.ClusterClient = clusterClient
+			clusterClient.GetRegionalClusterDefaultProviderNameReturns(preflightTestProviderName+":v1.0.0", nil)
+		})
+		It("should use the infrastructure provider of the management cluster and skip the management cluster checks", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(validatorCtx.ClusterRole).To(Equal(TkgLabelClusterRoleWorkload))
+			Expect(report.InfrastructureProvider).To(Equal(preflightTestProviderName))
+			Expect(getResult(PreflightCodeClusterName).Status).To(Equal(PreflightCheckPassed))
+			Expect(getResult(PreflightCodeClusterNameUniqueness).Status).To(Equal(PreflightCheckSkipped))
+			Expect(getResult(PreflightCodeIdentityProvider).Status).To(Equal(PreflightCheckSkipped))
+			Expect(getResult(PreflightCodeAviConfiguration).Status).To(Equal(PreflightCheckSkipped))
+		})
+	})
+
+	Context("when the infrastructure provider cannot be determined", func() {
+		BeforeEach(func() {
+			options.InfrastructureProvider = ""
+		})
+		It("should return an error", func() {
+			Expect(err).To(MatchError("infrastructure provider is required, please set 'INFRASTRUCTURE_PROVIDER' in the cluster configuration file"))
+		})
+	})
+})
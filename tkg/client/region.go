@@ -102,6 +102,22 @@ func (c *TkgClient) GetRegionContexts(clusterName string) ([]region.RegionContex
 	return res, nil
 }
 
+// getRegionContextOrCurrent returns the context of the named management cluster, or of the current one if no name is given
+func (c *TkgClient) getRegionContextOrCurrent(clusterName string) (region.RegionContext, error) {
+	if clusterName == "" {
+		currentRegion, err := c.GetCurrentRegionContext()
+		if err != nil {
+			return region.RegionContext{}, errors.Wrap(err, "cannot get current management cluster context")
+		}
+		return currentRegion, nil
+	}
+	contexts, err := c.GetRegionContexts(clusterName)
+	if err != nil || len(contexts) == 0 {
+		return region.RegionContext{}, errors.Errorf("management cluster %s not found", clusterName)
+	}
+	return contexts[0], nil
+}
+
 // SetRegionContext sets management cluster contexts
 func (c *TkgClient) SetRegionContext(clusterName, contextName string) error {
 	return c.regionManager.SetCurrentContext(clusterName, contextName)
@@ -4,11 +4,19 @@
 package client
 
 import (
-	"github.com/pkg/errors"
+	"context"
+	"time"
 
+	"github.com/pkg/errors"
+	capav1beta2 "sigs.k8s.io/cluster-api-provider-aws/api/v1beta2"
+	awscreds "sigs.k8s.io/cluster-api-provider-aws/cmd/clusterawsadm/credentials"
+	capzv1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 
+	"github.com/vmware-tanzu/tanzu-framework/tkg/aws"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/azure"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
 )
@@ -19,6 +27,8 @@ type UpdateCredentialsOptions struct {
 	Namespace                   string
 	Kubeconfig                  string
 	VSphereUpdateClusterOptions *VSphereUpdateClusterOptions
+	AWSUpdateClusterOptions     *AWSUpdateClusterOptions
+	AzureUpdateClusterOptions   *AzureUpdateClusterOptions
 	IsRegionalCluster           bool
 	IsCascading                 bool
 }
@@ -29,6 +39,20 @@ type VSphereUpdateClusterOptions struct {
 	Password string
 }
 
+// AWSUpdateClusterOptions aws credential options
+type AWSUpdateClusterOptions struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// AzureUpdateClusterOptions azure credential options
+// ClientID is optional and is only required when the service principal is replaced.
+type AzureUpdateClusterOptions struct {
+	ClientID     string
+	ClientSecret string
+}
+
 // UpdateCredentialsRegion update management cluster credentials
 func (c *TkgClient) UpdateCredentialsRegion(options *UpdateCredentialsOptions) error {
 	if options == nil {
@@ -69,15 +93,8 @@ func (c *TkgClient) UpdateCredentialsRegion(options *UpdateCredentialsOptions) e
 	}
 
 	log.Infof("Updating credentials for management cluster %q", options.ClusterName)
-	if infraProviderName == VSphereProviderName {
-		if err := c.UpdateVSphereClusterCredentials(regionalClusterClient, options); err != nil {
-			return err
-		}
-	}
-
-	// update operation is supported only on vsphere clusters for now
-	if infraProviderName != VSphereProviderName {
-		return errors.New("Updating '" + infraProviderName + "' cluster is not yet supported")
+	if err := c.updateClusterCredentials(regionalClusterClient, infraProviderName, options); err != nil {
+		return err
 	}
 
 	log.Infof("Updating credentials for management cluster successful")
@@ -116,15 +133,8 @@ func (c *TkgClient) UpdateCredentialsCluster(options *UpdateCredentialsOptions)
 	}
 
 	log.Infof("Updating credentials for workload cluster %q", options.ClusterName)
-	if infraProviderName == VSphereProviderName {
-		if err := c.UpdateVSphereClusterCredentials(regionalClusterClient, options); err != nil {
-			return err
-		}
-	}
-
-	// update operation is supported only on vsphere clusters for now
-	if infraProviderName != VSphereProviderName {
-		return errors.New("Updating '" + infraProviderName + "' cluster is not yet supported")
+	if err := c.updateClusterCredentials(regionalClusterClient, infraProviderName, options); err != nil {
+		return err
 	}
 
 	log.Infof("Updating credentials for workload cluster successful!")
@@ -141,34 +151,70 @@ func (c *TkgClient) UpdateVSphereClusterCredentials(clusterClient clusterclient.
 		return err
 	}
 
-	if options.IsRegionalCluster {
-		if options.IsCascading {
-			log.Infof("Updating credentials for all workload clusters under management cluster %q", options.ClusterName)
-			clusters, err := clusterClient.ListClusters("")
-			if err != nil {
-				return errors.Wrapf(err, "unable to update credentials on workload clusters")
-			}
-
-			for i := range clusters {
-				if clusters[i].Name == options.ClusterName {
-					continue
-				}
-
-				log.Infof("Updating credentials for workload cluster %q ...", clusters[i].Name)
-				err := c.updateVSphereCredentialsForCluster(clusterClient, &UpdateCredentialsOptions{
-					ClusterName:                 clusters[i].Name,
-					Namespace:                   clusters[i].Namespace,
-					IsRegionalCluster:           false,
-					VSphereUpdateClusterOptions: options.VSphereUpdateClusterOptions,
-				})
-				if err != nil {
-					log.Error(err, "unable to update credentials for workload cluster")
-					continue
-				}
-			}
+	return c.updateWorkloadClustersCredentials(clusterClient, options, c.updateVSphereCredentialsForCluster)
+}
+
+// updateClusterCredentials verifies and updates the credentials of a cluster based on its infrastructure provider
+func (c *TkgClient) updateClusterCredentials(clusterClient clusterclient.Client, infraProviderName string, options *UpdateCredentialsOptions) error {
+	switch infraProviderName {
+	case VSphereProviderName:
+		if options.VSphereUpdateClusterOptions == nil {
+			return errors.New("vSphere credentials are required to update a vSphere cluster")
 		}
+		return c.UpdateVSphereClusterCredentials(clusterClient, options)
+	case AWSProviderName:
+		if options.AWSUpdateClusterOptions == nil {
+			return errors.New("AWS credentials are required to update an AWS cluster")
+		}
+		if err := c.verifyAWSCredentials(clusterClient, options); err != nil {
+			return err
+		}
+		return c.UpdateAWSClusterCredentials(clusterClient, options)
+	case AzureProviderName:
+		if options.AzureUpdateClusterOptions == nil {
+			return errors.New("Azure credentials are required to update an Azure cluster")
+		}
+		if err := c.verifyAzureCredentials(clusterClient, options); err != nil {
+			return err
+		}
+		return c.UpdateAzureClusterCredentials(clusterClient, options)
+	default:
+		return errors.New("Updating '" + infraProviderName + "' cluster is not yet supported")
 	}
+}
+
+// updateWorkloadClustersCredentials updates the credentials of all the workload clusters
+// of a management cluster when a cascading update is requested
+func (c *TkgClient) updateWorkloadClustersCredentials(clusterClient clusterclient.Client, options *UpdateCredentialsOptions, updateFunc func(clusterclient.Client, *UpdateCredentialsOptions) error) error {
+	if !options.IsRegionalCluster || !options.IsCascading {
+		return nil
+	}
+
+	log.Infof("Updating credentials for all workload clusters under management cluster %q", options.ClusterName)
+	clusters, err := clusterClient.ListClusters("")
+	if err != nil {
+		return errors.Wrapf(err, "unable to update credentials on workload clusters")
+	}
+
+	for i := range clusters {
+		if clusters[i].Name == options.ClusterName {
+			continue
+		}
 
+		log.Infof("Updating credentials for workload cluster %q ...", clusters[i].Name)
+		err := updateFunc(clusterClient, &UpdateCredentialsOptions{
+			ClusterName:                 clusters[i].Name,
+			Namespace:                   clusters[i].Namespace,
+			IsRegionalCluster:           false,
+			VSphereUpdateClusterOptions: options.VSphereUpdateClusterOptions,
+			AWSUpdateClusterOptions:     options.AWSUpdateClusterOptions,
+			AzureUpdateClusterOptions:   options.AzureUpdateClusterOptions,
+		})
+		if err != nil {
+			log.Error(err, "unable to update credentials for workload cluster")
+			continue
+		}
+	}
 	return nil
 }
 
@@ -197,3 +243,162 @@ func (c *TkgClient) updateVSphereCredentialsForCluster(clusterClient clusterclie
 
 	return nil
 }
+
+// UpdateAWSClusterCredentials update aws cluster credentials
+func (c *TkgClient) UpdateAWSClusterCredentials(clusterClient clusterclient.Client, options *UpdateCredentialsOptions) error {
+	if options.AWSUpdateClusterOptions.AccessKeyID == "" || options.AWSUpdateClusterOptions.SecretAccessKey == "" {
+		return errors.New("either access key ID or secret access key should not be empty")
+	}
+
+	if err := c.updateAWSCredentialsForCluster(clusterClient, options); err != nil {
+		return err
+	}
+
+	return c.updateWorkloadClustersCredentials(clusterClient, options, c.updateAWSCredentialsForCluster)
+}
+
+func (c *TkgClient) updateAWSCredentialsForCluster(clusterClient clusterclient.Client, options *UpdateCredentialsOptions) error {
+	awsOptions := options.AWSUpdateClusterOptions
+
+	if options.IsRegionalCluster {
+		region, err := getAWSClusterRegion(clusterClient, options.ClusterName, options.Namespace)
+		if err != nil {
+			return err
+		}
+		// update capa-manager-bootstrap-credentials
+		if err := clusterClient.UpdateCapaManagerBootstrapCredentialsSecret(region, awsOptions.AccessKeyID, awsOptions.SecretAccessKey, awsOptions.SessionToken); err != nil {
+			return err
+		}
+	}
+
+	// update the AWSClusterStaticIdentity secret if the cluster uses one.
+	// The AWS cloud provider and EBS CSI driver of the cluster use the EC2 instance profile
+	// of the nodes and do not need to be updated.
+	return clusterClient.UpdateAWSClusterStaticIdentitySecret(options.ClusterName, options.Namespace, awsOptions.AccessKeyID, awsOptions.SecretAccessKey, awsOptions.SessionToken)
+}
+
+// verifyAWSCredentials checks that the new AWS credentials are valid in the region of the cluster
+func (c *TkgClient) verifyAWSCredentials(clusterClient clusterclient.Client, options *UpdateCredentialsOptions) error {
+	region, err := getAWSClusterRegion(clusterClient, options.ClusterName, options.Namespace)
+	if err != nil {
+		return err
+	}
+
+	awsClient, err := aws.New(awscreds.AWSCredentials{
+		Region:          region,
+		AccessKeyID:     options.AWSUpdateClusterOptions.AccessKeyID,
+		SecretAccessKey: options.AWSUpdateClusterOptions.SecretAccessKey,
+		SessionToken:    options.AWSUpdateClusterOptions.SessionToken,
+	})
+	if err != nil {
+		return err
+	}
+	if err := awsClient.VerifyAccount(); err != nil {
+		return errors.Wrap(err, "unable to verify the new AWS credentials")
+	}
+	return nil
+}
+
+func getAWSClusterRegion(clusterClient clusterclient.Client, clusterName, namespace string) (string, error) {
+	awsCluster := &capav1beta2.AWSCluster{}
+	if err := clusterClient.GetResource(awsCluster, clusterName, namespace, nil, nil); err != nil {
+		return "", errors.Wrapf(err, "unable to get AWSCluster %s/%s", namespace, clusterName)
+	}
+	return awsCluster.Spec.Region, nil
+}
+
+// UpdateAzureClusterCredentials update azure cluster credentials
+func (c *TkgClient) UpdateAzureClusterCredentials(clusterClient clusterclient.Client, options *UpdateCredentialsOptions) error {
+	if options.AzureUpdateClusterOptions.ClientSecret == "" {
+		return errors.New("client secret should not be empty")
+	}
+
+	if err := c.updateAzureCredentialsForCluster(clusterClient, options); err != nil {
+		return err
+	}
+
+	return c.updateWorkloadClustersCredentials(clusterClient, options, c.verifyAndUpdateAzureCredentialsForCluster)
+}
+
+// verifyAndUpdateAzureCredentialsForCluster updates the credentials of a workload cluster once they are verified
+// against the subscription and tenant of the cluster, which may differ from those of the management cluster
+func (c *TkgClient) verifyAndUpdateAzureCredentialsForCluster(clusterClient clusterclient.Client, options *UpdateCredentialsOptions) error {
+	if err := c.verifyAzureCredentials(clusterClient, options); err != nil {
+		return err
+	}
+	return c.updateAzureCredentialsForCluster(clusterClient, options)
+}
+
+func (c *TkgClient) updateAzureCredentialsForCluster(clusterClient clusterclient.Client, options *UpdateCredentialsOptions) error {
+	azureOptions := options.AzureUpdateClusterOptions
+
+	if options.IsRegionalCluster {
+		// update capz-manager-bootstrap-credentials
+		if err := clusterClient.UpdateCapzManagerBootstrapCredentialsSecret(azureOptions.ClientID, azureOptions.ClientSecret); err != nil {
+			return err
+		}
+	}
+
+	// update the AzureClusterIdentity used by the cluster
+	if err := clusterClient.UpdateAzureClusterIdentitySecret(options.ClusterName, options.Namespace, azureOptions.ClientID, azureOptions.ClientSecret); err != nil {
+		return err
+	}
+
+	// update the azure.json used by the Azure cloud provider and disk CSI driver on the nodes.
+	// Existing nodes use the new credentials once they are rolled out.
+	if err := clusterClient.UpdateAzureJSONSecrets(options.ClusterName, options.Namespace, azureOptions.ClientID, azureOptions.ClientSecret); err != nil {
+		return err
+	}
+
+	// the data values of the AzureCPIConfig and AzureDiskCSIConfig of the cluster are derived from its
+	// AzureClusterIdentity. Annotating the cluster triggers their reconciliation so that the cloud provider
+	// and disk CSI driver packages use the new credentials. The data values of the azurefile-csi-driver
+	// package do not hold any credentials and do not need to be updated.
+	return clusterClient.PatchClusterObjectAnnotations(options.ClusterName, options.Namespace, constants.CredentialsUpdatedAtAnnotation, time.Now().UTC().Format(time.RFC3339))
+}
+
+// verifyAzureCredentials checks that the new Azure service principal credentials are valid
+// for the subscription of the cluster and the tenant of its AzureClusterIdentity. The tenant
+// of the capz-controller-manager is used if the cluster does not reference an AzureClusterIdentity
+func (c *TkgClient) verifyAzureCredentials(clusterClient clusterclient.Client, options *UpdateCredentialsOptions) error {
+	azureCluster := &capzv1beta1.AzureCluster{}
+	if err := clusterClient.GetResource(azureCluster, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to get AzureCluster %s/%s", options.Namespace, options.ClusterName)
+	}
+
+	var creds azure.Credentials
+	if identityRef := azureCluster.Spec.IdentityRef; identityRef != nil {
+		identityNamespace := identityRef.Namespace
+		if identityNamespace == "" {
+			identityNamespace = azureCluster.Namespace
+		}
+		identity := &capzv1beta1.AzureClusterIdentity{}
+		if err := clusterClient.GetResource(identity, identityRef.Name, identityNamespace, nil, nil); err != nil {
+			return errors.Wrapf(err, "unable to get AzureClusterIdentity %s/%s", identityNamespace, identityRef.Name)
+		}
+		creds.TenantID = identity.Spec.TenantID
+		creds.ClientID = identity.Spec.ClientID
+	} else {
+		controllerCreds, err := clusterClient.GetAzureCredentialsFromSecret()
+		if err != nil {
+			return err
+		}
+		creds.TenantID = controllerCreds.TenantID
+		creds.ClientID = controllerCreds.ClientID
+	}
+	if options.AzureUpdateClusterOptions.ClientID != "" {
+		creds.ClientID = options.AzureUpdateClusterOptions.ClientID
+	}
+	creds.ClientSecret = options.AzureUpdateClusterOptions.ClientSecret
+	creds.SubscriptionID = azureCluster.Spec.SubscriptionID
+	creds.AzureCloud = azureCluster.Spec.AzureEnvironment
+
+	azureClient, err := azure.New(&creds)
+	if err != nil {
+		return err
+	}
+	if err := azureClient.VerifyAccount(context.Background()); err != nil {
+		return errors.Wrap(err, "unable to verify the new Azure credentials")
+	}
+	return nil
+}
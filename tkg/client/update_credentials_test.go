@@ -8,7 +8,13 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capav1beta2 "sigs.k8s.io/cluster-api-provider-aws/api/v1beta2"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
 
 	. "github.com/vmware-tanzu/tanzu-framework/tkg/client"
@@ -120,4 +126,166 @@ var _ = Describe("", func() {
 			Expect(pwd).To(Equal("password"))
 		})
 	})
+
+	Context("Update AWS credentials", func() {
+		It("Returns error when secret access key is empty", func() {
+			err := tkgClient.UpdateAWSClusterCredentials(clusterClient, &UpdateCredentialsOptions{
+				ClusterName: clusterName,
+				Kubeconfig:  kubeconfig,
+				AWSUpdateClusterOptions: &AWSUpdateClusterOptions{
+					AccessKeyID: "access-key-id",
+				},
+			})
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("Should update the static identity of a workload cluster only", func() {
+			err := tkgClient.UpdateAWSClusterCredentials(clusterClient, &UpdateCredentialsOptions{
+				ClusterName: clusterName,
+				Namespace:   "namespace",
+				Kubeconfig:  kubeconfig,
+				AWSUpdateClusterOptions: &AWSUpdateClusterOptions{
+					AccessKeyID:     "access-key-id",
+					SecretAccessKey: "secret-access-key",
+				},
+			})
+			Expect(err).To(BeNil())
+
+			Expect(clusterClient.UpdateCapaManagerBootstrapCredentialsSecretCallCount()).To(Equal(0))
+			Expect(clusterClient.UpdateAWSClusterStaticIdentitySecretCallCount()).To(Equal(1))
+			cname, namespace, accessKeyID, secretAccessKey, sessionToken := clusterClient.UpdateAWSClusterStaticIdentitySecretArgsForCall(0)
+			Expect(cname).To(Equal(clusterName))
+			Expect(namespace).To(Equal("namespace"))
+			Expect(accessKeyID).To(Equal("access-key-id"))
+			Expect(secretAccessKey).To(Equal("secret-access-key"))
+			Expect(sessionToken).To(Equal(""))
+		})
+
+		It("Should update the bootstrap credentials of a management cluster and cascade to the workload clusters", func() {
+			clusterClient.GetResourceCalls(func(obj interface{}, name, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+				obj.(*capav1beta2.AWSCluster).Spec.Region = "us-west-2"
+				return nil
+			})
+			clusterClient.ListClustersReturns([]capi.Cluster{
+				{ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: "tkg-system"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "wc-1", Namespace: "default"}},
+			}, nil)
+
+			err := tkgClient.UpdateAWSClusterCredentials(clusterClient, &UpdateCredentialsOptions{
+				ClusterName: clusterName,
+				Namespace:   "tkg-system",
+				Kubeconfig:  kubeconfig,
+				AWSUpdateClusterOptions: &AWSUpdateClusterOptions{
+					AccessKeyID:     "access-key-id",
+					SecretAccessKey: "secret-access-key",
+					SessionToken:    "session-token",
+				},
+				IsRegionalCluster: true,
+				IsCascading:       true,
+			})
+			Expect(err).To(BeNil())
+
+			Expect(clusterClient.UpdateCapaManagerBootstrapCredentialsSecretCallCount()).To(Equal(1))
+			region, accessKeyID, secretAccessKey, sessionToken := clusterClient.UpdateCapaManagerBootstrapCredentialsSecretArgsForCall(0)
+			Expect(region).To(Equal("us-west-2"))
+			Expect(accessKeyID).To(Equal("access-key-id"))
+			Expect(secretAccessKey).To(Equal("secret-access-key"))
+			Expect(sessionToken).To(Equal("session-token"))
+
+			Expect(clusterClient.UpdateAWSClusterStaticIdentitySecretCallCount()).To(Equal(2))
+			cname, namespace, _, _, _ := clusterClient.UpdateAWSClusterStaticIdentitySecretArgsForCall(1)
+			Expect(cname).To(Equal("wc-1"))
+			Expect(namespace).To(Equal("default"))
+		})
+	})
+
+	Context("Update Azure credentials", func() {
+		It("Returns error when client secret is empty", func() {
+			err := tkgClient.UpdateAzureClusterCredentials(clusterClient, &UpdateCredentialsOptions{
+				ClusterName:               clusterName,
+				Kubeconfig:                kubeconfig,
+				AzureUpdateClusterOptions: &AzureUpdateClusterOptions{ClientID: "client-id"},
+			})
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("Should update the identity and azure.json secrets of a workload cluster", func() {
+			err := tkgClient.UpdateAzureClusterCredentials(clusterClient, &UpdateCredentialsOptions{
+				ClusterName:               clusterName,
+				Namespace:                 "namespace",
+				Kubeconfig:                kubeconfig,
+				AzureUpdateClusterOptions: &AzureUpdateClusterOptions{ClientSecret: "client-secret"},
+			})
+			Expect(err).To(BeNil())
+
+			Expect(clusterClient.UpdateCapzManagerBootstrapCredentialsSecretCallCount()).To(Equal(0))
+
+			Expect(clusterClient.UpdateAzureClusterIdentitySecretCallCount()).To(Equal(1))
+			cname, namespace, clientID, clientSecret := clusterClient.UpdateAzureClusterIdentitySecretArgsForCall(0)
+			Expect(cname).To(Equal(clusterName))
+			Expect(namespace).To(Equal("namespace"))
+			Expect(clientID).To(Equal(""))
+			Expect(clientSecret).To(Equal("client-secret"))
+
+			Expect(clusterClient.UpdateAzureJSONSecretsCallCount()).To(Equal(1))
+			cname, namespace, clientID, clientSecret = clusterClient.UpdateAzureJSONSecretsArgsForCall(0)
+			Expect(cname).To(Equal(clusterName))
+			Expect(namespace).To(Equal("namespace"))
+			Expect(clientID).To(Equal(""))
+			Expect(clientSecret).To(Equal("client-secret"))
+
+			Expect(clusterClient.PatchClusterObjectAnnotationsCallCount()).To(Equal(1))
+			cname, namespace, key, _ := clusterClient.PatchClusterObjectAnnotationsArgsForCall(0)
+			Expect(cname).To(Equal(clusterName))
+			Expect(namespace).To(Equal("namespace"))
+			Expect(key).To(Equal(constants.CredentialsUpdatedAtAnnotation))
+		})
+
+		It("Should update the bootstrap credentials of a management cluster", func() {
+			err := tkgClient.UpdateAzureClusterCredentials(clusterClient, &UpdateCredentialsOptions{
+				ClusterName:               clusterName,
+				Namespace:                 "tkg-system",
+				Kubeconfig:                kubeconfig,
+				AzureUpdateClusterOptions: &AzureUpdateClusterOptions{ClientID: "client-id", ClientSecret: "client-secret"},
+				IsRegionalCluster:         true,
+			})
+			Expect(err).To(BeNil())
+
+			Expect(clusterClient.UpdateCapzManagerBootstrapCredentialsSecretCallCount()).To(Equal(1))
+			clientID, clientSecret := clusterClient.UpdateCapzManagerBootstrapCredentialsSecretArgsForCall(0)
+			Expect(clientID).To(Equal("client-id"))
+			Expect(clientSecret).To(Equal("client-secret"))
+			Expect(clusterClient.UpdateAzureClusterIdentitySecretCallCount()).To(Equal(1))
+			Expect(clusterClient.UpdateAzureJSONSecretsCallCount()).To(Equal(1))
+			Expect(clusterClient.ListClustersCallCount()).To(Equal(0))
+		})
+
+		It("Should not update a workload cluster whose credentials cannot be verified", func() {
+			clusterClient.ListClustersReturns([]capi.Cluster{
+				{ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: "tkg-system"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "wc-1", Namespace: "default"}},
+			}, nil)
+			clusterClient.GetResourceReturns(errors.New("not found"))
+
+			err := tkgClient.UpdateAzureClusterCredentials(clusterClient, &UpdateCredentialsOptions{
+				ClusterName:               clusterName,
+				Namespace:                 "tkg-system",
+				Kubeconfig:                kubeconfig,
+				AzureUpdateClusterOptions: &AzureUpdateClusterOptions{ClientID: "client-id", ClientSecret: "client-secret"},
+				IsRegionalCluster:         true,
+				IsCascading:               true,
+			})
+			Expect(err).To(BeNil())
+
+			Expect(clusterClient.GetResourceCallCount()).To(Equal(1))
+			_, name, namespace, _, _ := clusterClient.GetResourceArgsForCall(0)
+			Expect(name).To(Equal("wc-1"))
+			Expect(namespace).To(Equal("default"))
+			Expect(clusterClient.UpdateAzureClusterIdentitySecretCallCount()).To(Equal(1))
+			cname, _, _, _ := clusterClient.UpdateAzureClusterIdentitySecretArgsForCall(0)
+			Expect(cname).To(Equal(clusterName))
+			Expect(clusterClient.UpdateAzureJSONSecretsCallCount()).To(Equal(1))
+			Expect(clusterClient.PatchClusterObjectAnnotationsCallCount()).To(Equal(1))
+		})
+	})
 })
@@ -228,14 +228,14 @@ func (c *TkgClient) DoLegacyClusterUpgrade(regionalClusterClient, currentCluster
 
 	if options.IsRegionalCluster {
 		if !config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
-			log.Info("Waiting for additional components to be up and running...")
+			log.Wait("Waiting for additional components to be up and running...")
 			if err := c.WaitForAddonsDeployments(regionalClusterClient); err != nil {
 				return err
 			}
 		}
 	}
 
-	log.Info("Waiting for packages to be up and running...")
+	log.Wait("Waiting for packages to be up and running...")
 	if err := c.WaitForPackages(regionalClusterClient, currentClusterClient, options.ClusterName, options.Namespace, options.IsRegionalCluster); err != nil {
 		log.Warningf("Warning: Cluster is upgraded successfully, but some packages are failing. %v", err)
 	}
@@ -252,7 +252,7 @@ func (c *TkgClient) DoPacificClusterUpgrade(regionalClusterClient clusterclient.
 	if err := regionalClusterClient.PatchK8SVersionToPacificCluster(options.ClusterName, options.Namespace, options.KubernetesVersion); err != nil {
 		return errors.Wrap(err, "failed to update the Kubernetes version for TanzuKubernetesCluster object")
 	}
-	log.Info("Waiting for the 'Tanzu Kubernetes Cluster service for vSphere' cluster kubernetes version update and it may take a while...")
+	log.Wait("Waiting for the 'Tanzu Kubernetes Cluster service for vSphere' cluster kubernetes version update and it may take a while...")
 	if err := regionalClusterClient.WaitForPacificClusterK8sVersionUpdate(options.ClusterName, options.Namespace, options.KubernetesVersion); err != nil {
 		return errors.Wrap(err, "failed waiting on updating kubernetes version for 'Tanzu Kubernetes Cluster service for vSphere' cluster")
 	}
@@ -440,7 +440,7 @@ func (c *TkgClient) applyPatchAndWait(regionalClusterClient, currentClusterClien
 		}
 	}
 
-	log.Info("Waiting for kubernetes version to be updated for control plane nodes")
+	log.Wait("Waiting for kubernetes version to be updated for control plane nodes")
 	err = regionalClusterClient.WaitK8sVersionUpdateForCPNodes(upgradeClusterConfig.ClusterName, upgradeClusterConfig.ClusterNamespace, kubernetesVersion, currentClusterClient)
 	if err != nil {
 		return errors.Wrap(err, "error waiting for kubernetes version update for kubeadm control plane")
@@ -455,7 +455,7 @@ func (c *TkgClient) applyPatchAndWait(regionalClusterClient, currentClusterClien
 	}
 	upgradeClusterConfig.UpgradeState = upgradeStateMDPatchApplied
 
-	log.Info("Waiting for kubernetes version to be updated for worker nodes...")
+	log.Wait("Waiting for kubernetes version to be updated for worker nodes...")
 	err = regionalClusterClient.WaitK8sVersionUpdateForWorkerNodes(upgradeClusterConfig.ClusterName, upgradeClusterConfig.ClusterNamespace, kubernetesVersion, currentClusterClient)
 	if err != nil {
 		return errors.Wrap(err, "error waiting for kubernetes version update for worker nodes")
@@ -25,13 +25,13 @@ func (c *TkgClient) DoClassyClusterUpgrade(regionalClusterClient clusterclient.C
 		return errors.Wrap(err, "unable to patch kubernetes version to cluster")
 	}
 
-	log.Info("Waiting for kubernetes version to be updated for control plane nodes...")
+	log.Wait("Waiting for kubernetes version to be updated for control plane nodes...")
 	err = regionalClusterClient.WaitK8sVersionUpdateForCPNodes(options.ClusterName, options.Namespace, kubernetesVersion, currentClusterClient)
 	if err != nil {
 		return errors.Wrap(err, "error waiting for kubernetes version update for kubeadm control plane")
 	}
 
-	log.Info("Waiting for kubernetes version to be updated for worker nodes...")
+	log.Wait("Waiting for kubernetes version to be updated for worker nodes...")
 	err = regionalClusterClient.WaitK8sVersionUpdateForWorkerNodes(options.ClusterName, options.Namespace, kubernetesVersion, currentClusterClient)
 	if err != nil {
 		return errors.Wrap(err, "error waiting for kubernetes version update for worker nodes")
@@ -199,3 +199,24 @@ func deleteCRSObjectsIfPresent(clusterClient clusterclient.Client, clusterName,
 	log.V(3).Infof("successfully deleted ClusterResourceSet objects associated with cluster '%s'", clusterName)
 	return nil
 }
+
+// getWorkloadClusterClientFromManagementCluster returns a client for the workload cluster using the
+// kubeconfig of the cluster stored on its management cluster
+func (c *TkgClient) getWorkloadClusterClientFromManagementCluster(regionalClusterClient clusterclient.Client, clusterName, namespace string) (clusterclient.Client, error) {
+	kubeconfig, err := regionalClusterClient.GetKubeConfigForCluster(clusterName, namespace, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get workload cluster kubeconfig")
+	}
+	kubeconfigPath, err := utils.CreateTempFile("", "workload-kubeconfig")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create temporary file to save workload cluster kubeconfig")
+	}
+	if err := utils.WriteToFile(kubeconfigPath, kubeconfig); err != nil {
+		return nil, err
+	}
+	workloadClusterClient, err := c.clusterClientFactory.NewClient(kubeconfigPath, "", clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create workload cluster client")
+	}
+	return workloadClusterClient, nil
+}
@@ -143,8 +143,14 @@ type Client interface {
 	WaitK8sVersionUpdateForCPNodes(clusterName, namespace, kubernetesVersion string, workloadClusterClient Client) error
 	// WaitK8sVersionUpdateForWorkerNodes waits for k8s version to be updated in all worker nodes
 	WaitK8sVersionUpdateForWorkerNodes(clusterName, namespace, kubernetesVersion string, workloadClusterClient Client) error
+	// WaitRolloutForCPNodes waits for all the control plane machines to be replaced by machines created after rolloutAfter
+	WaitRolloutForCPNodes(clusterName, namespace string, rolloutAfter time.Time) error
+	// WaitRolloutForWorkerNodes waits for all the worker machines to be replaced by machines created after rolloutAfter
+	WaitRolloutForWorkerNodes(clusterName, namespace string, rolloutAfter time.Time) error
 	// GetKubeConfigForCluster returns the admin kube config for accessing the cluster
 	GetKubeConfigForCluster(clusterName string, namespace string, pollOptions *PollOptions) ([]byte, error)
+	// GetPodLogs returns the last tailLines lines of the logs of a pod container, or all the logs if tailLines is 0
+	GetPodLogs(podName, namespace, containerName string, tailLines int64) ([]byte, error)
 	// GetPackage returns the package for given package name in a given namespace
 	GetPackage(carvelPkgName, carvelPkgNamespace string) (*kapppkgv1alpha1.Package, error)
 	// GetSecretValue returns the value for a given key in a Secret
@@ -177,6 +183,8 @@ type Client interface {
 	// ListResources lists the kubernetes resources, pass reference of the object you want to get
 	// Note: Make sure resource you are retrieving is added into Scheme in init function below
 	ListResources(resourceReference interface{}, option ...crtclient.ListOption) error
+	// GetPreferredGroupVersionKind returns the version of a kind of an API group preferred by the cluster
+	GetPreferredGroupVersionKind(groupKind schema.GroupKind) (schema.GroupVersionKind, error)
 	// DeleteResource deletes the kubernetes resource, pass reference of the object you want to delete
 	DeleteResource(resourceReference interface{}) error
 	// PatchResource patches the kubernetes resource with procide patch string
@@ -323,6 +331,16 @@ type Client interface {
 	UpdateVsphereCloudProviderCredentialsSecret(clusterName string, namespace string, username string, password string) error
 	// UpdateVsphereCsiConfigSecret updates the vsphere csi config secret
 	UpdateVsphereCsiConfigSecret(clusterName string, namespace string, username string, password string) error
+	// UpdateCapaManagerBootstrapCredentialsSecret updates the AWS creds used by the capa provider
+	UpdateCapaManagerBootstrapCredentialsSecret(region, accessKeyID, secretAccessKey, sessionToken string) error
+	// UpdateAWSClusterStaticIdentitySecret updates the secret of the AWSClusterStaticIdentity used by the cluster
+	UpdateAWSClusterStaticIdentitySecret(clusterName, namespace, accessKeyID, secretAccessKey, sessionToken string) error
+	// UpdateCapzManagerBootstrapCredentialsSecret updates the Azure creds used by the capz provider
+	UpdateCapzManagerBootstrapCredentialsSecret(clientID, clientSecret string) error
+	// UpdateAzureClusterIdentitySecret updates the client secret of the AzureClusterIdentity used by the cluster
+	UpdateAzureClusterIdentitySecret(clusterName, namespace, clientID, clientSecret string) error
+	// UpdateAzureJSONSecrets updates the Azure creds in the azure.json secrets of the cluster machines
+	UpdateAzureJSONSecrets(clusterName, namespace, clientID, clientSecret string) error
 	// GetClientSet gets one clientset used to generate objects list
 	GetClientSet() CrtClient
 	// GetPinnipedIssuerURLAndCA fetches Pinniped supervisor IssuerURL and IssuerCA data from management cluster
@@ -742,7 +760,7 @@ func (c *client) ApplyPatchForAutoScalerDeployment(tkgBomClient tkgconfigbom.Cli
 		return errors.Wrap(err, "unable to update the container image for autoscaler deployment")
 	}
 
-	log.Infof("Waiting for cluster autoscaler to be patched and available...")
+	log.Wait("Waiting for cluster autoscaler to be patched and available...")
 	if err = c.WaitForAutoscalerDeployment(autoscalerDeploymentName, namespace); err != nil {
 		log.Warningf("Unable to wait for autoscaler deployment to be ready. reason: %v", err)
 	}
@@ -843,6 +861,75 @@ func isClusterStateChanged(lastClusterInfo, curClusterInfo *ClusterStatusInfo) b
 	return false
 }
 
+// verifyRolloutForCPNodes verifies that the control plane is ready and that all its machines
+// were replaced by machines created after rolloutAfter
+func verifyRolloutForCPNodes(clusterStatusInfo *ClusterStatusInfo, rolloutAfter time.Time) error {
+	if clusterStatusInfo.RetrievalError != nil {
+		return clusterStatusInfo.RetrievalError
+	}
+
+	clusterObj := clusterStatusInfo.ClusterObject
+	if !conditions.IsTrue(clusterObj, capi.ControlPlaneReadyCondition) {
+		return errors.Errorf("control-plane nodes are still being rolled out, reason:'%s', message:'%s'",
+			conditions.GetReason(clusterObj, capi.ControlPlaneReadyCondition), conditions.GetMessage(clusterObj, capi.ControlPlaneReadyCondition))
+	}
+
+	total := len(clusterStatusInfo.CPMachineObjects)
+	if pending := machinesCreatedBefore(clusterStatusInfo.CPMachineObjects, rolloutAfter); len(pending) != 0 {
+		return errors.Errorf("control-plane nodes are still being rolled out, %d of %d machines replaced, remaining: %v", total-len(pending), total, pending)
+	}
+
+	kcp := clusterStatusInfo.KCPObject
+	var desiredReplica int32 = 1
+	if kcp.Spec.Replicas != nil {
+		desiredReplica = *kcp.Spec.Replicas
+	}
+	if kcp.Status.Replicas != desiredReplica || kcp.Status.ReadyReplicas != desiredReplica || kcp.Status.UpdatedReplicas != desiredReplica {
+		return errors.Errorf("control-plane nodes are still being rolled out, DesiredReplicas=%v Replicas=%v ReadyReplicas=%v UpdatedReplicas=%v",
+			desiredReplica, kcp.Status.Replicas, kcp.Status.ReadyReplicas, kcp.Status.UpdatedReplicas)
+	}
+	return nil
+}
+
+// verifyRolloutForWorkerNodes verifies that all the worker machines were replaced by machines
+// created after rolloutAfter and that the machine deployments are fully updated and ready
+func verifyRolloutForWorkerNodes(clusterStatusInfo *ClusterStatusInfo, rolloutAfter time.Time) error {
+	if clusterStatusInfo.RetrievalError != nil {
+		return clusterStatusInfo.RetrievalError
+	}
+
+	total := len(clusterStatusInfo.WorkerMachineObjects)
+	if pending := machinesCreatedBefore(clusterStatusInfo.WorkerMachineObjects, rolloutAfter); len(pending) != 0 {
+		return errors.Errorf("worker nodes are still being rolled out, %d of %d machines replaced, remaining: %v", total-len(pending), total, pending)
+	}
+
+	errList := []error{}
+	for i := range clusterStatusInfo.MDObjects {
+		md := &clusterStatusInfo.MDObjects[i]
+		var desiredReplica int32 = 1
+		if md.Spec.Replicas != nil {
+			desiredReplica = *md.Spec.Replicas
+		}
+		if md.Status.Replicas != desiredReplica || md.Status.ReadyReplicas != desiredReplica || md.Status.UpdatedReplicas != desiredReplica {
+			errList = append(errList, errors.Errorf("worker nodes are still being rolled out for MachineDeployment '%s', DesiredReplicas=%v Replicas=%v ReadyReplicas=%v UpdatedReplicas=%v",
+				md.Name, desiredReplica, md.Status.Replicas, md.Status.ReadyReplicas, md.Status.UpdatedReplicas))
+		}
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+// machinesCreatedBefore returns the sorted names of the machines created before the given time
+func machinesCreatedBefore(machines map[string]capi.Machine, t time.Time) []string {
+	names := []string{}
+	for key := range machines {
+		if machines[key].CreationTimestamp.Time.Before(t) {
+			names = append(names, machines[key].Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // isClusterStateChangedForKCP functions verifies if the cluster object ControlplaneReady condition has
 // any transitions since last observation
 func isClusterStateChangedForKCP(lastClusterInfo, curClusterInfo *ClusterStatusInfo) bool {
@@ -937,6 +1024,36 @@ func (c *client) WaitK8sVersionUpdateForWorkerNodes(clusterName, namespace, newK
 	return c.waitK8sVersionUpdateGeneric(clusterName, namespace, newK8sVersion, workloadClusterClient, false)
 }
 
+func (c *client) WaitRolloutForCPNodes(clusterName, namespace string, rolloutAfter time.Time) error {
+	return c.waitRolloutGeneric(clusterName, namespace, rolloutAfter, true)
+}
+
+func (c *client) WaitRolloutForWorkerNodes(clusterName, namespace string, rolloutAfter time.Time) error {
+	return c.waitRolloutGeneric(clusterName, namespace, rolloutAfter, false)
+}
+
+// waitRolloutGeneric waits for the machines of the control plane or of the machine deployments to be replaced by
+// machines created after rolloutAfter, logging the progress of the rollout
+func (c *client) waitRolloutGeneric(clusterName, namespace string, rolloutAfter time.Time, isCP bool) error {
+	verifyRolloutFunc := verifyRolloutForCPNodes
+	isClusterStateChangedFunc := isClusterStateChangedForKCP
+	if !isCP {
+		verifyRolloutFunc = verifyRolloutForWorkerNodes
+		isClusterStateChangedFunc = isClusterStateChangedForMD
+	}
+
+	lastProgress := ""
+	verifyFunc := func(clusterStatusInfo *ClusterStatusInfo) error {
+		err := verifyRolloutFunc(clusterStatusInfo, rolloutAfter)
+		if err != nil && clusterStatusInfo.RetrievalError == nil && err.Error() != lastProgress {
+			lastProgress = err.Error()
+			log.Info(lastProgress)
+		}
+		return err
+	}
+	return c.waitClusterUpdateGeneric(clusterName, namespace, "rollout", nil, verifyFunc, isClusterStateChangedFunc)
+}
+
 func (c *client) waitK8sVersionUpdateGeneric(clusterName, namespace, newK8sVersion string, workloadClusterClient Client, isCP bool) error {
 	verifyKubernetesUpgradeFunc := verifyKubernetesUpgradeForCPNodes
 	isClusterStateChangedFunc := isClusterStateChangedForKCP
@@ -950,6 +1067,18 @@ func (c *client) waitK8sVersionUpdateGeneric(clusterName, namespace, newK8sVersi
 		verifyKubernetesUpgradeFunc = c.verificationClientFactory.VerifyKubernetesUpgradeFunc
 	}
 
+	verifyFunc := func(clusterStatusInfo *ClusterStatusInfo) error {
+		return verifyKubernetesUpgradeFunc(clusterStatusInfo, newK8sVersion)
+	}
+	return c.waitClusterUpdateGeneric(clusterName, namespace, "kubernetes version update", workloadClusterClient, verifyFunc, isClusterStateChangedFunc)
+}
+
+// waitClusterUpdateGeneric polls the cluster until verifyFunc succeeds. It fails when the cluster is not ready
+// with an error severity, when the cluster state is unchanged for the operation timeout, or after three times
+// the operation timeout.
+func (c *client) waitClusterUpdateGeneric(clusterName, namespace, operation string, workloadClusterClient Client,
+	verifyFunc func(*ClusterStatusInfo) error, isClusterStateChangedFunc func(lastClusterInfo, curClusterInfo *ClusterStatusInfo) bool) error {
+
 	var err error
 	var curClusterInfo ClusterStatusInfo
 	var lastClusterInfo ClusterStatusInfo
@@ -966,11 +1095,11 @@ func (c *client) waitK8sVersionUpdateGeneric(clusterName, namespace, newK8sVersi
 		// If cluster's ReadyCondition is False and severity is Error, it implies non-retriable error, so return error
 		if conditions.IsFalse(curClusterInfo.ClusterObject, capi.ReadyCondition) &&
 			(*conditions.GetSeverity(curClusterInfo.ClusterObject, capi.ReadyCondition) == capi.ConditionSeverityError) {
-			return true, errors.Errorf("kubernetes version update failed, reason:'%s', message:'%s' ",
+			return true, errors.Errorf("%s failed, reason:'%s', message:'%s' ", operation,
 				conditions.GetReason(curClusterInfo.ClusterObject, capi.ReadyCondition),
 				conditions.GetMessage(curClusterInfo.ClusterObject, capi.ReadyCondition))
 		}
-		err = verifyKubernetesUpgradeFunc(&curClusterInfo, newK8sVersion)
+		err = verifyFunc(&curClusterInfo)
 		if err == nil {
 			return false, nil
 		}
@@ -996,7 +1125,7 @@ func (c *client) waitK8sVersionUpdateGeneric(clusterName, namespace, newK8sVersi
 		// if unchanged for operationTimeout(30 min default) or exceeds maxTimeout, return error
 		if (interval*time.Duration(unchangedCounter) > timeout) ||
 			(interval*time.Duration(maxTimeoutCounter) > maxTimeout) {
-			return true, errors.New("timed out waiting for upgrade to complete")
+			return true, errors.Errorf("timed out waiting for %s to complete", operation)
 		}
 
 		return false, err
@@ -26,6 +26,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/utils/pointer"
 	capav1beta2 "sigs.k8s.io/cluster-api-provider-aws/api/v1beta2"
+	capzv1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	capiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
@@ -80,6 +82,7 @@ func init() {
 	_ = capiv1alpha3.AddToScheme(scheme)
 	_ = capiexp.AddToScheme(scheme)
 	_ = capav1beta2.AddToScheme(scheme)
+	_ = capzv1beta1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	_ = controlplanev1.AddToScheme(scheme)
 	_ = appsv1.AddToScheme(scheme)
@@ -1687,6 +1690,108 @@ var _ = Describe("Cluster Client", func() {
 		})
 	})
 
+	Describe("Wait for rollout of CP and worker nodes", func() {
+		var rolloutAfter time.Time
+
+		BeforeEach(func() {
+			reInitialize()
+			kubeConfigPath := getConfigFilePath("config1.yaml")
+			clstClient, err = NewClient(kubeConfigPath, "", clusterClientOptions)
+			Expect(err).NotTo(HaveOccurred())
+
+			rolloutAfter = time.Now()
+			kcpReplicas = Replicas{SpecReplica: 1, Replicas: 1, ReadyReplicas: 1, UpdatedReplicas: 1}
+			mdReplicas = Replicas{SpecReplica: 2, Replicas: 2, ReadyReplicas: 2, UpdatedReplicas: 2}
+			machineObjects = []capi.Machine{
+				getDummyRolledOutMachine("fake-machine-1", true, rolloutAfter.Add(time.Minute)),
+				getDummyRolledOutMachine("fake-machine-2", false, rolloutAfter.Add(time.Minute)),
+				getDummyRolledOutMachine("fake-machine-3", false, rolloutAfter.Add(time.Minute)),
+			}
+			clientset.GetCalls(func(ctx context.Context, namespace types.NamespacedName, cluster crtclient.Object) error {
+				cluster.(*capi.Cluster).Status.Conditions = capi.Conditions{{
+					Type:   capi.ControlPlaneReadyCondition,
+					Status: corev1.ConditionTrue,
+				}}
+				return nil
+			})
+		})
+		JustBeforeEach(func() {
+			clientset.ListCalls(func(ctx context.Context, o crtclient.ObjectList, opts ...crtclient.ListOption) error {
+				switch o := o.(type) {
+				case *capi.MachineList:
+					o.Items = append(o.Items, machineObjects...)
+				case *capi.MachineDeploymentList:
+					o.Items = append(o.Items, getDummyMD("fake-version", mdReplicas.SpecReplica, mdReplicas.Replicas, mdReplicas.ReadyReplicas, mdReplicas.UpdatedReplicas))
+				case *controlplanev1.KubeadmControlPlaneList:
+					o.Items = append(o.Items, getDummyKCP(kcpReplicas.SpecReplica, kcpReplicas.Replicas, kcpReplicas.ReadyReplicas, kcpReplicas.UpdatedReplicas))
+				default:
+					return errors.New("invalid object type")
+				}
+				return nil
+			})
+		})
+
+		Context("When waiting for the rollout of CP nodes", func() {
+			JustBeforeEach(func() {
+				err = clstClient.WaitRolloutForCPNodes("fake-cluster-name", "fake-cluster-namespace", rolloutAfter)
+			})
+
+			Context("When all CP machines are replaced", func() {
+				It("should not return an error", func() {
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+			Context("When a CP machine was created before the rollout", func() {
+				BeforeEach(func() {
+					machineObjects[0] = getDummyRolledOutMachine("fake-machine-1", true, rolloutAfter.Add(-time.Hour))
+				})
+				It("should return an error", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("control-plane nodes are still being rolled out, 0 of 1 machines replaced, remaining: [fake-machine-1]"))
+				})
+			})
+			Context("When KCP replicas are not updated", func() {
+				BeforeEach(func() {
+					kcpReplicas = Replicas{SpecReplica: 1, Replicas: 2, ReadyReplicas: 1, UpdatedReplicas: 1}
+				})
+				It("should return an error", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("control-plane nodes are still being rolled out, DesiredReplicas=1 Replicas=2 ReadyReplicas=1 UpdatedReplicas=1"))
+				})
+			})
+		})
+
+		Context("When waiting for the rollout of worker nodes", func() {
+			JustBeforeEach(func() {
+				err = clstClient.WaitRolloutForWorkerNodes("fake-cluster-name", "fake-cluster-namespace", rolloutAfter)
+			})
+
+			Context("When all worker machines are replaced", func() {
+				It("should not return an error", func() {
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+			Context("When a worker machine was created before the rollout", func() {
+				BeforeEach(func() {
+					machineObjects[2] = getDummyRolledOutMachine("fake-machine-3", false, rolloutAfter.Add(-time.Hour))
+				})
+				It("should return an error", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("worker nodes are still being rolled out, 1 of 2 machines replaced, remaining: [fake-machine-3]"))
+				})
+			})
+			Context("When MD replicas are not updated", func() {
+				BeforeEach(func() {
+					mdReplicas = Replicas{SpecReplica: 2, Replicas: 2, ReadyReplicas: 2, UpdatedReplicas: 1}
+				})
+				It("should return an error", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("worker nodes are still being rolled out for MachineDeployment 'fake-md-name', DesiredReplicas=2 Replicas=2 ReadyReplicas=2 UpdatedReplicas=1"))
+				})
+			})
+		})
+	})
+
 	Describe("Wait for Pacific cluster Kubernetes version update ", func() {
 		BeforeEach(func() {
 			reInitialize()
@@ -2245,6 +2350,283 @@ var _ = Describe("Cluster Client", func() {
 			})
 		})
 	})
+	Describe("Update AWS Credentials", func() {
+		var (
+			fakeClientSet crtclient.Client
+			objects       []runtime.Object
+			awsCluster    *capav1beta2.AWSCluster
+		)
+
+		BeforeEach(func() {
+			reInitialize()
+			awsCluster = fakehelper.NewAWSCluster(fakehelper.TestAWSClusterOptions{
+				Name:      "fake-clusterName",
+				Namespace: "fake-namespace",
+				Region:    "us-west-2",
+			}).(*capav1beta2.AWSCluster)
+			objects = []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: CAPACredentialsSecretName, Namespace: CAPAControllerNamespace},
+					Data:       map[string][]byte{KeyAWSCredentials: []byte("[default]\naws_access_key_id = old-id\n")},
+				},
+				&capav1beta2.AWSClusterStaticIdentity{
+					ObjectMeta: metav1.ObjectMeta{Name: "static-identity", Namespace: constants.DefaultNamespace},
+					Spec:       capav1beta2.AWSClusterStaticIdentitySpec{SecretRef: "static-identity-secret"},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "static-identity-secret", Namespace: CAPAControllerNamespace},
+					Data:       map[string][]byte{"AccessKeyID": []byte("old-id"), "SecretAccessKey": []byte("old-key")},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			fakeClientSet = fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(append(objects, awsCluster)...).Build()
+			crtClientFactory.NewClientReturns(fakeClientSet, nil)
+
+			clusterClientOptions = NewOptions(poller, crtClientFactory, discoveryClientFactory, nil)
+			kubeConfigPath := getConfigFilePath("config1.yaml")
+			clstClient, err = NewClient(kubeConfigPath, "", clusterClientOptions)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("UpdateCapaManagerBootstrapCredentialsSecret", func() {
+			It("should render the new credentials profile", func() {
+				err = clstClient.UpdateCapaManagerBootstrapCredentialsSecret("us-west-2", "new-id", "new-key", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(clstClient.GetResource(secret, CAPACredentialsSecretName, CAPAControllerNamespace, nil, nil)).To(Succeed())
+				Expect(string(secret.Data[KeyAWSCredentials])).To(ContainSubstring("aws_access_key_id = new-id"))
+				Expect(string(secret.Data[KeyAWSCredentials])).To(ContainSubstring("aws_secret_access_key = new-key"))
+				Expect(string(secret.Data[KeyAWSCredentials])).To(ContainSubstring("region = us-west-2"))
+			})
+
+			Context("When the capa-controller-manager uses the EC2 instance profile", func() {
+				BeforeEach(func() {
+					objects[0].(*corev1.Secret).Data[KeyAWSCredentials] = []byte("\n")
+				})
+				It("should not update the secret", func() {
+					err = clstClient.UpdateCapaManagerBootstrapCredentialsSecret("us-west-2", "new-id", "new-key", "")
+					Expect(err).NotTo(HaveOccurred())
+
+					secret := &corev1.Secret{}
+					Expect(clstClient.GetResource(secret, CAPACredentialsSecretName, CAPAControllerNamespace, nil, nil)).To(Succeed())
+					Expect(string(secret.Data[KeyAWSCredentials])).To(Equal("\n"))
+				})
+			})
+		})
+
+		Context("UpdateAWSClusterStaticIdentitySecret", func() {
+			Context("When the cluster uses an AWSClusterStaticIdentity", func() {
+				BeforeEach(func() {
+					awsCluster.Spec.IdentityRef = &capav1beta2.AWSIdentityReference{Name: "static-identity", Kind: capav1beta2.ClusterStaticIdentityKind}
+				})
+				It("should update the identity secret", func() {
+					err = clstClient.UpdateAWSClusterStaticIdentitySecret("fake-clusterName", "fake-namespace", "new-id", "new-key", "new-token")
+					Expect(err).NotTo(HaveOccurred())
+
+					secret := &corev1.Secret{}
+					Expect(clstClient.GetResource(secret, "static-identity-secret", CAPAControllerNamespace, nil, nil)).To(Succeed())
+					Expect(string(secret.Data["AccessKeyID"])).To(Equal("new-id"))
+					Expect(string(secret.Data["SecretAccessKey"])).To(Equal("new-key"))
+					Expect(string(secret.Data["SessionToken"])).To(Equal("new-token"))
+				})
+			})
+
+			Context("When the cluster uses the controller identity", func() {
+				It("should not update the identity secret", func() {
+					err = clstClient.UpdateAWSClusterStaticIdentitySecret("fake-clusterName", "fake-namespace", "new-id", "new-key", "")
+					Expect(err).NotTo(HaveOccurred())
+
+					secret := &corev1.Secret{}
+					Expect(clstClient.GetResource(secret, "static-identity-secret", CAPAControllerNamespace, nil, nil)).To(Succeed())
+					Expect(string(secret.Data["AccessKeyID"])).To(Equal("old-id"))
+				})
+			})
+		})
+	})
+
+	Describe("Update Azure Credentials", func() {
+		var (
+			fakeClientSet crtclient.Client
+			azureCluster  *capzv1beta1.AzureCluster
+			identity      *capzv1beta1.AzureClusterIdentity
+			capzManager   *appsv1.Deployment
+		)
+
+		BeforeEach(func() {
+			reInitialize()
+			azureCluster = &capzv1beta1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-clusterName", Namespace: constants.TkgNamespace},
+				Spec: capzv1beta1.AzureClusterSpec{
+					AzureClusterClassSpec: capzv1beta1.AzureClusterClassSpec{
+						IdentityRef: &corev1.ObjectReference{Name: "fake-clusterName-identity", Namespace: constants.TkgNamespace},
+					},
+				},
+			}
+			identity = &capzv1beta1.AzureClusterIdentity{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-clusterName-identity", Namespace: constants.TkgNamespace},
+				Spec: capzv1beta1.AzureClusterIdentitySpec{
+					ClientID:     "old-id",
+					ClientSecret: corev1.SecretReference{Name: "fake-clusterName-identity-secret", Namespace: constants.TkgNamespace},
+				},
+			}
+			capzManager = &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: CapzControllerDeploymentName, Namespace: CapzNamespace},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(1)},
+				Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+			}
+		})
+
+		JustBeforeEach(func() {
+			fakeClientSet = fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+				azureCluster,
+				identity,
+				capzManager,
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "fake-clusterName-identity-secret", Namespace: constants.TkgNamespace},
+					Data:       map[string][]byte{"clientSecret": []byte("old-secret")},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: AzureBootstrapCredentialsSecret, Namespace: CapzNamespace},
+					Data:       map[string][]byte{KeyAzureClientID: []byte("old-id"), KeyAzureClientSecret: []byte("old-secret")},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "fake-clusterName-md-0-azure-json", Namespace: constants.TkgNamespace,
+						Labels: map[string]string{capi.ClusterLabelName: "fake-clusterName"}},
+					Data: map[string][]byte{"worker-node-azure.json": []byte(`{"aadClientId": "old-id", "aadClientSecret": "old-secret", "location": "westus2"}`)},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "fake-clusterName-2-md-0-azure-json", Namespace: constants.TkgNamespace,
+						Labels: map[string]string{capi.ClusterLabelName: "fake-clusterName-2"}},
+					Data: map[string][]byte{"worker-node-azure.json": []byte(`{"aadClientId": "old-id", "aadClientSecret": "old-secret"}`)},
+				},
+			).Build()
+			crtClientFactory.NewClientReturns(fakeClientSet, nil)
+
+			clusterClientOptions = NewOptions(poller, crtClientFactory, discoveryClientFactory, nil)
+			kubeConfigPath := getConfigFilePath("config1.yaml")
+			clstClient, err = NewClient(kubeConfigPath, "", clusterClientOptions)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("UpdateCapzManagerBootstrapCredentialsSecret", func() {
+			It("should update the client secret and keep the client ID when none is provided", func() {
+				err = clstClient.UpdateCapzManagerBootstrapCredentialsSecret("", "new-secret")
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(clstClient.GetResource(secret, AzureBootstrapCredentialsSecret, CapzNamespace, nil, nil)).To(Succeed())
+				Expect(string(secret.Data[KeyAzureClientID])).To(Equal("old-id"))
+				Expect(string(secret.Data[KeyAzureClientSecret])).To(Equal("new-secret"))
+			})
+
+			It("should restart the capz-controller-manager to load the new client secret", func() {
+				err = clstClient.UpdateCapzManagerBootstrapCredentialsSecret("", "new-secret")
+				Expect(err).NotTo(HaveOccurred())
+
+				deployment := &appsv1.Deployment{}
+				Expect(clstClient.GetResource(deployment, CapzControllerDeploymentName, CapzNamespace, nil, nil)).To(Succeed())
+				Expect(deployment.Spec.Template.Annotations).To(HaveKey(constants.RestartedAtAnnotation))
+			})
+
+			Context("When the capz-controller-manager does not finish restarting", func() {
+				BeforeEach(func() {
+					capzManager.Status.UpdatedReplicas = 0
+				})
+				It("should return an error", func() {
+					err = clstClient.UpdateCapzManagerBootstrapCredentialsSecret("", "new-secret")
+					Expect(err).To(MatchError(ContainSubstring("capz-system/capz-controller-manager deployment did not finish restarting")))
+				})
+			})
+		})
+
+		Context("UpdateAzureClusterIdentitySecret", func() {
+			It("should update the AzureClusterIdentity and its secret", func() {
+				err = clstClient.UpdateAzureClusterIdentitySecret("fake-clusterName", constants.TkgNamespace, "new-id", "new-secret")
+				Expect(err).NotTo(HaveOccurred())
+
+				identity := &capzv1beta1.AzureClusterIdentity{}
+				Expect(clstClient.GetResource(identity, "fake-clusterName-identity", constants.TkgNamespace, nil, nil)).To(Succeed())
+				Expect(identity.Spec.ClientID).To(Equal("new-id"))
+
+				secret := &corev1.Secret{}
+				Expect(clstClient.GetResource(secret, "fake-clusterName-identity-secret", constants.TkgNamespace, nil, nil)).To(Succeed())
+				Expect(string(secret.Data["clientSecret"])).To(Equal("new-secret"))
+			})
+
+			Context("When the namespaces of the AzureClusterIdentity and its secret are not set", func() {
+				BeforeEach(func() {
+					azureCluster.Spec.IdentityRef.Namespace = ""
+					identity.Spec.ClientSecret.Namespace = ""
+				})
+				It("should default them to the namespace of the AzureCluster and of the AzureClusterIdentity", func() {
+					err = clstClient.UpdateAzureClusterIdentitySecret("fake-clusterName", constants.TkgNamespace, "", "new-secret")
+					Expect(err).NotTo(HaveOccurred())
+
+					secret := &corev1.Secret{}
+					Expect(clstClient.GetResource(secret, "fake-clusterName-identity-secret", constants.TkgNamespace, nil, nil)).To(Succeed())
+					Expect(string(secret.Data["clientSecret"])).To(Equal("new-secret"))
+				})
+			})
+
+			Context("When the cluster does not reference an AzureClusterIdentity", func() {
+				BeforeEach(func() {
+					azureCluster.Spec.IdentityRef = nil
+				})
+				It("should not update the identity secret", func() {
+					err = clstClient.UpdateAzureClusterIdentitySecret("fake-clusterName", constants.TkgNamespace, "", "new-secret")
+					Expect(err).NotTo(HaveOccurred())
+
+					secret := &corev1.Secret{}
+					Expect(clstClient.GetResource(secret, "fake-clusterName-identity-secret", constants.TkgNamespace, nil, nil)).To(Succeed())
+					Expect(string(secret.Data["clientSecret"])).To(Equal("old-secret"))
+				})
+			})
+		})
+
+		Context("UpdateAzureJSONSecrets", func() {
+			It("should only update the azure.json secrets of the cluster", func() {
+				err = clstClient.UpdateAzureJSONSecrets("fake-clusterName", constants.TkgNamespace, "", "new-secret")
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(clstClient.GetResource(secret, "fake-clusterName-md-0-azure-json", constants.TkgNamespace, nil, nil)).To(Succeed())
+				Expect(string(secret.Data["worker-node-azure.json"])).To(ContainSubstring(`"aadClientSecret": "new-secret"`))
+				Expect(string(secret.Data["worker-node-azure.json"])).To(ContainSubstring(`"aadClientId": "old-id"`))
+				Expect(string(secret.Data["worker-node-azure.json"])).To(ContainSubstring(`"location": "westus2"`))
+
+				Expect(clstClient.GetResource(secret, "fake-clusterName-2-md-0-azure-json", constants.TkgNamespace, nil, nil)).To(Succeed())
+				Expect(string(secret.Data["worker-node-azure.json"])).To(ContainSubstring(`"aadClientSecret": "old-secret"`))
+			})
+		})
+	})
+
+	Describe("GetPreferredGroupVersionKind", func() {
+		BeforeEach(func() {
+			reInitialize()
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{capi.GroupVersion})
+			restMapper.Add(capi.GroupVersion.WithKind("Cluster"), meta.RESTScopeNamespace)
+			crtClientFactory.NewClientReturns(fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(restMapper).Build(), nil)
+			clusterClientOptions = NewOptions(poller, crtClientFactory, discoveryClientFactory, nil)
+			kubeConfigPath := getConfigFilePath("config1.yaml")
+			clstClient, err = NewClient(kubeConfigPath, "", clusterClientOptions)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return the version of a kind served by the cluster", func() {
+			gvk, err := clstClient.GetPreferredGroupVersionKind(schema.GroupKind{Group: "cluster.x-k8s.io", Kind: "Cluster"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gvk.Version).To(Equal("v1beta1"))
+		})
+
+		It("should return an error when the kind is not served by the cluster", func() {
+			_, err := clstClient.GetPreferredGroupVersionKind(schema.GroupKind{Group: "cni.tanzu.vmware.com", Kind: "UnknownConfig"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("Get Vsphere Credentials from cluster", func() {
 		var (
 			username    string
@@ -3374,6 +3756,12 @@ func getDummyMachine(name, currentK8sVersion string, isCP bool) capi.Machine {
 	return machine
 }
 
+func getDummyRolledOutMachine(name string, isCP bool, creationTime time.Time) capi.Machine {
+	machine := getDummyMachine(name, "fake-version", isCP)
+	machine.CreationTimestamp = metav1.NewTime(creationTime)
+	return machine
+}
+
 func getv1alpha3DummyMachine(name, currentK8sVersion string, isCP bool) capiv1alpha3.Machine { //nolint:unparam
 	// TODO: Add test cases where isCP is true, currently there are no such tests
 	machine := capiv1alpha3.Machine{}
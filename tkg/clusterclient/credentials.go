@@ -5,14 +5,22 @@ package clusterclient
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	capav1beta2 "sigs.k8s.io/cluster-api-provider-aws/api/v1beta2"
+	awscreds "sigs.k8s.io/cluster-api-provider-aws/cmd/clusterawsadm/credentials"
+	capzv1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	capvv1beta1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	azureclient "github.com/vmware-tanzu/tanzu-framework/tkg/azure"
@@ -35,6 +43,13 @@ const (
 	KeyVSphereCpiConfig              = "values.yaml"
 	KeyCAInSecret                    = "ca.crt"
 	CapvNamespace                    = "capv-system"
+	CapzNamespace                    = "capz-system"
+	CapzControllerDeploymentName     = "capz-controller-manager"
+	KeyAWSStaticAccessKeyID          = "AccessKeyID"
+	KeyAWSStaticSecretAccessKey      = "SecretAccessKey"
+	KeyAWSStaticSessionToken         = "SessionToken"
+	KeyAzureIdentityClientSecret     = "clientSecret"
+	azureJSONSecretSuffix            = "-azure-json"
 )
 
 func (c *client) GetVCCredentialsFromCluster(clusterName, clusterNamespace string) (string, string, error) {
@@ -435,3 +450,238 @@ func (c *client) GetAzureCredentialsFromSecret() (azureclient.Credentials, error
 
 	return res, nil
 }
+
+// UpdateCapaManagerBootstrapCredentialsSecret updates the AWS credentials profile used by the capa-controller-manager.
+// The update is skipped if the secret has been zeroed out so that the controller uses the EC2 instance profile.
+func (c *client) UpdateCapaManagerBootstrapCredentialsSecret(region, accessKeyID, secretAccessKey, sessionToken string) error {
+	secret := &corev1.Secret{}
+	if err := c.GetResource(secret, CAPACredentialsSecretName, CAPAControllerNamespace, nil, nil); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Infof("%s secret not present. Skipping update...", CAPACredentialsSecretName)
+			return nil
+		}
+		return errors.Wrapf(err, "unable to retrieve %s secret", CAPACredentialsSecretName)
+	}
+
+	if strings.TrimSpace(string(secret.Data[KeyAWSCredentials])) == "" {
+		log.Info("capa-controller-manager is using the EC2 instance profile. Skipping update of bootstrap credentials...")
+		return nil
+	}
+
+	profile, err := awscreds.AWSCredentials{
+		Region:          region,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		SessionToken:    sessionToken,
+	}.RenderAWSDefaultProfile()
+	if err != nil {
+		return errors.Wrap(err, "unable to render AWS credentials")
+	}
+	secret.Data[KeyAWSCredentials] = []byte(profile)
+
+	log.V(4).Info("Updating capa-manager bootstrap credentials")
+
+	if err := c.UpdateResource(secret, CAPACredentialsSecretName, CAPAControllerNamespace); err != nil {
+		return errors.Wrapf(err, "unable to save %s secret", CAPACredentialsSecretName)
+	}
+	return nil
+}
+
+// UpdateAWSClusterStaticIdentitySecret updates the secret referenced by the AWSClusterStaticIdentity of a cluster.
+// The update is skipped if the cluster does not use an AWSClusterStaticIdentity.
+func (c *client) UpdateAWSClusterStaticIdentitySecret(clusterName, namespace, accessKeyID, secretAccessKey, sessionToken string) error {
+	awsCluster := &capav1beta2.AWSCluster{}
+	if err := c.GetResource(awsCluster, clusterName, namespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to retrieve AWSCluster %s/%s", namespace, clusterName)
+	}
+
+	identityRef := awsCluster.Spec.IdentityRef
+	if identityRef == nil || identityRef.Kind != capav1beta2.ClusterStaticIdentityKind {
+		log.V(4).Infof("AWSCluster %s/%s does not use an AWSClusterStaticIdentity. Skipping update...", namespace, clusterName)
+		return nil
+	}
+
+	identity := &capav1beta2.AWSClusterStaticIdentity{}
+	if err := c.GetResource(identity, identityRef.Name, "", nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to retrieve AWSClusterStaticIdentity %s", identityRef.Name)
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.GetResource(secret, identity.Spec.SecretRef, CAPAControllerNamespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to retrieve AWSClusterStaticIdentity secret %s/%s", CAPAControllerNamespace, identity.Spec.SecretRef)
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[KeyAWSStaticAccessKeyID] = []byte(accessKeyID)
+	secret.Data[KeyAWSStaticSecretAccessKey] = []byte(secretAccessKey)
+	secret.Data[KeyAWSStaticSessionToken] = []byte(sessionToken)
+
+	if err := c.UpdateResource(secret, identity.Spec.SecretRef, CAPAControllerNamespace); err != nil {
+		return errors.Wrap(err, "unable to save AWSClusterStaticIdentity secret")
+	}
+	return nil
+}
+
+// UpdateCapzManagerBootstrapCredentialsSecret updates the service principal used by the capz-controller-manager.
+// The capz-controller-manager reads the secret into environment variables at startup, so it is restarted to use
+// the new service principal.
+func (c *client) UpdateCapzManagerBootstrapCredentialsSecret(clientID, clientSecret string) error {
+	secret := &corev1.Secret{}
+	if err := c.GetResource(secret, AzureBootstrapCredentialsSecret, CapzNamespace, nil, nil); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Infof("%s secret not present. Skipping update...", AzureBootstrapCredentialsSecret)
+			return nil
+		}
+		return errors.Wrapf(err, "unable to retrieve %s secret", AzureBootstrapCredentialsSecret)
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if clientID != "" {
+		secret.Data[KeyAzureClientID] = []byte(clientID)
+	}
+	secret.Data[KeyAzureClientSecret] = []byte(clientSecret)
+
+	log.V(4).Info("Updating capz-manager bootstrap credentials")
+
+	if err := c.UpdateResource(secret, AzureBootstrapCredentialsSecret, CapzNamespace); err != nil {
+		return errors.Wrapf(err, "unable to save %s secret", AzureBootstrapCredentialsSecret)
+	}
+
+	return c.restartDeployment(CapzControllerDeploymentName, CapzNamespace)
+}
+
+// restartDeployment restarts the pods of a deployment, as 'kubectl rollout restart' does, and waits for the
+// rollout to complete. The restart is skipped if the deployment does not exist.
+func (c *client) restartDeployment(deploymentName, namespace string) error {
+	deployment := &appsv1.Deployment{}
+	if err := c.GetResource(deployment, deploymentName, namespace, nil, nil); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Warningf("%s/%s deployment not present. Skipping restart...", namespace, deploymentName)
+			return nil
+		}
+		return errors.Wrapf(err, "unable to retrieve %s/%s deployment", namespace, deploymentName)
+	}
+
+	log.Infof("Restarting %s/%s deployment", namespace, deploymentName)
+	restartPatch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, constants.RestartedAtAnnotation, time.Now().Format(time.RFC3339))
+	if err := c.PatchResource(deployment, deploymentName, namespace, restartPatch, types.MergePatchType, nil); err != nil {
+		return errors.Wrapf(err, "unable to restart %s/%s deployment", namespace, deploymentName)
+	}
+
+	pollOptions := &PollOptions{Interval: CheckResourceInterval, Timeout: c.operationTimeout}
+	if err := c.GetResource(deployment, deploymentName, namespace, VerifyDeploymentRolledOut, pollOptions); err != nil {
+		return errors.Wrapf(err, "%s/%s deployment did not finish restarting", namespace, deploymentName)
+	}
+	return nil
+}
+
+// UpdateAzureClusterIdentitySecret updates the client secret of the AzureClusterIdentity used by a cluster,
+// as well as its client ID if one is provided.
+// The update is skipped if the cluster does not reference an AzureClusterIdentity.
+func (c *client) UpdateAzureClusterIdentitySecret(clusterName, namespace, clientID, clientSecret string) error {
+	azureCluster := &capzv1beta1.AzureCluster{}
+	if err := c.GetResource(azureCluster, clusterName, namespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to retrieve AzureCluster %s/%s", namespace, clusterName)
+	}
+
+	identityRef := azureCluster.Spec.IdentityRef
+	if identityRef == nil {
+		log.V(4).Infof("AzureCluster %s/%s does not reference an AzureClusterIdentity. Skipping update...", namespace, clusterName)
+		return nil
+	}
+	// the AzureClusterIdentity defaults to the namespace of the AzureCluster
+	identityNamespace := identityRef.Namespace
+	if identityNamespace == "" {
+		identityNamespace = azureCluster.Namespace
+	}
+
+	identity := &capzv1beta1.AzureClusterIdentity{}
+	if err := c.GetResource(identity, identityRef.Name, identityNamespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to retrieve AzureClusterIdentity %s/%s", identityNamespace, identityRef.Name)
+	}
+
+	if clientID != "" && identity.Spec.ClientID != clientID {
+		identity.Spec.ClientID = clientID
+		if err := c.UpdateResource(identity, identity.Name, identity.Namespace); err != nil {
+			return errors.Wrap(err, "unable to save AzureClusterIdentity")
+		}
+	}
+
+	// the secret of the AzureClusterIdentity defaults to the namespace of the AzureClusterIdentity
+	secretName := identity.Spec.ClientSecret.Name
+	secretNamespace := identity.Spec.ClientSecret.Namespace
+	if secretNamespace == "" {
+		secretNamespace = identity.Namespace
+	}
+	secret := &corev1.Secret{}
+	if err := c.GetResource(secret, secretName, secretNamespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to retrieve AzureClusterIdentity secret %s/%s", secretNamespace, secretName)
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[KeyAzureIdentityClientSecret] = []byte(clientSecret)
+
+	if err := c.UpdateResource(secret, secretName, secretNamespace); err != nil {
+		return errors.Wrap(err, "unable to save AzureClusterIdentity secret")
+	}
+	return nil
+}
+
+// UpdateAzureJSONSecrets updates the service principal in the azure.json cloud provider configuration
+// rendered for the machines of a cluster, so that newly created nodes use the new credentials.
+func (c *client) UpdateAzureJSONSecrets(clusterName, namespace, clientID, clientSecret string) error {
+	secretList := &corev1.SecretList{}
+	if err := c.ListResources(secretList, crtclient.InNamespace(namespace), crtclient.MatchingLabels{capi.ClusterLabelName: clusterName}); err != nil {
+		return errors.Wrap(err, "unable to list azure.json secrets")
+	}
+
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		if !strings.HasSuffix(secret.Name, azureJSONSecretSuffix) {
+			continue
+		}
+
+		updated := false
+		for key, value := range secret.Data {
+			if !strings.HasSuffix(key, ".json") {
+				continue
+			}
+			azureJSON, err := updateAzureJSONCredentials(value, clientID, clientSecret)
+			if err != nil {
+				return errors.Wrapf(err, "unable to update %s in secret %s", key, secret.Name)
+			}
+			secret.Data[key] = azureJSON
+			updated = true
+		}
+		if !updated {
+			continue
+		}
+
+		log.V(4).Infof("Updating azure.json secret %s", secret.Name)
+
+		if err := c.UpdateResource(secret, secret.Name, secret.Namespace); err != nil {
+			return errors.Wrapf(err, "unable to save azure.json secret %s", secret.Name)
+		}
+	}
+	return nil
+}
+
+func updateAzureJSONCredentials(azureJSON []byte, clientID, clientSecret string) ([]byte, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal(azureJSON, &config); err != nil {
+		return nil, err
+	}
+
+	if clientID != "" {
+		config["aadClientId"] = clientID
+	}
+	config["aadClientSecret"] = clientSecret
+
+	return json.MarshalIndent(config, "", "    ")
+}
@@ -0,0 +1,31 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterclient
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+func (c *client) GetPodLogs(podName, namespace, containerName string, tailLines int64) ([]byte, error) {
+	restConfig, err := c.GetRestConfigClient()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create kubernetes clientset")
+	}
+
+	logOptions := &corev1.PodLogOptions{Container: containerName}
+	if tailLines > 0 {
+		logOptions.TailLines = &tailLines
+	}
+	logs, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, logOptions).Do(ctx).Raw()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get logs of container %s in pod %s/%s", containerName, namespace, podName)
+	}
+	return logs, nil
+}
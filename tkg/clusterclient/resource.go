@@ -10,6 +10,7 @@ import (
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -42,6 +43,14 @@ func (c *client) ListResources(resourceReference interface{}, option ...crtclien
 	return nil
 }
 
+func (c *client) GetPreferredGroupVersionKind(groupKind schema.GroupKind) (schema.GroupVersionKind, error) {
+	mapping, err := c.clientSet.RESTMapper().RESTMapping(groupKind)
+	if err != nil {
+		return schema.GroupVersionKind{}, errors.Wrapf(err, "failed to get the preferred version of %s", groupKind)
+	}
+	return mapping.GroupVersionKind, nil
+}
+
 func (c *client) DeleteResource(resourceReference interface{}) error {
 	obj, err := getRuntimeObject(resourceReference)
 	if err != nil {
@@ -283,6 +292,24 @@ func VerifyDeploymentAvailable(obj crtclient.Object) error {
 	return nil
 }
 
+// VerifyDeploymentRolledOut verifies that all the replicas of a deployment run its latest pod template
+func VerifyDeploymentRolledOut(obj crtclient.Object) error {
+	switch deployment := obj.(type) {
+	case *appsv1.Deployment:
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		if deployment.Status.ObservedGeneration < deployment.Generation || deployment.Status.UpdatedReplicas != replicas ||
+			deployment.Status.AvailableReplicas != replicas || deployment.Status.Replicas != replicas {
+			return errors.Errorf("rollout of deployment '%s' in namespace '%s' is not complete yet", deployment.Name, deployment.Namespace)
+		}
+	default:
+		return errors.Errorf("invalid type: %s during VerifyDeploymentRolledOut", reflect.TypeOf(deployment))
+	}
+	return nil
+}
+
 // VerifyAutoscalerDeploymentAvailable verifies autoscaler deployment's availability
 func VerifyAutoscalerDeploymentAvailable(obj crtclient.Object) error {
 	switch deployment := obj.(type) {
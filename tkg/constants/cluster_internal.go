@@ -74,6 +74,17 @@ const (
 	TKGDataValueFormatString = "#@data/values\n#@overlay/match-child-defaults missing_ok=True\n---\n"
 
 	CAPVClusterSelectorKey = "capv.vmware.com/cluster.name"
+
+	// MachineCertificatesExpiryAnnotation can be set by users on a control plane machine to override the expiry date
+	// of its kubeadm certificates, which is otherwise tracked by the status of the machine
+	MachineCertificatesExpiryAnnotation = "machine.cluster.x-k8s.io/certificates-expiry"
+
+	// CredentialsUpdatedAtAnnotation is the time the infrastructure credentials of a cluster were last updated.
+	// Updating it triggers the reconciliation of the addon configs deriving data values from the credentials
+	CredentialsUpdatedAtAnnotation = "tkg.tanzu.vmware.com/credentials-updated-at"
+
+	// RestartedAtAnnotation is the pod template annotation set by 'kubectl rollout restart' to restart the pods of a deployment
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
 )
 
 // deployment plan constants
@@ -170,6 +181,7 @@ const (
 	KindCluster                     = "Cluster"
 	KindTanzuKubernetesCluster      = "TanzuKubernetesCluster"
 	KindClusterClass                = "ClusterClass"
+	KindKubeadmControlPlane         = "KubeadmControlPlane"
 	KindKubeadmControlPlaneTemplate = "KubeadmControlPlaneTemplate"
 	KindKubeadmConfigTemplate       = "KubeadmConfigTemplate"
 	KindAWSClusterTemplate          = "AWSClusterTemplate"
@@ -179,6 +191,7 @@ const (
 	KindVSphereClusterTemplate      = "VSphereClusterTemplate"
 	KindVSphereMachineTemplate      = "VSphereMachineTemplate"
 	KindDockerMachineTemplate       = "DockerMachineTemplate"
+	KindMachineHealthCheck          = "MachineHealthCheck"
 )
 
 // Resources constants
@@ -12,6 +12,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha2"
+	v1alpha3b "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
@@ -44,6 +45,19 @@ type Client struct {
 	addRegionContextReturnsOnCall map[int]struct {
 		result1 error
 	}
+	BackupManagementClusterStub        func(*client.BackupManagementClusterOptions) (*client.ManagementClusterBackup, error)
+	backupManagementClusterMutex       sync.RWMutex
+	backupManagementClusterArgsForCall []struct {
+		arg1 *client.BackupManagementClusterOptions
+	}
+	backupManagementClusterReturns struct {
+		result1 *client.ManagementClusterBackup
+		result2 error
+	}
+	backupManagementClusterReturnsOnCall map[int]struct {
+		result1 *client.ManagementClusterBackup
+		result2 error
+	}
 	ConfigureAndValidateManagementClusterConfigurationStub        func(*client.InitRegionOptions, bool) *client.ValidationError
 	configureAndValidateManagementClusterConfigurationMutex       sync.RWMutex
 	configureAndValidateManagementClusterConfigurationArgsForCall []struct {
@@ -184,6 +198,32 @@ type Client struct {
 		result1 *v1alpha3.ProviderList
 		result2 error
 	}
+	DiagnoseClusterStub        func(*client.DiagnoseClusterOptions) (*client.DiagnosticsIndex, error)
+	diagnoseClusterMutex       sync.RWMutex
+	diagnoseClusterArgsForCall []struct {
+		arg1 *client.DiagnoseClusterOptions
+	}
+	diagnoseClusterReturns struct {
+		result1 *client.DiagnosticsIndex
+		result2 error
+	}
+	diagnoseClusterReturnsOnCall map[int]struct {
+		result1 *client.DiagnosticsIndex
+		result2 error
+	}
+	DiffClusterStub        func(*client.DiffClusterOptions) (*client.ClusterDiff, error)
+	diffClusterMutex       sync.RWMutex
+	diffClusterArgsForCall []struct {
+		arg1 *client.DiffClusterOptions
+	}
+	diffClusterReturns struct {
+		result1 *client.ClusterDiff
+		result2 error
+	}
+	diffClusterReturnsOnCall map[int]struct {
+		result1 *client.ClusterDiff
+		result2 error
+	}
 	DownloadBomFileStub        func(string) error
 	downloadBomFileMutex       sync.RWMutex
 	downloadBomFileArgsForCall []struct {
@@ -219,6 +259,19 @@ type Client struct {
 		result1 client.ClusterCeipInfo
 		result2 error
 	}
+	GetClusterCertificatesStub        func(client.ClusterCertificatesOptions) (*client.ClusterCertificates, error)
+	getClusterCertificatesMutex       sync.RWMutex
+	getClusterCertificatesArgsForCall []struct {
+		arg1 client.ClusterCertificatesOptions
+	}
+	getClusterCertificatesReturns struct {
+		result1 *client.ClusterCertificates
+		result2 error
+	}
+	getClusterCertificatesReturnsOnCall map[int]struct {
+		result1 *client.ClusterCertificates
+		result2 error
+	}
 	GetClusterConfigurationStub        func(*client.CreateClusterOptions) ([]byte, error)
 	getClusterConfigurationMutex       sync.RWMutex
 	getClusterConfigurationArgsForCall []struct {
@@ -232,6 +285,19 @@ type Client struct {
 		result1 []byte
 		result2 error
 	}
+	GetClusterPackagesStub        func(client.ClusterPackagesOptions) ([]v1alpha3b.ClusterBootstrapPackageStatus, error)
+	getClusterPackagesMutex       sync.RWMutex
+	getClusterPackagesArgsForCall []struct {
+		arg1 client.ClusterPackagesOptions
+	}
+	getClusterPackagesReturns struct {
+		result1 []v1alpha3b.ClusterBootstrapPackageStatus
+		result2 error
+	}
+	getClusterPackagesReturnsOnCall map[int]struct {
+		result1 []v1alpha3b.ClusterBootstrapPackageStatus
+		result2 error
+	}
 	GetClusterPinnipedInfoStub        func(client.GetClusterPinnipedInfoOptions) (*client.ClusterPinnipedInfo, error)
 	getClusterPinnipedInfoMutex       sync.RWMutex
 	getClusterPinnipedInfoArgsForCall []struct {
@@ -466,6 +532,56 @@ type Client struct {
 	parseHiddenArgsAsFeatureFlagsArgsForCall []struct {
 		arg1 *client.InitRegionOptions
 	}
+	PlanFleetUpgradeStub        func(*client.FleetUpgradeOptions) (*client.FleetUpgradePlan, error)
+	planFleetUpgradeMutex       sync.RWMutex
+	planFleetUpgradeArgsForCall []struct {
+		arg1 *client.FleetUpgradeOptions
+	}
+	planFleetUpgradeReturns struct {
+		result1 *client.FleetUpgradePlan
+		result2 error
+	}
+	planFleetUpgradeReturnsOnCall map[int]struct {
+		result1 *client.FleetUpgradePlan
+		result2 error
+	}
+	RestoreManagementClusterStub        func(*client.RestoreManagementClusterOptions) (*client.ManagementClusterBackup, error)
+	restoreManagementClusterMutex       sync.RWMutex
+	restoreManagementClusterArgsForCall []struct {
+		arg1 *client.RestoreManagementClusterOptions
+	}
+	restoreManagementClusterReturns struct {
+		result1 *client.ManagementClusterBackup
+		result2 error
+	}
+	restoreManagementClusterReturnsOnCall map[int]struct {
+		result1 *client.ManagementClusterBackup
+		result2 error
+	}
+	RotateClusterCertificatesStub        func(client.ClusterCertificatesOptions) error
+	rotateClusterCertificatesMutex       sync.RWMutex
+	rotateClusterCertificatesArgsForCall []struct {
+		arg1 client.ClusterCertificatesOptions
+	}
+	rotateClusterCertificatesReturns struct {
+		result1 error
+	}
+	rotateClusterCertificatesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RunPreflightChecksStub        func(*client.PreflightOptions) (*client.PreflightReport, error)
+	runPreflightChecksMutex       sync.RWMutex
+	runPreflightChecksArgsForCall []struct {
+		arg1 *client.PreflightOptions
+	}
+	runPreflightChecksReturns struct {
+		result1 *client.PreflightReport
+		result2 error
+	}
+	runPreflightChecksReturnsOnCall map[int]struct {
+		result1 *client.PreflightReport
+		result2 error
+	}
 	SaveFeatureFlagsStub        func(map[string]string) error
 	saveFeatureFlagsMutex       sync.RWMutex
 	saveFeatureFlagsArgsForCall []struct {
@@ -578,6 +694,18 @@ type Client struct {
 	upgradeClusterReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpgradeFleetStub        func(*client.FleetUpgradeOptions, *client.FleetUpgradePlan) error
+	upgradeFleetMutex       sync.RWMutex
+	upgradeFleetArgsForCall []struct {
+		arg1 *client.FleetUpgradeOptions
+		arg2 *client.FleetUpgradePlan
+	}
+	upgradeFleetReturns struct {
+		result1 error
+	}
+	upgradeFleetReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpgradeManagementClusterStub        func(*client.UpgradeClusterOptions) error
 	upgradeManagementClusterMutex       sync.RWMutex
 	upgradeManagementClusterArgsForCall []struct {
@@ -752,6 +880,70 @@ func (fake *Client) AddRegionContextReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Client) BackupManagementCluster(arg1 *client.BackupManagementClusterOptions) (*client.ManagementClusterBackup, error) {
+	fake.backupManagementClusterMutex.Lock()
+	ret, specificReturn := fake.backupManagementClusterReturnsOnCall[len(fake.backupManagementClusterArgsForCall)]
+	fake.backupManagementClusterArgsForCall = append(fake.backupManagementClusterArgsForCall, struct {
+		arg1 *client.BackupManagementClusterOptions
+	}{arg1})
+	stub := fake.BackupManagementClusterStub
+	fakeReturns := fake.backupManagementClusterReturns
+	fake.recordInvocation("BackupManagementCluster", []interface{}{arg1})
+	fake.backupManagementClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) BackupManagementClusterCallCount() int {
+	fake.backupManagementClusterMutex.RLock()
+	defer fake.backupManagementClusterMutex.RUnlock()
+	return len(fake.backupManagementClusterArgsForCall)
+}
+
+func (fake *Client) BackupManagementClusterCalls(stub func(*client.BackupManagementClusterOptions) (*client.ManagementClusterBackup, error)) {
+	fake.backupManagementClusterMutex.Lock()
+	defer fake.backupManagementClusterMutex.Unlock()
+	fake.BackupManagementClusterStub = stub
+}
+
+func (fake *Client) BackupManagementClusterArgsForCall(i int) *client.BackupManagementClusterOptions {
+	fake.backupManagementClusterMutex.RLock()
+	defer fake.backupManagementClusterMutex.RUnlock()
+	argsForCall := fake.backupManagementClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) BackupManagementClusterReturns(result1 *client.ManagementClusterBackup, result2 error) {
+	fake.backupManagementClusterMutex.Lock()
+	defer fake.backupManagementClusterMutex.Unlock()
+	fake.BackupManagementClusterStub = nil
+	fake.backupManagementClusterReturns = struct {
+		result1 *client.ManagementClusterBackup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) BackupManagementClusterReturnsOnCall(i int, result1 *client.ManagementClusterBackup, result2 error) {
+	fake.backupManagementClusterMutex.Lock()
+	defer fake.backupManagementClusterMutex.Unlock()
+	fake.BackupManagementClusterStub = nil
+	if fake.backupManagementClusterReturnsOnCall == nil {
+		fake.backupManagementClusterReturnsOnCall = make(map[int]struct {
+			result1 *client.ManagementClusterBackup
+			result2 error
+		})
+	}
+	fake.backupManagementClusterReturnsOnCall[i] = struct {
+		result1 *client.ManagementClusterBackup
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) ConfigureAndValidateManagementClusterConfiguration(arg1 *client.InitRegionOptions, arg2 bool) *client.ValidationError {
 	fake.configureAndValidateManagementClusterConfigurationMutex.Lock()
 	ret, specificReturn := fake.configureAndValidateManagementClusterConfigurationReturnsOnCall[len(fake.configureAndValidateManagementClusterConfigurationArgsForCall)]
@@ -1462,6 +1654,134 @@ func (fake *Client) DescribeProviderReturnsOnCall(i int, result1 *v1alpha3.Provi
 	}{result1, result2}
 }
 
+func (fake *Client) DiagnoseCluster(arg1 *client.DiagnoseClusterOptions) (*client.DiagnosticsIndex, error) {
+	fake.diagnoseClusterMutex.Lock()
+	ret, specificReturn := fake.diagnoseClusterReturnsOnCall[len(fake.diagnoseClusterArgsForCall)]
+	fake.diagnoseClusterArgsForCall = append(fake.diagnoseClusterArgsForCall, struct {
+		arg1 *client.DiagnoseClusterOptions
+	}{arg1})
+	stub := fake.DiagnoseClusterStub
+	fakeReturns := fake.diagnoseClusterReturns
+	fake.recordInvocation("DiagnoseCluster", []interface{}{arg1})
+	fake.diagnoseClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) DiagnoseClusterCallCount() int {
+	fake.diagnoseClusterMutex.RLock()
+	defer fake.diagnoseClusterMutex.RUnlock()
+	return len(fake.diagnoseClusterArgsForCall)
+}
+
+func (fake *Client) DiagnoseClusterCalls(stub func(*client.DiagnoseClusterOptions) (*client.DiagnosticsIndex, error)) {
+	fake.diagnoseClusterMutex.Lock()
+	defer fake.diagnoseClusterMutex.Unlock()
+	fake.DiagnoseClusterStub = stub
+}
+
+func (fake *Client) DiagnoseClusterArgsForCall(i int) *client.DiagnoseClusterOptions {
+	fake.diagnoseClusterMutex.RLock()
+	defer fake.diagnoseClusterMutex.RUnlock()
+	argsForCall := fake.diagnoseClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) DiagnoseClusterReturns(result1 *client.DiagnosticsIndex, result2 error) {
+	fake.diagnoseClusterMutex.Lock()
+	defer fake.diagnoseClusterMutex.Unlock()
+	fake.DiagnoseClusterStub = nil
+	fake.diagnoseClusterReturns = struct {
+		result1 *client.DiagnosticsIndex
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) DiagnoseClusterReturnsOnCall(i int, result1 *client.DiagnosticsIndex, result2 error) {
+	fake.diagnoseClusterMutex.Lock()
+	defer fake.diagnoseClusterMutex.Unlock()
+	fake.DiagnoseClusterStub = nil
+	if fake.diagnoseClusterReturnsOnCall == nil {
+		fake.diagnoseClusterReturnsOnCall = make(map[int]struct {
+			result1 *client.DiagnosticsIndex
+			result2 error
+		})
+	}
+	fake.diagnoseClusterReturnsOnCall[i] = struct {
+		result1 *client.DiagnosticsIndex
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) DiffCluster(arg1 *client.DiffClusterOptions) (*client.ClusterDiff, error) {
+	fake.diffClusterMutex.Lock()
+	ret, specificReturn := fake.diffClusterReturnsOnCall[len(fake.diffClusterArgsForCall)]
+	fake.diffClusterArgsForCall = append(fake.diffClusterArgsForCall, struct {
+		arg1 *client.DiffClusterOptions
+	}{arg1})
+	stub := fake.DiffClusterStub
+	fakeReturns := fake.diffClusterReturns
+	fake.recordInvocation("DiffCluster", []interface{}{arg1})
+	fake.diffClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) DiffClusterCallCount() int {
+	fake.diffClusterMutex.RLock()
+	defer fake.diffClusterMutex.RUnlock()
+	return len(fake.diffClusterArgsForCall)
+}
+
+func (fake *Client) DiffClusterCalls(stub func(*client.DiffClusterOptions) (*client.ClusterDiff, error)) {
+	fake.diffClusterMutex.Lock()
+	defer fake.diffClusterMutex.Unlock()
+	fake.DiffClusterStub = stub
+}
+
+func (fake *Client) DiffClusterArgsForCall(i int) *client.DiffClusterOptions {
+	fake.diffClusterMutex.RLock()
+	defer fake.diffClusterMutex.RUnlock()
+	argsForCall := fake.diffClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) DiffClusterReturns(result1 *client.ClusterDiff, result2 error) {
+	fake.diffClusterMutex.Lock()
+	defer fake.diffClusterMutex.Unlock()
+	fake.DiffClusterStub = nil
+	fake.diffClusterReturns = struct {
+		result1 *client.ClusterDiff
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) DiffClusterReturnsOnCall(i int, result1 *client.ClusterDiff, result2 error) {
+	fake.diffClusterMutex.Lock()
+	defer fake.diffClusterMutex.Unlock()
+	fake.DiffClusterStub = nil
+	if fake.diffClusterReturnsOnCall == nil {
+		fake.diffClusterReturnsOnCall = make(map[int]struct {
+			result1 *client.ClusterDiff
+			result2 error
+		})
+	}
+	fake.diffClusterReturnsOnCall[i] = struct {
+		result1 *client.ClusterDiff
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) DownloadBomFile(arg1 string) error {
 	fake.downloadBomFileMutex.Lock()
 	ret, specificReturn := fake.downloadBomFileReturnsOnCall[len(fake.downloadBomFileArgsForCall)]
@@ -1635,6 +1955,70 @@ func (fake *Client) GetCEIPParticipationReturnsOnCall(i int, result1 client.Clus
 	}{result1, result2}
 }
 
+func (fake *Client) GetClusterCertificates(arg1 client.ClusterCertificatesOptions) (*client.ClusterCertificates, error) {
+	fake.getClusterCertificatesMutex.Lock()
+	ret, specificReturn := fake.getClusterCertificatesReturnsOnCall[len(fake.getClusterCertificatesArgsForCall)]
+	fake.getClusterCertificatesArgsForCall = append(fake.getClusterCertificatesArgsForCall, struct {
+		arg1 client.ClusterCertificatesOptions
+	}{arg1})
+	stub := fake.GetClusterCertificatesStub
+	fakeReturns := fake.getClusterCertificatesReturns
+	fake.recordInvocation("GetClusterCertificates", []interface{}{arg1})
+	fake.getClusterCertificatesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetClusterCertificatesCallCount() int {
+	fake.getClusterCertificatesMutex.RLock()
+	defer fake.getClusterCertificatesMutex.RUnlock()
+	return len(fake.getClusterCertificatesArgsForCall)
+}
+
+func (fake *Client) GetClusterCertificatesCalls(stub func(client.ClusterCertificatesOptions) (*client.ClusterCertificates, error)) {
+	fake.getClusterCertificatesMutex.Lock()
+	defer fake.getClusterCertificatesMutex.Unlock()
+	fake.GetClusterCertificatesStub = stub
+}
+
+func (fake *Client) GetClusterCertificatesArgsForCall(i int) client.ClusterCertificatesOptions {
+	fake.getClusterCertificatesMutex.RLock()
+	defer fake.getClusterCertificatesMutex.RUnlock()
+	argsForCall := fake.getClusterCertificatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetClusterCertificatesReturns(result1 *client.ClusterCertificates, result2 error) {
+	fake.getClusterCertificatesMutex.Lock()
+	defer fake.getClusterCertificatesMutex.Unlock()
+	fake.GetClusterCertificatesStub = nil
+	fake.getClusterCertificatesReturns = struct {
+		result1 *client.ClusterCertificates
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetClusterCertificatesReturnsOnCall(i int, result1 *client.ClusterCertificates, result2 error) {
+	fake.getClusterCertificatesMutex.Lock()
+	defer fake.getClusterCertificatesMutex.Unlock()
+	fake.GetClusterCertificatesStub = nil
+	if fake.getClusterCertificatesReturnsOnCall == nil {
+		fake.getClusterCertificatesReturnsOnCall = make(map[int]struct {
+			result1 *client.ClusterCertificates
+			result2 error
+		})
+	}
+	fake.getClusterCertificatesReturnsOnCall[i] = struct {
+		result1 *client.ClusterCertificates
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) GetClusterConfiguration(arg1 *client.CreateClusterOptions) ([]byte, error) {
 	fake.getClusterConfigurationMutex.Lock()
 	ret, specificReturn := fake.getClusterConfigurationReturnsOnCall[len(fake.getClusterConfigurationArgsForCall)]
@@ -1699,6 +2083,70 @@ func (fake *Client) GetClusterConfigurationReturnsOnCall(i int, result1 []byte,
 	}{result1, result2}
 }
 
+func (fake *Client) GetClusterPackages(arg1 client.ClusterPackagesOptions) ([]v1alpha3b.ClusterBootstrapPackageStatus, error) {
+	fake.getClusterPackagesMutex.Lock()
+	ret, specificReturn := fake.getClusterPackagesReturnsOnCall[len(fake.getClusterPackagesArgsForCall)]
+	fake.getClusterPackagesArgsForCall = append(fake.getClusterPackagesArgsForCall, struct {
+		arg1 client.ClusterPackagesOptions
+	}{arg1})
+	stub := fake.GetClusterPackagesStub
+	fakeReturns := fake.getClusterPackagesReturns
+	fake.recordInvocation("GetClusterPackages", []interface{}{arg1})
+	fake.getClusterPackagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetClusterPackagesCallCount() int {
+	fake.getClusterPackagesMutex.RLock()
+	defer fake.getClusterPackagesMutex.RUnlock()
+	return len(fake.getClusterPackagesArgsForCall)
+}
+
+func (fake *Client) GetClusterPackagesCalls(stub func(client.ClusterPackagesOptions) ([]v1alpha3b.ClusterBootstrapPackageStatus, error)) {
+	fake.getClusterPackagesMutex.Lock()
+	defer fake.getClusterPackagesMutex.Unlock()
+	fake.GetClusterPackagesStub = stub
+}
+
+func (fake *Client) GetClusterPackagesArgsForCall(i int) client.ClusterPackagesOptions {
+	fake.getClusterPackagesMutex.RLock()
+	defer fake.getClusterPackagesMutex.RUnlock()
+	argsForCall := fake.getClusterPackagesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetClusterPackagesReturns(result1 []v1alpha3b.ClusterBootstrapPackageStatus, result2 error) {
+	fake.getClusterPackagesMutex.Lock()
+	defer fake.getClusterPackagesMutex.Unlock()
+	fake.GetClusterPackagesStub = nil
+	fake.getClusterPackagesReturns = struct {
+		result1 []v1alpha3b.ClusterBootstrapPackageStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetClusterPackagesReturnsOnCall(i int, result1 []v1alpha3b.ClusterBootstrapPackageStatus, result2 error) {
+	fake.getClusterPackagesMutex.Lock()
+	defer fake.getClusterPackagesMutex.Unlock()
+	fake.GetClusterPackagesStub = nil
+	if fake.getClusterPackagesReturnsOnCall == nil {
+		fake.getClusterPackagesReturnsOnCall = make(map[int]struct {
+			result1 []v1alpha3b.ClusterBootstrapPackageStatus
+			result2 error
+		})
+	}
+	fake.getClusterPackagesReturnsOnCall[i] = struct {
+		result1 []v1alpha3b.ClusterBootstrapPackageStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) GetClusterPinnipedInfo(arg1 client.GetClusterPinnipedInfoOptions) (*client.ClusterPinnipedInfo, error) {
 	fake.getClusterPinnipedInfoMutex.Lock()
 	ret, specificReturn := fake.getClusterPinnipedInfoReturnsOnCall[len(fake.getClusterPinnipedInfoArgsForCall)]
@@ -2849,6 +3297,259 @@ func (fake *Client) ParseHiddenArgsAsFeatureFlagsArgsForCall(i int) *client.Init
 	return argsForCall.arg1
 }
 
+func (fake *Client) PlanFleetUpgrade(arg1 *client.FleetUpgradeOptions) (*client.FleetUpgradePlan, error) {
+	fake.planFleetUpgradeMutex.Lock()
+	ret, specificReturn := fake.planFleetUpgradeReturnsOnCall[len(fake.planFleetUpgradeArgsForCall)]
+	fake.planFleetUpgradeArgsForCall = append(fake.planFleetUpgradeArgsForCall, struct {
+		arg1 *client.FleetUpgradeOptions
+	}{arg1})
+	stub := fake.PlanFleetUpgradeStub
+	fakeReturns := fake.planFleetUpgradeReturns
+	fake.recordInvocation("PlanFleetUpgrade", []interface{}{arg1})
+	fake.planFleetUpgradeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) PlanFleetUpgradeCallCount() int {
+	fake.planFleetUpgradeMutex.RLock()
+	defer fake.planFleetUpgradeMutex.RUnlock()
+	return len(fake.planFleetUpgradeArgsForCall)
+}
+
+func (fake *Client) PlanFleetUpgradeCalls(stub func(*client.FleetUpgradeOptions) (*client.FleetUpgradePlan, error)) {
+	fake.planFleetUpgradeMutex.Lock()
+	defer fake.planFleetUpgradeMutex.Unlock()
+	fake.PlanFleetUpgradeStub = stub
+}
+
+func (fake *Client) PlanFleetUpgradeArgsForCall(i int) *client.FleetUpgradeOptions {
+	fake.planFleetUpgradeMutex.RLock()
+	defer fake.planFleetUpgradeMutex.RUnlock()
+	argsForCall := fake.planFleetUpgradeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) PlanFleetUpgradeReturns(result1 *client.FleetUpgradePlan, result2 error) {
+	fake.planFleetUpgradeMutex.Lock()
+	defer fake.planFleetUpgradeMutex.Unlock()
+	fake.PlanFleetUpgradeStub = nil
+	fake.planFleetUpgradeReturns = struct {
+		result1 *client.FleetUpgradePlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) PlanFleetUpgradeReturnsOnCall(i int, result1 *client.FleetUpgradePlan, result2 error) {
+	fake.planFleetUpgradeMutex.Lock()
+	defer fake.planFleetUpgradeMutex.Unlock()
+	fake.PlanFleetUpgradeStub = nil
+	if fake.planFleetUpgradeReturnsOnCall == nil {
+		fake.planFleetUpgradeReturnsOnCall = make(map[int]struct {
+			result1 *client.FleetUpgradePlan
+			result2 error
+		})
+	}
+	fake.planFleetUpgradeReturnsOnCall[i] = struct {
+		result1 *client.FleetUpgradePlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) RestoreManagementCluster(arg1 *client.RestoreManagementClusterOptions) (*client.ManagementClusterBackup, error) {
+	fake.restoreManagementClusterMutex.Lock()
+	ret, specificReturn := fake.restoreManagementClusterReturnsOnCall[len(fake.restoreManagementClusterArgsForCall)]
+	fake.restoreManagementClusterArgsForCall = append(fake.restoreManagementClusterArgsForCall, struct {
+		arg1 *client.RestoreManagementClusterOptions
+	}{arg1})
+	stub := fake.RestoreManagementClusterStub
+	fakeReturns := fake.restoreManagementClusterReturns
+	fake.recordInvocation("RestoreManagementCluster", []interface{}{arg1})
+	fake.restoreManagementClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) RestoreManagementClusterCallCount() int {
+	fake.restoreManagementClusterMutex.RLock()
+	defer fake.restoreManagementClusterMutex.RUnlock()
+	return len(fake.restoreManagementClusterArgsForCall)
+}
+
+func (fake *Client) RestoreManagementClusterCalls(stub func(*client.RestoreManagementClusterOptions) (*client.ManagementClusterBackup, error)) {
+	fake.restoreManagementClusterMutex.Lock()
+	defer fake.restoreManagementClusterMutex.Unlock()
+	fake.RestoreManagementClusterStub = stub
+}
+
+func (fake *Client) RestoreManagementClusterArgsForCall(i int) *client.RestoreManagementClusterOptions {
+	fake.restoreManagementClusterMutex.RLock()
+	defer fake.restoreManagementClusterMutex.RUnlock()
+	argsForCall := fake.restoreManagementClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) RestoreManagementClusterReturns(result1 *client.ManagementClusterBackup, result2 error) {
+	fake.restoreManagementClusterMutex.Lock()
+	defer fake.restoreManagementClusterMutex.Unlock()
+	fake.RestoreManagementClusterStub = nil
+	fake.restoreManagementClusterReturns = struct {
+		result1 *client.ManagementClusterBackup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) RestoreManagementClusterReturnsOnCall(i int, result1 *client.ManagementClusterBackup, result2 error) {
+	fake.restoreManagementClusterMutex.Lock()
+	defer fake.restoreManagementClusterMutex.Unlock()
+	fake.RestoreManagementClusterStub = nil
+	if fake.restoreManagementClusterReturnsOnCall == nil {
+		fake.restoreManagementClusterReturnsOnCall = make(map[int]struct {
+			result1 *client.ManagementClusterBackup
+			result2 error
+		})
+	}
+	fake.restoreManagementClusterReturnsOnCall[i] = struct {
+		result1 *client.ManagementClusterBackup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) RotateClusterCertificates(arg1 client.ClusterCertificatesOptions) error {
+	fake.rotateClusterCertificatesMutex.Lock()
+	ret, specificReturn := fake.rotateClusterCertificatesReturnsOnCall[len(fake.rotateClusterCertificatesArgsForCall)]
+	fake.rotateClusterCertificatesArgsForCall = append(fake.rotateClusterCertificatesArgsForCall, struct {
+		arg1 client.ClusterCertificatesOptions
+	}{arg1})
+	stub := fake.RotateClusterCertificatesStub
+	fakeReturns := fake.rotateClusterCertificatesReturns
+	fake.recordInvocation("RotateClusterCertificates", []interface{}{arg1})
+	fake.rotateClusterCertificatesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) RotateClusterCertificatesCallCount() int {
+	fake.rotateClusterCertificatesMutex.RLock()
+	defer fake.rotateClusterCertificatesMutex.RUnlock()
+	return len(fake.rotateClusterCertificatesArgsForCall)
+}
+
+func (fake *Client) RotateClusterCertificatesCalls(stub func(client.ClusterCertificatesOptions) error) {
+	fake.rotateClusterCertificatesMutex.Lock()
+	defer fake.rotateClusterCertificatesMutex.Unlock()
+	fake.RotateClusterCertificatesStub = stub
+}
+
+func (fake *Client) RotateClusterCertificatesArgsForCall(i int) client.ClusterCertificatesOptions {
+	fake.rotateClusterCertificatesMutex.RLock()
+	defer fake.rotateClusterCertificatesMutex.RUnlock()
+	argsForCall := fake.rotateClusterCertificatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) RotateClusterCertificatesReturns(result1 error) {
+	fake.rotateClusterCertificatesMutex.Lock()
+	defer fake.rotateClusterCertificatesMutex.Unlock()
+	fake.RotateClusterCertificatesStub = nil
+	fake.rotateClusterCertificatesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) RotateClusterCertificatesReturnsOnCall(i int, result1 error) {
+	fake.rotateClusterCertificatesMutex.Lock()
+	defer fake.rotateClusterCertificatesMutex.Unlock()
+	fake.RotateClusterCertificatesStub = nil
+	if fake.rotateClusterCertificatesReturnsOnCall == nil {
+		fake.rotateClusterCertificatesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rotateClusterCertificatesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) RunPreflightChecks(arg1 *client.PreflightOptions) (*client.PreflightReport, error) {
+	fake.runPreflightChecksMutex.Lock()
+	ret, specificReturn := fake.runPreflightChecksReturnsOnCall[len(fake.runPreflightChecksArgsForCall)]
+	fake.runPreflightChecksArgsForCall = append(fake.runPreflightChecksArgsForCall, struct {
+		arg1 *client.PreflightOptions
+	}{arg1})
+	stub := fake.RunPreflightChecksStub
+	fakeReturns := fake.runPreflightChecksReturns
+	fake.recordInvocation("RunPreflightChecks", []interface{}{arg1})
+	fake.runPreflightChecksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) RunPreflightChecksCallCount() int {
+	fake.runPreflightChecksMutex.RLock()
+	defer fake.runPreflightChecksMutex.RUnlock()
+	return len(fake.runPreflightChecksArgsForCall)
+}
+
+func (fake *Client) RunPreflightChecksCalls(stub func(*client.PreflightOptions) (*client.PreflightReport, error)) {
+	fake.runPreflightChecksMutex.Lock()
+	defer fake.runPreflightChecksMutex.Unlock()
+	fake.RunPreflightChecksStub = stub
+}
+
+func (fake *Client) RunPreflightChecksArgsForCall(i int) *client.PreflightOptions {
+	fake.runPreflightChecksMutex.RLock()
+	defer fake.runPreflightChecksMutex.RUnlock()
+	argsForCall := fake.runPreflightChecksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) RunPreflightChecksReturns(result1 *client.PreflightReport, result2 error) {
+	fake.runPreflightChecksMutex.Lock()
+	defer fake.runPreflightChecksMutex.Unlock()
+	fake.RunPreflightChecksStub = nil
+	fake.runPreflightChecksReturns = struct {
+		result1 *client.PreflightReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) RunPreflightChecksReturnsOnCall(i int, result1 *client.PreflightReport, result2 error) {
+	fake.runPreflightChecksMutex.Lock()
+	defer fake.runPreflightChecksMutex.Unlock()
+	fake.RunPreflightChecksStub = nil
+	if fake.runPreflightChecksReturnsOnCall == nil {
+		fake.runPreflightChecksReturnsOnCall = make(map[int]struct {
+			result1 *client.PreflightReport
+			result2 error
+		})
+	}
+	fake.runPreflightChecksReturnsOnCall[i] = struct {
+		result1 *client.PreflightReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) SaveFeatureFlags(arg1 map[string]string) error {
 	fake.saveFeatureFlagsMutex.Lock()
 	ret, specificReturn := fake.saveFeatureFlagsReturnsOnCall[len(fake.saveFeatureFlagsArgsForCall)]
@@ -3454,6 +4155,68 @@ func (fake *Client) UpgradeClusterReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Client) UpgradeFleet(arg1 *client.FleetUpgradeOptions, arg2 *client.FleetUpgradePlan) error {
+	fake.upgradeFleetMutex.Lock()
+	ret, specificReturn := fake.upgradeFleetReturnsOnCall[len(fake.upgradeFleetArgsForCall)]
+	fake.upgradeFleetArgsForCall = append(fake.upgradeFleetArgsForCall, struct {
+		arg1 *client.FleetUpgradeOptions
+		arg2 *client.FleetUpgradePlan
+	}{arg1, arg2})
+	stub := fake.UpgradeFleetStub
+	fakeReturns := fake.upgradeFleetReturns
+	fake.recordInvocation("UpgradeFleet", []interface{}{arg1, arg2})
+	fake.upgradeFleetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) UpgradeFleetCallCount() int {
+	fake.upgradeFleetMutex.RLock()
+	defer fake.upgradeFleetMutex.RUnlock()
+	return len(fake.upgradeFleetArgsForCall)
+}
+
+func (fake *Client) UpgradeFleetCalls(stub func(*client.FleetUpgradeOptions, *client.FleetUpgradePlan) error) {
+	fake.upgradeFleetMutex.Lock()
+	defer fake.upgradeFleetMutex.Unlock()
+	fake.UpgradeFleetStub = stub
+}
+
+func (fake *Client) UpgradeFleetArgsForCall(i int) (*client.FleetUpgradeOptions, *client.FleetUpgradePlan) {
+	fake.upgradeFleetMutex.RLock()
+	defer fake.upgradeFleetMutex.RUnlock()
+	argsForCall := fake.upgradeFleetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) UpgradeFleetReturns(result1 error) {
+	fake.upgradeFleetMutex.Lock()
+	defer fake.upgradeFleetMutex.Unlock()
+	fake.UpgradeFleetStub = nil
+	fake.upgradeFleetReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) UpgradeFleetReturnsOnCall(i int, result1 error) {
+	fake.upgradeFleetMutex.Lock()
+	defer fake.upgradeFleetMutex.Unlock()
+	fake.UpgradeFleetStub = nil
+	if fake.upgradeFleetReturnsOnCall == nil {
+		fake.upgradeFleetReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.upgradeFleetReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Client) UpgradeManagementCluster(arg1 *client.UpgradeClusterOptions) error {
 	fake.upgradeManagementClusterMutex.Lock()
 	ret, specificReturn := fake.upgradeManagementClusterReturnsOnCall[len(fake.upgradeManagementClusterArgsForCall)]
@@ -3701,6 +4464,8 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.activateTanzuKubernetesReleasesMutex.RUnlock()
 	fake.addRegionContextMutex.RLock()
 	defer fake.addRegionContextMutex.RUnlock()
+	fake.backupManagementClusterMutex.RLock()
+	defer fake.backupManagementClusterMutex.RUnlock()
 	fake.configureAndValidateManagementClusterConfigurationMutex.RLock()
 	defer fake.configureAndValidateManagementClusterConfigurationMutex.RUnlock()
 	fake.configureAndValidateTkrVersionMutex.RLock()
@@ -3725,14 +4490,22 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.describeClusterMutex.RUnlock()
 	fake.describeProviderMutex.RLock()
 	defer fake.describeProviderMutex.RUnlock()
+	fake.diagnoseClusterMutex.RLock()
+	defer fake.diagnoseClusterMutex.RUnlock()
+	fake.diffClusterMutex.RLock()
+	defer fake.diffClusterMutex.RUnlock()
 	fake.downloadBomFileMutex.RLock()
 	defer fake.downloadBomFileMutex.RUnlock()
 	fake.generateAWSCloudFormationTemplateMutex.RLock()
 	defer fake.generateAWSCloudFormationTemplateMutex.RUnlock()
 	fake.getCEIPParticipationMutex.RLock()
 	defer fake.getCEIPParticipationMutex.RUnlock()
+	fake.getClusterCertificatesMutex.RLock()
+	defer fake.getClusterCertificatesMutex.RUnlock()
 	fake.getClusterConfigurationMutex.RLock()
 	defer fake.getClusterConfigurationMutex.RUnlock()
+	fake.getClusterPackagesMutex.RLock()
+	defer fake.getClusterPackagesMutex.RUnlock()
 	fake.getClusterPinnipedInfoMutex.RLock()
 	defer fake.getClusterPinnipedInfoMutex.RUnlock()
 	fake.getCurrentRegionContextMutex.RLock()
@@ -3771,6 +4544,14 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.listTKGClustersMutex.RUnlock()
 	fake.parseHiddenArgsAsFeatureFlagsMutex.RLock()
 	defer fake.parseHiddenArgsAsFeatureFlagsMutex.RUnlock()
+	fake.planFleetUpgradeMutex.RLock()
+	defer fake.planFleetUpgradeMutex.RUnlock()
+	fake.restoreManagementClusterMutex.RLock()
+	defer fake.restoreManagementClusterMutex.RUnlock()
+	fake.rotateClusterCertificatesMutex.RLock()
+	defer fake.rotateClusterCertificatesMutex.RUnlock()
+	fake.runPreflightChecksMutex.RLock()
+	defer fake.runPreflightChecksMutex.RUnlock()
 	fake.saveFeatureFlagsMutex.RLock()
 	defer fake.saveFeatureFlagsMutex.RUnlock()
 	fake.scaleClusterMutex.RLock()
@@ -3791,6 +4572,8 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.updateCredentialsRegionMutex.RUnlock()
 	fake.upgradeClusterMutex.RLock()
 	defer fake.upgradeClusterMutex.RUnlock()
+	fake.upgradeFleetMutex.RLock()
+	defer fake.upgradeFleetMutex.RUnlock()
 	fake.upgradeManagementClusterMutex.RLock()
 	defer fake.upgradeManagementClusterMutex.RUnlock()
 	fake.validateDockerResourcePrerequisitesMutex.RLock()
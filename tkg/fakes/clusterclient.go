@@ -516,6 +516,35 @@ type ClusterClient struct {
 		result2 string
 		result3 error
 	}
+	GetPodLogsStub        func(string, string, string, int64) ([]byte, error)
+	getPodLogsMutex       sync.RWMutex
+	getPodLogsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int64
+	}
+	getPodLogsReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getPodLogsReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	GetPreferredGroupVersionKindStub        func(schema.GroupKind) (schema.GroupVersionKind, error)
+	getPreferredGroupVersionKindMutex       sync.RWMutex
+	getPreferredGroupVersionKindArgsForCall []struct {
+		arg1 schema.GroupKind
+	}
+	getPreferredGroupVersionKindReturns struct {
+		result1 schema.GroupVersionKind
+		result2 error
+	}
+	getPreferredGroupVersionKindReturnsOnCall map[int]struct {
+		result1 schema.GroupVersionKind
+		result2 error
+	}
 	GetRegionalClusterDefaultProviderNameStub        func(v1alpha3a.ProviderType) (string, error)
 	getRegionalClusterDefaultProviderNameMutex       sync.RWMutex
 	getRegionalClusterDefaultProviderNameArgsForCall []struct {
@@ -1043,6 +1072,63 @@ type ClusterClient struct {
 	updateAWSCNIIngressRulesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateAWSClusterStaticIdentitySecretStub        func(string, string, string, string, string) error
+	updateAWSClusterStaticIdentitySecretMutex       sync.RWMutex
+	updateAWSClusterStaticIdentitySecretArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	updateAWSClusterStaticIdentitySecretReturns struct {
+		result1 error
+	}
+	updateAWSClusterStaticIdentitySecretReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateAzureClusterIdentitySecretStub        func(string, string, string, string) error
+	updateAzureClusterIdentitySecretMutex       sync.RWMutex
+	updateAzureClusterIdentitySecretArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	updateAzureClusterIdentitySecretReturns struct {
+		result1 error
+	}
+	updateAzureClusterIdentitySecretReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateAzureJSONSecretsStub        func(string, string, string, string) error
+	updateAzureJSONSecretsMutex       sync.RWMutex
+	updateAzureJSONSecretsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	updateAzureJSONSecretsReturns struct {
+		result1 error
+	}
+	updateAzureJSONSecretsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateCapaManagerBootstrapCredentialsSecretStub        func(string, string, string, string) error
+	updateCapaManagerBootstrapCredentialsSecretMutex       sync.RWMutex
+	updateCapaManagerBootstrapCredentialsSecretArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	updateCapaManagerBootstrapCredentialsSecretReturns struct {
+		result1 error
+	}
+	updateCapaManagerBootstrapCredentialsSecretReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateCapvManagerBootstrapCredentialsSecretStub        func(string, string) error
 	updateCapvManagerBootstrapCredentialsSecretMutex       sync.RWMutex
 	updateCapvManagerBootstrapCredentialsSecretArgsForCall []struct {
@@ -1055,6 +1141,18 @@ type ClusterClient struct {
 	updateCapvManagerBootstrapCredentialsSecretReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateCapzManagerBootstrapCredentialsSecretStub        func(string, string) error
+	updateCapzManagerBootstrapCredentialsSecretMutex       sync.RWMutex
+	updateCapzManagerBootstrapCredentialsSecretArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	updateCapzManagerBootstrapCredentialsSecretReturns struct {
+		result1 error
+	}
+	updateCapzManagerBootstrapCredentialsSecretReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateReplicasStub        func(interface{}, string, string, int32) error
 	updateReplicasMutex       sync.RWMutex
 	updateReplicasArgsForCall []struct {
@@ -1328,6 +1426,32 @@ type ClusterClient struct {
 	waitK8sVersionUpdateForWorkerNodesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WaitRolloutForCPNodesStub        func(string, string, time.Time) error
+	waitRolloutForCPNodesMutex       sync.RWMutex
+	waitRolloutForCPNodesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}
+	waitRolloutForCPNodesReturns struct {
+		result1 error
+	}
+	waitRolloutForCPNodesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WaitRolloutForWorkerNodesStub        func(string, string, time.Time) error
+	waitRolloutForWorkerNodesMutex       sync.RWMutex
+	waitRolloutForWorkerNodesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}
+	waitRolloutForWorkerNodesReturns struct {
+		result1 error
+	}
+	waitRolloutForWorkerNodesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -3692,6 +3816,137 @@ func (fake *ClusterClient) GetPinnipedIssuerURLAndCAReturnsOnCall(i int, result1
 	}{result1, result2, result3}
 }
 
+func (fake *ClusterClient) GetPodLogs(arg1 string, arg2 string, arg3 string, arg4 int64) ([]byte, error) {
+	fake.getPodLogsMutex.Lock()
+	ret, specificReturn := fake.getPodLogsReturnsOnCall[len(fake.getPodLogsArgsForCall)]
+	fake.getPodLogsArgsForCall = append(fake.getPodLogsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int64
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetPodLogsStub
+	fakeReturns := fake.getPodLogsReturns
+	fake.recordInvocation("GetPodLogs", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getPodLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ClusterClient) GetPodLogsCallCount() int {
+	fake.getPodLogsMutex.RLock()
+	defer fake.getPodLogsMutex.RUnlock()
+	return len(fake.getPodLogsArgsForCall)
+}
+
+func (fake *ClusterClient) GetPodLogsCalls(stub func(string, string, string, int64) ([]byte, error)) {
+	fake.getPodLogsMutex.Lock()
+	defer fake.getPodLogsMutex.Unlock()
+	fake.GetPodLogsStub = stub
+}
+
+func (fake *ClusterClient) GetPodLogsArgsForCall(i int) (string, string, string, int64) {
+	fake.getPodLogsMutex.RLock()
+	defer fake.getPodLogsMutex.RUnlock()
+	argsForCall := fake.getPodLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ClusterClient) GetPodLogsReturns(result1 []byte, result2 error) {
+	fake.getPodLogsMutex.Lock()
+	defer fake.getPodLogsMutex.Unlock()
+	fake.GetPodLogsStub = nil
+	fake.getPodLogsReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ClusterClient) GetPodLogsReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getPodLogsMutex.Lock()
+	defer fake.getPodLogsMutex.Unlock()
+	fake.GetPodLogsStub = nil
+	if fake.getPodLogsReturnsOnCall == nil {
+		fake.getPodLogsReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getPodLogsReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ClusterClient) GetPreferredGroupVersionKind(arg1 schema.GroupKind) (schema.GroupVersionKind, error) {
+	fake.getPreferredGroupVersionKindMutex.Lock()
+	ret, specificReturn := fake.getPreferredGroupVersionKindReturnsOnCall[len(fake.getPreferredGroupVersionKindArgsForCall)]
+	fake.getPreferredGroupVersionKindArgsForCall = append(fake.getPreferredGroupVersionKindArgsForCall, struct {
+		arg1 schema.GroupKind
+	}{arg1})
+	stub := fake.GetPreferredGroupVersionKindStub
+	fakeReturns := fake.getPreferredGroupVersionKindReturns
+	fake.recordInvocation("GetPreferredGroupVersionKind", []interface{}{arg1})
+	fake.getPreferredGroupVersionKindMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ClusterClient) GetPreferredGroupVersionKindCallCount() int {
+	fake.getPreferredGroupVersionKindMutex.RLock()
+	defer fake.getPreferredGroupVersionKindMutex.RUnlock()
+	return len(fake.getPreferredGroupVersionKindArgsForCall)
+}
+
+func (fake *ClusterClient) GetPreferredGroupVersionKindCalls(stub func(schema.GroupKind) (schema.GroupVersionKind, error)) {
+	fake.getPreferredGroupVersionKindMutex.Lock()
+	defer fake.getPreferredGroupVersionKindMutex.Unlock()
+	fake.GetPreferredGroupVersionKindStub = stub
+}
+
+func (fake *ClusterClient) GetPreferredGroupVersionKindArgsForCall(i int) schema.GroupKind {
+	fake.getPreferredGroupVersionKindMutex.RLock()
+	defer fake.getPreferredGroupVersionKindMutex.RUnlock()
+	argsForCall := fake.getPreferredGroupVersionKindArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ClusterClient) GetPreferredGroupVersionKindReturns(result1 schema.GroupVersionKind, result2 error) {
+	fake.getPreferredGroupVersionKindMutex.Lock()
+	defer fake.getPreferredGroupVersionKindMutex.Unlock()
+	fake.GetPreferredGroupVersionKindStub = nil
+	fake.getPreferredGroupVersionKindReturns = struct {
+		result1 schema.GroupVersionKind
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ClusterClient) GetPreferredGroupVersionKindReturnsOnCall(i int, result1 schema.GroupVersionKind, result2 error) {
+	fake.getPreferredGroupVersionKindMutex.Lock()
+	defer fake.getPreferredGroupVersionKindMutex.Unlock()
+	fake.GetPreferredGroupVersionKindStub = nil
+	if fake.getPreferredGroupVersionKindReturnsOnCall == nil {
+		fake.getPreferredGroupVersionKindReturnsOnCall = make(map[int]struct {
+			result1 schema.GroupVersionKind
+			result2 error
+		})
+	}
+	fake.getPreferredGroupVersionKindReturnsOnCall[i] = struct {
+		result1 schema.GroupVersionKind
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ClusterClient) GetRegionalClusterDefaultProviderName(arg1 v1alpha3a.ProviderType) (string, error) {
 	fake.getRegionalClusterDefaultProviderNameMutex.Lock()
 	ret, specificReturn := fake.getRegionalClusterDefaultProviderNameReturnsOnCall[len(fake.getRegionalClusterDefaultProviderNameArgsForCall)]
@@ -6206,6 +6461,263 @@ func (fake *ClusterClient) UpdateAWSCNIIngressRulesReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *ClusterClient) UpdateAWSClusterStaticIdentitySecret(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string) error {
+	fake.updateAWSClusterStaticIdentitySecretMutex.Lock()
+	ret, specificReturn := fake.updateAWSClusterStaticIdentitySecretReturnsOnCall[len(fake.updateAWSClusterStaticIdentitySecretArgsForCall)]
+	fake.updateAWSClusterStaticIdentitySecretArgsForCall = append(fake.updateAWSClusterStaticIdentitySecretArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.UpdateAWSClusterStaticIdentitySecretStub
+	fakeReturns := fake.updateAWSClusterStaticIdentitySecretReturns
+	fake.recordInvocation("UpdateAWSClusterStaticIdentitySecret", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.updateAWSClusterStaticIdentitySecretMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ClusterClient) UpdateAWSClusterStaticIdentitySecretCallCount() int {
+	fake.updateAWSClusterStaticIdentitySecretMutex.RLock()
+	defer fake.updateAWSClusterStaticIdentitySecretMutex.RUnlock()
+	return len(fake.updateAWSClusterStaticIdentitySecretArgsForCall)
+}
+
+func (fake *ClusterClient) UpdateAWSClusterStaticIdentitySecretCalls(stub func(string, string, string, string, string) error) {
+	fake.updateAWSClusterStaticIdentitySecretMutex.Lock()
+	defer fake.updateAWSClusterStaticIdentitySecretMutex.Unlock()
+	fake.UpdateAWSClusterStaticIdentitySecretStub = stub
+}
+
+func (fake *ClusterClient) UpdateAWSClusterStaticIdentitySecretArgsForCall(i int) (string, string, string, string, string) {
+	fake.updateAWSClusterStaticIdentitySecretMutex.RLock()
+	defer fake.updateAWSClusterStaticIdentitySecretMutex.RUnlock()
+	argsForCall := fake.updateAWSClusterStaticIdentitySecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ClusterClient) UpdateAWSClusterStaticIdentitySecretReturns(result1 error) {
+	fake.updateAWSClusterStaticIdentitySecretMutex.Lock()
+	defer fake.updateAWSClusterStaticIdentitySecretMutex.Unlock()
+	fake.UpdateAWSClusterStaticIdentitySecretStub = nil
+	fake.updateAWSClusterStaticIdentitySecretReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) UpdateAWSClusterStaticIdentitySecretReturnsOnCall(i int, result1 error) {
+	fake.updateAWSClusterStaticIdentitySecretMutex.Lock()
+	defer fake.updateAWSClusterStaticIdentitySecretMutex.Unlock()
+	fake.UpdateAWSClusterStaticIdentitySecretStub = nil
+	if fake.updateAWSClusterStaticIdentitySecretReturnsOnCall == nil {
+		fake.updateAWSClusterStaticIdentitySecretReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateAWSClusterStaticIdentitySecretReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) UpdateAzureClusterIdentitySecret(arg1 string, arg2 string, arg3 string, arg4 string) error {
+	fake.updateAzureClusterIdentitySecretMutex.Lock()
+	ret, specificReturn := fake.updateAzureClusterIdentitySecretReturnsOnCall[len(fake.updateAzureClusterIdentitySecretArgsForCall)]
+	fake.updateAzureClusterIdentitySecretArgsForCall = append(fake.updateAzureClusterIdentitySecretArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateAzureClusterIdentitySecretStub
+	fakeReturns := fake.updateAzureClusterIdentitySecretReturns
+	fake.recordInvocation("UpdateAzureClusterIdentitySecret", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateAzureClusterIdentitySecretMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ClusterClient) UpdateAzureClusterIdentitySecretCallCount() int {
+	fake.updateAzureClusterIdentitySecretMutex.RLock()
+	defer fake.updateAzureClusterIdentitySecretMutex.RUnlock()
+	return len(fake.updateAzureClusterIdentitySecretArgsForCall)
+}
+
+func (fake *ClusterClient) UpdateAzureClusterIdentitySecretCalls(stub func(string, string, string, string) error) {
+	fake.updateAzureClusterIdentitySecretMutex.Lock()
+	defer fake.updateAzureClusterIdentitySecretMutex.Unlock()
+	fake.UpdateAzureClusterIdentitySecretStub = stub
+}
+
+func (fake *ClusterClient) UpdateAzureClusterIdentitySecretArgsForCall(i int) (string, string, string, string) {
+	fake.updateAzureClusterIdentitySecretMutex.RLock()
+	defer fake.updateAzureClusterIdentitySecretMutex.RUnlock()
+	argsForCall := fake.updateAzureClusterIdentitySecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ClusterClient) UpdateAzureClusterIdentitySecretReturns(result1 error) {
+	fake.updateAzureClusterIdentitySecretMutex.Lock()
+	defer fake.updateAzureClusterIdentitySecretMutex.Unlock()
+	fake.UpdateAzureClusterIdentitySecretStub = nil
+	fake.updateAzureClusterIdentitySecretReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) UpdateAzureClusterIdentitySecretReturnsOnCall(i int, result1 error) {
+	fake.updateAzureClusterIdentitySecretMutex.Lock()
+	defer fake.updateAzureClusterIdentitySecretMutex.Unlock()
+	fake.UpdateAzureClusterIdentitySecretStub = nil
+	if fake.updateAzureClusterIdentitySecretReturnsOnCall == nil {
+		fake.updateAzureClusterIdentitySecretReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateAzureClusterIdentitySecretReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) UpdateAzureJSONSecrets(arg1 string, arg2 string, arg3 string, arg4 string) error {
+	fake.updateAzureJSONSecretsMutex.Lock()
+	ret, specificReturn := fake.updateAzureJSONSecretsReturnsOnCall[len(fake.updateAzureJSONSecretsArgsForCall)]
+	fake.updateAzureJSONSecretsArgsForCall = append(fake.updateAzureJSONSecretsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateAzureJSONSecretsStub
+	fakeReturns := fake.updateAzureJSONSecretsReturns
+	fake.recordInvocation("UpdateAzureJSONSecrets", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateAzureJSONSecretsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ClusterClient) UpdateAzureJSONSecretsCallCount() int {
+	fake.updateAzureJSONSecretsMutex.RLock()
+	defer fake.updateAzureJSONSecretsMutex.RUnlock()
+	return len(fake.updateAzureJSONSecretsArgsForCall)
+}
+
+func (fake *ClusterClient) UpdateAzureJSONSecretsCalls(stub func(string, string, string, string) error) {
+	fake.updateAzureJSONSecretsMutex.Lock()
+	defer fake.updateAzureJSONSecretsMutex.Unlock()
+	fake.UpdateAzureJSONSecretsStub = stub
+}
+
+func (fake *ClusterClient) UpdateAzureJSONSecretsArgsForCall(i int) (string, string, string, string) {
+	fake.updateAzureJSONSecretsMutex.RLock()
+	defer fake.updateAzureJSONSecretsMutex.RUnlock()
+	argsForCall := fake.updateAzureJSONSecretsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ClusterClient) UpdateAzureJSONSecretsReturns(result1 error) {
+	fake.updateAzureJSONSecretsMutex.Lock()
+	defer fake.updateAzureJSONSecretsMutex.Unlock()
+	fake.UpdateAzureJSONSecretsStub = nil
+	fake.updateAzureJSONSecretsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) UpdateAzureJSONSecretsReturnsOnCall(i int, result1 error) {
+	fake.updateAzureJSONSecretsMutex.Lock()
+	defer fake.updateAzureJSONSecretsMutex.Unlock()
+	fake.UpdateAzureJSONSecretsStub = nil
+	if fake.updateAzureJSONSecretsReturnsOnCall == nil {
+		fake.updateAzureJSONSecretsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateAzureJSONSecretsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) UpdateCapaManagerBootstrapCredentialsSecret(arg1 string, arg2 string, arg3 string, arg4 string) error {
+	fake.updateCapaManagerBootstrapCredentialsSecretMutex.Lock()
+	ret, specificReturn := fake.updateCapaManagerBootstrapCredentialsSecretReturnsOnCall[len(fake.updateCapaManagerBootstrapCredentialsSecretArgsForCall)]
+	fake.updateCapaManagerBootstrapCredentialsSecretArgsForCall = append(fake.updateCapaManagerBootstrapCredentialsSecretArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateCapaManagerBootstrapCredentialsSecretStub
+	fakeReturns := fake.updateCapaManagerBootstrapCredentialsSecretReturns
+	fake.recordInvocation("UpdateCapaManagerBootstrapCredentialsSecret", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateCapaManagerBootstrapCredentialsSecretMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ClusterClient) UpdateCapaManagerBootstrapCredentialsSecretCallCount() int {
+	fake.updateCapaManagerBootstrapCredentialsSecretMutex.RLock()
+	defer fake.updateCapaManagerBootstrapCredentialsSecretMutex.RUnlock()
+	return len(fake.updateCapaManagerBootstrapCredentialsSecretArgsForCall)
+}
+
+func (fake *ClusterClient) UpdateCapaManagerBootstrapCredentialsSecretCalls(stub func(string, string, string, string) error) {
+	fake.updateCapaManagerBootstrapCredentialsSecretMutex.Lock()
+	defer fake.updateCapaManagerBootstrapCredentialsSecretMutex.Unlock()
+	fake.UpdateCapaManagerBootstrapCredentialsSecretStub = stub
+}
+
+func (fake *ClusterClient) UpdateCapaManagerBootstrapCredentialsSecretArgsForCall(i int) (string, string, string, string) {
+	fake.updateCapaManagerBootstrapCredentialsSecretMutex.RLock()
+	defer fake.updateCapaManagerBootstrapCredentialsSecretMutex.RUnlock()
+	argsForCall := fake.updateCapaManagerBootstrapCredentialsSecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ClusterClient) UpdateCapaManagerBootstrapCredentialsSecretReturns(result1 error) {
+	fake.updateCapaManagerBootstrapCredentialsSecretMutex.Lock()
+	defer fake.updateCapaManagerBootstrapCredentialsSecretMutex.Unlock()
+	fake.UpdateCapaManagerBootstrapCredentialsSecretStub = nil
+	fake.updateCapaManagerBootstrapCredentialsSecretReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) UpdateCapaManagerBootstrapCredentialsSecretReturnsOnCall(i int, result1 error) {
+	fake.updateCapaManagerBootstrapCredentialsSecretMutex.Lock()
+	defer fake.updateCapaManagerBootstrapCredentialsSecretMutex.Unlock()
+	fake.UpdateCapaManagerBootstrapCredentialsSecretStub = nil
+	if fake.updateCapaManagerBootstrapCredentialsSecretReturnsOnCall == nil {
+		fake.updateCapaManagerBootstrapCredentialsSecretReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateCapaManagerBootstrapCredentialsSecretReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ClusterClient) UpdateCapvManagerBootstrapCredentialsSecret(arg1 string, arg2 string) error {
 	fake.updateCapvManagerBootstrapCredentialsSecretMutex.Lock()
 	ret, specificReturn := fake.updateCapvManagerBootstrapCredentialsSecretReturnsOnCall[len(fake.updateCapvManagerBootstrapCredentialsSecretArgsForCall)]
@@ -6268,6 +6780,68 @@ func (fake *ClusterClient) UpdateCapvManagerBootstrapCredentialsSecretReturnsOnC
 	}{result1}
 }
 
+func (fake *ClusterClient) UpdateCapzManagerBootstrapCredentialsSecret(arg1 string, arg2 string) error {
+	fake.updateCapzManagerBootstrapCredentialsSecretMutex.Lock()
+	ret, specificReturn := fake.updateCapzManagerBootstrapCredentialsSecretReturnsOnCall[len(fake.updateCapzManagerBootstrapCredentialsSecretArgsForCall)]
+	fake.updateCapzManagerBootstrapCredentialsSecretArgsForCall = append(fake.updateCapzManagerBootstrapCredentialsSecretArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UpdateCapzManagerBootstrapCredentialsSecretStub
+	fakeReturns := fake.updateCapzManagerBootstrapCredentialsSecretReturns
+	fake.recordInvocation("UpdateCapzManagerBootstrapCredentialsSecret", []interface{}{arg1, arg2})
+	fake.updateCapzManagerBootstrapCredentialsSecretMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ClusterClient) UpdateCapzManagerBootstrapCredentialsSecretCallCount() int {
+	fake.updateCapzManagerBootstrapCredentialsSecretMutex.RLock()
+	defer fake.updateCapzManagerBootstrapCredentialsSecretMutex.RUnlock()
+	return len(fake.updateCapzManagerBootstrapCredentialsSecretArgsForCall)
+}
+
+func (fake *ClusterClient) UpdateCapzManagerBootstrapCredentialsSecretCalls(stub func(string, string) error) {
+	fake.updateCapzManagerBootstrapCredentialsSecretMutex.Lock()
+	defer fake.updateCapzManagerBootstrapCredentialsSecretMutex.Unlock()
+	fake.UpdateCapzManagerBootstrapCredentialsSecretStub = stub
+}
+
+func (fake *ClusterClient) UpdateCapzManagerBootstrapCredentialsSecretArgsForCall(i int) (string, string) {
+	fake.updateCapzManagerBootstrapCredentialsSecretMutex.RLock()
+	defer fake.updateCapzManagerBootstrapCredentialsSecretMutex.RUnlock()
+	argsForCall := fake.updateCapzManagerBootstrapCredentialsSecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ClusterClient) UpdateCapzManagerBootstrapCredentialsSecretReturns(result1 error) {
+	fake.updateCapzManagerBootstrapCredentialsSecretMutex.Lock()
+	defer fake.updateCapzManagerBootstrapCredentialsSecretMutex.Unlock()
+	fake.UpdateCapzManagerBootstrapCredentialsSecretStub = nil
+	fake.updateCapzManagerBootstrapCredentialsSecretReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) UpdateCapzManagerBootstrapCredentialsSecretReturnsOnCall(i int, result1 error) {
+	fake.updateCapzManagerBootstrapCredentialsSecretMutex.Lock()
+	defer fake.updateCapzManagerBootstrapCredentialsSecretMutex.Unlock()
+	fake.UpdateCapzManagerBootstrapCredentialsSecretStub = nil
+	if fake.updateCapzManagerBootstrapCredentialsSecretReturnsOnCall == nil {
+		fake.updateCapzManagerBootstrapCredentialsSecretReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateCapzManagerBootstrapCredentialsSecretReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ClusterClient) UpdateReplicas(arg1 interface{}, arg2 string, arg3 string, arg4 int32) error {
 	fake.updateReplicasMutex.Lock()
 	ret, specificReturn := fake.updateReplicasReturnsOnCall[len(fake.updateReplicasArgsForCall)]
@@ -7586,6 +8160,132 @@ func (fake *ClusterClient) WaitK8sVersionUpdateForWorkerNodesReturnsOnCall(i int
 	}{result1}
 }
 
+func (fake *ClusterClient) WaitRolloutForCPNodes(arg1 string, arg2 string, arg3 time.Time) error {
+	fake.waitRolloutForCPNodesMutex.Lock()
+	ret, specificReturn := fake.waitRolloutForCPNodesReturnsOnCall[len(fake.waitRolloutForCPNodesArgsForCall)]
+	fake.waitRolloutForCPNodesArgsForCall = append(fake.waitRolloutForCPNodesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.WaitRolloutForCPNodesStub
+	fakeReturns := fake.waitRolloutForCPNodesReturns
+	fake.recordInvocation("WaitRolloutForCPNodes", []interface{}{arg1, arg2, arg3})
+	fake.waitRolloutForCPNodesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ClusterClient) WaitRolloutForCPNodesCallCount() int {
+	fake.waitRolloutForCPNodesMutex.RLock()
+	defer fake.waitRolloutForCPNodesMutex.RUnlock()
+	return len(fake.waitRolloutForCPNodesArgsForCall)
+}
+
+func (fake *ClusterClient) WaitRolloutForCPNodesCalls(stub func(string, string, time.Time) error) {
+	fake.waitRolloutForCPNodesMutex.Lock()
+	defer fake.waitRolloutForCPNodesMutex.Unlock()
+	fake.WaitRolloutForCPNodesStub = stub
+}
+
+func (fake *ClusterClient) WaitRolloutForCPNodesArgsForCall(i int) (string, string, time.Time) {
+	fake.waitRolloutForCPNodesMutex.RLock()
+	defer fake.waitRolloutForCPNodesMutex.RUnlock()
+	argsForCall := fake.waitRolloutForCPNodesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ClusterClient) WaitRolloutForCPNodesReturns(result1 error) {
+	fake.waitRolloutForCPNodesMutex.Lock()
+	defer fake.waitRolloutForCPNodesMutex.Unlock()
+	fake.WaitRolloutForCPNodesStub = nil
+	fake.waitRolloutForCPNodesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) WaitRolloutForCPNodesReturnsOnCall(i int, result1 error) {
+	fake.waitRolloutForCPNodesMutex.Lock()
+	defer fake.waitRolloutForCPNodesMutex.Unlock()
+	fake.WaitRolloutForCPNodesStub = nil
+	if fake.waitRolloutForCPNodesReturnsOnCall == nil {
+		fake.waitRolloutForCPNodesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitRolloutForCPNodesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) WaitRolloutForWorkerNodes(arg1 string, arg2 string, arg3 time.Time) error {
+	fake.waitRolloutForWorkerNodesMutex.Lock()
+	ret, specificReturn := fake.waitRolloutForWorkerNodesReturnsOnCall[len(fake.waitRolloutForWorkerNodesArgsForCall)]
+	fake.waitRolloutForWorkerNodesArgsForCall = append(fake.waitRolloutForWorkerNodesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.WaitRolloutForWorkerNodesStub
+	fakeReturns := fake.waitRolloutForWorkerNodesReturns
+	fake.recordInvocation("WaitRolloutForWorkerNodes", []interface{}{arg1, arg2, arg3})
+	fake.waitRolloutForWorkerNodesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ClusterClient) WaitRolloutForWorkerNodesCallCount() int {
+	fake.waitRolloutForWorkerNodesMutex.RLock()
+	defer fake.waitRolloutForWorkerNodesMutex.RUnlock()
+	return len(fake.waitRolloutForWorkerNodesArgsForCall)
+}
+
+func (fake *ClusterClient) WaitRolloutForWorkerNodesCalls(stub func(string, string, time.Time) error) {
+	fake.waitRolloutForWorkerNodesMutex.Lock()
+	defer fake.waitRolloutForWorkerNodesMutex.Unlock()
+	fake.WaitRolloutForWorkerNodesStub = stub
+}
+
+func (fake *ClusterClient) WaitRolloutForWorkerNodesArgsForCall(i int) (string, string, time.Time) {
+	fake.waitRolloutForWorkerNodesMutex.RLock()
+	defer fake.waitRolloutForWorkerNodesMutex.RUnlock()
+	argsForCall := fake.waitRolloutForWorkerNodesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ClusterClient) WaitRolloutForWorkerNodesReturns(result1 error) {
+	fake.waitRolloutForWorkerNodesMutex.Lock()
+	defer fake.waitRolloutForWorkerNodesMutex.Unlock()
+	fake.WaitRolloutForWorkerNodesStub = nil
+	fake.waitRolloutForWorkerNodesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) WaitRolloutForWorkerNodesReturnsOnCall(i int, result1 error) {
+	fake.waitRolloutForWorkerNodesMutex.Lock()
+	defer fake.waitRolloutForWorkerNodesMutex.Unlock()
+	fake.WaitRolloutForWorkerNodesStub = nil
+	if fake.waitRolloutForWorkerNodesReturnsOnCall == nil {
+		fake.waitRolloutForWorkerNodesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitRolloutForWorkerNodesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ClusterClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -7667,6 +8367,10 @@ func (fake *ClusterClient) Invocations() map[string][][]interface{} {
 	defer fake.getPackageMutex.RUnlock()
 	fake.getPinnipedIssuerURLAndCAMutex.RLock()
 	defer fake.getPinnipedIssuerURLAndCAMutex.RUnlock()
+	fake.getPodLogsMutex.RLock()
+	defer fake.getPodLogsMutex.RUnlock()
+	fake.getPreferredGroupVersionKindMutex.RLock()
+	defer fake.getPreferredGroupVersionKindMutex.RUnlock()
 	fake.getRegionalClusterDefaultProviderNameMutex.RLock()
 	defer fake.getRegionalClusterDefaultProviderNameMutex.RUnlock()
 	fake.getResourceMutex.RLock()
@@ -7747,8 +8451,18 @@ func (fake *ClusterClient) Invocations() map[string][][]interface{} {
 	defer fake.scalePacificClusterWorkerNodesMutex.RUnlock()
 	fake.updateAWSCNIIngressRulesMutex.RLock()
 	defer fake.updateAWSCNIIngressRulesMutex.RUnlock()
+	fake.updateAWSClusterStaticIdentitySecretMutex.RLock()
+	defer fake.updateAWSClusterStaticIdentitySecretMutex.RUnlock()
+	fake.updateAzureClusterIdentitySecretMutex.RLock()
+	defer fake.updateAzureClusterIdentitySecretMutex.RUnlock()
+	fake.updateAzureJSONSecretsMutex.RLock()
+	defer fake.updateAzureJSONSecretsMutex.RUnlock()
+	fake.updateCapaManagerBootstrapCredentialsSecretMutex.RLock()
+	defer fake.updateCapaManagerBootstrapCredentialsSecretMutex.RUnlock()
 	fake.updateCapvManagerBootstrapCredentialsSecretMutex.RLock()
 	defer fake.updateCapvManagerBootstrapCredentialsSecretMutex.RUnlock()
+	fake.updateCapzManagerBootstrapCredentialsSecretMutex.RLock()
+	defer fake.updateCapzManagerBootstrapCredentialsSecretMutex.RUnlock()
 	fake.updateReplicasMutex.RLock()
 	defer fake.updateReplicasMutex.RUnlock()
 	fake.updateResourceMutex.RLock()
@@ -7791,6 +8505,10 @@ func (fake *ClusterClient) Invocations() map[string][][]interface{} {
 	defer fake.waitK8sVersionUpdateForCPNodesMutex.RUnlock()
 	fake.waitK8sVersionUpdateForWorkerNodesMutex.RLock()
 	defer fake.waitK8sVersionUpdateForWorkerNodesMutex.RUnlock()
+	fake.waitRolloutForCPNodesMutex.RLock()
+	defer fake.waitRolloutForCPNodesMutex.RUnlock()
+	fake.waitRolloutForWorkerNodesMutex.RLock()
+	defer fake.waitRolloutForWorkerNodesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
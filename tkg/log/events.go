@@ -0,0 +1,162 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventType is the type of an event of the events file. The event types are stable
+// and can be relied upon by the tools consuming the events file.
+type EventType string
+
+const (
+	// EventOperationStarted is sent when an operation starts
+	EventOperationStarted EventType = "OperationStarted"
+	// EventPhaseStarted is sent when a phase of an operation starts
+	EventPhaseStarted EventType = "PhaseStarted"
+	// EventPhaseCompleted is sent when a phase of an operation completes
+	EventPhaseCompleted EventType = "PhaseCompleted"
+	// EventPhaseFailed is sent when a phase of an operation fails
+	EventPhaseFailed EventType = "PhaseFailed"
+	// EventInfo is sent for the informational messages of an operation
+	EventInfo EventType = "Info"
+	// EventWait is sent for the informational messages of an operation waiting for objects, logged with Wait or Waitf
+	EventWait EventType = "Wait"
+	// EventWarning is sent for the warning messages of an operation
+	EventWarning EventType = "Warning"
+	// EventError is sent for the error messages of an operation
+	EventError EventType = "Error"
+	// EventResult is sent when an operation completes, with the status of the operation
+	EventResult EventType = "Result"
+)
+
+// Status of an operation reported by the EventResult event
+const (
+	EventStatusSucceeded = "succeeded"
+	EventStatusFailed    = "failed"
+)
+
+// progress status sent by SendProgressUpdate
+const (
+	progressStatusRunning    = "running"
+	progressStatusSuccessful = "successful"
+	progressStatusFailed     = "failed"
+)
+
+// Event is an event of the events file, written as a JSON line
+type Event struct {
+	Time        time.Time `json:"time"`
+	Type        EventType `json:"type"`
+	Operation   string    `json:"operation,omitempty"`
+	Phase       string    `json:"phase,omitempty"`
+	TotalPhases []string  `json:"totalPhases,omitempty"`
+	Message     string    `json:"message,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// eventWriter writes the events of the operations to the events file
+type eventWriter struct {
+	mutex     sync.Mutex
+	file      string
+	operation string
+	phase     string
+}
+
+func (e *eventWriter) setFile(fileName string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.file = fileName
+}
+
+func (e *eventWriter) operationStarted(operation string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.operation = operation
+	e.phase = ""
+	e.write(&Event{Type: EventOperationStarted})
+}
+
+func (e *eventWriter) operationResult(err error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	event := &Event{Type: EventResult, Status: EventStatusSucceeded}
+	if err != nil {
+		event.Status = EventStatusFailed
+		event.Error = err.Error()
+	}
+	e.write(event)
+	e.operation = ""
+	e.phase = ""
+}
+
+// progressUpdate converts the progress updates into phase events, the completion of a phase being implied by
+// the start of the next phase or by the final status of the progress
+func (e *eventWriter) progressUpdate(status, currentPhase string, totalPhases []string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	switch status {
+	case progressStatusRunning:
+		if currentPhase == e.phase {
+			return
+		}
+		if e.phase != "" {
+			e.write(&Event{Type: EventPhaseCompleted, Phase: e.phase})
+		}
+		e.phase = currentPhase
+		e.write(&Event{Type: EventPhaseStarted, Phase: currentPhase, TotalPhases: totalPhases})
+	case progressStatusSuccessful:
+		if e.phase != "" {
+			e.write(&Event{Type: EventPhaseCompleted, Phase: e.phase})
+		}
+		e.phase = ""
+	case progressStatusFailed:
+		if e.phase != "" {
+			e.write(&Event{Type: EventPhaseFailed, Phase: e.phase})
+		}
+		e.phase = ""
+	}
+}
+
+func (e *eventWriter) message(msg []byte, logType string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	message := strings.TrimSpace(string(msg))
+	if message == "" {
+		return
+	}
+	event := &Event{Message: message}
+	switch logType {
+	case logTypeINFO:
+		event.Type = EventInfo
+	case logTypeWAIT:
+		event.Type = EventWait
+	case logTypeWARN:
+		event.Type = EventWarning
+	case logTypeERROR, logTypeFATAL:
+		event.Type = EventError
+	default:
+		return
+	}
+	e.write(event)
+}
+
+// write writes an event to the events file, the caller must hold the mutex
+func (e *eventWriter) write(event *Event) {
+	if e.file == "" {
+		return
+	}
+	event.Time = time.Now().UTC()
+	event.Operation = e.operation
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		ForceWriteToStdErr([]byte("unable to marshal event"))
+		return
+	}
+	fileWriter(e.file, append(eventBytes, '\n'))
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type progress struct {
+	status       string
+	currentPhase string
+}
+
+// readEvents reads the events written to the events file, clearing their time and operation once checked
+func readEvents(t *testing.T, eventsFile string) []Event {
+	f, err := os.Open(eventsFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("unable to open events file: %v", err)
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid event line %q: %v", scanner.Text(), err)
+		}
+		if event.Time.IsZero() {
+			t.Errorf("event line %q has no time", scanner.Text())
+		}
+		if event.Operation != "CreateCluster" {
+			t.Errorf("event line %q has operation %q, expected %q", scanner.Text(), event.Operation, "CreateCluster")
+		}
+		event.Time = time.Time{}
+		event.Operation = ""
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestEventWriterProgressUpdate(t *testing.T) {
+	totalPhases := []string{"ConfigPrep", "Validation", "ClusterCreation"}
+
+	tests := []struct {
+		name     string
+		updates  []progress
+		expected []Event
+	}{
+		{
+			name:    "phase start",
+			updates: []progress{{progressStatusRunning, "ConfigPrep"}},
+			expected: []Event{
+				{Type: EventPhaseStarted, Phase: "ConfigPrep", TotalPhases: totalPhases},
+			},
+		},
+		{
+			name:    "repeated phase start",
+			updates: []progress{{progressStatusRunning, "ConfigPrep"}, {progressStatusRunning, "ConfigPrep"}},
+			expected: []Event{
+				{Type: EventPhaseStarted, Phase: "ConfigPrep", TotalPhases: totalPhases},
+			},
+		},
+		{
+			name:    "next phase start completes the current phase",
+			updates: []progress{{progressStatusRunning, "ConfigPrep"}, {progressStatusRunning, "Validation"}},
+			expected: []Event{
+				{Type: EventPhaseStarted, Phase: "ConfigPrep", TotalPhases: totalPhases},
+				{Type: EventPhaseCompleted, Phase: "ConfigPrep"},
+				{Type: EventPhaseStarted, Phase: "Validation", TotalPhases: totalPhases},
+			},
+		},
+		{
+			name:    "phase complete",
+			updates: []progress{{progressStatusRunning, "ClusterCreation"}, {progressStatusSuccessful, "ClusterCreation"}},
+			expected: []Event{
+				{Type: EventPhaseStarted, Phase: "ClusterCreation", TotalPhases: totalPhases},
+				{Type: EventPhaseCompleted, Phase: "ClusterCreation"},
+			},
+		},
+		{
+			name:    "phase fail",
+			updates: []progress{{progressStatusRunning, "Validation"}, {progressStatusFailed, "Validation"}},
+			expected: []Event{
+				{Type: EventPhaseStarted, Phase: "Validation", TotalPhases: totalPhases},
+				{Type: EventPhaseFailed, Phase: "Validation"},
+			},
+		},
+		{
+			name:     "final status without a started phase",
+			updates:  []progress{{progressStatusFailed, ""}},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			eventsFile := filepath.Join(t.TempDir(), "events.jsonl")
+			e := &eventWriter{}
+			e.setFile(eventsFile)
+			e.operation = "CreateCluster"
+
+			for _, update := range tc.updates {
+				e.progressUpdate(update.status, update.currentPhase, totalPhases)
+			}
+
+			events := readEvents(t, eventsFile)
+			if !reflect.DeepEqual(events, tc.expected) {
+				t.Errorf("expected events %+v, got %+v", tc.expected, events)
+			}
+		})
+	}
+}
+
+func TestEventWriterMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		logType  string
+		message  string
+		expected []Event
+	}{
+		{name: "info", logType: logTypeINFO, message: "Waiting for nothing, just informing\n", expected: []Event{{Type: EventInfo, Message: "Waiting for nothing, just informing"}}},
+		{name: "wait", logType: logTypeWAIT, message: "waiting for cluster nodes to be available...", expected: []Event{{Type: EventWait, Message: "waiting for cluster nodes to be available..."}}},
+		{name: "warning", logType: logTypeWARN, message: "unable to wait", expected: []Event{{Type: EventWarning, Message: "unable to wait"}}},
+		{name: "error", logType: logTypeERROR, message: "failed", expected: []Event{{Type: EventError, Message: "failed"}}},
+		{name: "output", logType: "OUTPUT", message: "table", expected: nil},
+		{name: "empty", logType: logTypeINFO, message: " \n", expected: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			eventsFile := filepath.Join(t.TempDir(), "events.jsonl")
+			e := &eventWriter{}
+			e.setFile(eventsFile)
+			e.operation = "CreateCluster"
+
+			e.message([]byte(tc.message), tc.logType)
+
+			events := readEvents(t, eventsFile)
+			if !reflect.DeepEqual(events, tc.expected) {
+				t.Errorf("expected events %+v, got %+v", tc.expected, events)
+			}
+		})
+	}
+}
@@ -87,6 +87,19 @@ func Infof(format string, args ...interface{}) {
 	l.Print(msg, nil, "INFO")
 }
 
+// Wait logs a non-error message announcing a wait on objects with the given key/value pairs as context.
+// The message is written to the events file as a wait event.
+func Wait(msg string, kvs ...interface{}) {
+	l.Print(msg, nil, logTypeWAIT, kvs...)
+}
+
+// Waitf logs a non-error message announcing a wait on objects with the given message format with format
+// specifier and arguments. The message is written to the events file as a wait event.
+func Waitf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	l.Print(msg, nil, logTypeWAIT)
+}
+
 // Error logs an error message with the given key/value pairs as context.
 func Error(err error, msg string, kvs ...interface{}) {
 	l.Print(msg, err, "ERROR", kvs...)
@@ -167,3 +180,20 @@ func SetVerbosity(verbosity int32) {
 func SendProgressUpdate(status, currentPhase string, totalPhases []string) {
 	logWriter.SendProgressUpdate(status, currentPhase, totalPhases)
 }
+
+// SetEventsFile sets the events file to writer
+// if the non-empty file name is used, the events of the
+// operations are written to this file as JSON lines
+func SetEventsFile(fileName string) {
+	logWriter.SetEventsFile(fileName)
+}
+
+// SendOperationStarted writes the start of an operation to the events file
+func SendOperationStarted(operation string) {
+	logWriter.SendOperationStarted(operation)
+}
+
+// SendOperationResult writes the result of the current operation to the events file
+func SendOperationResult(err error) {
+	logWriter.SendOperationResult(err)
+}
@@ -159,7 +159,12 @@ func (l *logger) Print(msg string, err error, logType string, kvs ...interface{}
 	if err != nil {
 		values = append(values, "error", err)
 	}
-	header := []byte(l.header(logType, l.callDepth))
+	headerType := logType
+	if logType == logTypeWAIT {
+		// wait messages are informational messages only distinguished in the events file
+		headerType = logTypeINFO
+	}
+	header := []byte(l.header(headerType, l.callDepth))
 	_, _ = logWriter.Write(header, []byte(l.getLogString(values)), l.Enabled(), l.level, logType)
 }
 
@@ -12,6 +12,7 @@ const (
 
 const (
 	logTypeINFO    = "INFO"
+	logTypeWAIT    = "WAIT"
 	logTypeWARN    = "WARN"
 	logTypeERROR   = "ERROR"
 	logTypeFATAL   = "FATAL"
@@ -43,6 +43,17 @@ type Writer interface {
 
 	// SendProgressUpdate sends the progress to the listening logChannel
 	SendProgressUpdate(status string, step string, totalSteps []string)
+
+	// SetEventsFile sets the events file to writer
+	// if the non-empty file name is used, writer will write the events
+	// of the operations to this file as JSON lines
+	SetEventsFile(fileName string)
+
+	// SendOperationStarted writes the start of an operation to the events file
+	SendOperationStarted(operation string)
+
+	// SendOperationResult writes the result of the current operation to the events file
+	SendOperationResult(err error)
 }
 
 var (
@@ -63,6 +74,7 @@ type writer struct {
 	verbosity  int32
 	quiet      bool
 	auditFile  string
+	events     eventWriter
 }
 
 // SetFile sets the logFile to writer
@@ -84,6 +96,13 @@ func (w *writer) SetChannel(channel chan<- []byte) {
 	w.logChannel = channel
 }
 
+// SetEventsFile sets the events file to writer
+// if the non-empty file name is used, writer will write the events
+// of the operations to this file as JSON lines
+func (w *writer) SetEventsFile(fileName string) {
+	w.events.setFile(fileName)
+}
+
 // QuietMode sets the logging mode to quiet
 // If this mode is set, writer will not write anything to stderr
 func (w *writer) QuietMode(quiet bool) {
@@ -123,6 +142,11 @@ func (w *writer) Write(header, msg []byte, logEnabled bool, logVerbosity int32,
 		}
 	}
 
+	// only the messages logged without verbosity level are written to the events file
+	if logVerbosity == 0 {
+		w.events.message(msg, logType)
+	}
+
 	// write to stdout/stderr if quiet mode is not set and logEnabled is true
 	if !w.quiet && logEnabled {
 		if logType == "OUTPUT" {
@@ -136,6 +160,8 @@ func (w *writer) Write(header, msg []byte, logEnabled bool, logVerbosity int32,
 }
 
 func (w *writer) SendProgressUpdate(status, currentPhase string, totalPhases []string) {
+	w.events.progressUpdate(status, currentPhase, totalPhases)
+
 	if w.logChannel == nil {
 		return
 	}
@@ -148,6 +174,14 @@ func (w *writer) SendProgressUpdate(status, currentPhase string, totalPhases []s
 	w.logChannel <- convertProgressMsgToJSONBytes(&msgData)
 }
 
+func (w *writer) SendOperationStarted(operation string) {
+	w.events.operationStarted(operation)
+}
+
+func (w *writer) SendOperationResult(err error) {
+	w.events.operationResult(err)
+}
+
 // UnsetStdoutStderr intercept the actual stdout and stderr
 // this will ensure no other external library prints to stdout/stderr
 // and use actual stdout/stderr through tkg writer only
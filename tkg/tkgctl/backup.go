@@ -0,0 +1,47 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+)
+
+// BackupRegionOptions options to back up the workload clusters of a management cluster
+type BackupRegionOptions struct {
+	ClusterName string
+	Directory   string
+	Namespaces  []string
+}
+
+// RestoreRegionOptions options to restore a backup into a management cluster
+type RestoreRegionOptions struct {
+	ClusterName string
+	Directory   string
+}
+
+// BackupRegion saves the Cluster API objects of the workload clusters of a management cluster, and the Tanzu
+// objects they depend on, to a directory
+func (t *tkgctl) BackupRegion(options BackupRegionOptions) (*client.ManagementClusterBackup, error) {
+	if options.Directory == "" {
+		return nil, errors.New("output directory of the backup is required")
+	}
+	return t.tkgClient.BackupManagementCluster(&client.BackupManagementClusterOptions{
+		ClusterName: options.ClusterName,
+		Directory:   options.Directory,
+		Namespaces:  options.Namespaces,
+	})
+}
+
+// RestoreRegion restores a backup taken by BackupRegion into a management cluster
+func (t *tkgctl) RestoreRegion(options RestoreRegionOptions) (*client.ManagementClusterBackup, error) {
+	if options.Directory == "" {
+		return nil, errors.New("input directory of the backup is required")
+	}
+	return t.tkgClient.RestoreManagementCluster(&client.RestoreManagementClusterOptions{
+		ClusterName: options.ClusterName,
+		Directory:   options.Directory,
+	})
+}
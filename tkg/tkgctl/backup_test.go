@@ -0,0 +1,58 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+)
+
+var _ = Describe("Unit tests for management cluster backup and restore", func() {
+	var (
+		tkgClient    *fakes.Client
+		tkgctlClient *tkgctl
+	)
+
+	BeforeEach(func() {
+		tkgClient = &fakes.Client{}
+		tkgctlClient = &tkgctl{
+			tkgClient:  tkgClient,
+			kubeconfig: getConfigFilePath(),
+		}
+	})
+
+	It("should back up the management cluster to a directory", func() {
+		tkgClient.BackupManagementClusterReturns(&client.ManagementClusterBackup{ClusterName: "mc-1"}, nil)
+
+		backup, err := tkgctlClient.BackupRegion(BackupRegionOptions{ClusterName: "mc-1", Directory: "backup", Namespaces: []string{"ns-1"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backup.ClusterName).To(Equal("mc-1"))
+
+		options := tkgClient.BackupManagementClusterArgsForCall(0)
+		Expect(options.ClusterName).To(Equal("mc-1"))
+		Expect(options.Directory).To(Equal("backup"))
+		Expect(options.Namespaces).To(ConsistOf("ns-1"))
+	})
+
+	It("should require the directory of the backup", func() {
+		_, err := tkgctlClient.BackupRegion(BackupRegionOptions{})
+		Expect(err).To(HaveOccurred())
+		_, err = tkgctlClient.RestoreRegion(RestoreRegionOptions{})
+		Expect(err).To(HaveOccurred())
+		Expect(tkgClient.BackupManagementClusterCallCount()).To(Equal(0))
+		Expect(tkgClient.RestoreManagementClusterCallCount()).To(Equal(0))
+	})
+
+	It("should restore the backup into a management cluster", func() {
+		_, err := tkgctlClient.RestoreRegion(RestoreRegionOptions{ClusterName: "mc-2", Directory: "backup"})
+		Expect(err).NotTo(HaveOccurred())
+
+		options := tkgClient.RestoreManagementClusterArgsForCall(0)
+		Expect(options.ClusterName).To(Equal("mc-2"))
+		Expect(options.Directory).To(Equal("backup"))
+	})
+})
@@ -0,0 +1,90 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// ListClusterCertificatesOptions options to list the certificates of a cluster
+type ListClusterCertificatesOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// RotateClusterCertificatesOptions options to rotate the certificates of a cluster
+type RotateClusterCertificatesOptions struct {
+	ClusterName string
+	Namespace   string
+	// IncludeWorkers also rolls out the worker machines of the cluster
+	IncludeWorkers bool
+	SkipPrompt     bool
+	Timeout        time.Duration
+}
+
+// GetClusterCertificates returns the expiry of the certificates of a cluster
+func (t *tkgctl) GetClusterCertificates(options ListClusterCertificatesOptions) (*client.ClusterCertificates, error) {
+	if err := t.checkClusterCertificatesSupported(); err != nil {
+		return nil, err
+	}
+
+	return t.tkgClient.GetClusterCertificates(client.ClusterCertificatesOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+	})
+}
+
+// RotateClusterCertificates renews the control plane machine certificates of a cluster by rolling out its machines
+func (t *tkgctl) RotateClusterCertificates(options RotateClusterCertificatesOptions) error {
+	// rolling out the machines requires minimum 15 minutes timeout
+	minTimeoutReq := 15 * time.Minute
+	if options.Timeout < minTimeoutReq {
+		log.V(6).Infof("timeout duration of at least 15 minutes is required, using default timeout %v", constants.DefaultLongRunningOperationTimeout)
+		options.Timeout = constants.DefaultLongRunningOperationTimeout
+	}
+	defer t.restoreAfterSettingTimeout(options.Timeout)()
+
+	if err := t.checkClusterCertificatesSupported(); err != nil {
+		return err
+	}
+
+	if !options.SkipPrompt {
+		nodes := "control plane nodes"
+		if options.IncludeWorkers {
+			nodes = "control plane and worker nodes"
+		}
+		if err := askForConfirmation(fmt.Sprintf("Rotating the certificates replaces all the %s of cluster '%s'. Are you sure?", nodes, options.ClusterName)); err != nil {
+			return err
+		}
+	}
+
+	err := t.tkgClient.RotateClusterCertificates(client.ClusterCertificatesOptions{
+		ClusterName:    options.ClusterName,
+		Namespace:      options.Namespace,
+		IncludeWorkers: options.IncludeWorkers,
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Certificates of cluster '%s' rotated\n", options.ClusterName)
+	return nil
+}
+
+func (t *tkgctl) checkClusterCertificatesSupported() error {
+	isPacific, err := t.tkgClient.IsPacificManagementCluster()
+	if err != nil {
+		return err
+	}
+	if isPacific {
+		return errors.New("managing cluster certificates is not supported on vSphere with Tanzu")
+	}
+	return nil
+}
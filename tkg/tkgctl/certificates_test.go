@@ -0,0 +1,72 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+)
+
+var _ = Describe("Unit tests for cluster certificates", func() {
+	var (
+		ctl       tkgctl
+		tkgClient *fakes.Client
+		err       error
+	)
+
+	BeforeEach(func() {
+		tkgClient = &fakes.Client{}
+		ctl = tkgctl{
+			configDir: testingDir,
+			tkgClient: tkgClient,
+		}
+	})
+
+	Describe("GetClusterCertificates", func() {
+		JustBeforeEach(func() {
+			_, err = ctl.GetClusterCertificates(ListClusterCertificatesOptions{ClusterName: "wc-1", Namespace: "ns-1"})
+		})
+
+		It("should get the certificates of the cluster", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tkgClient.GetClusterCertificatesArgsForCall(0)).To(Equal(client.ClusterCertificatesOptions{ClusterName: "wc-1", Namespace: "ns-1"}))
+		})
+
+		Context("when the management cluster is a vSphere with Tanzu supervisor", func() {
+			BeforeEach(func() {
+				tkgClient.IsPacificManagementClusterReturns(true, nil)
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("not supported on vSphere with Tanzu")))
+				Expect(tkgClient.GetClusterCertificatesCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("RotateClusterCertificates", func() {
+		JustBeforeEach(func() {
+			err = ctl.RotateClusterCertificates(RotateClusterCertificatesOptions{ClusterName: "wc-1", IncludeWorkers: true, SkipPrompt: true})
+		})
+
+		It("should rotate the certificates of the cluster", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tkgClient.RotateClusterCertificatesArgsForCall(0)).To(Equal(client.ClusterCertificatesOptions{ClusterName: "wc-1", IncludeWorkers: true}))
+		})
+
+		Context("when the management cluster is a vSphere with Tanzu supervisor", func() {
+			BeforeEach(func() {
+				tkgClient.IsPacificManagementClusterReturns(true, nil)
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("not supported on vSphere with Tanzu")))
+				Expect(tkgClient.RotateClusterCertificatesCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
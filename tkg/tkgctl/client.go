@@ -54,6 +54,9 @@ type LoggingOptions struct {
 	// LogChannel if channel is set, writer will forward log messages to this log channel
 	// The result of this will be in the format of 'LogData' struct mentioned in `pkg/log/type.go`
 	LogChannel chan<- []byte
+	// EventsFile if set, the progress events of the operations are written to this file as JSON lines,
+	// see the event types of `pkg/log/events.go`
+	EventsFile string
 }
 
 // Options options to create tkgctl client
@@ -242,6 +245,9 @@ func configureLogging(logOptions LoggingOptions) {
 	if logOptions.LogChannel != nil {
 		log.SetChannel(logOptions.LogChannel)
 	}
+	if logOptions.EventsFile != "" {
+		log.SetEventsFile(logOptions.EventsFile)
+	}
 	log.QuietMode(logOptions.Quietly)
 	log.SetVerbosity(logOptions.Verbosity)
 
@@ -54,7 +54,9 @@ type CreateClusterOptions struct {
 // CreateCluster create tkg cluster
 //
 //nolint:gocritic,gocyclo,revive
-func (t *tkgctl) CreateCluster(cc CreateClusterOptions) error {
+func (t *tkgctl) CreateCluster(cc CreateClusterOptions) (err error) {
+	defer sendOperationEvents(operationCreateCluster)(&err)
+
 	isTKGSCluster, err := t.tkgClient.IsPacificManagementCluster()
 	if err != nil {
 		return err
@@ -19,7 +19,9 @@ type DeleteClustersOptions struct {
 }
 
 // DeleteCluster deletes workload cluster
-func (t *tkgctl) DeleteCluster(options DeleteClustersOptions) error {
+func (t *tkgctl) DeleteCluster(options DeleteClustersOptions) (err error) {
+	defer sendOperationEvents(operationDeleteCluster)(&err)
+
 	// Make sure activity is captured in the audit log in case of deletion failure.
 	if logPath, err := t.getAuditLogPath(options.ClusterName); err == nil {
 		log.SetAuditLog(logPath)
@@ -41,7 +43,7 @@ func (t *tkgctl) DeleteCluster(options DeleteClustersOptions) error {
 		Namespace:   options.Namespace,
 	}
 
-	err := t.tkgClient.DeleteWorkloadCluster(deleteWcOptions)
+	err = t.tkgClient.DeleteWorkloadCluster(deleteWcOptions)
 	if err != nil {
 		return err
 	}
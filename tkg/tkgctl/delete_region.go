@@ -24,14 +24,14 @@ type DeleteRegionOptions struct {
 }
 
 // DeleteRegion deletes management cluster
-func (t *tkgctl) DeleteRegion(options DeleteRegionOptions) error {
+func (t *tkgctl) DeleteRegion(options DeleteRegionOptions) (err error) {
+	defer sendOperationEvents(operationDeleteRegion)(&err)
+
 	// Make sure activity is captured in the audit log in case of deletion failure.
 	if logPath, err := t.getAuditLogPath(options.ClusterName); err == nil {
 		log.SetAuditLog(logPath)
 	}
 
-	var err error
-
 	// delete region requires minimum 15 minutes timeout
 	minTimeoutReq := 15 * time.Minute
 	if options.Timeout < minTimeoutReq {
@@ -9,11 +9,13 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	clusterctltree "sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
 
+	runv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
@@ -26,6 +28,8 @@ type DescribeTKGClustersOptions struct {
 	ShowOtherConditions string
 	ShowDetails         bool
 	ShowGroupMembers    bool
+	// ShowPackages also returns the status of the core and additional packages of the cluster
+	ShowPackages bool
 }
 
 // DescribeClusterResult the result object for when the cluster's description is returned
@@ -34,6 +38,7 @@ type DescribeClusterResult struct {
 	Cluster            *clusterv1.Cluster
 	InstalledProviders *clusterctlv1.ProviderList
 	ClusterInfo        client.ClusterInfo
+	Packages           []runv1alpha3.ClusterBootstrapPackageStatus
 }
 
 // DescribeCluster returns list of cluster
@@ -104,6 +109,20 @@ func (t *tkgctl) DescribeCluster(options DescribeTKGClustersOptions) (DescribeCl
 		}
 	}
 
+	if options.ShowPackages {
+		results.Packages, err = t.tkgClient.GetClusterPackages(client.ClusterPackagesOptions{
+			ClusterName: options.ClusterName,
+			Namespace:   options.Namespace,
+		})
+		if err != nil {
+			if !apierrors.IsNotFound(errors.Cause(err)) {
+				return results, err
+			}
+			// clusters without ClusterBootstrap are still described, without the status of their packages
+			log.Warningf("Unable to get the status of the packages of cluster '%s': %v", options.ClusterName, err)
+		}
+	}
+
 	objs, cluster, installedProviders, err := t.tkgClient.DescribeCluster(DescribeTKGClustersOptions)
 	if err != nil {
 		// If it is pacific(TKGS), it would be the best effort to return the objectTree and cluster, so if there is an error
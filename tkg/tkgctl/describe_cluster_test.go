@@ -7,7 +7,10 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	runv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
 )
@@ -113,4 +116,44 @@ var _ = Describe("Unit test for describe cluster", func() {
 			Expect(result.Cluster).To(BeNil())
 		})
 	})
+	Context("when the status of the packages of the cluster is requested", func() {
+		BeforeEach(func() {
+			ops.ShowPackages = true
+			tkgClient.IsPacificManagementClusterReturns(false, nil)
+			tkgClient.ListTKGClustersReturns([]client.ClusterInfo{{Name: "my-cluster", Roles: []string{"<none>"}}}, nil)
+			tkgClient.DescribeClusterReturns(nil, nil, nil, nil)
+			tkgClient.GetClusterPackagesReturns([]runv1alpha3.ClusterBootstrapPackageStatus{
+				{Name: "antrea.tanzu.vmware.com", RefName: "antrea.tanzu.vmware.com.1.5.3--vmware.1-tkg.1", Version: "1.5.3+vmware.1-tkg.1", State: "ReconcileSucceeded"},
+			}, nil)
+		})
+		AfterEach(func() {
+			ops.ShowPackages = false
+		})
+		It("should return the status of the packages", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Packages).To(HaveLen(1))
+			Expect(result.Packages[0].Name).To(Equal("antrea.tanzu.vmware.com"))
+			options := tkgClient.GetClusterPackagesArgsForCall(tkgClient.GetClusterPackagesCallCount() - 1)
+			Expect(options).To(Equal(client.ClusterPackagesOptions{ClusterName: "my-cluster", Namespace: "default"}))
+		})
+		Context("when the cluster has no ClusterBootstrap", func() {
+			BeforeEach(func() {
+				notFound := apierrors.NewNotFound(schema.GroupResource{Group: "run.tanzu.vmware.com", Resource: "clusterbootstraps"}, "my-cluster")
+				tkgClient.GetClusterPackagesReturns(nil, errors.Wrap(notFound, "cluster 'my-cluster' has no ClusterBootstrap"))
+			})
+			It("should describe the cluster without the status of the packages", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.ClusterInfo.Name).To(Equal("my-cluster"))
+				Expect(result.Packages).To(BeEmpty())
+			})
+		})
+		Context("when tkgClient failed to get the status of the packages", func() {
+			BeforeEach(func() {
+				tkgClient.GetClusterPackagesReturns(nil, errors.New("unable to get the ClusterBootstrap of cluster default/my-cluster"))
+			})
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("unable to get the ClusterBootstrap")))
+			})
+		})
+	})
 })
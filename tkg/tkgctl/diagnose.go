@@ -0,0 +1,35 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+)
+
+// DiagnoseClusterOptions options to collect the support bundle of a cluster
+type DiagnoseClusterOptions struct {
+	ClusterName string
+	Namespace   string
+	OutputFile  string
+	// IsManagementCluster diagnoses the management cluster instead of a workload cluster
+	IsManagementCluster bool
+	// UseBootstrapCluster diagnoses the bootstrap cluster left behind by a failed management cluster creation
+	UseBootstrapCluster bool
+	SkipWorkloadCluster bool
+	LogTailLines        int64
+}
+
+// DiagnoseCluster collects the Cluster API objects, package install statuses, events and controller logs
+// of a cluster into a tar.gz support bundle, and returns the index of the bundle
+func (t *tkgctl) DiagnoseCluster(options DiagnoseClusterOptions) (*client.DiagnosticsIndex, error) {
+	return t.tkgClient.DiagnoseCluster(&client.DiagnoseClusterOptions{
+		ClusterName:         options.ClusterName,
+		Namespace:           options.Namespace,
+		OutputFile:          options.OutputFile,
+		IsManagementCluster: options.IsManagementCluster || options.UseBootstrapCluster,
+		UseBootstrapCluster: options.UseBootstrapCluster,
+		SkipWorkloadCluster: options.SkipWorkloadCluster,
+		LogTailLines:        options.LogTailLines,
+	})
+}
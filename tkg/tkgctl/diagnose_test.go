@@ -0,0 +1,60 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+)
+
+var _ = Describe("Unit tests for diagnose cluster", func() {
+	var (
+		tkgClient    *fakes.Client
+		tkgctlClient *tkgctl
+	)
+
+	BeforeEach(func() {
+		tkgClient = &fakes.Client{}
+		tkgctlClient = &tkgctl{
+			tkgClient:  tkgClient,
+			kubeconfig: getConfigFilePath(),
+		}
+	})
+
+	It("should collect the support bundle of a workload cluster", func() {
+		tkgClient.DiagnoseClusterReturns(&client.DiagnosticsIndex{ClusterName: "wc-1"}, nil)
+
+		index, err := tkgctlClient.DiagnoseCluster(DiagnoseClusterOptions{
+			ClusterName:  "wc-1",
+			Namespace:    "ns-1",
+			OutputFile:   "wc-1.tar.gz",
+			LogTailLines: 100,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(index.ClusterName).To(Equal("wc-1"))
+
+		Expect(tkgClient.DiagnoseClusterCallCount()).To(Equal(1))
+		options := tkgClient.DiagnoseClusterArgsForCall(0)
+		Expect(options.ClusterName).To(Equal("wc-1"))
+		Expect(options.Namespace).To(Equal("ns-1"))
+		Expect(options.OutputFile).To(Equal("wc-1.tar.gz"))
+		Expect(options.LogTailLines).To(Equal(int64(100)))
+		Expect(options.IsManagementCluster).To(BeFalse())
+	})
+
+	It("should diagnose the bootstrap cluster as a management cluster", func() {
+		tkgClient.DiagnoseClusterReturns(nil, errors.New("no bootstrap cluster found"))
+
+		_, err := tkgctlClient.DiagnoseCluster(DiagnoseClusterOptions{UseBootstrapCluster: true})
+		Expect(err).To(HaveOccurred())
+
+		options := tkgClient.DiagnoseClusterArgsForCall(0)
+		Expect(options.UseBootstrapCluster).To(BeTrue())
+		Expect(options.IsManagementCluster).To(BeTrue())
+	})
+})
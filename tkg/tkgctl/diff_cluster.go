@@ -0,0 +1,104 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+)
+
+// DiffClusterOptions options to diff a workload cluster against its cluster configuration file
+type DiffClusterOptions struct {
+	ClusterConfigFile string
+	ClusterName       string
+	Namespace         string
+	// Apply patches the cluster topology and machine health checks to match the configuration
+	Apply bool
+	// Tanzu edition (either tce or tkg)
+	Edition string
+}
+
+// DiffCluster regenerates the desired cluster from the cluster configuration file, the same way as
+// CreateCluster does, and compares it with the live cluster
+func (t *tkgctl) DiffCluster(options DiffClusterOptions) (*client.ClusterDiff, error) {
+	if options.ClusterConfigFile == "" {
+		return nil, errors.New("cluster configuration file is required to diff a cluster")
+	}
+
+	isTKGSCluster, err := t.tkgClient.IsPacificManagementCluster()
+	if err != nil {
+		return nil, err
+	}
+	if isTKGSCluster {
+		return nil, errors.New("diffing clusters is not supported on vSphere with Tanzu")
+	}
+
+	cc := CreateClusterOptions{
+		ClusterConfigFile: options.ClusterConfigFile,
+		ClusterName:       options.ClusterName,
+		Namespace:         options.Namespace,
+		Edition:           options.Edition,
+	}
+	desired, err := t.getDesiredClusterConfiguration(&cc)
+	if err != nil {
+		return nil, err
+	}
+	if options.ClusterName != "" && cc.ClusterName != options.ClusterName {
+		return nil, errors.Errorf("the cluster configuration file is for cluster %q, not %q", cc.ClusterName, options.ClusterName)
+	}
+
+	return t.tkgClient.DiffCluster(&client.DiffClusterOptions{
+		ClusterName:          cc.ClusterName,
+		Namespace:            cc.Namespace,
+		DesiredConfiguration: desired,
+		Apply:                options.Apply,
+	})
+}
+
+// getDesiredClusterConfiguration returns the yaml of the cluster described by the cluster configuration file.
+// A ClusterClass based input file already is the desired cluster, a legacy configuration file is converted
+// the same way as by ConfigCluster.
+func (t *tkgctl) getDesiredClusterConfiguration(cc *CreateClusterOptions) ([]byte, error) {
+	isInputFileClusterClassBased, err := t.processWorkloadClusterInputFile(cc, false)
+	if err != nil {
+		return nil, err
+	}
+	if isInputFileClusterClassBased {
+		desired, err := os.ReadFile(cc.ClusterConfigFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read cluster configuration file %s", cc.ClusterConfigFile)
+		}
+		return desired, nil
+	}
+
+	cc.ClusterConfigFile, err = t.ensureClusterConfigFile(cc.ClusterConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.configureCreateClusterOptionsFromConfigFile(cc); err != nil {
+		return nil, err
+	}
+	if cc.Namespace == "" {
+		if namespace, err := t.TKGConfigReaderWriter().Get(constants.ConfigVariableNamespace); err == nil {
+			cc.Namespace = namespace
+		}
+	}
+
+	options, err := t.getCreateClusterOptions(cc.ClusterName, cc, false)
+	if err != nil {
+		return nil, err
+	}
+	options.TKRVersion, options.KubernetesVersion, err = t.getAndDownloadTkrIfNeeded(cc.TkrVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to determine the TKr version and kubernetes version based on '%v'", cc.TkrVersion)
+	}
+	// the cluster already exists, its configuration is only regenerated
+	options.SkipValidation = true
+
+	return t.tkgClient.GetClusterConfiguration(&options)
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigupdater"
+)
+
+var _ = Describe("Unit tests for cluster diff", func() {
+	var (
+		ctl       tkgctl
+		tkgClient *fakes.Client
+		options   DiffClusterOptions
+		diff      *client.ClusterDiff
+		err       error
+	)
+
+	BeforeEach(func() {
+		tkgClient = &fakes.Client{}
+		tkgClient.IsFeatureActivatedReturns(true)
+		tkgClient.DiffClusterReturns(&client.ClusterDiff{ClusterName: "aws-workload-cluster1"}, nil)
+
+		tkgConfigReaderWriter, err := tkgconfigreaderwriter.NewReaderWriterFromConfigFile(configFilePath, configFilePath)
+		Expect(err).NotTo(HaveOccurred())
+		ctl = tkgctl{
+			configDir:              testingDir,
+			tkgClient:              tkgClient,
+			tkgConfigReaderWriter:  tkgConfigReaderWriter,
+			tkgConfigUpdaterClient: tkgconfigupdater.New(testingDir, nil, tkgConfigReaderWriter),
+			tkgBomClient:           &fakes.TKGConfigBomClient{},
+		}
+		options = DiffClusterOptions{
+			ClusterConfigFile: inputFileAws,
+			ClusterName:       "aws-workload-cluster1",
+			Apply:             true,
+		}
+	})
+
+	JustBeforeEach(func() {
+		diff, err = ctl.DiffCluster(options)
+	})
+
+	Context("when the input file is ClusterClass based", func() {
+		It("should diff the cluster against the Cluster object of the input file", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.ClusterName).To(Equal("aws-workload-cluster1"))
+
+			Expect(tkgClient.DiffClusterCallCount()).To(Equal(1))
+			diffOptions := tkgClient.DiffClusterArgsForCall(0)
+			Expect(diffOptions.ClusterName).To(Equal("aws-workload-cluster1"))
+			Expect(diffOptions.Namespace).To(Equal("default"))
+			Expect(diffOptions.Apply).To(BeTrue())
+			expected, _ := os.ReadFile(inputFileAws)
+			Expect(diffOptions.DesiredConfiguration).To(Equal(expected))
+			Expect(tkgClient.GetClusterConfigurationCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the input file is for another cluster", func() {
+		BeforeEach(func() {
+			options.ClusterName = "wc-1"
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`the cluster configuration file is for cluster "aws-workload-cluster1", not "wc-1"`))
+			Expect(tkgClient.DiffClusterCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the management cluster is a vSphere with Tanzu supervisor", func() {
+		BeforeEach(func() {
+			tkgClient.IsPacificManagementClusterReturns(true, nil)
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not supported on vSphere with Tanzu"))
+		})
+	})
+
+	Context("when no cluster configuration file is given", func() {
+		BeforeEach(func() {
+			options.ClusterConfigFile = ""
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(tkgClient.DiffClusterCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -35,6 +35,28 @@ var errMessageCIDRsIPFamiliesNotSame = `the IP Families of input CIDRs: "%v":"%v
 var regExMachineDep = regexp.MustCompile(constants.RegexpMachineDeploymentsOverrides)
 var regExpTopologyClassVal = regexp.MustCompile(constants.RegexpTopologyClassValue)
 
+// names of the operations reported in the events file
+const (
+	operationInit           = "Init"
+	operationCreateCluster  = "CreateCluster"
+	operationUpgradeCluster = "UpgradeCluster"
+	operationUpgradeRegion  = "UpgradeRegion"
+	operationUpgradeFleet   = "UpgradeFleet"
+	operationDeleteCluster  = "DeleteCluster"
+	operationDeleteRegion   = "DeleteRegion"
+	operationScaleCluster   = "ScaleCluster"
+)
+
+// sendOperationEvents writes the start of an operation to the events file and returns a function
+// writing the result of the operation. It should be used with a defer call on the named error
+// result of the operation, e.g. defer sendOperationEvents(operationInit)(&err)
+func sendOperationEvents(operation string) func(*error) {
+	log.SendOperationStarted(operation)
+	return func(err *error) {
+		log.SendOperationResult(*err)
+	}
+}
+
 func askForConfirmation(message string) error {
 	var response string
 	msg := message + " [y/N]: "
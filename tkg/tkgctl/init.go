@@ -47,6 +47,7 @@ type InitRegionOptions struct {
 	DeployTKGonVsphere7         bool
 	SkipPrompt                  bool
 	GenerateOnly                bool
+	Resume                      bool
 }
 
 const (
@@ -56,8 +57,12 @@ const (
 // Init initializes tkg management cluster
 //
 //nolint:gocritic,gocyclo,funlen
-func (t *tkgctl) Init(options InitRegionOptions) error {
-	var err error
+func (t *tkgctl) Init(options InitRegionOptions) (err error) {
+	defer sendOperationEvents(operationInit)(&err)
+
+	if options.Resume && (options.UI || options.GenerateOnly) {
+		return errors.New("resuming the creation of a management cluster is not supported with the interactive UI or with dry-run")
+	}
 	options.ClusterConfigFile, err = t.ensureClusterConfigFile(options.ClusterConfigFile)
 	if err != nil {
 		return err
@@ -358,6 +363,7 @@ func (t *tkgctl) populateClientInitRegionOptions(options *InitRegionOptions, nod
 		VsphereControlPlaneEndpoint: options.VsphereControlPlaneEndpoint,
 		Edition:                     options.Edition,
 		AdditionalTKGManifests:      options.AdditionalTKGManifests,
+		Resume:                      options.Resume,
 	}
 }
 
@@ -50,6 +50,20 @@ type TKGClient interface {
 	GetRegions(managementClusterName string) ([]region.RegionContext, error)
 	// Init initializes tkg management cluster
 	Init(options InitRegionOptions) error
+	// Preflight runs the preflight validation of a cluster configuration file
+	Preflight(options PreflightOptions) (*client.PreflightReport, error)
+	// DiagnoseCluster collects the support bundle of a cluster
+	DiagnoseCluster(options DiagnoseClusterOptions) (*client.DiagnosticsIndex, error)
+	// BackupRegion saves the Cluster API objects of the workload clusters of a management cluster to a directory
+	BackupRegion(options BackupRegionOptions) (*client.ManagementClusterBackup, error)
+	// RestoreRegion restores a management cluster backup into a management cluster
+	RestoreRegion(options RestoreRegionOptions) (*client.ManagementClusterBackup, error)
+	// DiffCluster compares a workload cluster with its cluster configuration file and optionally applies the configuration
+	DiffCluster(options DiffClusterOptions) (*client.ClusterDiff, error)
+	// GetClusterCertificates returns the expiry of the certificate authorities and control plane machine certificates of a cluster
+	GetClusterCertificates(options ListClusterCertificatesOptions) (*client.ClusterCertificates, error)
+	// RotateClusterCertificates renews the control plane machine certificates of a cluster by rolling out its machines
+	RotateClusterCertificates(options RotateClusterCertificatesOptions) error
 	// ScaleCluster scales cluster
 	ScaleCluster(options ScaleClusterOptions) error
 	// SetCeip sets CEIP to the management cluster
@@ -66,6 +80,8 @@ type TKGClient interface {
 	SetRegion(options SetRegionOptions) error
 	// UpgradeCluster upgrade tkg workload cluster
 	UpgradeCluster(options UpgradeClusterOptions) error
+	// UpgradeFleet upgrades the workload clusters matching a label selector in waves
+	UpgradeFleet(options UpgradeFleetOptions) (*client.FleetUpgradePlan, error)
 	// UpgradeRegion upgrades management cluster
 	UpgradeRegion(options UpgradeRegionOptions) error
 	// Updates management cluster
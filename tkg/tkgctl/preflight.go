@@ -0,0 +1,64 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+)
+
+// PreflightOptions options to run the preflight validation of a cluster configuration
+type PreflightOptions struct {
+	ClusterConfigFile      string
+	ClusterName            string
+	Plan                   string
+	InfrastructureProvider string
+	TkrVersion             string
+	// Tanzu edition (either tce or tkg)
+	Edition             string
+	IsManagementCluster bool
+}
+
+// Preflight runs all the applicable validations of the cluster configuration file without creating
+// anything, and returns the report of the failed and passed checks
+func (t *tkgctl) Preflight(options PreflightOptions) (*client.PreflightReport, error) {
+	var err error
+	options.ClusterConfigFile, err = t.ensureClusterConfigFile(options.ClusterConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	preflightOptions := &client.PreflightOptions{
+		ClusterName:                 t.getPreflightConfigVariable(options.ClusterName, constants.ConfigVariableClusterName),
+		Plan:                        t.getPreflightConfigVariable(options.Plan, constants.ConfigVariableClusterPlan),
+		InfrastructureProvider:      t.getPreflightConfigVariable(options.InfrastructureProvider, constants.ConfigVariableInfraProvider),
+		TKRVersion:                  options.TkrVersion,
+		CniType:                     t.getPreflightConfigVariable("", constants.ConfigVariableCNI),
+		Edition:                     t.getPreflightConfigVariable(options.Edition, constants.ConfigVariableBuildEdition),
+		VsphereControlPlaneEndpoint: t.getPreflightConfigVariable("", constants.ConfigVariableVsphereControlPlaneEndpoint),
+		NodeSizeOptions: client.NodeSizeOptions{
+			Size:             t.getPreflightConfigVariable("", constants.ConfigVariableSize),
+			ControlPlaneSize: t.getPreflightConfigVariable("", constants.ConfigVariableControlPlaneSize),
+			WorkerSize:       t.getPreflightConfigVariable("", constants.ConfigVariableWorkerSize),
+		},
+		IsManagementCluster: options.IsManagementCluster,
+	}
+	if preflightOptions.CniType == "" {
+		preflightOptions.CniType = constants.DefaultCNIType
+	}
+
+	return t.tkgClient.RunPreflightChecks(preflightOptions)
+}
+
+// getPreflightConfigVariable returns the value if set, otherwise the value of the config variable
+func (t *tkgctl) getPreflightConfigVariable(value, configVariable string) string {
+	if value != "" {
+		return value
+	}
+	value, err := t.TKGConfigReaderWriter().Get(configVariable)
+	if err != nil {
+		return ""
+	}
+	return value
+}
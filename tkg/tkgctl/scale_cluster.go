@@ -21,7 +21,9 @@ type ScaleClusterOptions struct {
 }
 
 // ScaleCluster scales cluster
-func (t *tkgctl) ScaleCluster(options ScaleClusterOptions) error {
+func (t *tkgctl) ScaleCluster(options ScaleClusterOptions) (err error) {
+	defer sendOperationEvents(operationScaleCluster)(&err)
+
 	if options.Namespace == "" {
 		options.Namespace = constants.DefaultNamespace
 	}
@@ -39,7 +41,7 @@ func (t *tkgctl) ScaleCluster(options ScaleClusterOptions) error {
 		NodePoolName:      options.NodePoolName,
 	}
 
-	err := t.tkgClient.ScaleCluster(scaleClusterOptions)
+	err = t.tkgClient.ScaleCluster(scaleClusterOptions)
 	if err != nil {
 		return err
 	}
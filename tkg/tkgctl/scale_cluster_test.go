@@ -4,11 +4,17 @@
 package tkgctl
 
 import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 
 	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
 )
 
 var _ = Describe("Unit test for scale cluster", func() {
@@ -60,4 +66,60 @@ var _ = Describe("Unit test for scale cluster", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+
+	Context("when an events file is set", func() {
+		var eventsFile string
+
+		BeforeEach(func() {
+			ops.ControlPlaneCount = 1
+			ops.WorkerCount = 1
+			tkgClient.ScaleClusterReturns(nil)
+			eventsFile = filepath.Join(testingDir, "events.json")
+			log.SetEventsFile(eventsFile)
+		})
+		AfterEach(func() {
+			log.SetEventsFile("")
+			os.Remove(eventsFile)
+		})
+		It("should write the events of the operation to the events file", func() {
+			Expect(err).ToNot(HaveOccurred())
+			events := readEvents(eventsFile)
+			Expect(events).To(HaveLen(3))
+			Expect(events[0].Type).To(Equal(log.EventOperationStarted))
+			Expect(events[0].Operation).To(Equal(operationScaleCluster))
+			Expect(events[1].Type).To(Equal(log.EventInfo))
+			Expect(events[1].Message).To(Equal("Workload cluster 'my-cluster' is being scaled"))
+			Expect(events[2].Type).To(Equal(log.EventResult))
+			Expect(events[2].Operation).To(Equal(operationScaleCluster))
+			Expect(events[2].Status).To(Equal(log.EventStatusSucceeded))
+		})
+		Context("when failed to scale the cluster", func() {
+			BeforeEach(func() {
+				tkgClient.ScaleClusterReturns(errors.New("region not found"))
+			})
+			It("should write the failed result to the events file", func() {
+				Expect(err).To(HaveOccurred())
+				events := readEvents(eventsFile)
+				Expect(events).To(HaveLen(2))
+				Expect(events[1].Type).To(Equal(log.EventResult))
+				Expect(events[1].Status).To(Equal(log.EventStatusFailed))
+				Expect(events[1].Error).To(Equal("region not found"))
+			})
+		})
+	})
 })
+
+func readEvents(eventsFile string) []log.Event {
+	file, err := os.Open(eventsFile)
+	Expect(err).ToNot(HaveOccurred())
+	defer file.Close()
+
+	events := []log.Event{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		event := log.Event{}
+		Expect(json.Unmarshal(scanner.Bytes(), &event)).To(Succeed())
+		events = append(events, event)
+	}
+	return events
+}
@@ -12,11 +12,16 @@ import (
 
 // UpdateCredentialsClusterOptions options that can be passed while updating cluster credentials
 type UpdateCredentialsClusterOptions struct {
-	ClusterName     string
-	Namespace       string
-	VSphereUsername string
-	VSpherePassword string
-	Timeout         time.Duration
+	ClusterName        string
+	Namespace          string
+	VSphereUsername    string
+	VSpherePassword    string
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSSessionToken    string
+	AzureClientID      string
+	AzureClientSecret  string
+	Timeout            time.Duration
 }
 
 // UpdateCredentialsCluster updates credentials used to access a cluster
@@ -35,6 +40,15 @@ func (t *tkgctl) UpdateCredentialsCluster(options UpdateCredentialsClusterOption
 			Username: options.VSphereUsername,
 			Password: options.VSpherePassword,
 		},
+		AWSUpdateClusterOptions: &client.AWSUpdateClusterOptions{
+			AccessKeyID:     options.AWSAccessKeyID,
+			SecretAccessKey: options.AWSSecretAccessKey,
+			SessionToken:    options.AWSSessionToken,
+		},
+		AzureUpdateClusterOptions: &client.AzureUpdateClusterOptions{
+			ClientID:     options.AzureClientID,
+			ClientSecret: options.AzureClientSecret,
+		},
 	}
 
 	return t.tkgClient.UpdateCredentialsCluster(updateCredentialsOptions)
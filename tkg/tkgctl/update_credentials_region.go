@@ -11,11 +11,16 @@ import (
 
 // UpdateCredentialsRegionOptions options that can passed while updating credentials of a management-cluster
 type UpdateCredentialsRegionOptions struct {
-	ClusterName     string
-	VSphereUsername string
-	VSpherePassword string
-	IsCascading     bool
-	Timeout         time.Duration
+	ClusterName        string
+	VSphereUsername    string
+	VSpherePassword    string
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSSessionToken    string
+	AzureClientID      string
+	AzureClientSecret  string
+	IsCascading        bool
+	Timeout            time.Duration
 }
 
 // UpdateCredentialsRegion updates credentials used to login to a management-cluster
@@ -30,6 +35,15 @@ func (t *tkgctl) UpdateCredentialsRegion(options UpdateCredentialsRegionOptions)
 			Username: options.VSphereUsername,
 			Password: options.VSpherePassword,
 		},
+		AWSUpdateClusterOptions: &client.AWSUpdateClusterOptions{
+			AccessKeyID:     options.AWSAccessKeyID,
+			SecretAccessKey: options.AWSSecretAccessKey,
+			SessionToken:    options.AWSSessionToken,
+		},
+		AzureUpdateClusterOptions: &client.AzureUpdateClusterOptions{
+			ClientID:     options.AzureClientID,
+			ClientSecret: options.AzureClientSecret,
+		},
 		IsCascading: options.IsCascading,
 	}
 
@@ -65,5 +65,31 @@ var _ = Describe("Unit tests for update credentials", func() {
 			Expect(updateCredentialOptions.IsRegionalCluster).To(Equal(true))
 			Expect(updateCredentialOptions.IsCascading).To(Equal(true))
 		})
+
+		It("Update AWS and Azure credentials for management cluster", func() {
+			kubeConfigPath := getConfigFilePath()
+
+			tkgClient = &fakes.Client{}
+
+			tkgctlClient := &tkgctl{
+				tkgClient:  tkgClient,
+				kubeconfig: kubeConfigPath,
+			}
+
+			err := tkgctlClient.UpdateCredentialsRegion(UpdateCredentialsRegionOptions{
+				ClusterName:        "clusterName",
+				AWSAccessKeyID:     "access-key-id",
+				AWSSecretAccessKey: "secret-access-key",
+				AzureClientSecret:  "client-secret",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tkgClient.UpdateCredentialsRegionCallCount()).To(Equal(1))
+			updateCredentialOptions := tkgClient.UpdateCredentialsRegionArgsForCall(0)
+			Expect(updateCredentialOptions.AWSUpdateClusterOptions.AccessKeyID).To(Equal("access-key-id"))
+			Expect(updateCredentialOptions.AWSUpdateClusterOptions.SecretAccessKey).To(Equal("secret-access-key"))
+			Expect(updateCredentialOptions.AWSUpdateClusterOptions.SessionToken).To(Equal(""))
+			Expect(updateCredentialOptions.AzureUpdateClusterOptions.ClientSecret).To(Equal("client-secret"))
+		})
 	})
 })
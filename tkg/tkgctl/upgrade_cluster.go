@@ -36,8 +36,9 @@ type UpgradeClusterOptions struct {
 // UpgradeCluster upgrade tkg workload cluster
 //
 //nolint:gocritic
-func (t *tkgctl) UpgradeCluster(options UpgradeClusterOptions) error {
-	var err error
+func (t *tkgctl) UpgradeCluster(options UpgradeClusterOptions) (err error) {
+	defer sendOperationEvents(operationUpgradeCluster)(&err)
+
 	var k8sVersion string
 
 	if logPath, err := t.getAuditLogPath(options.ClusterName); err == nil {
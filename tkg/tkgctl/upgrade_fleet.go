@@ -0,0 +1,117 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// UpgradeFleetOptions options to upgrade the workload clusters matching a label selector
+type UpgradeFleetOptions struct {
+	Selector string
+	// Namespace of the workload clusters, all namespaces if empty
+	Namespace string
+	// TkrName is the name or name prefix of the TKr to upgrade to, the latest available upgrade if empty
+	TkrName       string
+	MaxConcurrent int
+	MaxFailures   int
+	// PlanFile persists the plan and the status of the upgrade, an existing plan file is resumed
+	PlanFile   string
+	DryRun     bool
+	SkipPrompt bool
+	Timeout    time.Duration
+	OSName     string
+	OSVersion  string
+	OSArch     string
+	// Tanzu edition (either tce or tkg)
+	Edition string
+}
+
+// UpgradeFleet upgrades the workload clusters matching a label selector in waves, and returns the plan
+// updated with the upgrade status of each cluster
+//
+//nolint:gocritic
+func (t *tkgctl) UpgradeFleet(options UpgradeFleetOptions) (plan *client.FleetUpgradePlan, err error) {
+	defer sendOperationEvents(operationUpgradeFleet)(&err)
+
+	if options.Selector == "" && options.PlanFile == "" {
+		return nil, errors.New("a cluster selector or a fleet upgrade plan file is required to upgrade a fleet of clusters")
+	}
+
+	// upgrade requires minimum 15 minutes timeout
+	minTimeoutReq := 15 * time.Minute
+	if options.Timeout < minTimeoutReq {
+		log.V(6).Infof("timeout duration of at least 15 minutes is required, using default timeout %v", constants.DefaultLongRunningOperationTimeout)
+		options.Timeout = constants.DefaultLongRunningOperationTimeout
+	}
+	defer t.restoreAfterSettingTimeout(options.Timeout)()
+
+	isPacific, err := t.tkgClient.IsPacificManagementCluster()
+	if err != nil {
+		return nil, err
+	}
+	if isPacific {
+		return nil, errors.New("upgrading a fleet of clusters is not supported on vSphere with Tanzu")
+	}
+
+	fleetOptions := &client.FleetUpgradeOptions{
+		Selector:      options.Selector,
+		Namespace:     options.Namespace,
+		TkrName:       options.TkrName,
+		MaxConcurrent: options.MaxConcurrent,
+		MaxFailures:   options.MaxFailures,
+		PlanFile:      options.PlanFile,
+		OSName:        options.OSName,
+		OSVersion:     options.OSVersion,
+		OSArch:        options.OSArch,
+		Edition:       options.Edition,
+	}
+	plan, err = t.tkgClient.PlanFleetUpgrade(fleetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	upgrades := 0
+	tkrVersions := map[string]bool{}
+	for i := range plan.Clusters {
+		if plan.Clusters[i].Status != client.FleetUpgradeSucceeded && plan.Clusters[i].Status != client.FleetUpgradeSkipped {
+			upgrades++
+			tkrVersions[plan.Clusters[i].TkrVersion] = true
+		}
+	}
+	if options.DryRun || upgrades == 0 {
+		return plan, nil
+	}
+
+	// the BoM of the target TKrs are needed to upgrade legacy clusters
+	for tkrVersion := range tkrVersions {
+		if _, _, err := t.getAndDownloadTkrIfNeeded(tkrVersion); err != nil {
+			return plan, errors.Wrapf(err, "unable to get the BoM of TKr version '%s'", tkrVersion)
+		}
+	}
+
+	if !options.SkipPrompt {
+		if err := askForConfirmation(fmt.Sprintf("Upgrading %d workload clusters, %d at a time. Are you sure?", upgrades, plan.MaxConcurrent)); err != nil {
+			return plan, err
+		}
+	}
+
+	if err := t.tkgClient.UpgradeFleet(fleetOptions, plan); err != nil {
+		return plan, err
+	}
+
+	counts := plan.Counts()
+	if counts[client.FleetUpgradeFailed] > 0 {
+		return plan, errors.Errorf("%d of %d workload clusters failed to upgrade", counts[client.FleetUpgradeFailed], upgrades)
+	}
+	log.Infof("%d workload clusters successfully upgraded\n", upgrades)
+	return plan, nil
+}
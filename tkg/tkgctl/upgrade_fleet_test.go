@@ -0,0 +1,175 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigbom"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
+)
+
+var _ = Describe("Unit tests for fleet upgrade", func() {
+	var (
+		ctl       tkgctl
+		tkgClient *fakes.Client
+		bomClient *fakes.TKGConfigBomClient
+		options   UpgradeFleetOptions
+		plan      *client.FleetUpgradePlan
+		err       error
+	)
+
+	BeforeEach(func() {
+		tkgClient = &fakes.Client{}
+		bomClient = &fakes.TKGConfigBomClient{}
+		bomClient.GetBOMConfigurationFromTkrVersionReturns(&tkgconfigbom.BOMConfiguration{}, nil)
+		tkgClient.PlanFleetUpgradeReturns(&client.FleetUpgradePlan{
+			Selector:      "env=dev",
+			MaxConcurrent: 2,
+			Clusters: []client.FleetUpgradeCluster{
+				{Name: "wc-1", Namespace: "default", TkrVersion: "v1.23.8+vmware.2-tkg.1", Wave: 1, Status: client.FleetUpgradePending},
+				{Name: "wc-2", Namespace: "default", TkrVersion: "v1.23.8+vmware.2-tkg.1", Wave: 1, Status: client.FleetUpgradeSucceeded},
+				{Name: "wc-3", Namespace: "default", Status: client.FleetUpgradeSkipped},
+			},
+		}, nil)
+		tkgConfigReaderWriter, err := tkgconfigreaderwriter.NewReaderWriterFromConfigFile(configFilePath, configFilePath)
+		Expect(err).NotTo(HaveOccurred())
+		ctl = tkgctl{
+			configDir:             testingDir,
+			tkgClient:             tkgClient,
+			tkgBomClient:          bomClient,
+			tkgConfigReaderWriter: tkgConfigReaderWriter,
+		}
+		options = UpgradeFleetOptions{
+			Selector:      "env=dev",
+			TkrName:       "v1.23.8",
+			MaxConcurrent: 2,
+			SkipPrompt:    true,
+		}
+	})
+
+	JustBeforeEach(func() {
+		plan, err = ctl.UpgradeFleet(options)
+	})
+
+	Context("when the clusters are upgraded", func() {
+		It("should upgrade the clusters of the plan", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Clusters).To(HaveLen(3))
+
+			fleetOptions := tkgClient.PlanFleetUpgradeArgsForCall(0)
+			Expect(fleetOptions.Selector).To(Equal("env=dev"))
+			Expect(fleetOptions.TkrName).To(Equal("v1.23.8"))
+			Expect(fleetOptions.MaxConcurrent).To(Equal(2))
+
+			Expect(bomClient.GetBOMConfigurationFromTkrVersionCallCount()).To(Equal(1))
+			Expect(bomClient.GetBOMConfigurationFromTkrVersionArgsForCall(0)).To(Equal("v1.23.8+vmware.2-tkg.1"))
+			Expect(tkgClient.UpgradeFleetCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when it is a dry run", func() {
+		BeforeEach(func() {
+			options.DryRun = true
+		})
+
+		It("should only plan the upgrade", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan).NotTo(BeNil())
+			Expect(tkgClient.UpgradeFleetCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when clusters failed to upgrade", func() {
+		BeforeEach(func() {
+			tkgClient.UpgradeFleetCalls(func(_ *client.FleetUpgradeOptions, plan *client.FleetUpgradePlan) error {
+				plan.Clusters[0].Status = client.FleetUpgradeFailed
+				return nil
+			})
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(MatchError("1 of 1 workload clusters failed to upgrade"))
+			Expect(plan.Clusters[0].Status).To(Equal(client.FleetUpgradeFailed))
+		})
+	})
+
+	Context("when the fleet upgrade is halted", func() {
+		BeforeEach(func() {
+			tkgClient.UpgradeFleetReturns(errors.New("fleet upgrade halted"))
+		})
+
+		It("should return the plan and the error", func() {
+			Expect(err).To(MatchError("fleet upgrade halted"))
+			Expect(plan).NotTo(BeNil())
+		})
+	})
+
+	Context("when an events file is set", func() {
+		var eventsFile string
+
+		BeforeEach(func() {
+			eventsFile = filepath.Join(testingDir, "events.json")
+			log.SetEventsFile(eventsFile)
+		})
+		AfterEach(func() {
+			log.SetEventsFile("")
+			os.Remove(eventsFile)
+		})
+		It("should write the events of the operation to the events file", func() {
+			Expect(err).NotTo(HaveOccurred())
+			events := readEvents(eventsFile)
+			Expect(len(events)).To(BeNumerically(">=", 2))
+			Expect(events[0].Type).To(Equal(log.EventOperationStarted))
+			Expect(events[0].Operation).To(Equal(operationUpgradeFleet))
+			Expect(events[len(events)-2].Type).To(Equal(log.EventInfo))
+			Expect(events[len(events)-2].Message).To(Equal("1 workload clusters successfully upgraded"))
+			Expect(events[len(events)-1].Type).To(Equal(log.EventResult))
+			Expect(events[len(events)-1].Operation).To(Equal(operationUpgradeFleet))
+			Expect(events[len(events)-1].Status).To(Equal(log.EventStatusSucceeded))
+		})
+		Context("when the fleet upgrade is halted", func() {
+			BeforeEach(func() {
+				tkgClient.UpgradeFleetReturns(errors.New("fleet upgrade halted"))
+			})
+			It("should write the failed result to the events file", func() {
+				Expect(err).To(HaveOccurred())
+				events := readEvents(eventsFile)
+				Expect(events[len(events)-1].Type).To(Equal(log.EventResult))
+				Expect(events[len(events)-1].Operation).To(Equal(operationUpgradeFleet))
+				Expect(events[len(events)-1].Status).To(Equal(log.EventStatusFailed))
+				Expect(events[len(events)-1].Error).To(Equal("fleet upgrade halted"))
+			})
+		})
+	})
+
+	Context("when neither a selector nor a plan file is given", func() {
+		BeforeEach(func() {
+			options.Selector = ""
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(tkgClient.PlanFleetUpgradeCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the management cluster is a vSphere with Tanzu supervisor", func() {
+		BeforeEach(func() {
+			tkgClient.IsPacificManagementClusterReturns(true, nil)
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("not supported on vSphere with Tanzu")))
+		})
+	})
+})
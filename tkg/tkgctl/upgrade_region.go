@@ -34,8 +34,8 @@ type UpgradeRegionOptions struct {
 // UpgradeRegion upgrades management cluster
 //
 //nolint:gocritic
-func (t *tkgctl) UpgradeRegion(options UpgradeRegionOptions) error {
-	var err error
+func (t *tkgctl) UpgradeRegion(options UpgradeRegionOptions) (err error) {
+	defer sendOperationEvents(operationUpgradeRegion)(&err)
 
 	if logPath, err := t.getAuditLogPath(options.ClusterName); err == nil {
 		log.SetAuditLog(logPath)